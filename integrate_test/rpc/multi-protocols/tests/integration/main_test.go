@@ -56,7 +56,7 @@ func TestMain(m *testing.M) {
 		panic(err)
 	}
 
-	connDubbo, err = cliDubbo.Dial("GreetProvider")
+	connDubbo, err = cliDubbo.Dial(greet.GreetServiceName)
 	if err != nil {
 		panic(err)
 	}
@@ -71,7 +71,7 @@ func TestMain(m *testing.M) {
 		panic(err)
 	}
 
-	connJsonRpc, err = cliJsonRpc.Dial("GreetProvider")
+	connJsonRpc, err = cliJsonRpc.Dial(greet.GreetServiceName)
 
 	if err != nil {
 		panic(err)
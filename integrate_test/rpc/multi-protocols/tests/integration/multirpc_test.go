@@ -26,7 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestSayHello(t *testing.T) {
+func TestGreet(t *testing.T) {
 	//Triple
 	req := &greet.GreetRequest{Name: "hello world"}
 
@@ -36,23 +36,17 @@ func TestSayHello(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.Equal(t, "hello world", reply.Greeting)
-	logger.Infof("GreetProvider.Greet reply: %s", reply.Greeting)
+	logger.Infof("GreetService.Greet reply: %s", reply.Greeting)
 
 	//Dubbo
 	var respDubbo string
-	if err = connDubbo.CallUnary(context.Background(), []interface{}{"hello", "new", "dubbo"}, &respDubbo, "SayHello"); err != nil {
-		logger.Errorf("GreetProvider.Greet err: %s", err)
-		return
-	}
+	err = connDubbo.CallUnary(context.Background(), []interface{}{"hello world"}, &respDubbo, "Greet")
 	assert.Nil(t, err)
-	assert.Equal(t, "hellonewdubbo", respDubbo)
+	assert.Equal(t, reply.Greeting, respDubbo)
 
 	//JsonRpc
 	var respJsonRpc string
-	if err = connJsonRpc.CallUnary(context.Background(), []interface{}{"hello", "new", "jsonrpc"}, &respJsonRpc, "SayHello"); err != nil {
-		logger.Errorf("GreetProvider.Greet err: %s", err)
-		return
-	}
+	err = connJsonRpc.CallUnary(context.Background(), []interface{}{"hello world"}, &respJsonRpc, "Greet")
 	assert.Nil(t, err)
-	assert.Equal(t, "hellonewjsonrpc", respJsonRpc)
+	assert.Equal(t, reply.Greeting, respJsonRpc)
 }
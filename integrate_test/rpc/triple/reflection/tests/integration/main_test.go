@@ -30,11 +30,13 @@ import (
 	greet "github.com/apache/dubbo-go-samples/rpc/triple/reflection/proto"
 )
 
+var cli *client.Client
 var greetService greet.GreetService
 var stream reflection.ServerReflection_ServerReflectionInfoClient
 
 func TestMain(m *testing.M) {
-	cli, err := client.NewClient(
+	var err error
+	cli, err = client.NewClient(
 		client.WithClientURL("127.0.0.1:20000"),
 	)
 	if err != nil {
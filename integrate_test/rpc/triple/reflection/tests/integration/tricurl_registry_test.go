@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package integration
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3"
+	"dubbo.apache.org/dubbo-go/v3/protocol"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+
+	greet "github.com/apache/dubbo-go-samples/rpc/triple/reflection/proto"
+	"github.com/apache/dubbo-go-samples/rpc/triple/reflection/tricurl"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+// registryProvider is a provider of the greet service registered in the
+// ZooKeeper of the integration tests. The lookups never call it.
+type registryProvider struct {
+	greet.GreetServiceHandler
+}
+
+func TestTricurlRegistryProviders(t *testing.T) {
+	ins, err := dubbo.NewInstance(
+		dubbo.WithName("dubbo_rpc_triple_reflection_registry_provider"),
+		dubbo.WithRegistry(
+			registry.WithZookeeper(),
+			registry.WithAddress("127.0.0.1:2181"),
+		),
+		dubbo.WithProtocol(
+			protocol.WithTriple(),
+			protocol.WithPort(20001),
+		),
+	)
+	assert.Nil(t, err)
+	srv, err := ins.NewServer()
+	assert.Nil(t, err)
+	assert.Nil(t, greet.RegisterGreetServiceHandler(srv, &registryProvider{}))
+	go srv.Serve()
+
+	target, err := tricurl.ParseTarget("zookeeper://127.0.0.1:2181")
+	assert.Nil(t, err)
+
+	// the lookups share the directories the lookup cluster joins, each one
+	// must get the providers of its own service
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			providers, err := target.Providers("greet.GreetService", 20*time.Second)
+			assert.Nil(t, err)
+			assert.True(t, hasPort(providers, ":20001"), "providers %v", providers)
+		}()
+	}
+	wg.Wait()
+
+	url, err := target.Resolve("greet.GreetService", 20*time.Second)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(url, "tri://"), url)
+
+	// nothing joined by the lookups before is left over for this one
+	_, err = target.Providers("greet.NoSuchService", time.Second)
+	assert.NotNil(t, err)
+}
+
+func hasPort(providers []string, port string) bool {
+	for _, p := range providers {
+		if strings.HasSuffix(p, port) {
+			return true
+		}
+	}
+	return false
+}
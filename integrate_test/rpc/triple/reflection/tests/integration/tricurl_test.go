@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+import (
+	"github.com/apache/dubbo-go-samples/rpc/triple/reflection/tricurl"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTricurlList(t *testing.T) {
+	src, err := tricurl.NewSource(context.Background(), cli)
+	assert.Nil(t, err)
+	defer src.Close()
+
+	sd, err := src.FindService("greet.GreetService")
+	assert.Nil(t, err)
+	assert.NotNil(t, sd.Methods().ByName("Greet"))
+}
+
+func TestTricurlDescribe(t *testing.T) {
+	src, err := tricurl.NewSource(context.Background(), cli)
+	assert.Nil(t, err)
+	defer src.Close()
+
+	d, err := src.FindSymbol("greet.GreetService.Greet")
+	assert.Nil(t, err)
+	assert.Equal(t, "method", tricurl.Kind(d))
+	assert.Equal(t, "rpc Greet ( .greet.GreetRequest ) returns ( .greet.GreetResponse );\n", tricurl.Describe(d))
+
+	d, err = src.FindSymbol("greet.GreetRequest")
+	assert.Nil(t, err)
+	assert.Equal(t, "message GreetRequest {\n  string name = 1;\n}\n", tricurl.Describe(d))
+}
+
+func TestTricurlCall(t *testing.T) {
+	ctx := context.Background()
+	src, err := tricurl.NewSource(ctx, cli)
+	assert.Nil(t, err)
+	defer src.Close()
+
+	md, err := src.FindMethod("greet.GreetService/Greet")
+	assert.Nil(t, err)
+	var out bytes.Buffer
+	err = tricurl.Call(ctx, cli, md, strings.NewReader(`{"name": "hello world"}`), &out)
+	assert.Nil(t, err)
+	var resp map[string]string
+	assert.Nil(t, json.Unmarshal(out.Bytes(), &resp))
+	assert.Equal(t, "hello world", resp["greeting"])
+
+	err = tricurl.Call(ctx, cli, md, strings.NewReader(`{"name": "a"} {"name": "b"}`), &out)
+	assert.NotNil(t, err)
+}
+
+func TestTricurlStreams(t *testing.T) {
+	ctx := context.Background()
+	src, err := tricurl.NewSource(ctx, cli)
+	assert.Nil(t, err)
+	defer src.Close()
+
+	tests := []struct {
+		method string
+		in     string
+		want   []string
+	}{
+		{"GreetClientStream", `{"name": "a"} {"name": "b"} {"name": "c"}`, []string{"a,b,c"}},
+		{"GreetServerStream", `{"name": "a"}`, []string{"a", "a", "a", "a", "a"}},
+		{"GreetStream", `{"name": "a"} {"name": "b"} {"name": "c"}`, []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			md, err := src.FindMethod("greet.GreetService/" + tt.method)
+			assert.Nil(t, err)
+			var out bytes.Buffer
+			err = tricurl.Call(ctx, cli, md, strings.NewReader(tt.in), &out)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, greetings(t, &out))
+		})
+	}
+
+	// a server stream takes one request, as a unary call does
+	md, err := src.FindMethod("greet.GreetService/GreetServerStream")
+	assert.Nil(t, err)
+	err = tricurl.Call(ctx, cli, md, strings.NewReader(`{"name": "a"} {"name": "b"}`), io.Discard)
+	assert.NotNil(t, err)
+}
+
+// greetings decodes the greetings of the JSON responses written by a call.
+func greetings(t *testing.T, out io.Reader) []string {
+	var list []string
+	dec := json.NewDecoder(out)
+	for {
+		var resp map[string]string
+		err := dec.Decode(&resp)
+		if errors.Is(err, io.EOF) {
+			return list
+		}
+		assert.Nil(t, err)
+		list = append(list, resp["greeting"])
+	}
+}
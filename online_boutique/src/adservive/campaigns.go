@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"os"
+
+	"dubbo.apache.org/dubbo-go/v3/config"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"github.com/dubbogo/gost/log/logger"
+
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/adservice/handler"
+)
+
+const (
+	campaignsGroup  = "online-boutique"
+	campaignsDataID = "adservice.campaigns"
+)
+
+// loadCampaigns reads the campaign config at path, or the built-in one when
+// path is empty.
+func loadCampaigns(path string) (*handler.CampaignConfig, error) {
+	data := defaultCampaigns
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	return handler.ParseCampaigns(data)
+}
+
+// campaignListener applies campaign configs published to the config center.
+// Invalid configs are logged and ignored, so a typo never takes ads down.
+type campaignListener struct {
+	svc *handler.AdService
+}
+
+func (l campaignListener) Process(event *config_center.ConfigChangeEvent) {
+	content, ok := event.Value.(string)
+	if !ok || content == "" {
+		logger.Warnf("ignoring campaign config event %s", event)
+		return
+	}
+	l.apply(content)
+}
+
+func (l campaignListener) apply(content string) {
+	cfg, err := handler.ParseCampaigns([]byte(content))
+	if err != nil {
+		logger.Errorf("ignoring campaign config from the config center: %v", err)
+		return
+	}
+	l.svc.SetCampaigns(cfg)
+}
+
+// watchConfigCenter serves the campaigns stored in the zookeeper config center
+// at addr, under group "online-boutique" and data ID "adservice.campaigns",
+// and follows their changes. The local campaigns stay in use until the config
+// center has some.
+func watchConfigCenter(addr string, svc *handler.AdService) error {
+	dynamicConfig, err := config.NewConfigCenterConfigBuilder().
+		SetProtocol("zookeeper").
+		SetAddress(addr).
+		SetGroup(campaignsGroup).
+		// the zookeeper listener resolves keys in the namespace
+		SetNamespace(campaignsGroup).
+		Build().
+		CreateDynamicConfiguration()
+	if err != nil {
+		return err
+	}
+	l := campaignListener{svc: svc}
+	dynamicConfig.AddListener(campaignsDataID, l, config_center.WithGroup(campaignsGroup))
+	content, err := dynamicConfig.GetProperties(campaignsDataID, config_center.WithGroup(campaignsGroup))
+	if err != nil {
+		logger.Infof("no campaigns in the config center yet: %v", err)
+		return nil
+	}
+	l.apply(content)
+	return nil
+}
@@ -19,67 +19,160 @@ package handler
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
 
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/adservice/proto"
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 	"github.com/dubbogo/gost/log/logger"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
 )
 
-const MAX_ADS_TO_SERVE = 2
+type campaignCounters struct {
+	served      atomic.Int64
+	impressions atomic.Int64
+	clicks      atomic.Int64
+}
+
+type AdService struct {
+	config atomic.Pointer[CampaignConfig]
+	caps   *frequencyCaps
 
-var adsMap = createAdsMap()
+	mu       sync.Mutex
+	rnd      *rand.Rand
+	counters map[string]*campaignCounters
+}
 
-type AdService struct{}
+func NewAdService(cfg *CampaignConfig) *AdService {
+	s := &AdService{
+		caps:     newFrequencyCaps(),
+		rnd:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		counters: make(map[string]*campaignCounters),
+	}
+	s.SetCampaigns(cfg)
+	return s
+}
 
-func (s *AdService) GetAds(ctx context.Context, req *pb.AdRequest) (res *pb.AdResponse, err error) {
+// SetCampaigns replaces the campaigns being served. Counters and frequency
+// caps of campaigns that keep their ID carry over, those of removed campaigns
+// are dropped.
+func (s *AdService) SetCampaigns(cfg *CampaignConfig) {
+	s.config.Store(cfg)
+	s.mu.Lock()
+	for id := range s.counters {
+		if cfg.byID[id] == nil {
+			delete(s.counters, id)
+		}
+	}
+	s.mu.Unlock()
+	s.caps.retain(cfg.byID)
+	logger.Infof("serving %d ad campaigns", len(cfg.Campaigns))
+}
+
+func (s *AdService) GetAds(ctx context.Context, req *pb.AdRequest) (*pb.AdResponse, error) {
 	logger.Infof("received ad request (context_words= %v )", req.ContextKeys)
-	allAds := make([]*pb.Ad, 0)
-	res = new(pb.AdResponse)
-	if len(req.ContextKeys) > 0 {
-		for _, category := range req.ContextKeys {
-			ads := getAdsByCategory(category)
-			allAds = append(allAds, ads...)
+	cfg := s.config.Load()
+	now := time.Now()
+
+	// targeted campaigns matching the context come first, when none match any
+	// running campaign may be shown
+	var matching, fallback []*Campaign
+	for _, c := range cfg.Campaigns {
+		if !c.running(now) || !s.caps.allowed(req.SessionId, c) {
+			continue
 		}
-		if len(allAds) == 0 {
-			allAds = getRandomAds()
+		fallback = append(fallback, c)
+		if c.targeted() && c.matches(req.ContextKeys) {
+			matching = append(matching, c)
 		}
-	} else {
-		allAds = getRandomAds()
 	}
-	res.Ads = allAds
+	candidates := matching
+	if len(candidates) == 0 {
+		candidates = fallback
+	}
+
+	res := new(pb.AdResponse)
+	// The frontend shows only some of the ads returned, so what was shown is
+	// counted from the IMPRESSION events it reports, apart from what was served.
+	for _, c := range s.pick(candidates, cfg.MaxAdsPerRequest) {
+		s.counter(c.ID).served.Add(1)
+		res.Ads = append(res.Ads, &pb.Ad{RedirectUrl: c.RedirectURL, Text: c.Text, AdId: c.ID})
+	}
 	return res, nil
 }
 
-func getAdsByCategory(category string) []*pb.Ad {
-	return adsMap[category]
+func (s *AdService) ReportAdEvent(ctx context.Context, req *pb.AdEventRequest) (*pb.AdEventResponse, error) {
+	c, ok := s.config.Load().byID[req.AdId]
+	if !ok {
+		return nil, triple.NewError(triple.CodeNotFound, fmt.Errorf("unknown ad %q", req.AdId))
+	}
+	switch req.Type {
+	case pb.AdEventType_IMPRESSION:
+		s.counter(c.ID).impressions.Add(1)
+		s.caps.record(req.SessionId, c.ID, time.Now())
+	case pb.AdEventType_CLICK:
+		s.counter(c.ID).clicks.Add(1)
+	default:
+		return nil, triple.NewError(triple.CodeInvalidArgument, fmt.Errorf("unsupported ad event type %s", req.Type))
+	}
+	return &pb.AdEventResponse{}, nil
 }
 
-func getRandomAds() []*pb.Ad {
-	ads := make([]*pb.Ad, 0, MAX_ADS_TO_SERVE)
-	allAds := make([]*pb.Ad, 0, 7)
-	for _, ads := range adsMap {
-		allAds = append(allAds, ads...)
+func (s *AdService) GetCampaignStats(ctx context.Context, req *pb.CampaignStatsRequest) (*pb.CampaignStatsResponse, error) {
+	for _, c := range s.config.Load().Campaigns {
+		s.counter(c.ID)
 	}
-	for i := 0; i < MAX_ADS_TO_SERVE; i++ {
-		ads = append(ads, allAds[rand.Intn(len(allAds))])
+	s.mu.Lock()
+	res := &pb.CampaignStatsResponse{}
+	for id, counters := range s.counters {
+		res.Campaigns = append(res.Campaigns, &pb.CampaignStats{
+			CampaignId:  id,
+			Served:      counters.served.Load(),
+			Impressions: counters.impressions.Load(),
+			Clicks:      counters.clicks.Load(),
+		})
 	}
-	return ads
+	s.mu.Unlock()
+	sort.Slice(res.Campaigns, func(i, j int) bool { return res.Campaigns[i].CampaignId < res.Campaigns[j].CampaignId })
+	return res, nil
 }
 
-func createAdsMap() map[string][]*pb.Ad {
-	hairdryer := &pb.Ad{RedirectUrl: "/product/2ZYFJ3GM2N", Text: "Hairdryer for sale. 50% off."}
-	tankTop := &pb.Ad{RedirectUrl: "/product/66VCHSJNUP", Text: "Tank top for sale. 20% off."}
-	candleHolder := &pb.Ad{RedirectUrl: "/product/0PUK6V6EV0", Text: "Candle holder for sale. 30% off."}
-	bambooGlassJar := &pb.Ad{RedirectUrl: "/product/9SIQT8TOJO", Text: "Bamboo glass jar for sale. 10% off."}
-	watch := &pb.Ad{RedirectUrl: "/product/1YMWWN1N4O", Text: "Watch for sale. Buy one, get second kit for free"}
-	mug := &pb.Ad{RedirectUrl: "/product/6E92ZMYYFZ", Text: "Mug for sale. Buy two, get third one for free"}
-	loafers := &pb.Ad{RedirectUrl: "/product/L9ECAV7KIM", Text: "Loafers for sale. Buy one, get second one for free"}
-	return map[string][]*pb.Ad{
-		"clothing":    {tankTop},
-		"accessories": {watch},
-		"footwear":    {loafers},
-		"hair":        {hairdryer},
-		"decor":       {candleHolder},
-		"kitchen":     {bambooGlassJar, mug},
+func (s *AdService) counter(id string) *campaignCounters {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.counters[id]
+	if !ok {
+		c = &campaignCounters{}
+		s.counters[id] = c
+	}
+	return c
+}
+
+// pick samples up to n campaigns without replacement, each draw weighted by
+// the campaign weight.
+func (s *AdService) pick(candidates []*Campaign, n int) []*Campaign {
+	remaining := append([]*Campaign(nil), candidates...)
+	picked := make([]*Campaign, 0, n)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(picked) < n && len(remaining) > 0 {
+		total := 0.0
+		for _, c := range remaining {
+			total += c.Weight
+		}
+		r := s.rnd.Float64() * total
+		i := 0
+		for ; i < len(remaining)-1; i++ {
+			if r -= remaining[i].Weight; r < 0 {
+				break
+			}
+		}
+		picked = append(picked, remaining[i])
+		remaining = append(remaining[:i], remaining[i+1:]...)
 	}
+	return picked
 }
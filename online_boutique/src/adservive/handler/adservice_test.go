@@ -0,0 +1,180 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+const testCampaigns = `
+max_ads_per_request: 2
+campaigns:
+  - {id: mug, text: Mug, redirect_url: /product/mug, categories: [kitchen], frequency_cap: 2}
+  - {id: jar, text: Jar, redirect_url: /product/jar, categories: [kitchen], keywords: [bamboo]}
+  - {id: watch, text: Watch, redirect_url: /product/watch, categories: [accessories]}
+  - {id: expired, text: Old, redirect_url: /product/old, start: 2020-01-01T00:00:00Z, end: 2020-02-01T00:00:00Z}
+  - {id: future, text: New, redirect_url: /product/new, start: 2999-01-01T00:00:00Z}
+`
+
+func newTestService(t *testing.T) *AdService {
+	t.Helper()
+	cfg, err := ParseCampaigns([]byte(testCampaigns))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewAdService(cfg)
+}
+
+func adIDs(t *testing.T, s *AdService, session string, keys ...string) map[string]bool {
+	t.Helper()
+	res, err := s.GetAds(context.Background(), &pb.AdRequest{ContextKeys: keys, SessionId: session})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make(map[string]bool)
+	for _, ad := range res.Ads {
+		if ids[ad.AdId] {
+			t.Errorf("ad %s served twice", ad.AdId)
+		}
+		ids[ad.AdId] = true
+	}
+	return ids
+}
+
+func TestGetAdsTargeting(t *testing.T) {
+	s := newTestService(t)
+	for i := 0; i < 50; i++ {
+		if ids := adIDs(t, s, "", "Kitchen"); len(ids) != 2 || !ids["mug"] || !ids["jar"] {
+			t.Fatalf("kitchen ads = %v, want mug and jar", ids)
+		}
+		if ids := adIDs(t, s, "", "bamboo"); len(ids) != 1 || !ids["jar"] {
+			t.Fatalf("bamboo ads = %v, want jar", ids)
+		}
+		// unmatched context falls back to any running campaign
+		ids := adIDs(t, s, "", "garden")
+		if len(ids) != 2 || ids["expired"] || ids["future"] {
+			t.Fatalf("fallback ads = %v, want two running campaigns", ids)
+		}
+	}
+}
+
+func TestFrequencyCap(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := s.ReportAdEvent(ctx, &pb.AdEventRequest{AdId: "mug", SessionId: "s1", Type: pb.AdEventType_IMPRESSION}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ids := adIDs(t, s, "s1", "kitchen"); ids["mug"] {
+		t.Errorf("capped campaign served again: %v", ids)
+	}
+	if ids := adIDs(t, s, "s2", "kitchen"); !ids["mug"] {
+		t.Errorf("cap of another session applied: %v", ids)
+	}
+}
+
+func TestReportAdEvent(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	// ads returned but not shown are served, not counted as impressions
+	adIDs(t, s, "", "accessories")
+	adIDs(t, s, "", "accessories")
+	for _, typ := range []pb.AdEventType{pb.AdEventType_IMPRESSION, pb.AdEventType_CLICK} {
+		if _, err := s.ReportAdEvent(ctx, &pb.AdEventRequest{AdId: "watch", Type: typ}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.ReportAdEvent(ctx, &pb.AdEventRequest{AdId: "unknown", Type: pb.AdEventType_CLICK}); err == nil {
+		t.Errorf("event for an unknown ad accepted")
+	}
+	if _, err := s.ReportAdEvent(ctx, &pb.AdEventRequest{AdId: "watch"}); err == nil {
+		t.Errorf("event without type accepted")
+	}
+
+	stats, err := s.GetCampaignStats(ctx, &pb.CampaignStatsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats.Campaigns) != 5 {
+		t.Fatalf("stats for %d campaigns, want 5", len(stats.Campaigns))
+	}
+	for _, c := range stats.Campaigns {
+		if c.CampaignId == "watch" && (c.Served != 2 || c.Impressions != 1 || c.Clicks != 1) {
+			t.Errorf("watch stats = %v, want 2 served, 1 impression and 1 click", c)
+		}
+	}
+}
+
+func TestSetCampaignsDropsRemoved(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+	for _, id := range []string{"mug", "watch"} {
+		if _, err := s.ReportAdEvent(ctx, &pb.AdEventRequest{AdId: id, SessionId: "s1", Type: pb.AdEventType_IMPRESSION}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg, err := ParseCampaigns([]byte(`
+campaigns:
+  - {id: watch, text: Watch, redirect_url: /product/watch, categories: [accessories]}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetCampaigns(cfg)
+
+	stats, err := s.GetCampaignStats(ctx, &pb.CampaignStatsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats.Campaigns) != 1 || stats.Campaigns[0].CampaignId != "watch" || stats.Campaigns[0].Impressions != 1 {
+		t.Errorf("stats = %v, want the impression of watch only", stats.Campaigns)
+	}
+	if counts := s.caps.sessions["s1"].counts; counts["mug"] != 0 || counts["watch"] != 1 {
+		t.Errorf("frequency counts = %v, want watch only", counts)
+	}
+}
+
+func TestParseCampaignsRejects(t *testing.T) {
+	tests := map[string]string{
+		"missing id":   `campaigns: [{text: a, redirect_url: /}]`,
+		"duplicate id": `campaigns: [{id: a, text: a, redirect_url: /}, {id: a, text: b, redirect_url: /}]`,
+		"no text":      `campaigns: [{id: a, redirect_url: /}]`,
+		"ends early":   `campaigns: [{id: a, text: a, redirect_url: /, start: 2024-02-01T00:00:00Z, end: 2024-01-01T00:00:00Z}]`,
+		"negative cap": `campaigns: [{id: a, text: a, redirect_url: /, frequency_cap: -1}]`,
+	}
+	for name, config := range tests {
+		if _, err := ParseCampaigns([]byte(config)); err == nil {
+			t.Errorf("%s: config accepted", name)
+		}
+	}
+}
+
+func TestDefaultCampaigns(t *testing.T) {
+	data, err := os.ReadFile("../data/campaigns.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseCampaigns(data); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultMaxAdsPerRequest = 2
+
+// Campaign is an ad together with the rules deciding where and how often it
+// is shown.
+type Campaign struct {
+	ID          string   `yaml:"id"`
+	Text        string   `yaml:"text"`
+	RedirectURL string   `yaml:"redirect_url"`
+	Categories  []string `yaml:"categories"`
+	Keywords    []string `yaml:"keywords"`
+	// Weight is the relative chance of the campaign to be picked. Defaults to 1.
+	Weight float64 `yaml:"weight"`
+	// Start and End bound when the campaign runs, zero means unbounded.
+	Start time.Time `yaml:"start"`
+	End   time.Time `yaml:"end"`
+	// FrequencyCap is the number of impressions per session, zero means no cap.
+	FrequencyCap int `yaml:"frequency_cap"`
+
+	targets map[string]bool
+}
+
+type CampaignConfig struct {
+	MaxAdsPerRequest int         `yaml:"max_ads_per_request"`
+	Campaigns        []*Campaign `yaml:"campaigns"`
+
+	byID map[string]*Campaign
+}
+
+// ParseCampaigns reads a campaign config in YAML, or JSON which is a subset of it.
+func ParseCampaigns(data []byte) (*CampaignConfig, error) {
+	cfg := &CampaignConfig{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid campaign config: %w", err)
+	}
+	if cfg.MaxAdsPerRequest == 0 {
+		cfg.MaxAdsPerRequest = defaultMaxAdsPerRequest
+	}
+	if cfg.MaxAdsPerRequest < 0 {
+		return nil, fmt.Errorf("max_ads_per_request must be positive")
+	}
+	cfg.byID = make(map[string]*Campaign, len(cfg.Campaigns))
+	for i, c := range cfg.Campaigns {
+		switch {
+		case c.ID == "":
+			return nil, fmt.Errorf("campaign %d has no id", i)
+		case cfg.byID[c.ID] != nil:
+			return nil, fmt.Errorf("duplicate campaign id %q", c.ID)
+		case c.Text == "" || c.RedirectURL == "":
+			return nil, fmt.Errorf("campaign %q needs a text and a redirect_url", c.ID)
+		case c.Weight < 0 || c.FrequencyCap < 0:
+			return nil, fmt.Errorf("campaign %q has a negative weight or frequency_cap", c.ID)
+		case !c.End.IsZero() && !c.End.After(c.Start):
+			return nil, fmt.Errorf("campaign %q ends before it starts", c.ID)
+		}
+		if c.Weight == 0 {
+			c.Weight = 1
+		}
+		c.targets = make(map[string]bool, len(c.Categories)+len(c.Keywords))
+		for _, t := range append(append([]string(nil), c.Categories...), c.Keywords...) {
+			c.targets[strings.ToLower(t)] = true
+		}
+		cfg.byID[c.ID] = c
+	}
+	return cfg, nil
+}
+
+func (c *Campaign) running(now time.Time) bool {
+	return !now.Before(c.Start) && (c.End.IsZero() || now.Before(c.End))
+}
+
+// targeted reports whether the campaign is restricted to some context keys.
+func (c *Campaign) targeted() bool {
+	return len(c.targets) > 0
+}
+
+func (c *Campaign) matches(keys []string) bool {
+	for _, k := range keys {
+		if c.targets[strings.ToLower(k)] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"sync"
+	"time"
+)
+
+// sessionTTL is how long the impressions of an idle session are remembered.
+const sessionTTL = 24 * time.Hour
+
+type sessionImpressions struct {
+	lastSeen time.Time
+	counts   map[string]int
+}
+
+// frequencyCaps counts impressions per session and campaign in memory.
+type frequencyCaps struct {
+	mu        sync.Mutex
+	sessions  map[string]*sessionImpressions
+	lastPrune time.Time
+}
+
+func newFrequencyCaps() *frequencyCaps {
+	return &frequencyCaps{sessions: make(map[string]*sessionImpressions)}
+}
+
+// allowed reports whether the session may see the campaign once more.
+func (f *frequencyCaps) allowed(sessionID string, c *Campaign) bool {
+	if sessionID == "" || c.FrequencyCap == 0 {
+		return true
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.sessions[sessionID]
+	return !ok || s.counts[c.ID] < c.FrequencyCap
+}
+
+func (f *frequencyCaps) record(sessionID, campaignID string, now time.Time) {
+	if sessionID == "" {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.sessions[sessionID]
+	if !ok {
+		s = &sessionImpressions{counts: make(map[string]int)}
+		f.sessions[sessionID] = s
+	}
+	s.lastSeen = now
+	s.counts[campaignID]++
+
+	if now.Sub(f.lastPrune) > sessionTTL/24 {
+		f.lastPrune = now
+		for id, s := range f.sessions {
+			if now.Sub(s.lastSeen) > sessionTTL {
+				delete(f.sessions, id)
+			}
+		}
+	}
+}
+
+// retain forgets the impressions of the campaigns that are not in ids.
+func (f *frequencyCaps) retain(ids map[string]*Campaign) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, s := range f.sessions {
+		for id := range s.counts {
+			if ids[id] == nil {
+				delete(s.counts, id)
+			}
+		}
+	}
+}
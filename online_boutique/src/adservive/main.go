@@ -18,24 +18,47 @@
 package main
 
 import (
+	_ "embed"
+	"os"
+
 	"dubbo.apache.org/dubbo-go/v3"
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
 	"dubbo.apache.org/dubbo-go/v3/protocol"
 	"dubbo.apache.org/dubbo-go/v3/registry"
 	"github.com/apache/dubbo-go-samples/online_boutique_demo/adservice/handler"
-	hipstershop "github.com/apache/dubbo-go-samples/online_boutique_demo/adservice/proto"
+	hipstershop "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/proto/tracing"
 	"github.com/dubbogo/gost/log/logger"
-	"os"
 )
 
+//go:embed data/campaigns.yaml
+var defaultCampaigns []byte
+
 func main() {
 	regAddr := os.Getenv("DUBBO_REGISTRY_ADDRESS")
 	if regAddr == "" {
 		regAddr = "127.0.0.1:2181"
 	}
 
+	campaigns, err := loadCampaigns(os.Getenv("ADS_CAMPAIGNS_PATH"))
+	if err != nil {
+		panic(err)
+	}
+	adService := handler.NewAdService(campaigns)
+	if addr := os.Getenv("ADS_CONFIG_CENTER_ADDRESS"); addr != "" {
+		if err := watchConfigCenter(addr, adService); err != nil {
+			panic(err)
+		}
+	}
+
+	withTracing, err := tracing.Option()
+	if err != nil {
+		panic(err)
+	}
+
 	ins, err := dubbo.NewInstance(
 		dubbo.WithName("adservice"),
+		withTracing,
 		dubbo.WithRegistry(
 			registry.WithZookeeper(),
 			registry.WithAddress(regAddr),
@@ -54,11 +77,7 @@ func main() {
 		panic(err)
 	}
 
-	if err != nil {
-		panic(err)
-	}
-
-	if err := hipstershop.RegisterAdServiceHandler(srv, &handler.AdService{}); err != nil {
+	if err := hipstershop.RegisterAdServiceHandler(srv, adService); err != nil {
 		panic(err)
 	}
 
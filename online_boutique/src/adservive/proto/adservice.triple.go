@@ -1,118 +0,0 @@
-// Code generated by protoc-gen-triple. DO NOT EDIT.
-//
-// Source: proto/adservice.proto
-package hipstershop
-
-import (
-	"context"
-
-	"dubbo.apache.org/dubbo-go/v3"
-	"dubbo.apache.org/dubbo-go/v3/client"
-	"dubbo.apache.org/dubbo-go/v3/common"
-	"dubbo.apache.org/dubbo-go/v3/common/constant"
-	"dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
-	"dubbo.apache.org/dubbo-go/v3/server"
-)
-
-// This is a compile-time assertion to ensure that this generated file and the Triple package
-// are compatible. If you get a compiler error that this constant is not defined, this code was
-// generated with a version of Triple newer than the one compiled into your binary. You can fix the
-// problem by either regenerating this code with an older version of Triple or updating the Triple
-// version compiled into your binary.
-const _ = triple_protocol.IsAtLeastVersion0_1_0
-
-const (
-	// AdServiceName is the fully-qualified name of the AdService service.
-	AdServiceName = "hipstershop.AdService"
-)
-
-// These constants are the fully-qualified names of the RPCs defined in this package. They're
-// exposed at runtime as procedure and as the final two segments of the HTTP route.
-//
-// Note that these are different from the fully-qualified method names used by
-// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
-// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
-// period.
-const (
-	// AdServiceGetAdsProcedure is the fully-qualified name of the AdService's GetAds RPC.
-	AdServiceGetAdsProcedure = "/hipstershop.AdService/GetAds"
-)
-
-var _ AdService = (*AdServiceImpl)(nil)
-
-// AdService is a client for the hipstershop.AdService service.
-type AdService interface {
-	GetAds(ctx context.Context, req *AdRequest, opts ...client.CallOption) (*AdResponse, error)
-}
-
-// NewAdService constructs a client for the hipstershop.AdService service.
-func NewAdService(cli *client.Client, opts ...client.ReferenceOption) (AdService, error) {
-	conn, err := cli.DialWithInfo("hipstershop.AdService", &AdService_ClientInfo, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return &AdServiceImpl{
-		conn: conn,
-	}, nil
-}
-
-func SetConsumerService(srv common.RPCService) {
-	dubbo.SetConsumerServiceWithInfo(srv, &AdService_ClientInfo)
-}
-
-// AdServiceImpl implements AdService.
-type AdServiceImpl struct {
-	conn *client.Connection
-}
-
-func (c *AdServiceImpl) GetAds(ctx context.Context, req *AdRequest, opts ...client.CallOption) (*AdResponse, error) {
-	resp := new(AdResponse)
-	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "GetAds", opts...); err != nil {
-		return nil, err
-	}
-	return resp, nil
-}
-
-var AdService_ClientInfo = client.ClientInfo{
-	InterfaceName: "hipstershop.AdService",
-	MethodNames:   []string{"GetAds"},
-	ConnectionInjectFunc: func(dubboCliRaw interface{}, conn *client.Connection) {
-		dubboCli := dubboCliRaw.(*AdServiceImpl)
-		dubboCli.conn = conn
-	},
-}
-
-// AdServiceHandler is an implementation of the hipstershop.AdService service.
-type AdServiceHandler interface {
-	GetAds(context.Context, *AdRequest) (*AdResponse, error)
-}
-
-func RegisterAdServiceHandler(srv *server.Server, hdlr AdServiceHandler, opts ...server.ServiceOption) error {
-	return srv.Register(hdlr, &AdService_ServiceInfo, opts...)
-}
-
-func SetProviderService(srv common.RPCService) {
-	dubbo.SetProviderServiceWithInfo(srv, &AdService_ServiceInfo)
-}
-
-var AdService_ServiceInfo = server.ServiceInfo{
-	InterfaceName: "hipstershop.AdService",
-	ServiceType:   (*AdServiceHandler)(nil),
-	Methods: []server.MethodInfo{
-		{
-			Name: "GetAds",
-			Type: constant.CallUnary,
-			ReqInitFunc: func() interface{} {
-				return new(AdRequest)
-			},
-			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
-				req := args[0].(*AdRequest)
-				res, err := handler.(AdServiceHandler).GetAds(ctx, req)
-				if err != nil {
-					return nil, err
-				}
-				return triple_protocol.NewResponse(res), nil
-			},
-		},
-	},
-}
@@ -0,0 +1,108 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command allinone runs the whole online boutique in a single process, with
+// no registry: the services share one triple server and reach each other
+// through its direct tri:// URL. Services can be switched off one by one and
+// pointed at an instance running elsewhere instead.
+//
+// -root points at online_boutique/src, where the services keep their data:
+//
+//	make run ARGS="-email=false -email-url tri://127.0.0.1:20004"
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/dubbogo/gost/log/logger"
+)
+
+type serviceFlags struct {
+	enabled bool
+	url     string
+}
+
+type options struct {
+	root     string
+	stateDir string
+	port     int
+	httpAddr string
+	services map[string]*serviceFlags
+}
+
+var serviceNames = []string{
+	"ad", "cart", "checkout", "currency", "email", "frontend", "inventory",
+	"payment", "productcatalog", "promotion", "recommendation", "shipping", "user",
+}
+
+func parseFlags(args []string) (*options, error) {
+	opts := &options{services: make(map[string]*serviceFlags)}
+	fs := flag.NewFlagSet("allinone", flag.ContinueOnError)
+	fs.StringVar(&opts.root, "root", ".", "the online_boutique/src directory, where the services read their data files")
+	fs.StringVar(&opts.stateDir, "state", "", "directory for shipments, mail and accounts, a temporary one when empty")
+	fs.IntVar(&opts.port, "port", 20020, "port of the triple server shared by the services")
+	fs.StringVar(&opts.httpAddr, "http", ":8090", "address of the frontend")
+	for _, name := range serviceNames {
+		f := &serviceFlags{}
+		fs.BoolVar(&f.enabled, name, true, fmt.Sprintf("run the %s service in this process", name))
+		fs.StringVar(&f.url, name+"-url", "", fmt.Sprintf("tri:// URL of the %s service when it is not run in this process", name))
+		opts.services[name] = f
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if opts.stateDir == "" {
+		dir, err := os.MkdirTemp("", "online-boutique-")
+		if err != nil {
+			return nil, err
+		}
+		opts.stateDir = dir
+	}
+	return opts, nil
+}
+
+func main() {
+	opts, err := parseFlags(os.Args[1:])
+	if err != nil {
+		os.Exit(2)
+	}
+	shop, err := start(opts)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	logger.Infof("online boutique is running, state in %s", opts.stateDir)
+
+	if shop.frontend != nil {
+		go func() {
+			logger.Infof("frontend listening on %s", opts.httpAddr)
+			if err := http.ListenAndServe(opts.httpAddr, shop.frontend); err != nil {
+				logger.Fatal(err)
+			}
+		}()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	shop.close()
+}
@@ -0,0 +1,178 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alibaba/sentinel-golang/core/stat"
+)
+
+func freePort(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func TestCheckout(t *testing.T) {
+	t.Setenv("TRACING_EXPORTER", "memory")
+	opts, err := parseFlags([]string{"-root", "..", "-state", t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts.port = freePort(t)
+	shop, err := start(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shop.close()
+
+	ts := httptest.NewServer(shop.frontend)
+	defer ts.Close()
+	jar, _ := cookiejar.New(nil)
+	c := &http.Client{Jar: jar, Timeout: 10 * time.Second}
+
+	// The triple server starts in the background, wait for the home page.
+	deadline := time.Now().Add(20 * time.Second)
+	for {
+		resp, err := c.Get(ts.URL + "/")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				break
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("home page not ready: %v", err)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	resp, err := c.PostForm(ts.URL+"/cart", url.Values{"product_id": {"OLJCESPC7Z"}, "quantity": {"3"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("add to cart: got status %d", resp.StatusCode)
+	}
+
+	resp, err = c.PostForm(ts.URL+"/cart/checkout", url.Values{
+		"email":                        {"someone@example.com"},
+		"street_address":               {"1600 Amphitheatre Parkway"},
+		"zip_code":                     {"94043"},
+		"city":                         {"Mountain View"},
+		"state":                        {"CA"},
+		"country":                      {"United States"},
+		"credit_card_number":           {"4432801561520454"},
+		"credit_card_expiration_month": {"1"},
+		"credit_card_expiration_year":  {"2039"},
+		"credit_card_cvv":              {"672"},
+		"coupon_code":                  {"WELCOME10"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("checkout: got status %d", resp.StatusCode)
+	}
+	if !strings.Contains(string(page), "$10 off your order") {
+		t.Error("the order page has no discount of the coupon")
+	}
+
+	// The calls of the frontend to the services it can do without go through
+	// their circuit breakers.
+	for _, res := range []string{"hipstershop.CartService::", "hipstershop.CurrencyService::", "hipstershop.RecommendationService::"} {
+		if stat.GetResourceNode(res) == nil {
+			t.Errorf("no circuit breaker statistics of %s", res)
+		}
+	}
+
+	newMail := filepath.Join(opts.stateDir, "maildir", "new")
+	for {
+		entries, _ := os.ReadDir(newMail)
+		if len(entries) > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("no order confirmation delivered")
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	// The checkout is one trace, with a server span below the client span of
+	// each call, across all the services taking part.
+	traces, err := recordedTraces(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var checkout *debugTrace
+	for i := range traces {
+		if traces[i].Spans[0].Name == "POST /cart/checkout" {
+			checkout = &traces[i]
+		}
+	}
+	if checkout == nil {
+		t.Fatal("no trace of the checkout")
+	}
+	byID := make(map[string]debugSpan)
+	for _, sp := range checkout.Spans {
+		byID[sp.SpanID] = sp
+	}
+	served := make(map[string]bool)
+	for _, sp := range checkout.Spans[1:] {
+		parent, ok := byID[sp.ParentID]
+		if !ok {
+			t.Errorf("span %s %s has no parent in the trace", sp.Service, sp.Name)
+			continue
+		}
+		if sp.Kind == "server" {
+			if parent.Kind != "client" || parent.Service != sp.Service || parent.Name != sp.Name {
+				t.Errorf("server span %s %s is a child of %s %s %s", sp.Service, sp.Name, parent.Kind, parent.Service, parent.Name)
+			}
+			served[sp.Service] = true
+		}
+	}
+	for _, svc := range []string{
+		"hipstershop.CheckoutService", "hipstershop.CartService", "hipstershop.ProductCatalogService",
+		"hipstershop.CurrencyService", "hipstershop.ShippingService", "hipstershop.PaymentService",
+		"hipstershop.EmailService", "hipstershop.RecommendationService", "hipstershop.InventoryService",
+		"hipstershop.PromotionService",
+	} {
+		if !served[svc] {
+			t.Errorf("%s is missing from the checkout trace", svc)
+		}
+	}
+}
@@ -0,0 +1,361 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"dubbo.apache.org/dubbo-go/v3"
+	"dubbo.apache.org/dubbo-go/v3/client"
+	_ "dubbo.apache.org/dubbo-go/v3/imports"
+	"dubbo.apache.org/dubbo-go/v3/protocol"
+	"dubbo.apache.org/dubbo-go/v3/server"
+	"github.com/dubbogo/gost/log/logger"
+	"github.com/sirupsen/logrus"
+
+	adhandler "github.com/apache/dubbo-go-samples/online_boutique_demo/adservice/handler"
+	carthandler "github.com/apache/dubbo-go-samples/online_boutique_demo/cartservice/handler"
+	checkouthandler "github.com/apache/dubbo-go-samples/online_boutique_demo/checkoutservice/handler"
+	currencyhandler "github.com/apache/dubbo-go-samples/online_boutique_demo/currencyservice/handler"
+	emailconfig "github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/config"
+	emailhandler "github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/handler"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/mailer"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/outbox"
+	frontend "github.com/apache/dubbo-go-samples/online_boutique_demo/frontend/server"
+	inventoryhandler "github.com/apache/dubbo-go-samples/online_boutique_demo/inventoryservice/handler"
+	paymentconfig "github.com/apache/dubbo-go-samples/online_boutique_demo/paymentservice/config"
+	paymenthandler "github.com/apache/dubbo-go-samples/online_boutique_demo/paymentservice/handler"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/paymentservice/processor"
+	cataloghandler "github.com/apache/dubbo-go-samples/online_boutique_demo/productcatalogservice/handler"
+	promotionhandler "github.com/apache/dubbo-go-samples/online_boutique_demo/promotionservice/handler"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/proto/tracing"
+	recommendationhandler "github.com/apache/dubbo-go-samples/online_boutique_demo/recommendationservice/handler"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/recommendationservice/recommender"
+	shippinghandler "github.com/apache/dubbo-go-samples/online_boutique_demo/shippingservice/handler"
+	userhandler "github.com/apache/dubbo-go-samples/online_boutique_demo/userservice/handler"
+)
+
+// shop is the running set of services.
+type shop struct {
+	opts     *options
+	ins      *dubbo.Instance
+	srv      *server.Server
+	clients  map[string]*client.Client // by service name
+	frontend http.Handler
+	closers  []func()
+}
+
+// start registers the enabled services on a triple server shared by all of
+// them and starts serving.
+func start(opts *options) (*shop, error) {
+	withTracing, err := tracing.Option("memory")
+	if err != nil {
+		return nil, err
+	}
+	ins, err := dubbo.NewInstance(
+		dubbo.WithName("online-boutique"),
+		withTracing,
+		dubbo.WithProtocol(
+			protocol.WithTriple(),
+			protocol.WithPort(opts.port),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	srv, err := ins.NewServer()
+	if err != nil {
+		return nil, err
+	}
+	s := &shop{opts: opts, ins: ins, srv: srv, clients: make(map[string]*client.Client)}
+
+	byURL := make(map[string]*client.Client)
+	for _, name := range serviceNames {
+		url := s.url(name)
+		if byURL[url] == nil {
+			if byURL[url], err = ins.NewClient(client.WithClientURL(url)); err != nil {
+				return nil, err
+			}
+		}
+		s.clients[name] = byURL[url]
+	}
+
+	for _, name := range serviceNames {
+		if !opts.services[name].enabled {
+			logger.Infof("%s service disabled, using %s", name, s.url(name))
+			continue
+		}
+		if err := s.register(name); err != nil {
+			s.close()
+			return nil, fmt.Errorf("starting the %s service: %w", name, err)
+		}
+	}
+
+	go func() {
+		if err := srv.Serve(); err != nil {
+			logger.Fatal(err)
+		}
+	}()
+	return s, nil
+}
+
+func (s *shop) close() {
+	for i := len(s.closers) - 1; i >= 0; i-- {
+		s.closers[i]()
+	}
+}
+
+// url returns where the named service is reached: the shared server when it
+// runs in this process, or its -<name>-url otherwise.
+func (s *shop) url(name string) string {
+	if f := s.opts.services[name]; !f.enabled && f.url != "" {
+		return f.url
+	}
+	return fmt.Sprintf("tri://127.0.0.1:%d", s.opts.port)
+}
+
+func (s *shop) path(service string, elem ...string) string {
+	return filepath.Join(append([]string{s.opts.root, service}, elem...)...)
+}
+
+func (s *shop) register(name string) error {
+	switch name {
+	case "ad":
+		data, err := os.ReadFile(s.path("adservive", "data", "campaigns.yaml"))
+		if err != nil {
+			return err
+		}
+		campaigns, err := adhandler.ParseCampaigns(data)
+		if err != nil {
+			return err
+		}
+		return pb.RegisterAdServiceHandler(s.srv, adhandler.NewAdService(campaigns))
+
+	case "cart":
+		return pb.RegisterCartServiceHandler(s.srv, carthandler.NewCartService())
+
+	case "checkout":
+		return s.registerCheckout()
+
+	case "currency":
+		return pb.RegisterCurrencyServiceHandler(s.srv, &currencyhandler.CurrencyService{
+			DataPath: s.path("currencyservice", "data", "currency_conversion.json"),
+		})
+
+	case "email":
+		maildir, err := mailer.NewMaildirSender(filepath.Join(s.opts.stateDir, "maildir"))
+		if err != nil {
+			return err
+		}
+		ob, err := outbox.Open(filepath.Join(s.opts.stateDir, "outbox"), maildir, outbox.Options{})
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		s.closers = append(s.closers, cancel)
+		go ob.Run(ctx)
+		svc, err := emailhandler.NewEmailService(os.DirFS(s.path("emailservice", "template")), emailconfig.Mail().From, ob)
+		if err != nil {
+			return err
+		}
+		return pb.RegisterEmailServiceHandler(s.srv, svc)
+
+	case "frontend":
+		return s.startFrontend()
+
+	case "inventory":
+		stock, err := inventoryhandler.LoadStock(s.path("productcatalogservice", "data", "stock.json"), 10*time.Minute)
+		if err != nil {
+			return err
+		}
+		return pb.RegisterInventoryServiceHandler(s.srv, inventoryhandler.NewInventoryService(stock))
+
+	case "payment":
+		if err := paymentconfig.Load(); err != nil {
+			return err
+		}
+		simulator, err := processor.NewSimulator(processor.SimulatorOptions{
+			Latency:     paymentconfig.Simulator().Latency,
+			FailureRate: paymentconfig.Simulator().FailureRate,
+		})
+		if err != nil {
+			return err
+		}
+		return pb.RegisterPaymentServiceHandler(s.srv, paymenthandler.NewPaymentService(simulator, paymentconfig.AcceptedCardBrands()))
+
+	case "productcatalog":
+		catalog, err := cataloghandler.NewProductCatalogService(s.path("productcatalogservice", "data", "products.json"), "")
+		if err != nil {
+			return err
+		}
+		if err := catalog.Watch(); err != nil {
+			return err
+		}
+		s.closers = append(s.closers, func() { catalog.Close() })
+		return pb.RegisterProductCatalogServiceHandler(s.srv, catalog)
+
+	case "promotion":
+		data, err := os.ReadFile(s.path("promotionservice", "data", "promotions.yaml"))
+		if err != nil {
+			return err
+		}
+		cfg, err := promotionhandler.ParsePromotions(data)
+		if err != nil {
+			return err
+		}
+		return pb.RegisterPromotionServiceHandler(s.srv, promotionhandler.NewPromotionService(promotionhandler.NewPromotions(cfg)))
+
+	case "recommendation":
+		catalog, err := pb.NewProductCatalogService(s.clients["productcatalog"])
+		if err != nil {
+			return err
+		}
+		svc, err := recommendationhandler.NewRecommendationServiceImpl(catalog, recommender.StrategyHybrid)
+		if err != nil {
+			return err
+		}
+		return pb.RegisterRecommendationServiceHandler(s.srv, svc)
+
+	case "shipping":
+		data, err := os.ReadFile(s.path("shippingservice", "data", "rates.json"))
+		if err != nil {
+			return err
+		}
+		rates, err := shippinghandler.ParseRateTable(data)
+		if err != nil {
+			return err
+		}
+		store, err := shippinghandler.NewShipmentStore(filepath.Join(s.opts.stateDir, "shipments.json"))
+		if err != nil {
+			return err
+		}
+		return pb.RegisterShippingServiceHandler(s.srv, shippinghandler.NewShippingService(rates, store, shippinghandler.DefaultTimeline))
+
+	case "user":
+		store, err := userhandler.NewUserStore(filepath.Join(s.opts.stateDir, "users.json"))
+		if err != nil {
+			return err
+		}
+		secret, err := userhandler.LoadSecret(filepath.Join(s.opts.stateDir, "secret.key"))
+		if err != nil {
+			return err
+		}
+		svc, err := userhandler.NewUserService(store, secret, 7*24*time.Hour)
+		if err != nil {
+			return err
+		}
+		return pb.RegisterUserServiceHandler(s.srv, svc)
+	}
+	return fmt.Errorf("unknown service %q", name)
+}
+
+func (s *shop) registerCheckout() error {
+	var err error
+	svc := &checkouthandler.CheckoutService{}
+	if svc.CartService, err = pb.NewCartService(s.clients["cart"]); err != nil {
+		return err
+	}
+	if svc.CurrencyService, err = pb.NewCurrencyService(s.clients["currency"]); err != nil {
+		return err
+	}
+	if svc.EmailService, err = pb.NewEmailService(s.clients["email"]); err != nil {
+		return err
+	}
+	if svc.InventoryService, err = pb.NewInventoryService(s.clients["inventory"]); err != nil {
+		return err
+	}
+	if svc.PaymentService, err = pb.NewPaymentService(s.clients["payment"]); err != nil {
+		return err
+	}
+	if svc.PromotionService, err = pb.NewPromotionService(s.clients["promotion"]); err != nil {
+		return err
+	}
+	if svc.ProductCatalogService, err = pb.NewProductCatalogService(s.clients["productcatalog"]); err != nil {
+		return err
+	}
+	if svc.RecommendationService, err = pb.NewRecommendationService(s.clients["recommendation"]); err != nil {
+		return err
+	}
+	if svc.ShippingService, err = pb.NewShippingService(s.clients["shipping"]); err != nil {
+		return err
+	}
+	return pb.RegisterCheckoutServiceHandler(s.srv, svc)
+}
+
+func (s *shop) startFrontend() error {
+	var err error
+	services := frontend.Services{}
+	breaker := client.WithFilter(frontend.BreakerFilter)
+	if services.Ad, err = pb.NewAdService(s.clients["ad"], breaker); err != nil {
+		return err
+	}
+	if services.Cart, err = pb.NewCartService(s.clients["cart"], breaker); err != nil {
+		return err
+	}
+	if services.Checkout, err = pb.NewCheckoutService(s.clients["checkout"]); err != nil {
+		return err
+	}
+	if services.Currency, err = pb.NewCurrencyService(s.clients["currency"], breaker); err != nil {
+		return err
+	}
+	if services.Inventory, err = pb.NewInventoryService(s.clients["inventory"]); err != nil {
+		return err
+	}
+	if services.ProductCatalog, err = pb.NewProductCatalogService(s.clients["productcatalog"]); err != nil {
+		return err
+	}
+	if services.Promotion, err = pb.NewPromotionService(s.clients["promotion"]); err != nil {
+		return err
+	}
+	if services.Recommendation, err = pb.NewRecommendationService(s.clients["recommendation"], breaker); err != nil {
+		return err
+	}
+	if services.Shipping, err = pb.NewShippingService(s.clients["shipping"]); err != nil {
+		return err
+	}
+	if services.User, err = pb.NewUserService(s.clients["user"]); err != nil {
+		return err
+	}
+
+	if err := frontend.LoadCircuitBreakers(); err != nil {
+		return err
+	}
+
+	log := logrus.New()
+	log.Level = logrus.InfoLevel
+	log.Formatter = &logrus.TextFormatter{TimestampFormat: time.RFC3339Nano, FullTimestamp: true}
+	log.Out = os.Stdout
+	handler, err := frontend.NewHandler(services, s.path("frontend"), log)
+	if err != nil {
+		return err
+	}
+	if memory != nil {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/debug/traces", debugTracesHandler)
+		mux.Handle("/", handler)
+		handler = mux
+	}
+	s.frontend = handler
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/otel/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// maxTraces is how many traces the memory exporter keeps, the older ones are
+// dropped for the memory of a long running shop not to grow without bound.
+const maxTraces = 200
+
+// memoryExporter keeps the spans of the shop in memory, for /debug/traces to
+// show them. As every service runs in this process, a trace is complete here.
+type memoryExporter struct {
+	*trace.DefaultExporter
+	spans *traceRing
+}
+
+// traceRing is a span exporter keeping the spans of the last traces it saw.
+type traceRing struct {
+	max    int
+	mu     sync.Mutex
+	order  []string // trace IDs, the oldest first
+	traces map[string][]sdktrace.ReadOnlySpan
+}
+
+func newTraceRing(max int) *traceRing {
+	return &traceRing{max: max, traces: make(map[string][]sdktrace.ReadOnlySpan)}
+}
+
+func (r *traceRing) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range spans {
+		id := s.SpanContext().TraceID().String()
+		if _, ok := r.traces[id]; !ok {
+			if len(r.order) == r.max {
+				delete(r.traces, r.order[0])
+				r.order = append(r.order[:0], r.order[1:]...)
+			}
+			r.order = append(r.order, id)
+		}
+		r.traces[id] = append(r.traces[id], s)
+	}
+	return nil
+}
+
+func (r *traceRing) Shutdown(context.Context) error { return nil }
+
+// GetSpans returns the spans kept, trace by trace.
+func (r *traceRing) GetSpans() tracetest.SpanStubs {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var spans []sdktrace.ReadOnlySpan
+	for _, id := range r.order {
+		spans = append(spans, r.traces[id]...)
+	}
+	return tracetest.SpanStubsFromReadOnlySpans(spans)
+}
+
+var (
+	memoryOnce sync.Once
+	memory     *memoryExporter
+)
+
+func init() {
+	extension.SetTraceExporter("memory", newMemoryExporter)
+}
+
+func newMemoryExporter(config *trace.ExporterConfig) (trace.Exporter, error) {
+	var initErr error
+	memoryOnce.Do(func() {
+		spans := newTraceRing(maxTraces)
+		tp, propagator, err := trace.NewExporter(config, func() (sdktrace.SpanExporter, error) {
+			return spans, nil
+		})
+		if err != nil {
+			initErr = err
+			return
+		}
+		memory = &memoryExporter{
+			DefaultExporter: &trace.DefaultExporter{TracerProvider: tp, Propagator: propagator},
+			spans:           spans,
+		}
+	})
+	if memory == nil {
+		if initErr == nil {
+			initErr = errors.New("memory trace exporter is not configured")
+		}
+		return nil, initErr
+	}
+	return memory, nil
+}
+
+type debugSpan struct {
+	SpanID   string  `json:"span_id"`
+	ParentID string  `json:"parent_id,omitempty"`
+	Name     string  `json:"name"`
+	Kind     string  `json:"kind"`
+	Service  string  `json:"service,omitempty"`
+	Status   string  `json:"status"`
+	Start    string  `json:"start"`
+	TookMs   float64 `json:"took_ms"`
+}
+
+type debugTrace struct {
+	TraceID string      `json:"trace_id"`
+	Spans   []debugSpan `json:"spans"`
+}
+
+// recordedTraces groups the spans kept by the memory exporter by trace, the
+// most recent trace first. Only the last maxTraces traces are kept.
+func recordedTraces(ctx context.Context) ([]debugTrace, error) {
+	if memory == nil {
+		return nil, errors.New("spans are only kept with TRACING_EXPORTER=memory")
+	}
+	if err := memory.TracerProvider.ForceFlush(ctx); err != nil {
+		return nil, err
+	}
+	spans := memory.spans.GetSpans()
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].StartTime.Before(spans[j].StartTime) })
+
+	var traces []debugTrace
+	index := make(map[string]int)
+	for _, s := range spans {
+		id := s.SpanContext.TraceID().String()
+		i, ok := index[id]
+		if !ok {
+			i = len(traces)
+			index[id] = i
+			traces = append(traces, debugTrace{TraceID: id})
+		}
+		ds := debugSpan{
+			SpanID: s.SpanContext.SpanID().String(),
+			Name:   s.Name,
+			Kind:   s.SpanKind.String(),
+			Status: s.Status.Code.String(),
+			Start:  s.StartTime.Format(time.RFC3339Nano),
+			TookMs: float64(s.EndTime.Sub(s.StartTime)) / float64(time.Millisecond),
+		}
+		if s.Parent.IsValid() {
+			ds.ParentID = s.Parent.SpanID().String()
+		}
+		for _, kv := range s.Attributes {
+			if kv.Key == semconv.RPCServiceKey {
+				ds.Service = kv.Value.AsString()
+			}
+		}
+		traces[i].Spans = append(traces[i].Spans, ds)
+	}
+	for i, j := 0, len(traces)-1; i < j; i, j = i+1, j-1 {
+		traces[i], traces[j] = traces[j], traces[i]
+	}
+	return traces, nil
+}
+
+func debugTracesHandler(w http.ResponseWriter, r *http.Request) {
+	traces, err := recordedTraces(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(traces)
+}
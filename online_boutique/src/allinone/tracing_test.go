@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestTraceRingKeepsLastTraces(t *testing.T) {
+	ring := newTraceRing(3)
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSyncer(ring)).Tracer("test")
+	for i := 0; i < 5; i++ {
+		ctx, root := tracer.Start(context.Background(), fmt.Sprintf("trace %d", i))
+		_, child := tracer.Start(ctx, fmt.Sprintf("child %d", i))
+		child.End()
+		root.End()
+	}
+
+	var names []string
+	for _, s := range ring.GetSpans() {
+		names = append(names, s.Name)
+	}
+	want := "[child 2 trace 2 child 3 trace 3 child 4 trace 4]"
+	if got := fmt.Sprint(names); got != want {
+		t.Errorf("spans kept = %s, want %s", got, want)
+	}
+}
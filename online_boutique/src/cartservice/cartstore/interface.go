@@ -18,7 +18,7 @@
 package cartstore
 
 import "context"
-import pb "github.com/apache/dubbo-go-samples/online_boutique_demo/cartservice/proto"
+import pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
 
 type CartStore interface {
 	AddItem(ctx context.Context, userID, productID string, quantity int32) error
@@ -19,7 +19,7 @@ package cartstore
 
 import (
 	"context"
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/cartservice/proto"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
 	"sync"
 )
 
@@ -4,8 +4,8 @@ go 1.20
 
 require (
 	dubbo.apache.org/dubbo-go/v3 v3.2.0-rc1.0.20240808044912-f61d7c94bfd2
+	github.com/apache/dubbo-go-samples/online_boutique_demo/proto v0.0.0
 	github.com/dubbogo/gost v1.14.0
-	google.golang.org/protobuf v1.34.1
 )
 
 require (
@@ -130,8 +130,11 @@ require (
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/genproto v0.0.0-20221227171554-f9683d7f8bef // indirect
 	google.golang.org/grpc v1.52.0 // indirect
+	google.golang.org/protobuf v1.34.1 // indirect
 	gopkg.in/ini.v1 v1.66.2 // indirect
 	gopkg.in/natefinch/lumberjack.v2 v2.2.1 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/apache/dubbo-go-samples/online_boutique_demo/proto => ../proto
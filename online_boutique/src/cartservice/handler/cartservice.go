@@ -20,7 +20,7 @@ package handler
 import (
 	"context"
 	"github.com/apache/dubbo-go-samples/online_boutique_demo/cartservice/cartstore"
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/cartservice/proto"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
 )
 
 type CartService struct {
@@ -19,39 +19,73 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 	"github.com/dubbogo/gost/log/logger"
 
 	"github.com/google/uuid"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 
 	"github.com/apache/dubbo-go-samples/online_boutique_demo/checkoutservice/money"
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/checkoutservice/proto"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/proto/tracing"
 )
 
 type CheckoutService struct {
 	CartService           pb.CartService
 	CurrencyService       pb.CurrencyService
 	EmailService          pb.EmailService
+	InventoryService      pb.InventoryService
 	PaymentService        pb.PaymentService
+	PromotionService      pb.PromotionService
 	ProductCatalogService pb.ProductCatalogService
+	RecommendationService pb.RecommendationService
 	ShippingService       pb.ShippingService
 }
 
+// upstreamError passes on the code of the service a step of the checkout
+// failed in, so that a declined card is not reported as an internal error.
+func upstreamError(err error) error {
+	code := triple.CodeOf(err)
+	if code == triple.CodeUnknown || code == triple.CodeBizError {
+		code = triple.CodeInternal
+	}
+	return triple.NewError(code, err)
+}
+
 func (s *CheckoutService) PlaceOrder(ctx context.Context, in *pb.PlaceOrderRequest) (*pb.PlaceOrderResponse, error) {
-	logger.Infof("[PlaceOrder] user_id=%q user_currency=%q", in.UserId, in.UserCurrency)
+	logger.Infof("[PlaceOrder] user_id=%q user_currency=%q%s", in.UserId, in.UserCurrency, requestFields(ctx))
+	ctx = tracing.OutgoingContext(ctx)
 
 	orderID, err := uuid.NewUUID()
 	if err != nil {
 		logger.Error(err)
-		return nil, status.Errorf(codes.Internal, "failed to generate order uuid")
+		return nil, triple.NewError(triple.CodeInternal, errors.New("failed to generate order uuid"))
 	}
 
 	prep, err := s.prepareOrderItemsAndShippingQuoteFromCart(ctx, in.UserId, in.UserCurrency, in.Address)
 	if err != nil {
 		logger.Error(err)
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, upstreamError(err)
+	}
+
+	if len(prep.cartItems) == 0 {
+		return nil, triple.NewError(triple.CodeInvalidArgument, errors.New("cart is empty"))
+	}
+
+	// The items are held before the card is charged, so that nobody pays for
+	// an item that is sold out, and taken out of the stock once shipped.
+	if err := s.reserveItems(ctx, orderID.String(), prep.cartItems); err != nil {
+		logger.Warn(err)
+		return nil, upstreamError(err)
+	}
+
+	discounts, err := s.redeemDiscounts(ctx, orderID.String(), prep.promotionLines, in.CouponCode, in.UserCurrency)
+	if err != nil {
+		logger.Warn(err)
+		s.releaseItems(tracing.DetachedContext(ctx), orderID.String())
+		return nil, upstreamError(err)
 	}
 
 	total := &pb.Money{CurrencyCode: in.UserCurrency, Units: 0, Nanos: 0}
@@ -60,18 +94,31 @@ func (s *CheckoutService) PlaceOrder(ctx context.Context, in *pb.PlaceOrderReque
 		multPrice := money.MultiplySlow(it.Cost, uint32(it.GetItem().GetQuantity()))
 		total = money.Must(money.Sum(total, multPrice))
 	}
+	for _, d := range discounts {
+		total = money.Must(money.Sum(total, money.Negate(d.Amount)))
+	}
 
 	txID, err := s.chargeCard(ctx, total, in.CreditCard)
 	if err != nil {
 		logger.Error(err)
-		return nil, status.Errorf(codes.Internal, "failed to charge card: %+v", err)
+		s.cancelOrder(tracing.DetachedContext(ctx), orderID.String())
+		return nil, upstreamError(fmt.Errorf("failed to charge card: %w", err))
 	}
 	logger.Infof("payment went through (transaction_id: %s)", txID)
 
 	shippingTrackingID, err := s.shipOrder(ctx, in.Address, prep.cartItems)
 	if err != nil {
 		logger.Error(err)
-		return nil, status.Errorf(codes.Unavailable, "shipping error: %+v", err)
+		// The card was charged for an order that is not going anywhere.
+		undo := tracing.DetachedContext(ctx)
+		s.refund(undo, txID)
+		s.cancelOrder(undo, orderID.String())
+		return nil, triple.NewError(triple.CodeUnavailable, fmt.Errorf("shipping error: %w", err))
+	}
+
+	if _, err := s.InventoryService.Commit(ctx, &pb.CommitRequest{OrderId: orderID.String()}); err != nil {
+		// The order has shipped, it is the stock count that is off now.
+		logger.Errorf("failed to commit the reserved items of order %s: %+v", orderID, err)
 	}
 
 	if err := s.emptyUserCart(ctx, in.UserId); err != nil {
@@ -84,6 +131,7 @@ func (s *CheckoutService) PlaceOrder(ctx context.Context, in *pb.PlaceOrderReque
 		ShippingCost:       prep.shippingCostLocalized,
 		ShippingAddress:    in.Address,
 		Items:              prep.orderItems,
+		Discounts:          discounts,
 	}
 
 	if err := s.sendOrderConfirmation(ctx, in.Email, orderResult); err != nil {
@@ -91,12 +139,17 @@ func (s *CheckoutService) PlaceOrder(ctx context.Context, in *pb.PlaceOrderReque
 	} else {
 		logger.Infof("order confirmation email sent to %q", in.Email)
 	}
+
+	if err := s.recordPurchase(ctx, in.UserId, prep.cartItems); err != nil {
+		logger.Warnf("failed to record purchase of %s for recommendations: %+v", in.UserId, err)
+	}
 	return &pb.PlaceOrderResponse{Order: orderResult}, nil
 }
 
 type orderPrep struct {
 	orderItems            []*pb.OrderItem
 	cartItems             []*pb.CartItem
+	promotionLines        []*pb.PromotionLine
 	shippingCostLocalized *pb.Money
 }
 
@@ -104,24 +157,25 @@ func (s *CheckoutService) prepareOrderItemsAndShippingQuoteFromCart(ctx context.
 	out := orderPrep{}
 	cartItems, err := s.getUserCart(ctx, userID)
 	if err != nil {
-		return out, fmt.Errorf("cart failure: %+v", err)
+		return out, fmt.Errorf("cart failure: %w", err)
 	}
-	orderItems, err := s.prepOrderItems(ctx, cartItems, userCurrency)
+	orderItems, promotionLines, err := s.prepOrderItems(ctx, cartItems, userCurrency)
 	if err != nil {
-		return out, fmt.Errorf("failed to prepare order: %+v", err)
+		return out, fmt.Errorf("failed to prepare order: %w", err)
 	}
 	shippingUSD, err := s.quoteShipping(ctx, address, cartItems)
 	if err != nil {
-		return out, fmt.Errorf("shipping quote failure: %+v", err)
+		return out, fmt.Errorf("shipping quote failure: %w", err)
 	}
 	shippingPrice, err := s.convertCurrency(ctx, shippingUSD, userCurrency)
 	if err != nil {
-		return out, fmt.Errorf("failed to convert shipping cost to currency: %+v", err)
+		return out, fmt.Errorf("failed to convert shipping cost to currency: %w", err)
 	}
 
 	out.shippingCostLocalized = shippingPrice
 	out.cartItems = cartItems
 	out.orderItems = orderItems
+	out.promotionLines = promotionLines
 	return out, nil
 }
 
@@ -131,49 +185,104 @@ func (s *CheckoutService) quoteShipping(ctx context.Context, address *pb.Address
 		Items:   items,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get shipping quote: %+v", err)
+		return nil, fmt.Errorf("failed to get shipping quote: %w", err)
 	}
 	return shippingQuote.GetCostUsd(), nil
 }
 
+func (s *CheckoutService) reserveItems(ctx context.Context, orderID string, items []*pb.CartItem) error {
+	if _, err := s.InventoryService.Reserve(ctx, &pb.ReserveRequest{OrderId: orderID, Items: items}); err != nil {
+		return fmt.Errorf("failed to reserve the items: %w", err)
+	}
+	return nil
+}
+
+// releaseItems gives up the reservation of an order that failed. Left alone,
+// the reservation would expire all the same, only later.
+func (s *CheckoutService) releaseItems(ctx context.Context, orderID string) {
+	if _, err := s.InventoryService.Release(ctx, &pb.ReleaseRequest{OrderId: orderID}); err != nil {
+		logger.Warnf("failed to release the reserved items of order %s: %+v", orderID, err)
+	}
+}
+
+// redeemDiscounts uses up the coupon for the order and returns the discounts
+// in the currency of the user.
+func (s *CheckoutService) redeemDiscounts(ctx context.Context, orderID string, lines []*pb.PromotionLine, coupon, userCurrency string) ([]*pb.Discount, error) {
+	resp, err := s.PromotionService.Redeem(ctx, &pb.RedeemRequest{OrderId: orderID, Lines: lines, CouponCode: coupon})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply the promotions: %w", err)
+	}
+	discounts := resp.GetDiscounts()
+	for _, d := range discounts {
+		if d.Amount, err = s.convertCurrency(ctx, d.GetAmount(), userCurrency); err != nil {
+			s.cancelRedemption(ctx, orderID)
+			return nil, fmt.Errorf("failed to convert discount %s to %s: %w", d.GetPromotionId(), userCurrency, err)
+		}
+	}
+	return discounts, nil
+}
+
+func (s *CheckoutService) cancelRedemption(ctx context.Context, orderID string) {
+	if _, err := s.PromotionService.CancelRedemption(ctx, &pb.CancelRedemptionRequest{OrderId: orderID}); err != nil {
+		logger.Warnf("failed to give back the coupon of order %s: %+v", orderID, err)
+	}
+}
+
+// cancelOrder undoes what an order that failed holds: its items and its coupon.
+// It is called with a tracing.DetachedContext, for the order to be undone even
+// when its caller gave up.
+func (s *CheckoutService) cancelOrder(ctx context.Context, orderID string) {
+	s.releaseItems(ctx, orderID)
+	s.cancelRedemption(ctx, orderID)
+}
+
 func (s *CheckoutService) getUserCart(ctx context.Context, userID string) ([]*pb.CartItem, error) {
 	cart, err := s.CartService.GetCart(ctx, &pb.GetCartRequest{UserId: userID})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user cart during checkout: %+v", err)
+		return nil, fmt.Errorf("failed to get user cart during checkout: %w", err)
 	}
 	return cart.GetItems(), nil
 }
 
 func (s *CheckoutService) emptyUserCart(ctx context.Context, userID string) error {
 	if _, err := s.CartService.EmptyCart(ctx, &pb.EmptyCartRequest{UserId: userID}); err != nil {
-		return fmt.Errorf("failed to empty user cart during checkout: %+v", err)
+		return fmt.Errorf("failed to empty user cart during checkout: %w", err)
 	}
 	return nil
 }
 
-func (s *CheckoutService) prepOrderItems(ctx context.Context, items []*pb.CartItem, userCurrency string) ([]*pb.OrderItem, error) {
+// prepOrderItems prices the items in the currency of the user, and in USD for
+// the promotions.
+func (s *CheckoutService) prepOrderItems(ctx context.Context, items []*pb.CartItem, userCurrency string) ([]*pb.OrderItem, []*pb.PromotionLine, error) {
 	out := make([]*pb.OrderItem, len(items))
+	lines := make([]*pb.PromotionLine, len(items))
 	for i, item := range items {
 		product, err := s.ProductCatalogService.GetProduct(ctx, &pb.GetProductRequest{Id: item.GetProductId()})
 		if err != nil {
-			return nil, fmt.Errorf("failed to get product #%q", item.GetProductId())
+			return nil, nil, fmt.Errorf("failed to get product #%q", item.GetProductId())
 		}
 		price, err := s.convertCurrency(ctx, product.GetPriceUsd(), userCurrency)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert price of %q to %s", item.GetProductId(), userCurrency)
+			return nil, nil, fmt.Errorf("failed to convert price of %q to %s", item.GetProductId(), userCurrency)
 		}
 		out[i] = &pb.OrderItem{Item: item, Cost: price}
+		lines[i] = &pb.PromotionLine{
+			ProductId:  item.GetProductId(),
+			Categories: product.GetCategories(),
+			Quantity:   item.GetQuantity(),
+			UnitPrice:  product.GetPriceUsd(),
+		}
 	}
-	return out, nil
+	return out, lines, nil
 }
 
 func (s *CheckoutService) convertCurrency(ctx context.Context, from *pb.Money, toCurrency string) (*pb.Money, error) {
-	result, err := s.CurrencyService.Convert(context.TODO(), &pb.CurrencyConversionRequest{
+	result, err := s.CurrencyService.Convert(ctx, &pb.CurrencyConversionRequest{
 		From:   from,
 		ToCode: toCurrency,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert currency: %+v", err)
+		return nil, fmt.Errorf("failed to convert currency: %w", err)
 	}
 	return result, err
 }
@@ -184,11 +293,18 @@ func (s *CheckoutService) chargeCard(ctx context.Context, amount *pb.Money, paym
 		CreditCard: paymentInfo,
 	})
 	if err != nil {
-		return "", fmt.Errorf("could not charge the card: %+v", err)
+		return "", fmt.Errorf("could not charge the card: %w", err)
 	}
 	return paymentResp.GetTransactionId(), nil
 }
 
+// refund gives the charge of an order that failed back to the card.
+func (s *CheckoutService) refund(ctx context.Context, transactionID string) {
+	if _, err := s.PaymentService.Refund(ctx, &pb.RefundRequest{TransactionId: transactionID}); err != nil {
+		logger.Errorf("failed to refund transaction %s: %+v", transactionID, err)
+	}
+}
+
 func (s *CheckoutService) sendOrderConfirmation(ctx context.Context, email string, order *pb.OrderResult) error {
 	_, err := s.EmailService.SendOrderConfirmation(ctx, &pb.SendOrderConfirmationRequest{
 		Email: email,
@@ -203,7 +319,22 @@ func (s *CheckoutService) shipOrder(ctx context.Context, address *pb.Address, it
 		Items:   items,
 	})
 	if err != nil {
-		return "", fmt.Errorf("shipment failed: %+v", err)
+		return "", fmt.Errorf("shipment failed: %w", err)
 	}
 	return resp.GetTrackingId(), nil
 }
+
+func (s *CheckoutService) recordPurchase(ctx context.Context, userID string, items []*pb.CartItem) error {
+	if s.RecommendationService == nil || len(items) == 0 {
+		return nil
+	}
+	productIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.GetProductId())
+	}
+	_, err := s.RecommendationService.RecordPurchase(ctx, &pb.RecordPurchaseRequest{
+		UserId:     userID,
+		ProductIds: productIDs,
+	})
+	return err
+}
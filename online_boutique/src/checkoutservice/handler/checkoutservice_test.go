@@ -0,0 +1,204 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"dubbo.apache.org/dubbo-go/v3/client"
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+// fakeBackends stands in for every service the checkout calls, and records
+// the calls that hold, undo or complete an order. The calls that undo one fail
+// once its context is done.
+type fakeBackends struct {
+	// The methods the checkout does not call are left to these.
+	pb.CartService
+	pb.CurrencyService
+	pb.EmailService
+	pb.InventoryService
+	pb.PaymentService
+	pb.PromotionService
+	pb.ProductCatalogService
+	pb.ShippingService
+
+	redeemErr, chargeErr, shipErr error
+	calls                         []string
+}
+
+func (f *fakeBackends) GetCart(context.Context, *pb.GetCartRequest, ...client.CallOption) (*pb.Cart, error) {
+	return &pb.Cart{Items: []*pb.CartItem{{ProductId: "OLJCESPC7Z", Quantity: 2}}}, nil
+}
+
+func (f *fakeBackends) EmptyCart(context.Context, *pb.EmptyCartRequest, ...client.CallOption) (*pb.Empty, error) {
+	return &pb.Empty{}, nil
+}
+
+func (f *fakeBackends) GetProduct(_ context.Context, req *pb.GetProductRequest, _ ...client.CallOption) (*pb.Product, error) {
+	return &pb.Product{Id: req.Id, PriceUsd: &pb.Money{CurrencyCode: "USD", Units: 10}}, nil
+}
+
+func (f *fakeBackends) Convert(_ context.Context, req *pb.CurrencyConversionRequest, _ ...client.CallOption) (*pb.Money, error) {
+	return req.From, nil
+}
+
+func (f *fakeBackends) GetQuote(context.Context, *pb.GetQuoteRequest, ...client.CallOption) (*pb.GetQuoteResponse, error) {
+	return &pb.GetQuoteResponse{CostUsd: &pb.Money{CurrencyCode: "USD", Units: 5}}, nil
+}
+
+func (f *fakeBackends) ShipOrder(context.Context, *pb.ShipOrderRequest, ...client.CallOption) (*pb.ShipOrderResponse, error) {
+	if f.shipErr != nil {
+		return nil, f.shipErr
+	}
+	return &pb.ShipOrderResponse{TrackingId: "track-1"}, nil
+}
+
+func (f *fakeBackends) Reserve(context.Context, *pb.ReserveRequest, ...client.CallOption) (*pb.Reservation, error) {
+	f.calls = append(f.calls, "Reserve")
+	return &pb.Reservation{}, nil
+}
+
+func (f *fakeBackends) Release(ctx context.Context, _ *pb.ReleaseRequest, _ ...client.CallOption) (*pb.Empty, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	f.calls = append(f.calls, "Release")
+	return &pb.Empty{}, nil
+}
+
+func (f *fakeBackends) Commit(context.Context, *pb.CommitRequest, ...client.CallOption) (*pb.Empty, error) {
+	f.calls = append(f.calls, "Commit")
+	return &pb.Empty{}, nil
+}
+
+func (f *fakeBackends) Redeem(context.Context, *pb.RedeemRequest, ...client.CallOption) (*pb.EvaluateResponse, error) {
+	if f.redeemErr != nil {
+		return nil, f.redeemErr
+	}
+	f.calls = append(f.calls, "Redeem")
+	return &pb.EvaluateResponse{}, nil
+}
+
+func (f *fakeBackends) CancelRedemption(ctx context.Context, _ *pb.CancelRedemptionRequest, _ ...client.CallOption) (*pb.Empty, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	f.calls = append(f.calls, "CancelRedemption")
+	return &pb.Empty{}, nil
+}
+
+func (f *fakeBackends) Charge(context.Context, *pb.ChargeRequest, ...client.CallOption) (*pb.ChargeResponse, error) {
+	if f.chargeErr != nil {
+		return nil, f.chargeErr
+	}
+	f.calls = append(f.calls, "Charge")
+	return &pb.ChargeResponse{TransactionId: "tx-1"}, nil
+}
+
+func (f *fakeBackends) Refund(ctx context.Context, req *pb.RefundRequest, _ ...client.CallOption) (*pb.RefundResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	f.calls = append(f.calls, "Refund "+req.TransactionId)
+	return &pb.RefundResponse{}, nil
+}
+
+func (f *fakeBackends) SendOrderConfirmation(context.Context, *pb.SendOrderConfirmationRequest, ...client.CallOption) (*pb.SendOrderConfirmationResponse, error) {
+	return &pb.SendOrderConfirmationResponse{}, nil
+}
+
+func newTestCheckout(f *fakeBackends) *CheckoutService {
+	return &CheckoutService{
+		CartService:           f,
+		CurrencyService:       f,
+		EmailService:          f,
+		InventoryService:      f,
+		PaymentService:        f,
+		PromotionService:      f,
+		ProductCatalogService: f,
+		ShippingService:       f,
+	}
+}
+
+func TestPlaceOrderCompensation(t *testing.T) {
+	tests := []struct {
+		name      string
+		fake      *fakeBackends
+		wantCode  triple.Code
+		wantCalls []string
+	}{
+		{"placed", &fakeBackends{}, 0,
+			[]string{"Reserve", "Redeem", "Charge", "Commit"}},
+		{"coupon refused", &fakeBackends{redeemErr: triple.NewError(triple.CodeFailedPrecondition, errors.New("coupon expired"))},
+			triple.CodeFailedPrecondition, []string{"Reserve", "Release"}},
+		{"card declined", &fakeBackends{chargeErr: triple.NewError(triple.CodeFailedPrecondition, errors.New("declined"))},
+			triple.CodeFailedPrecondition, []string{"Reserve", "Redeem", "Release", "CancelRedemption"}},
+		{"shipping down", &fakeBackends{shipErr: errors.New("no carrier")},
+			triple.CodeUnavailable, []string{"Reserve", "Redeem", "Charge", "Refund tx-1", "Release", "CancelRedemption"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestCheckout(tt.fake).PlaceOrder(context.Background(), &pb.PlaceOrderRequest{
+				UserId:       "u1",
+				UserCurrency: "USD",
+				Address:      &pb.Address{Country: "US"},
+				CreditCard:   &pb.CreditCardInfo{CreditCardNumber: "4111111111111111"},
+			})
+			if tt.wantCode == 0 && err != nil {
+				t.Fatalf("PlaceOrder: %v", err)
+			}
+			if tt.wantCode != 0 && triple.CodeOf(err) != tt.wantCode {
+				t.Errorf("PlaceOrder error %v, want code %v", err, tt.wantCode)
+			}
+			if !reflect.DeepEqual(tt.fake.calls, tt.wantCalls) {
+				t.Errorf("calls %v, want %v", tt.fake.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestPlaceOrderUndoesAfterCallerGaveUp(t *testing.T) {
+	f := &fakeBackends{shipErr: context.Canceled}
+	ctx, cancel := context.WithCancel(context.Background())
+	s := newTestCheckout(f)
+	s.ShippingService = &cancelOnShip{fakeBackends: f, cancel: cancel}
+	if _, err := s.PlaceOrder(ctx, &pb.PlaceOrderRequest{UserId: "u1", UserCurrency: "USD"}); err == nil {
+		t.Fatal("PlaceOrder succeeded without shipping")
+	}
+	if want := []string{"Reserve", "Redeem", "Charge", "Refund tx-1", "Release", "CancelRedemption"}; !reflect.DeepEqual(f.calls, want) {
+		t.Errorf("calls %v, want %v", f.calls, want)
+	}
+}
+
+// cancelOnShip cancels the order's context as the shipment fails, the way a
+// caller that gives up does.
+type cancelOnShip struct {
+	*fakeBackends
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnShip) ShipOrder(ctx context.Context, req *pb.ShipOrderRequest, opts ...client.CallOption) (*pb.ShipOrderResponse, error) {
+	c.cancel()
+	return c.fakeBackends.ShipOrder(ctx, req, opts...)
+}
@@ -0,0 +1,38 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// requestFields names the trace and the frontend request a call belongs to,
+// for the log lines of an order to be found next to its trace.
+func requestFields(ctx context.Context) string {
+	var fields string
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields += " trace_id=" + sc.TraceID().String()
+	}
+	if id := baggage.FromContext(ctx).Member("request_id").Value(); id != "" {
+		fields += " request_id=" + id
+	}
+	return fields
+}
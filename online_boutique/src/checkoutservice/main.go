@@ -23,7 +23,8 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/protocol"
 	"dubbo.apache.org/dubbo-go/v3/registry"
 	"github.com/apache/dubbo-go-samples/online_boutique_demo/checkoutservice/handler"
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/checkoutservice/proto"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/proto/tracing"
 	"github.com/dubbogo/gost/log/logger"
 	"os"
 )
@@ -39,8 +40,14 @@ func main() {
 		regAddr = "127.0.0.1:2181"
 	}
 
+	withTracing, err := tracing.Option()
+	if err != nil {
+		panic(err)
+	}
+
 	ins, err := dubbo.NewInstance(
 		dubbo.WithName(name),
+		withTracing,
 		dubbo.WithRegistry(
 			registry.WithZookeeper(),
 			registry.WithAddress(regAddr),
@@ -62,15 +69,21 @@ func main() {
 	cartService, err := pb.NewCartService(client)
 	currencyService, err := pb.NewCurrencyService(client)
 	emailService, err := pb.NewEmailService(client)
+	inventoryService, err := pb.NewInventoryService(client)
 	paymentService, err := pb.NewPaymentService(client)
+	promotionService, err := pb.NewPromotionService(client)
 	productCatalogService, err := pb.NewProductCatalogService(client)
+	recommendationService, err := pb.NewRecommendationService(client)
 	shippingService, err := pb.NewShippingService(client)
 	if err := pb.RegisterCheckoutServiceHandler(srv, &handler.CheckoutService{
 		CartService:           cartService,
 		CurrencyService:       currencyService,
 		EmailService:          emailService,
+		InventoryService:      inventoryService,
 		PaymentService:        paymentService,
+		PromotionService:      promotionService,
 		ProductCatalogService: productCatalogService,
+		RecommendationService: recommendationService,
 		ShippingService:       shippingService,
 	}); err != nil {
 		panic(err)
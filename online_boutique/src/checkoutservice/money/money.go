@@ -19,7 +19,7 @@ package money
 
 import (
 	"errors"
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/checkoutservice/proto"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
 )
 
 const (
@@ -22,7 +22,7 @@ import (
 	"reflect"
 	"testing"
 
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/checkoutservice/proto"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
 )
 
 func mmc(u int64, n int32, c string) *pb.Money { return &pb.Money{Units: u, Nanos: n, CurrencyCode: c} }
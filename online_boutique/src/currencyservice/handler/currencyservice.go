@@ -20,25 +20,38 @@ package handler
 import (
 	"context"
 	"encoding/json"
-	"github.com/dubbogo/grpc-go/codes"
-	"github.com/dubbogo/grpc-go/status"
+	"fmt"
 	"io/ioutil"
 	"math"
 
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/currencyservice/proto"
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
 )
 
-type CurrencyService struct{}
+const defaultDataPath = "data/currency_conversion.json"
+
+type CurrencyService struct {
+	// DataPath is the file holding the conversion rates, data/currency_conversion.json
+	// when empty.
+	DataPath string
+}
+
+func (s *CurrencyService) dataPath() string {
+	if s.DataPath == "" {
+		return defaultDataPath
+	}
+	return s.DataPath
+}
 
 func (s *CurrencyService) GetSupportedCurrencies(ctx context.Context, in *pb.Empty) (*pb.GetSupportedCurrenciesResponse, error) {
 	out := &pb.GetSupportedCurrenciesResponse{}
-	data, err := ioutil.ReadFile("data/currency_conversion.json")
+	data, err := ioutil.ReadFile(s.dataPath())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to load currency data : %+v", err.Error())
+		return nil, triple.NewError(triple.CodeInternal, fmt.Errorf("failed to load currency data : %+v", err.Error()))
 	}
 	currencies := make(map[string]float32)
 	if err := json.Unmarshal(data, &currencies); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to unmarshal currency data : %+v", err.Error())
+		return nil, triple.NewError(triple.CodeInternal, fmt.Errorf("failed to unmarshal currency data : %+v", err.Error()))
 	}
 	out.CurrencyCodes = make([]string, 0, len(currencies))
 	for k := range currencies {
@@ -49,21 +62,21 @@ func (s *CurrencyService) GetSupportedCurrencies(ctx context.Context, in *pb.Emp
 
 func (s *CurrencyService) Convert(ctx context.Context, in *pb.CurrencyConversionRequest) (*pb.Money, error) {
 	out := &pb.Money{}
-	data, err := ioutil.ReadFile("data/currency_conversion.json")
+	data, err := ioutil.ReadFile(s.dataPath())
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, triple.NewError(triple.CodeInternal, err)
 	}
 	currencies := make(map[string]float64)
 	if err := json.Unmarshal(data, &currencies); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to unmarshal currency data: %+v", err)
+		return nil, triple.NewError(triple.CodeInternal, fmt.Errorf("failed to unmarshal currency data: %+v", err))
 	}
 	fromCurrency, found := currencies[in.From.CurrencyCode]
 	if !found {
-		return nil, status.Errorf(codes.InvalidArgument, "unsupported currency: %s", in.From.CurrencyCode)
+		return nil, triple.NewError(triple.CodeInvalidArgument, fmt.Errorf("unsupported currency: %s", in.From.CurrencyCode))
 	}
 	toCurrency, found := currencies[in.ToCode]
 	if !found {
-		return nil, status.Errorf(codes.InvalidArgument, "unsupported currency: %s", in.ToCode)
+		return nil, triple.NewError(triple.CodeInvalidArgument, fmt.Errorf("unsupported currency: %s", in.ToCode))
 	}
 	out.CurrencyCode = in.ToCode
 	total := int64(math.Floor(float64(in.From.Units*10^9+int64(in.From.Nanos)) / fromCurrency * toCurrency))
@@ -23,7 +23,8 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/protocol"
 	"dubbo.apache.org/dubbo-go/v3/registry"
 	"github.com/apache/dubbo-go-samples/online_boutique_demo/currencyservice/handler"
-	hipstershop "github.com/apache/dubbo-go-samples/online_boutique_demo/currencyservice/proto"
+	hipstershop "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/proto/tracing"
 	"github.com/dubbogo/gost/log/logger"
 	"os"
 )
@@ -34,8 +35,14 @@ func main() {
 		regAddr = "127.0.0.1:2181"
 	}
 
+	withTracing, err := tracing.Option()
+	if err != nil {
+		panic(err)
+	}
+
 	ins, err := dubbo.NewInstance(
 		dubbo.WithName("currencyservice"),
+		withTracing,
 		dubbo.WithRegistry(
 			registry.WithZookeeper(),
 			registry.WithAddress(regAddr),
@@ -19,11 +19,13 @@ package config
 
 import (
 	"fmt"
+	"os"
 )
 
 type Config struct {
 	Port    int
 	Tracing TracingConfig
+	Mail    MailConfig
 }
 
 type TracingConfig struct {
@@ -35,8 +37,29 @@ type JaegerConfig struct {
 	URL string
 }
 
+type MailConfig struct {
+	From string
+	// Sender is either "maildir" or "smtp".
+	Sender     string
+	MaildirDir string
+	OutboxDir  string
+	SMTP       SMTPConfig
+}
+
+type SMTPConfig struct {
+	Addr     string
+	Username string
+	Password string
+}
+
 var cfg *Config = &Config{
 	Port: 50051,
+	Mail: MailConfig{
+		From:       "Online Boutique <no-reply@example.com>",
+		Sender:     "maildir",
+		MaildirDir: "data/maildir",
+		OutboxDir:  "data/outbox",
+	},
 }
 
 func Address() string {
@@ -47,7 +70,42 @@ func Tracing() TracingConfig {
 	return cfg.Tracing
 }
 
-// Load TODO:
+func Mail() MailConfig {
+	return cfg.Mail
+}
+
+// Load overrides the defaults from the environment:
+//
+//	EMAIL_FROM         sender address of the confirmations
+//	EMAIL_SENDER       "maildir" (default) writes messages to EMAIL_MAILDIR,
+//	                   "smtp" relays them through SMTP_ADDR
+//	EMAIL_MAILDIR      Maildir of the maildir sender
+//	EMAIL_OUTBOX_DIR   where messages wait for delivery
+//	SMTP_ADDR          host:port of the SMTP relay
+//	SMTP_USERNAME      optional SMTP credentials
+//	SMTP_PASSWORD
 func Load() error {
+	for env, field := range map[string]*string{
+		"EMAIL_FROM":       &cfg.Mail.From,
+		"EMAIL_SENDER":     &cfg.Mail.Sender,
+		"EMAIL_MAILDIR":    &cfg.Mail.MaildirDir,
+		"EMAIL_OUTBOX_DIR": &cfg.Mail.OutboxDir,
+		"SMTP_ADDR":        &cfg.Mail.SMTP.Addr,
+		"SMTP_USERNAME":    &cfg.Mail.SMTP.Username,
+		"SMTP_PASSWORD":    &cfg.Mail.SMTP.Password,
+	} {
+		if v := os.Getenv(env); v != "" {
+			*field = v
+		}
+	}
+	switch cfg.Mail.Sender {
+	case "maildir":
+	case "smtp":
+		if cfg.Mail.SMTP.Addr == "" {
+			return fmt.Errorf("EMAIL_SENDER=smtp requires SMTP_ADDR")
+		}
+	default:
+		return fmt.Errorf("unknown EMAIL_SENDER %q", cfg.Mail.Sender)
+	}
 	return nil
 }
@@ -18,19 +18,96 @@
 package handler
 
 import (
+	"bytes"
 	"context"
-	email "github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/proto"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"net/mail"
+	texttemplate "text/template"
+	"time"
+
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 	"github.com/dubbogo/gost/log/logger"
+
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/mailer"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/outbox"
+	email "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
 )
 
-type EmailService struct{}
+const confirmationSubject = "Your Order Confirmation"
+
+var templateFuncs = map[string]any{
+	"money": formatMoney,
+}
+
+type EmailService struct {
+	From   string
+	Outbox *outbox.Outbox
 
-type SendOrderConfirmationRequest struct {
-	Email string
-	Order string
+	html *htmltemplate.Template
+	text *texttemplate.Template
 }
 
+// NewEmailService parses confirmation.html and confirmation.txt from templates.
+func NewEmailService(templates fs.FS, from string, ob *outbox.Outbox) (*EmailService, error) {
+	if _, err := mail.ParseAddress(from); err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", from, err)
+	}
+	html, err := htmltemplate.New("confirmation.html").Funcs(templateFuncs).ParseFS(templates, "confirmation.html")
+	if err != nil {
+		return nil, err
+	}
+	text, err := texttemplate.New("confirmation.txt").Funcs(templateFuncs).ParseFS(templates, "confirmation.txt")
+	if err != nil {
+		return nil, err
+	}
+	return &EmailService{From: from, Outbox: ob, html: html, text: text}, nil
+}
+
+// SendOrderConfirmation queues the confirmation for delivery. It succeeds as
+// soon as the message is in the outbox, so a mail outage never fails checkout.
 func (e *EmailService) SendOrderConfirmation(ctx context.Context, req *email.SendOrderConfirmationRequest) (*email.SendOrderConfirmationResponse, error) {
-	logger.Infof("Sending order confirmation to %s for order %s", req.Email, req.Order)
-	return &email.SendOrderConfirmationResponse{Message: "Order confirmation sent successfully!"}, nil
+	to, err := mail.ParseAddress(req.Email)
+	if err != nil {
+		return nil, triple.NewError(triple.CodeInvalidArgument, fmt.Errorf("invalid email address %q", req.Email))
+	}
+	if req.Order == nil {
+		return nil, triple.NewError(triple.CodeInvalidArgument, errors.New("order is required"))
+	}
+	msg, err := e.confirmation(to.Address, req.Order)
+	if err != nil {
+		logger.Errorf("Failed to render order confirmation for order %s: %v", req.Order.OrderId, err)
+		return nil, triple.NewError(triple.CodeInternal, errors.New("failed to render order confirmation"))
+	}
+	if err := e.Outbox.Enqueue(msg); err != nil {
+		logger.Errorf("Failed to queue order confirmation for order %s: %v", req.Order.OrderId, err)
+		return nil, triple.NewError(triple.CodeInternal, errors.New("failed to queue order confirmation"))
+	}
+	logger.Infof("Queued order confirmation %s to %s for order %s", msg.ID, to.Address, req.Order.OrderId)
+	return &email.SendOrderConfirmationResponse{Message: "Order confirmation queued"}, nil
+}
+
+func (e *EmailService) confirmation(to string, order *email.OrderResult) (*mailer.Message, error) {
+	var html, text bytes.Buffer
+	if err := e.html.Execute(&html, order); err != nil {
+		return nil, err
+	}
+	if err := e.text.Execute(&text, order); err != nil {
+		return nil, err
+	}
+	return &mailer.Message{
+		ID:      mailer.NewID(),
+		From:    e.From,
+		To:      []string{to},
+		Subject: confirmationSubject,
+		Text:    text.String(),
+		HTML:    html.String(),
+		Date:    time.Now(),
+	}, nil
+}
+
+func formatMoney(m *email.Money) string {
+	return fmt.Sprintf("%d.%02d %s", m.GetUnits(), m.GetNanos()/10000000, m.GetCurrencyCode())
 }
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/outbox"
+	email "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+func TestConfirmation(t *testing.T) {
+	svc, err := NewEmailService(os.DirFS("../template"), "Shop <shop@example.com>", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := &email.OrderResult{
+		OrderId:            "order-1",
+		ShippingTrackingId: "AB-123-45",
+		ShippingCost:       &email.Money{CurrencyCode: "USD", Units: 8, Nanos: 990000000},
+		ShippingAddress:    &email.Address{StreetAddress: "1 <Main> St", City: "Springfield", Country: "US", ZipCode: 12345},
+		Items: []*email.OrderItem{
+			{Item: &email.CartItem{ProductId: "OLJCESPC7Z", Quantity: 2}, Cost: &email.Money{CurrencyCode: "USD", Units: 19, Nanos: 990000000}},
+		},
+	}
+	msg, err := svc.confirmation("buyer@example.com", order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"#order-1", "#AB-123-45", "8.99 USD", "#OLJCESPC7Z", "19.99 USD", "1 &lt;Main&gt; St"} {
+		if !strings.Contains(msg.HTML, want) {
+			t.Errorf("HTML part does not contain %q:\n%s", want, msg.HTML)
+		}
+	}
+	for _, want := range []string{"#order-1", "#OLJCESPC7Z x 2: 19.99 USD", "1 <Main> St"} {
+		if !strings.Contains(msg.Text, want) {
+			t.Errorf("text part does not contain %q:\n%s", want, msg.Text)
+		}
+	}
+}
+
+func TestSendOrderConfirmation(t *testing.T) {
+	ob, err := outbox.Open(t.TempDir(), nil, outbox.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc, err := NewEmailService(os.DirFS("../template"), "shop@example.com", ob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err := svc.SendOrderConfirmation(ctx, &email.SendOrderConfirmationRequest{Email: "not an address", Order: &email.OrderResult{}}); err == nil {
+		t.Errorf("SendOrderConfirmation accepted an invalid address")
+	}
+	if _, err := svc.SendOrderConfirmation(ctx, &email.SendOrderConfirmationRequest{Email: "buyer@example.com", Order: &email.OrderResult{OrderId: "order-1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if ob.Pending() != 1 {
+		t.Errorf("outbox has %d pending messages, want 1", ob.Pending())
+	}
+}
@@ -18,24 +18,77 @@
 package main
 
 import (
+	"context"
+	"embed"
+	"io/fs"
+	"os"
+	"time"
+
 	"dubbo.apache.org/dubbo-go/v3"
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
 	"dubbo.apache.org/dubbo-go/v3/protocol"
 	"dubbo.apache.org/dubbo-go/v3/registry"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/config"
 	"github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/handler"
-	email "github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/mailer"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/outbox"
+	email "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/proto/tracing"
 	"github.com/dubbogo/gost/log/logger"
-	"os"
 )
 
+//go:embed template
+var templates embed.FS
+
 func main() {
 	regAddr := os.Getenv("DUBBO_REGISTRY_ADDRESS")
 	if regAddr == "" {
 		regAddr = "127.0.0.1:2181"
 	}
 
+	if err := config.Load(); err != nil {
+		panic(err)
+	}
+	mailCfg := config.Mail()
+	var sender mailer.Sender
+	switch mailCfg.Sender {
+	case "smtp":
+		sender = &mailer.SMTPSender{
+			Addr:     mailCfg.SMTP.Addr,
+			Username: mailCfg.SMTP.Username,
+			Password: mailCfg.SMTP.Password,
+			Timeout:  30 * time.Second,
+		}
+	default:
+		maildir, err := mailer.NewMaildirSender(mailCfg.MaildirDir)
+		if err != nil {
+			panic(err)
+		}
+		sender = maildir
+	}
+	ob, err := outbox.Open(mailCfg.OutboxDir, sender, outbox.Options{})
+	if err != nil {
+		panic(err)
+	}
+	go ob.Run(context.Background())
+
+	tmpl, err := fs.Sub(templates, "template")
+	if err != nil {
+		panic(err)
+	}
+	emailService, err := handler.NewEmailService(tmpl, mailCfg.From, ob)
+	if err != nil {
+		panic(err)
+	}
+
+	withTracing, err := tracing.Option()
+	if err != nil {
+		panic(err)
+	}
+
 	ins, err := dubbo.NewInstance(
 		dubbo.WithName("emailservice"),
+		withTracing,
 		dubbo.WithRegistry(
 			registry.WithZookeeper(),
 			registry.WithAddress(regAddr),
@@ -56,7 +109,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := email.RegisterEmailServiceHandler(srv, &handler.EmailService{}); err != nil {
+	if err := email.RegisterEmailServiceHandler(srv, emailService); err != nil {
 		panic(err)
 	}
 	if err := srv.Serve(); err != nil {
@@ -0,0 +1,266 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package outbox persists outgoing messages on disk and delivers them in the
+// background, retrying with exponential backoff, so that callers never wait
+// for the mail relay.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dubbogo/gost/log/logger"
+
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/mailer"
+)
+
+// Options tunes delivery. Zero values pick the defaults.
+type Options struct {
+	// MaxAttempts is the number of deliveries tried before a message is
+	// moved to the failed directory. Defaults to 10.
+	MaxAttempts int
+	// BaseDelay is the wait after the first failure, doubled after every
+	// further failure up to MaxDelay. Defaults to 1s and 10m.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// SendTimeout bounds a single delivery. Defaults to 30s.
+	SendTimeout time.Duration
+}
+
+func (o *Options) setDefaults() {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 10
+	}
+	if o.BaseDelay <= 0 {
+		o.BaseDelay = time.Second
+	}
+	if o.MaxDelay <= 0 {
+		o.MaxDelay = 10 * time.Minute
+	}
+	if o.SendTimeout <= 0 {
+		o.SendTimeout = 30 * time.Second
+	}
+}
+
+type entry struct {
+	Message     *mailer.Message `json:"message"`
+	Attempts    int             `json:"attempts"`
+	NextAttempt time.Time       `json:"next_attempt"`
+	LastError   string          `json:"last_error,omitempty"`
+}
+
+// Outbox keeps one JSON file per pending message in its directory. Messages
+// that exhausted their attempts are moved to the "failed" subdirectory.
+type Outbox struct {
+	dir    string
+	sender mailer.Sender
+	opts   Options
+	wake   chan struct{}
+
+	mu      sync.Mutex
+	pending map[string]*entry
+}
+
+// Open loads the messages left over in dir by a previous run.
+func Open(dir string, sender mailer.Sender, opts Options) (*Outbox, error) {
+	opts.setDefaults()
+	if err := os.MkdirAll(filepath.Join(dir, "failed"), 0o755); err != nil {
+		return nil, err
+	}
+	o := &Outbox{
+		dir:     dir,
+		sender:  sender,
+		opts:    opts,
+		wake:    make(chan struct{}, 1),
+		pending: make(map[string]*entry),
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		e := &entry{}
+		if err := json.Unmarshal(data, e); err != nil || e.Message == nil {
+			logger.Warnf("[outbox] skipping unreadable message %s: %v", f, err)
+			continue
+		}
+		o.pending[e.Message.ID] = e
+	}
+	if len(o.pending) > 0 {
+		logger.Infof("[outbox] resuming delivery of %d messages", len(o.pending))
+	}
+	return o, nil
+}
+
+// Enqueue stores msg and schedules it for immediate delivery. It returns once
+// the message is on disk.
+func (o *Outbox) Enqueue(msg *mailer.Message) error {
+	if msg.ID == "" {
+		msg.ID = mailer.NewID()
+	}
+	e := &entry{Message: msg, NextAttempt: time.Now()}
+	if err := o.save(e); err != nil {
+		return err
+	}
+	o.mu.Lock()
+	o.pending[msg.ID] = e
+	o.mu.Unlock()
+
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Pending returns the number of messages waiting for delivery.
+func (o *Outbox) Pending() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.pending)
+}
+
+// Run delivers messages until ctx is done.
+func (o *Outbox) Run(ctx context.Context) {
+	for {
+		next := o.deliverDue(ctx)
+		var timer *time.Timer
+		var fire <-chan time.Time
+		if !next.IsZero() {
+			timer = time.NewTimer(time.Until(next))
+			fire = timer.C
+		}
+		select {
+		case <-ctx.Done():
+		case <-o.wake:
+		case <-fire:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// deliverDue tries every message whose time has come, oldest first, and
+// returns when the next attempt is due, or the zero time if nothing is left.
+func (o *Outbox) deliverDue(ctx context.Context) time.Time {
+	now := time.Now()
+	var due []*entry
+	o.mu.Lock()
+	for _, e := range o.pending {
+		if !e.NextAttempt.After(now) {
+			due = append(due, e)
+		}
+	}
+	o.mu.Unlock()
+	sort.Slice(due, func(i, j int) bool { return due[i].Message.Date.Before(due[j].Message.Date) })
+
+	for _, e := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		o.deliver(ctx, e)
+	}
+
+	var next time.Time
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, e := range o.pending {
+		if next.IsZero() || e.NextAttempt.Before(next) {
+			next = e.NextAttempt
+		}
+	}
+	return next
+}
+
+func (o *Outbox) deliver(ctx context.Context, e *entry) {
+	id := e.Message.ID
+	sendCtx, cancel := context.WithTimeout(ctx, o.opts.SendTimeout)
+	err := o.sender.Send(sendCtx, e.Message)
+	cancel()
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if err == nil {
+		delete(o.pending, id)
+		if rmErr := os.Remove(o.path(id)); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) {
+			logger.Warnf("[outbox] failed to remove delivered message %s: %v", id, rmErr)
+		}
+		logger.Infof("[outbox] delivered message %s to %s", id, strings.Join(e.Message.To, ", "))
+		return
+	}
+
+	e.Attempts++
+	e.LastError = err.Error()
+	if e.Attempts >= o.opts.MaxAttempts {
+		delete(o.pending, id)
+		if err := o.save(e); err != nil {
+			logger.Warnf("[outbox] failed to persist message %s: %v", id, err)
+		} else if err := os.Rename(o.path(id), filepath.Join(o.dir, "failed", id+".json")); err != nil {
+			logger.Warnf("[outbox] failed to move message %s to failed: %v", id, err)
+		}
+		logger.Errorf("[outbox] giving up on message %s after %d attempts: %s", id, e.Attempts, e.LastError)
+		return
+	}
+	e.NextAttempt = time.Now().Add(o.backoff(e.Attempts))
+	if err := o.save(e); err != nil {
+		logger.Warnf("[outbox] failed to persist message %s: %v", id, err)
+	}
+	logger.Warnf("[outbox] delivery of message %s failed (attempt %d), retrying at %s: %s",
+		id, e.Attempts, e.NextAttempt.Format(time.RFC3339), e.LastError)
+}
+
+func (o *Outbox) backoff(attempts int) time.Duration {
+	d := o.opts.BaseDelay
+	for i := 1; i < attempts && d < o.opts.MaxDelay; i++ {
+		d *= 2
+	}
+	return min(d, o.opts.MaxDelay)
+}
+
+func (o *Outbox) path(id string) string {
+	return filepath.Join(o.dir, id+".json")
+}
+
+// save writes e through a temporary file so that a crash never leaves a
+// truncated message behind.
+func (o *Outbox) save(e *entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	tmp := o.path(e.Message.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, o.path(e.Message.ID))
+}
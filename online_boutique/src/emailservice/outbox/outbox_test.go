@@ -0,0 +1,144 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/mailer"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/emailservice/mailer/smtptest"
+)
+
+var fastRetries = Options{BaseDelay: 10 * time.Millisecond, MaxDelay: 20 * time.Millisecond}
+
+func newMessage() *mailer.Message {
+	return &mailer.Message{
+		ID:      mailer.NewID(),
+		From:    "Shop <shop@example.com>",
+		To:      []string{"buyer@example.com"},
+		Subject: "Your Order Confirmation",
+		Text:    "thanks",
+		HTML:    "<p>thanks</p>",
+		Date:    time.Now(),
+	}
+}
+
+func newServer(t *testing.T) *smtptest.Server {
+	t.Helper()
+	srv, err := smtptest.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { srv.Close() })
+	return srv
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestOutboxRetriesUntilDelivered(t *testing.T) {
+	srv := newServer(t)
+	srv.FailNext(2)
+	ob, err := Open(t.TempDir(), &mailer.SMTPSender{Addr: srv.Addr()}, fastRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go ob.Run(ctx)
+
+	if err := ob.Enqueue(newMessage()); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return ob.Pending() == 0 })
+
+	msgs := srv.Messages()
+	if len(msgs) != 1 {
+		t.Fatalf("server received %d messages, want 1", len(msgs))
+	}
+	if msgs[0].From != "shop@example.com" || len(msgs[0].To) != 1 || msgs[0].To[0] != "buyer@example.com" {
+		t.Errorf("envelope = %s -> %v", msgs[0].From, msgs[0].To)
+	}
+	for _, want := range []string{"Subject: Your Order Confirmation", "text/plain", "text/html"} {
+		if !bytes.Contains(msgs[0].Data, []byte(want)) {
+			t.Errorf("message does not contain %q:\n%s", want, msgs[0].Data)
+		}
+	}
+}
+
+func TestOutboxGivesUp(t *testing.T) {
+	srv := newServer(t)
+	srv.FailNext(100)
+	dir := t.TempDir()
+	opts := fastRetries
+	opts.MaxAttempts = 2
+	ob, err := Open(dir, &mailer.SMTPSender{Addr: srv.Addr()}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go ob.Run(ctx)
+
+	msg := newMessage()
+	if err := ob.Enqueue(msg); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, func() bool { return ob.Pending() == 0 })
+	if _, err := os.Stat(filepath.Join(dir, "failed", msg.ID+".json")); err != nil {
+		t.Errorf("message not moved to failed: %v", err)
+	}
+}
+
+func TestOutboxResumesAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	srv := newServer(t)
+	// nothing runs the first outbox, as if the process died right after Enqueue
+	ob, err := Open(dir, &mailer.SMTPSender{Addr: srv.Addr()}, fastRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ob.Enqueue(newMessage()); err != nil {
+		t.Fatal(err)
+	}
+
+	ob, err = Open(dir, &mailer.SMTPSender{Addr: srv.Addr()}, fastRetries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ob.Pending() != 1 {
+		t.Fatalf("reopened outbox has %d pending messages, want 1", ob.Pending())
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go ob.Run(ctx)
+	waitFor(t, func() bool { return len(srv.Messages()) == 1 })
+}
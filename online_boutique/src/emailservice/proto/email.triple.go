@@ -1,122 +0,0 @@
-// Code generated by protoc-gen-triple. DO NOT EDIT.
-//
-// Source: proto/email.proto
-package hipstershop
-
-import (
-	"context"
-)
-
-import (
-	"dubbo.apache.org/dubbo-go/v3"
-	"dubbo.apache.org/dubbo-go/v3/client"
-	"dubbo.apache.org/dubbo-go/v3/common"
-	"dubbo.apache.org/dubbo-go/v3/common/constant"
-	"dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
-	"dubbo.apache.org/dubbo-go/v3/server"
-)
-
-// This is a compile-time assertion to ensure that this generated file and the Triple package
-// are compatible. If you get a compiler error that this constant is not defined, this code was
-// generated with a version of Triple newer than the one compiled into your binary. You can fix the
-// problem by either regenerating this code with an older version of Triple or updating the Triple
-// version compiled into your binary.
-const _ = triple_protocol.IsAtLeastVersion0_1_0
-
-const (
-	// EmailServiceName is the fully-qualified name of the EmailService service.
-	EmailServiceName = "hipstershop.EmailService"
-)
-
-// These constants are the fully-qualified names of the RPCs defined in this package. They're
-// exposed at runtime as procedure and as the final two segments of the HTTP route.
-//
-// Note that these are different from the fully-qualified method names used by
-// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
-// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
-// period.
-const (
-	// EmailServiceSendOrderConfirmationProcedure is the fully-qualified name of the EmailService's SendOrderConfirmation RPC.
-	EmailServiceSendOrderConfirmationProcedure = "/hipstershop.EmailService/SendOrderConfirmation"
-)
-
-var (
-	_ EmailService = (*EmailServiceImpl)(nil)
-)
-
-// EmailService is a client for the hipstershop.EmailService service.
-type EmailService interface {
-	SendOrderConfirmation(ctx context.Context, req *SendOrderConfirmationRequest, opts ...client.CallOption) (*SendOrderConfirmationResponse, error)
-}
-
-// NewEmailService constructs a client for the hipstershop.EmailService service.
-func NewEmailService(cli *client.Client, opts ...client.ReferenceOption) (EmailService, error) {
-	conn, err := cli.DialWithInfo("hipstershop.EmailService", &EmailService_ClientInfo, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return &EmailServiceImpl{
-		conn: conn,
-	}, nil
-}
-
-func SetConsumerEmailService(srv common.RPCService) {
-	dubbo.SetConsumerServiceWithInfo(srv, &EmailService_ClientInfo)
-}
-
-// EmailServiceImpl implements EmailService.
-type EmailServiceImpl struct {
-	conn *client.Connection
-}
-
-func (c *EmailServiceImpl) SendOrderConfirmation(ctx context.Context, req *SendOrderConfirmationRequest, opts ...client.CallOption) (*SendOrderConfirmationResponse, error) {
-	resp := new(SendOrderConfirmationResponse)
-	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "SendOrderConfirmation", opts...); err != nil {
-		return nil, err
-	}
-	return resp, nil
-}
-
-var EmailService_ClientInfo = client.ClientInfo{
-	InterfaceName: "hipstershop.EmailService",
-	MethodNames:   []string{"SendOrderConfirmation"},
-	ConnectionInjectFunc: func(dubboCliRaw interface{}, conn *client.Connection) {
-		dubboCli := dubboCliRaw.(*EmailServiceImpl)
-		dubboCli.conn = conn
-	},
-}
-
-// EmailServiceHandler is an implementation of the hipstershop.EmailService service.
-type EmailServiceHandler interface {
-	SendOrderConfirmation(context.Context, *SendOrderConfirmationRequest) (*SendOrderConfirmationResponse, error)
-}
-
-func RegisterEmailServiceHandler(srv *server.Server, hdlr EmailServiceHandler, opts ...server.ServiceOption) error {
-	return srv.Register(hdlr, &EmailService_ServiceInfo, opts...)
-}
-
-func SetProviderEmailService(srv common.RPCService) {
-	dubbo.SetProviderServiceWithInfo(srv, &EmailService_ServiceInfo)
-}
-
-var EmailService_ServiceInfo = server.ServiceInfo{
-	InterfaceName: "hipstershop.EmailService",
-	ServiceType:   (*EmailServiceHandler)(nil),
-	Methods: []server.MethodInfo{
-		{
-			Name: "SendOrderConfirmation",
-			Type: constant.CallUnary,
-			ReqInitFunc: func() interface{} {
-				return new(SendOrderConfirmationRequest)
-			},
-			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
-				req := args[0].(*SendOrderConfirmationRequest)
-				res, err := handler.(EmailServiceHandler).SendOrderConfirmation(ctx, req)
-				if err != nil {
-					return nil, err
-				}
-				return triple_protocol.NewResponse(res), nil
-			},
-		},
-	},
-}
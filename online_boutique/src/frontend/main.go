@@ -19,14 +19,15 @@ package main
 
 import (
 	"dubbo.apache.org/dubbo-go/v3"
+	"dubbo.apache.org/dubbo-go/v3/client"
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
 	"dubbo.apache.org/dubbo-go/v3/protocol"
 	"dubbo.apache.org/dubbo-go/v3/registry"
-	"fmt"
 	"github.com/apache/dubbo-go-samples/online_boutique_demo/frontend/config"
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/frontend/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/frontend/server"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/proto/tracing"
 	"github.com/dubbogo/gost/log/logger"
-	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 	"net/http"
 	"os"
@@ -36,46 +37,22 @@ import (
 const (
 	name    = "frontend"
 	version = "1.0.0"
-
-	defaultCurrency = "USD"
-	cookieMaxAge    = 60 * 60 * 48
-
-	cookiePrefix    = "shop_"
-	cookieSessionID = cookiePrefix + "session-id"
-	cookieCurrency  = cookiePrefix + "currency"
-)
-
-var (
-	whitelistedCurrencies = map[string]bool{
-		"USD": true,
-		"EUR": true,
-		"CAD": true,
-		"JPY": true,
-		"GBP": true,
-		"TRY": true,
-	}
 )
 
-type ctxKeySessionID struct{}
-
-type frontendServer struct {
-	adService             pb.AdService
-	cartService           pb.CartService
-	checkoutService       pb.CheckoutService
-	currencyService       pb.CurrencyService
-	productCatalogService pb.ProductCatalogService
-	recommendationService pb.RecommendationService
-	shippingService       pb.ShippingService
-}
-
 func main() {
 	regAddr := os.Getenv("DUBBO_REGISTRY_ADDRESS")
 	if regAddr == "" {
 		regAddr = "127.0.0.1:2181"
 	}
 
+	withTracing, err := tracing.Option()
+	if err != nil {
+		panic(err)
+	}
+
 	ins, err := dubbo.NewInstance(
 		dubbo.WithName("frontendservice"),
+		withTracing,
 		dubbo.WithRegistry(
 			registry.WithZookeeper(),
 			registry.WithAddress(regAddr),
@@ -112,13 +89,17 @@ func main() {
 	}
 	log.Out = os.Stdout
 
+	// The pages do without ads, recommendations, live exchange rates and
+	// the cart when these fail, behind circuit breakers.
+	breaker := client.WithFilter(server.BreakerFilter)
+
 	//adservice
-	adService, err := pb.NewAdService(cli)
+	adService, err := pb.NewAdService(cli, breaker)
 	if err != nil {
 		panic(err)
 	}
 	//cartService
-	cartService, err := pb.NewCartService(cli)
+	cartService, err := pb.NewCartService(cli, breaker)
 	if err != nil {
 		panic(err)
 	}
@@ -129,7 +110,7 @@ func main() {
 	}
 
 	//currencyService
-	currencyService, err := pb.NewCurrencyService(cli)
+	currencyService, err := pb.NewCurrencyService(cli, breaker)
 	if err != nil {
 		panic(err)
 	}
@@ -140,7 +121,10 @@ func main() {
 		panic(err)
 	}
 	//recommendation
-	recommendationService, err := pb.NewRecommendationService(cli)
+	recommendationService, err := pb.NewRecommendationService(cli, breaker)
+	if err != nil {
+		panic(err)
+	}
 
 	//shippingService
 	shippingService, err := pb.NewShippingService(cli)
@@ -148,35 +132,43 @@ func main() {
 		panic(err)
 	}
 
-	svc := frontendServer{
-		adService:             adService,
-		cartService:           cartService,
-		checkoutService:       checkoutService,
-		currencyService:       currencyService,
-		productCatalogService: productCatalogService,
-		shippingService:       shippingService,
-		recommendationService: recommendationService,
-	}
-
-	r := mux.NewRouter()
-	r.HandleFunc("/", svc.homeHandler).Methods(http.MethodGet, http.MethodHead)
-	r.HandleFunc("/product/{id}", svc.productHandler).Methods(http.MethodGet, http.MethodHead)
-	r.HandleFunc("/cart", svc.viewCartHandler).Methods(http.MethodGet, http.MethodHead)
-	r.HandleFunc("/cart", svc.addToCartHandler).Methods(http.MethodPost)
-	r.HandleFunc("/cart/empty", svc.emptyCartHandler).Methods(http.MethodPost)
-	r.HandleFunc("/setCurrency", svc.setCurrencyHandler).Methods(http.MethodPost)
-	r.HandleFunc("/logout", svc.logoutHandler).Methods(http.MethodGet)
-	r.HandleFunc("/cart/checkout", svc.placeOrderHandler).Methods(http.MethodPost)
-	r.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir("./static/"))))
-	r.HandleFunc("/robots.txt", func(w http.ResponseWriter, _ *http.Request) { fmt.Fprint(w, "User-agent: *\nDisallow: /") })
-	r.HandleFunc("/_healthz", func(w http.ResponseWriter, _ *http.Request) { fmt.Fprint(w, "ok") })
-
-	var handler http.Handler = r
-	handler = &logHandler{log: log, next: handler} // add logging
-	handler = ensureSessionID(handler)             // add session ID
-	// handler = tracing(handler)                     // add opentelemetry instrumentation
-	//r.Use(otelmux.Middleware(name))
-	//r.Use(tracingContextWrapper)
+	//userService
+	userService, err := pb.NewUserService(cli)
+	if err != nil {
+		panic(err)
+	}
+
+	//inventoryService
+	inventoryService, err := pb.NewInventoryService(cli)
+	if err != nil {
+		panic(err)
+	}
+
+	//promotionService
+	promotionService, err := pb.NewPromotionService(cli)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := server.LoadCircuitBreakers(); err != nil {
+		panic(err)
+	}
+
+	handler, err := server.NewHandler(server.Services{
+		Ad:             adService,
+		Cart:           cartService,
+		Checkout:       checkoutService,
+		Currency:       currencyService,
+		ProductCatalog: productCatalogService,
+		Recommendation: recommendationService,
+		Shipping:       shippingService,
+		User:           userService,
+		Inventory:      inventoryService,
+		Promotion:      promotionService,
+	}, ".", log)
+	if err != nil {
+		panic(err)
+	}
 
 	//TODO:REGISTER HANDLER
 	//if err := micro.RegisterHandler(srv.Server(), handler); err != nil {
@@ -20,7 +20,7 @@ package money
 import (
 	"errors"
 
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/frontend/proto"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
 )
 
 const (
@@ -1,130 +0,0 @@
-/*
- * Licensed to the Apache Software Foundation (ASF) under one or more
- * contributor license agreements.  See the NOTICE file distributed with
- * this work for additional information regarding copyright ownership.
- * The ASF licenses this file to You under the Apache License, Version 2.0
- * (the "License"); you may not use this file except in compliance with
- * the License.  You may obtain a copy of the License at
- *
- *     http://www.apache.org/licenses/LICENSE-2.0
- *
- * Unless required by applicable law or agreed to in writing, software
- * distributed under the License is distributed on an "AS IS" BASIS,
- * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
- * See the License for the specific language governing permissions and
- * limitations under the License.
- */
-
-package main
-
-import (
-	"context"
-	"time"
-
-	"github.com/pkg/errors"
-
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/frontend/proto"
-)
-
-const (
-	avoidNoopCurrencyConversionRPC = false
-)
-
-func (fe *frontendServer) getCurrencies(ctx context.Context) ([]string, error) {
-	currs, err := fe.currencyService.GetSupportedCurrencies(ctx, &pb.Empty{})
-	if err != nil {
-		return nil, err
-	}
-	var out []string
-	for _, c := range currs.CurrencyCodes {
-		if _, ok := whitelistedCurrencies[c]; ok {
-			out = append(out, c)
-		}
-	}
-	return out, nil
-}
-
-func (fe *frontendServer) getProducts(ctx context.Context) ([]*pb.Product, error) {
-	resp, err := fe.productCatalogService.ListProducts(ctx, &pb.Empty{})
-	return resp.GetProducts(), err
-}
-
-func (fe *frontendServer) getProduct(ctx context.Context, id string) (*pb.Product, error) {
-	resp, err := fe.productCatalogService.GetProduct(ctx, &pb.GetProductRequest{Id: id})
-	return resp, err
-}
-
-func (fe *frontendServer) getCart(ctx context.Context, userID string) ([]*pb.CartItem, error) {
-	resp, err := fe.cartService.GetCart(ctx, &pb.GetCartRequest{UserId: userID})
-	return resp.GetItems(), err
-}
-
-func (fe *frontendServer) emptyCart(ctx context.Context, userID string) error {
-	_, err := fe.cartService.EmptyCart(ctx, &pb.EmptyCartRequest{UserId: userID})
-	return err
-}
-
-func (fe *frontendServer) insertCart(ctx context.Context, userID, productID string, quantity int32) error {
-	_, err := fe.cartService.AddItem(ctx, &pb.AddItemRequest{
-		UserId: userID,
-		Item: &pb.CartItem{
-			ProductId: productID,
-			Quantity:  quantity,
-		},
-	})
-	return err
-}
-
-func (fe *frontendServer) convertCurrency(ctx context.Context, money *pb.Money, currency string) (*pb.Money, error) {
-	if avoidNoopCurrencyConversionRPC && money.GetCurrencyCode() == currency {
-		return money, nil
-	}
-	return fe.currencyService.Convert(ctx, &pb.CurrencyConversionRequest{
-		From:   money,
-		ToCode: currency,
-	})
-}
-
-func (fe *frontendServer) getShippingQuote(ctx context.Context, items []*pb.CartItem, currency string) (*pb.Money, error) {
-	quote, err := fe.shippingService.GetQuote(ctx, &pb.GetQuoteRequest{
-		Address: nil,
-		Items:   items,
-	})
-	if err != nil {
-		return nil, err
-	}
-	localized, err := fe.convertCurrency(ctx, quote.GetCostUsd(), currency)
-	return localized, errors.Wrap(err, "failed to convert currency for shipping cost")
-}
-
-func (fe *frontendServer) getRecommendations(ctx context.Context, userID string, productIDs []string) ([]*pb.Product, error) {
-	resp, err := fe.recommendationService.ListRecommendations(ctx, &pb.ListRecommendationsRequest{
-		UserId:     userID,
-		ProductIds: productIDs,
-	})
-	if err != nil {
-		return nil, err
-	}
-	out := make([]*pb.Product, len(resp.GetProductIds()))
-	for i, v := range resp.GetProductIds() {
-		p, err := fe.getProduct(ctx, v)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get recommended product info (#%s)", v)
-		}
-		out[i] = p
-	}
-	if len(out) > 4 {
-		out = out[:4] // take only first four to fit the UI
-	}
-	return out, err
-}
-
-func (fe *frontendServer) getAd(ctx context.Context, ctxKeys []string) ([]*pb.Ad, error) {
-	ctx, cancel := context.WithTimeout(ctx, time.Millisecond*100)
-	defer cancel()
-
-	resp, err := fe.adService.GetAds(ctx, &pb.AdRequest{
-		ContextKeys: ctxKeys,
-	})
-	return resp.GetAds(), errors.Wrap(err, "failed to get ads")
-}
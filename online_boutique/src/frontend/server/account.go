@@ -0,0 +1,272 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+const demoEmail = "someone@example.com"
+
+// demoAddress fills in the checkout form of shoppers without a saved address.
+var demoAddress = &pb.Address{
+	StreetAddress: "1600 Amphitheatre Parkway",
+	City:          "Mountain View",
+	State:         "CA",
+	Country:       "United States",
+	ZipCode:       94043,
+}
+
+// registerAccount adds the sign in, sign up and account pages. They are only
+// served when the frontend has a user service.
+func (fe *frontendServer) registerAccount(r *mux.Router) {
+	r.HandleFunc("/login", fe.loginPageHandler).Methods(http.MethodGet, http.MethodHead)
+	r.HandleFunc("/login", fe.loginHandler).Methods(http.MethodPost)
+	r.HandleFunc("/register", fe.loginPageHandler).Methods(http.MethodGet, http.MethodHead)
+	r.HandleFunc("/register", fe.registerHandler).Methods(http.MethodPost)
+	r.HandleFunc("/account", fe.accountHandler).Methods(http.MethodGet, http.MethodHead)
+	r.HandleFunc("/account/addresses", fe.addAddressHandler).Methods(http.MethodPost)
+	r.HandleFunc("/account/addresses/{id}/delete", fe.deleteAddressHandler).Methods(http.MethodPost)
+	r.HandleFunc("/account/cards", fe.addCardHandler).Methods(http.MethodPost)
+	r.HandleFunc("/account/cards/{token}/delete", fe.deleteCardHandler).Methods(http.MethodPost)
+}
+
+// checkoutDefaults returns what the checkout form is filled in with: the
+// user's email and the saved address with the given ID, or their first one.
+func checkoutDefaults(user *pb.User, addressID string) (string, *pb.SavedAddress) {
+	email, address := demoEmail, &pb.SavedAddress{Address: demoAddress}
+	if user == nil {
+		return email, address
+	}
+	email = user.GetEmail()
+	for i, a := range user.GetAddresses() {
+		if i == 0 || a.GetId() == addressID {
+			address = a
+		}
+	}
+	return email, address
+}
+
+// userError tells whether err is a mistake of the shopper, to be shown on the
+// form they sent rather than on the error page.
+func userError(err error) bool {
+	switch triple.CodeOf(err) {
+	case triple.CodeInvalidArgument, triple.CodeFailedPrecondition, triple.CodeAlreadyExists,
+		triple.CodeUnauthenticated, triple.CodeNotFound:
+		return true
+	}
+	return false
+}
+
+func (fe *frontendServer) loginPageHandler(w http.ResponseWriter, r *http.Request) {
+	fe.renderLogin(w, r, http.StatusOK, "")
+}
+
+func (fe *frontendServer) renderLogin(w http.ResponseWriter, r *http.Request, code int, errMsg string) {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+	register := r.URL.Path == "/register"
+	next := r.FormValue("next")
+	if !isLocalPath(next) {
+		next = "/"
+	}
+	w.WriteHeader(code)
+	if err := templates.ExecuteTemplate(w, "login", map[string]interface{}{
+		"session_id":        sessionID(r),
+		"request_id":        r.Context().Value(ctxKeyRequestID{}),
+		"show_currency":     false,
+		"register":          register,
+		"next":              next,
+		"email":             r.PostFormValue("email"),
+		"name":              r.PostFormValue("name"),
+		"error":             errMsg,
+		"platform_css":      plat.css,
+		"platform_name":     plat.provider,
+		"is_cymbal_brand":   isCymbalBrand,
+		"user":              currentUser(r),
+		"accounts":          true,
+		"deploymentDetails": deploymentDetails(),
+	}); err != nil {
+		log.Println(err)
+	}
+}
+
+func (fe *frontendServer) loginHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := fe.userService.Login(r.Context(), &pb.LoginRequest{
+		Email:    r.FormValue("email"),
+		Password: r.FormValue("password"),
+	})
+	fe.signIn(w, r, resp, err)
+}
+
+func (fe *frontendServer) registerHandler(w http.ResponseWriter, r *http.Request) {
+	resp, err := fe.userService.Register(r.Context(), &pb.RegisterRequest{
+		Email:    r.FormValue("email"),
+		Password: r.FormValue("password"),
+		Name:     r.FormValue("name"),
+	})
+	fe.signIn(w, r, resp, err)
+}
+
+// signIn finishes a login or registration: the anonymous cart is merged into
+// the user's cart, and the session token is kept in a cookie.
+func (fe *frontendServer) signIn(w http.ResponseWriter, r *http.Request, resp *pb.AuthResponse, err error) {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+	if err != nil {
+		if userError(err) {
+			fe.renderLogin(w, r, httpStatus(triple.CodeOf(err)), errorMessage(err))
+		} else {
+			renderHTTPError(log, r, w, errors.Wrap(err, "failed to sign in"), http.StatusInternalServerError)
+		}
+		return
+	}
+	userID := resp.GetUser().GetUserId()
+	log.WithField("user", userID).Info("signed in")
+
+	// The session cookie still names the anonymous cart, even when another
+	// user was signed in before.
+	if c, err := r.Cookie(cookieSessionID); err == nil && validSessionID(c.Value) {
+		if err := fe.mergeCart(r.Context(), c.Value, userSessionID(userID)); err != nil {
+			log.WithField("error", err).Warn("failed to merge cart")
+		}
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieAuth,
+		Value:    resp.GetSessionToken(),
+		Path:     "/",
+		MaxAge:   int(time.Until(time.Unix(resp.GetExpiresAt(), 0)).Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	next := r.FormValue("next")
+	if !isLocalPath(next) {
+		next = "/"
+	}
+	http.Redirect(w, r, next, http.StatusFound)
+}
+
+func (fe *frontendServer) accountHandler(w http.ResponseWriter, r *http.Request) {
+	fe.renderAccount(w, r, http.StatusOK, "")
+}
+
+func (fe *frontendServer) renderAccount(w http.ResponseWriter, r *http.Request, code int, errMsg string) {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+	user := currentUser(r)
+	if user == nil {
+		http.Redirect(w, r, "/login?next=/account", http.StatusFound)
+		return
+	}
+	cart, err := fe.cartOrNone(r.Context(), sessionID(r))
+	if err != nil {
+		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
+		return
+	}
+	year := time.Now().Year()
+
+	w.WriteHeader(code)
+	if err := templates.ExecuteTemplate(w, "account", map[string]interface{}{
+		"session_id":        sessionID(r),
+		"request_id":        r.Context().Value(ctxKeyRequestID{}),
+		"show_currency":     false,
+		"cart_size":         cartSize(cart),
+		"degraded":          fe.health.degraded(),
+		"error":             errMsg,
+		"expiration_years":  []int{year, year + 1, year + 2, year + 3, year + 4},
+		"platform_css":      plat.css,
+		"platform_name":     plat.provider,
+		"is_cymbal_brand":   isCymbalBrand,
+		"user":              user,
+		"accounts":          true,
+		"deploymentDetails": deploymentDetails(),
+	}); err != nil {
+		log.Println(err)
+	}
+}
+
+// updateAccount runs one change of the account page for the user of the
+// session token and shows the page again with the updated user.
+func (fe *frontendServer) updateAccount(w http.ResponseWriter, r *http.Request, what string, update func(sessionToken string) (*pb.User, error)) {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+	user := currentUser(r)
+	if user == nil {
+		http.Redirect(w, r, "/login?next=/account", http.StatusFound)
+		return
+	}
+	if _, err := update(sessionToken(r.Context())); err != nil {
+		if userError(err) {
+			fe.renderAccount(w, r, httpStatus(triple.CodeOf(err)), errorMessage(err))
+		} else {
+			renderHTTPError(log, r, w, errors.Wrap(err, "failed to "+what), http.StatusInternalServerError)
+		}
+		return
+	}
+	log.WithField("user", user.GetUserId()).Debug(what)
+	http.Redirect(w, r, "/account", http.StatusFound)
+}
+
+func (fe *frontendServer) addAddressHandler(w http.ResponseWriter, r *http.Request) {
+	zipCode, _ := strconv.ParseInt(r.FormValue("zip_code"), 10, 32)
+	fe.updateAccount(w, r, "add address", func(token string) (*pb.User, error) {
+		return fe.userService.AddAddress(r.Context(), &pb.AddAddressRequest{
+			SessionToken: token,
+			Label:        r.FormValue("label"),
+			Address: &pb.Address{
+				StreetAddress: r.FormValue("street_address"),
+				City:          r.FormValue("city"),
+				State:         r.FormValue("state"),
+				Country:       r.FormValue("country"),
+				ZipCode:       int32(zipCode),
+			},
+		})
+	})
+}
+
+func (fe *frontendServer) deleteAddressHandler(w http.ResponseWriter, r *http.Request) {
+	fe.updateAccount(w, r, "delete address", func(token string) (*pb.User, error) {
+		return fe.userService.DeleteAddress(r.Context(), &pb.DeleteAddressRequest{SessionToken: token, AddressId: mux.Vars(r)["id"]})
+	})
+}
+
+func (fe *frontendServer) addCardHandler(w http.ResponseWriter, r *http.Request) {
+	month, _ := strconv.ParseInt(r.FormValue("credit_card_expiration_month"), 10, 32)
+	year, _ := strconv.ParseInt(r.FormValue("credit_card_expiration_year"), 10, 32)
+	fe.updateAccount(w, r, "add card", func(token string) (*pb.User, error) {
+		return fe.userService.AddCard(r.Context(), &pb.AddCardRequest{
+			SessionToken: token,
+			CreditCard: &pb.CreditCardInfo{
+				CreditCardNumber:          strings.TrimSpace(r.FormValue("credit_card_number")),
+				CreditCardExpirationMonth: int32(month),
+				CreditCardExpirationYear:  int32(year),
+			},
+		})
+	})
+}
+
+func (fe *frontendServer) deleteCardHandler(w http.ResponseWriter, r *http.Request) {
+	fe.updateAccount(w, r, "delete card", func(token string) (*pb.User, error) {
+		return fe.userService.DeleteCard(r.Context(), &pb.DeleteCardRequest{SessionToken: token, CardToken: mux.Vars(r)["token"]})
+	})
+}
@@ -0,0 +1,320 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"dubbo.apache.org/dubbo-go/v3/client"
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+// fakeUsers is a user service with one account, ann@example.com, whose
+// password is "password".
+type fakeUsers struct {
+	mu      sync.Mutex
+	user    *pb.User
+	number  string
+	issued  int
+	revoked map[string]bool
+}
+
+func newFakeUsers() *fakeUsers {
+	return &fakeUsers{
+		user: &pb.User{
+			UserId: "ann",
+			Email:  "ann@example.com",
+			Name:   "Ann",
+			Addresses: []*pb.SavedAddress{
+				{Id: "home", Label: "Home", Address: &pb.Address{StreetAddress: "1 Main St", City: "Berlin", Country: "Germany", ZipCode: 10115}},
+				{Id: "work", Label: "Work", Address: &pb.Address{StreetAddress: "2 Side St", City: "Hamburg", Country: "Germany", ZipCode: 20095}},
+			},
+			Cards: []*pb.SavedCard{{Token: "card1", Brand: "visa", LastFour: "0454", ExpirationYear: 2039, ExpirationMonth: 1}},
+		},
+		number:  "4432801561520454",
+		revoked: make(map[string]bool),
+	}
+}
+
+func (f *fakeUsers) token() string {
+	f.issued++
+	return "token" + strconv.Itoa(f.issued)
+}
+
+func (f *fakeUsers) Register(_ context.Context, req *pb.RegisterRequest, _ ...client.CallOption) (*pb.AuthResponse, error) {
+	return nil, triple.NewError(triple.CodeAlreadyExists, errors.New("email already registered"))
+}
+
+func (f *fakeUsers) Login(_ context.Context, req *pb.LoginRequest, _ ...client.CallOption) (*pb.AuthResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if req.Email != f.user.Email || req.Password != "password" {
+		return nil, triple.NewError(triple.CodeUnauthenticated, errors.New("invalid email or password"))
+	}
+	return &pb.AuthResponse{User: f.user, SessionToken: f.token(), ExpiresAt: 4102444800}, nil
+}
+
+func (f *fakeUsers) Logout(_ context.Context, req *pb.LogoutRequest, _ ...client.CallOption) (*pb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.revoked[req.SessionToken] = true
+	return &pb.Empty{}, nil
+}
+
+func (f *fakeUsers) VerifyToken(_ context.Context, req *pb.VerifyTokenRequest, _ ...client.CallOption) (*pb.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !strings.HasPrefix(req.SessionToken, "token") || f.revoked[req.SessionToken] {
+		return nil, triple.NewError(triple.CodeUnauthenticated, errors.New("invalid session token"))
+	}
+	return f.user, nil
+}
+
+func (f *fakeUsers) GetProfile(ctx context.Context, req *pb.GetProfileRequest, _ ...client.CallOption) (*pb.User, error) {
+	return f.VerifyToken(ctx, &pb.VerifyTokenRequest{SessionToken: req.SessionToken})
+}
+
+func (f *fakeUsers) AddAddress(ctx context.Context, req *pb.AddAddressRequest, _ ...client.CallOption) (*pb.User, error) {
+	if _, err := f.VerifyToken(ctx, &pb.VerifyTokenRequest{SessionToken: req.SessionToken}); err != nil {
+		return nil, err
+	}
+	if req.Address.GetCountry() == "" {
+		return nil, triple.NewError(triple.CodeInvalidArgument, errors.New("street address, city and country are required"))
+	}
+	return f.user, nil
+}
+
+func (f *fakeUsers) DeleteAddress(ctx context.Context, req *pb.DeleteAddressRequest, _ ...client.CallOption) (*pb.User, error) {
+	return f.VerifyToken(ctx, &pb.VerifyTokenRequest{SessionToken: req.SessionToken})
+}
+
+func (f *fakeUsers) AddCard(ctx context.Context, req *pb.AddCardRequest, _ ...client.CallOption) (*pb.User, error) {
+	return f.VerifyToken(ctx, &pb.VerifyTokenRequest{SessionToken: req.SessionToken})
+}
+
+func (f *fakeUsers) DeleteCard(ctx context.Context, req *pb.DeleteCardRequest, _ ...client.CallOption) (*pb.User, error) {
+	return f.VerifyToken(ctx, &pb.VerifyTokenRequest{SessionToken: req.SessionToken})
+}
+
+func (f *fakeUsers) GetCard(ctx context.Context, req *pb.GetCardRequest, _ ...client.CallOption) (*pb.CreditCardInfo, error) {
+	if _, err := f.VerifyToken(ctx, &pb.VerifyTokenRequest{SessionToken: req.SessionToken}); err != nil {
+		return nil, err
+	}
+	if req.CardToken != "card1" {
+		return nil, triple.NewError(triple.CodeNotFound, errors.New("card not found"))
+	}
+	return &pb.CreditCardInfo{CreditCardNumber: f.number, CreditCardExpirationYear: 2039, CreditCardExpirationMonth: 1}, nil
+}
+
+// browser sends requests with the cookies it was given, like a browser would.
+type browser struct {
+	t       *testing.T
+	h       http.Handler
+	cookies map[string]*http.Cookie
+}
+
+func (b *browser) do(method, path string, form url.Values) *httptest.ResponseRecorder {
+	b.t.Helper()
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req := httptest.NewRequest(method, path, body)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	for _, c := range b.cookies {
+		req.AddCookie(c)
+	}
+	rec := httptest.NewRecorder()
+	b.h.ServeHTTP(rec, req)
+	for _, c := range rec.Result().Cookies() {
+		if c.MaxAge < 0 {
+			delete(b.cookies, c.Name)
+		} else {
+			b.cookies[c.Name] = c
+		}
+	}
+	return rec
+}
+
+func TestLoginMergesCartAndFillsCheckout(t *testing.T) {
+	shop, users := newFakeShop(), newFakeUsers()
+	b := &browser{t: t, h: newTestFrontend(t, shop, users), cookies: make(map[string]*http.Cookie)}
+
+	if rec := b.do(http.MethodPost, "/cart", url.Values{"product_id": {"OLJCESPC7Z"}, "quantity": {"2"}}); rec.Code != http.StatusFound {
+		t.Fatalf("add to cart: status %d", rec.Code)
+	}
+	anonymous := b.cookies[cookieSessionID].Value
+	shop.carts["user:ann"] = []*pb.CartItem{{ProductId: "66VCHSJNUP", Quantity: 1}}
+
+	rec := b.do(http.MethodPost, "/login", url.Values{"email": {"ann@example.com"}, "password": {"wrong"}})
+	if rec.Code != http.StatusUnauthorized || !strings.Contains(rec.Body.String(), "invalid email or password") {
+		t.Errorf("wrong password: status %d", rec.Code)
+	}
+	if _, ok := b.cookies[cookieAuth]; ok {
+		t.Fatal("signed in with a wrong password")
+	}
+
+	rec = b.do(http.MethodPost, "/login", url.Values{"email": {"ann@example.com"}, "password": {"password"}, "next": {"/cart"}})
+	if rec.Code != http.StatusFound || rec.Header().Get("Location") != "/cart" {
+		t.Fatalf("login: status %d to %q", rec.Code, rec.Header().Get("Location"))
+	}
+	if c := b.cookies[cookieAuth]; c == nil || !c.HttpOnly {
+		t.Fatalf("auth cookie %v", c)
+	}
+	if len(shop.carts[anonymous]) != 0 || len(shop.carts["user:ann"]) != 2 {
+		t.Errorf("carts after login: anonymous %v, user %v", shop.carts[anonymous], shop.carts["user:ann"])
+	}
+
+	page := b.do(http.MethodGet, "/cart?address=work", nil).Body.String()
+	for _, want := range []string{`value="ann@example.com"`, `value="2 Side St"`, `value="Hamburg"`, `<option value="card1">visa ending in 0454`, `href="/account">Ann</a>`} {
+		if !strings.Contains(page, want) {
+			t.Errorf("cart page does not contain %s", want)
+		}
+	}
+	if strings.Contains(page, "session-id:") {
+		t.Error("cart page of a signed in user shows the session")
+	}
+
+	rec = b.do(http.MethodPost, "/cart/checkout", url.Values{
+		"email": {"ann@example.com"}, "street_address": {"1 Main St"}, "zip_code": {"10115"}, "city": {"Berlin"},
+		"state": {"BE"}, "country": {"Germany"}, "card_token": {"card1"}, "credit_card_cvv": {"672"},
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("checkout: status %d", rec.Code)
+	}
+	if card := shop.placed.GetCreditCard(); shop.placed.GetUserId() != "user:ann" || card.GetCreditCardNumber() != users.number || card.GetCreditCardCvv() != 672 {
+		t.Errorf("placed order for %s with card %v", shop.placed.GetUserId(), card)
+	}
+
+	token := b.cookies[cookieAuth].Value
+	b.do(http.MethodGet, "/logout", nil)
+	if !users.revoked[token] {
+		t.Error("logout did not revoke the session token")
+	}
+	if rec := b.do(http.MethodGet, "/account", nil); rec.Code != http.StatusFound || !strings.HasPrefix(rec.Header().Get("Location"), "/login") {
+		t.Errorf("account after logout: status %d to %q", rec.Code, rec.Header().Get("Location"))
+	}
+}
+
+func TestForgedSessionID(t *testing.T) {
+	shop, users := newFakeShop(), newFakeUsers()
+	b := &browser{t: t, h: newTestFrontend(t, shop, users), cookies: make(map[string]*http.Cookie)}
+	shop.carts["user:ann"] = []*pb.CartItem{{ProductId: "66VCHSJNUP", Quantity: 1}}
+
+	for _, forged := range []string{"ann", "user:ann"} {
+		b.cookies[cookieSessionID] = &http.Cookie{Name: cookieSessionID, Value: forged}
+		page := b.do(http.MethodGet, "/cart", nil).Body.String()
+		if c := b.cookies[cookieSessionID]; c.Value == forged || !validSessionID(c.Value) {
+			t.Errorf("session cookie %q kept, now %q", forged, c.Value)
+		}
+		if strings.Contains(page, "66VCHSJNUP") {
+			t.Errorf("session %q shows the cart of ann", forged)
+		}
+		b.cookies[cookieSessionID] = &http.Cookie{Name: cookieSessionID, Value: forged}
+		b.do(http.MethodPost, "/login", url.Values{"email": {"ann@example.com"}, "password": {"password"}})
+		delete(b.cookies, cookieAuth)
+	}
+	if len(shop.carts["user:ann"]) != 1 {
+		t.Errorf("cart of ann after forged sign ins: %v", shop.carts["user:ann"])
+	}
+}
+
+func TestAccountPage(t *testing.T) {
+	shop, users := newFakeShop(), newFakeUsers()
+	b := &browser{t: t, h: newTestFrontend(t, shop, users), cookies: make(map[string]*http.Cookie)}
+	b.cookies[cookieAuth] = &http.Cookie{Name: cookieAuth, Value: "token1"}
+
+	page := b.do(http.MethodGet, "/account", nil).Body.String()
+	for _, want := range []string{"1 Main St", "2 Side St", "ending in 0454", `action="/account/cards/card1/delete"`} {
+		if !strings.Contains(page, want) {
+			t.Errorf("account page does not contain %s", want)
+		}
+	}
+	if rec := b.do(http.MethodPost, "/account/addresses", url.Values{"street_address": {"3 Elm St"}, "city": {"Bonn"}, "country": {"Germany"}}); rec.Code != http.StatusFound {
+		t.Errorf("add address: status %d", rec.Code)
+	}
+	rec := b.do(http.MethodPost, "/account/addresses", url.Values{"street_address": {"3 Elm St"}})
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "country are required") {
+		t.Errorf("add incomplete address: status %d", rec.Code)
+	}
+
+	b.cookies[cookieAuth].Value = "forged"
+	b.do(http.MethodGet, "/account", nil)
+	if _, ok := b.cookies[cookieAuth]; ok {
+		t.Error("rejected token was kept")
+	}
+}
+
+func TestAPISession(t *testing.T) {
+	shop, users := newFakeShop(), newFakeUsers()
+	h := newTestFrontend(t, shop, users)
+	shop.carts[shopper] = []*pb.CartItem{{ProductId: "OLJCESPC7Z", Quantity: 1}}
+
+	send := func(method, path, token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		req.Header.Set(headerSessionID, shopper)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := send(http.MethodPost, "/api/v1/session", "", `{"email":"ann@example.com","password":"password"}`)
+	var session struct {
+		Token string
+		User  apiUser
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &session); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("login: status %d, %v", rec.Code, err)
+	}
+	if session.Token == "" || session.User.ID != "ann" || len(session.User.Cards) != 1 {
+		t.Errorf("session %+v", session)
+	}
+	if rec := send(http.MethodGet, "/api/v1/cart", session.Token, ""); rec.Code != http.StatusOK || rec.Header().Get(headerSessionID) != "user:ann" ||
+		!strings.Contains(rec.Body.String(), `"size":1`) {
+		t.Errorf("cart of the user: status %d, session %q, %s", rec.Code, rec.Header().Get(headerSessionID), rec.Body)
+	}
+	if rec := send(http.MethodGet, "/api/v1/account", "forged", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("forged token: status %d", rec.Code)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/cart", nil)
+	req.Header.Set(headerSessionID, "user:ann")
+	forged := httptest.NewRecorder()
+	h.ServeHTTP(forged, req)
+	if forged.Code != http.StatusBadRequest {
+		t.Errorf("forged session header: status %d, %s", forged.Code, forged.Body)
+	}
+	if code := call(t, h, http.MethodGet, "/api/v1/account", "", nil); code != http.StatusUnauthorized {
+		t.Errorf("anonymous account: status %d", code)
+	}
+}
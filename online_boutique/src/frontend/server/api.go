@@ -0,0 +1,859 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/frontend/money"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+// headerSessionID lets clients without cookies keep a session.
+const headerSessionID = "X-Session-ID"
+
+const maxAPIBody = 1 << 20
+
+//go:embed openapi.yaml
+var openAPIDoc []byte
+
+// registerAPI adds the JSON API, described by openapi.yaml, under /api/v1.
+func (fe *frontendServer) registerAPI(r *mux.Router) {
+	api := r.PathPrefix("/api/v1").Subrouter()
+	api.Use(fe.apiSession)
+	api.HandleFunc("/openapi.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(openAPIDoc)
+	}).Methods(http.MethodGet)
+	api.HandleFunc("/products", fe.apiListProducts).Methods(http.MethodGet)
+	api.HandleFunc("/products/search", fe.apiSearchProducts).Methods(http.MethodGet)
+	api.HandleFunc("/products/{id}", fe.apiGetProduct).Methods(http.MethodGet)
+	api.HandleFunc("/cart", fe.apiGetCart).Methods(http.MethodGet)
+	api.HandleFunc("/cart", fe.apiEmptyCart).Methods(http.MethodDelete)
+	api.HandleFunc("/cart/items", fe.apiAddToCart).Methods(http.MethodPost)
+	api.HandleFunc("/currencies", fe.apiListCurrencies).Methods(http.MethodGet)
+	api.HandleFunc("/currency", fe.apiSetCurrency).Methods(http.MethodPut)
+	api.HandleFunc("/shipping/quote", fe.apiShippingQuote).Methods(http.MethodPost)
+	api.HandleFunc("/checkout", fe.apiCheckout).Methods(http.MethodPost)
+	api.HandleFunc("/recommendations", fe.apiRecommendations).Methods(http.MethodGet)
+	if fe.userService != nil {
+		api.HandleFunc("/users", fe.apiRegister).Methods(http.MethodPost)
+		api.HandleFunc("/session", fe.apiLogin).Methods(http.MethodPost)
+		api.HandleFunc("/session", fe.apiLogout).Methods(http.MethodDelete)
+		api.HandleFunc("/account", fe.apiAccount).Methods(http.MethodGet)
+	}
+	api.PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, r, triple.NewError(triple.CodeNotFound, fmt.Errorf("no API endpoint %s %s", r.Method, r.URL.Path)))
+	})
+}
+
+// apiSession signs the client in with the session token in the Authorization
+// header, if any. Anonymous clients may send their session in the X-Session-ID
+// header instead of the session cookie.
+func (fe *frontendServer) apiSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token, ok := bearerToken(r); ok {
+			if fe.userService == nil {
+				writeAPIError(w, r, triple.NewError(triple.CodeUnimplemented, errors.New("this shop has no accounts")))
+				return
+			}
+			user, err := fe.verifyToken(r.Context(), token)
+			if err != nil {
+				writeAPIError(w, r, fmt.Errorf("failed to verify session token: %w", err))
+				return
+			}
+			r = r.WithContext(withUser(r.Context(), user, token))
+		} else if id := r.Header.Get(headerSessionID); id != "" && currentUser(r) == nil {
+			if !validSessionID(id) {
+				writeAPIError(w, r, triple.NewError(triple.CodeInvalidArgument, fmt.Errorf("invalid %s header", headerSessionID)))
+				return
+			}
+			r = r.WithContext(context.WithValue(r.Context(), ctxKeySessionID{}, id))
+		}
+		w.Header().Set(headerSessionID, sessionID(r))
+		next.ServeHTTP(w, r)
+	})
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	return token, ok && strings.EqualFold(scheme, "Bearer") && token != ""
+}
+
+type apiMoney struct {
+	CurrencyCode string `json:"currency_code"`
+	Units        int64  `json:"units"`
+	Nanos        int32  `json:"nanos"`
+}
+
+func toAPIMoney(m *pb.Money) apiMoney {
+	return apiMoney{CurrencyCode: m.GetCurrencyCode(), Units: m.GetUnits(), Nanos: m.GetNanos()}
+}
+
+type apiProduct struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Picture     string   `json:"picture"`
+	Categories  []string `json:"categories"`
+	Price       apiMoney `json:"price"`
+	// Available is only set for a single product, and only when the shop
+	// tracks stock.
+	Available *int32 `json:"available,omitempty"`
+}
+
+type apiCartItem struct {
+	Product  apiProduct `json:"product"`
+	Quantity int32      `json:"quantity"`
+	Subtotal apiMoney   `json:"subtotal"`
+}
+
+type apiDiscount struct {
+	PromotionID string   `json:"promotion_id"`
+	Description string   `json:"description"`
+	Amount      apiMoney `json:"amount"`
+}
+
+type apiCart struct {
+	Items        []apiCartItem `json:"items"`
+	Size         int           `json:"size"`
+	ShippingCost apiMoney      `json:"shipping_cost"`
+	Discounts    []apiDiscount `json:"discounts"`
+	Total        apiMoney      `json:"total"`
+}
+
+type apiAddress struct {
+	StreetAddress string `json:"street_address"`
+	City          string `json:"city"`
+	State         string `json:"state"`
+	Country       string `json:"country"`
+	ZipCode       int32  `json:"zip_code"`
+}
+
+func (a *apiAddress) proto() *pb.Address {
+	if a == nil {
+		return nil
+	}
+	return &pb.Address{
+		StreetAddress: a.StreetAddress,
+		City:          a.City,
+		State:         a.State,
+		Country:       a.Country,
+		ZipCode:       a.ZipCode,
+	}
+}
+
+type apiItem struct {
+	ProductID string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
+
+type apiOrderItem struct {
+	ProductID string   `json:"product_id"`
+	Quantity  int32    `json:"quantity"`
+	Cost      apiMoney `json:"cost"`
+}
+
+type apiOrder struct {
+	OrderID            string         `json:"order_id"`
+	ShippingTrackingID string         `json:"shipping_tracking_id"`
+	ShippingCost       apiMoney       `json:"shipping_cost"`
+	ShippingAddress    apiAddress     `json:"shipping_address"`
+	Items              []apiOrderItem `json:"items"`
+	Discounts          []apiDiscount  `json:"discounts"`
+	Total              apiMoney       `json:"total"`
+}
+
+// apiCurrency returns the currency prices are shown in: the currency query
+// parameter if given, the shopper's choice otherwise.
+func apiCurrency(r *http.Request) (string, error) {
+	if c := r.URL.Query().Get("currency"); c != "" {
+		if !whitelistedCurrencies[c] {
+			return "", triple.NewError(triple.CodeInvalidArgument, fmt.Errorf("unsupported currency %q", c))
+		}
+		return c, nil
+	}
+	return currentCurrency(r), nil
+}
+
+func toAPIDiscounts(discounts []*pb.Discount) []apiDiscount {
+	out := make([]apiDiscount, 0, len(discounts))
+	for _, d := range discounts {
+		out = append(out, apiDiscount{
+			PromotionID: d.GetPromotionId(),
+			Description: d.GetDescription(),
+			Amount:      toAPIMoney(d.GetAmount()),
+		})
+	}
+	return out
+}
+
+func toAPIProduct(p *pb.Product, price *pb.Money) apiProduct {
+	categories := p.GetCategories()
+	if categories == nil {
+		categories = []string{}
+	}
+	return apiProduct{
+		ID:          p.GetId(),
+		Name:        p.GetName(),
+		Description: p.GetDescription(),
+		Picture:     p.GetPicture(),
+		Categories:  categories,
+		Price:       toAPIMoney(price),
+	}
+}
+
+// localizedProducts converts the prices of the products to the currency.
+func (fe *frontendServer) localizedProducts(ctx context.Context, products []*pb.Product, currency string) ([]apiProduct, error) {
+	out := make([]apiProduct, 0, len(products))
+	for _, p := range products {
+		price, err := fe.convertCurrency(ctx, p.GetPriceUsd(), currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the price of product %s: %w", p.GetId(), err)
+		}
+		out = append(out, toAPIProduct(p, price))
+	}
+	return out, nil
+}
+
+func (fe *frontendServer) apiListProducts(w http.ResponseWriter, r *http.Request) {
+	currency, err := apiCurrency(r)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	products, err := fe.getProducts(r.Context())
+	if err != nil {
+		writeAPIError(w, r, fmt.Errorf("could not retrieve products: %w", err))
+		return
+	}
+	out, err := fe.localizedProducts(r.Context(), products, currency)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"products": out})
+}
+
+func (fe *frontendServer) apiSearchProducts(w http.ResponseWriter, r *http.Request) {
+	currency, err := apiCurrency(r)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	req, err := apiSearchRequest(r)
+	if err != nil {
+		writeAPIError(w, r, triple.NewError(triple.CodeInvalidArgument, err))
+		return
+	}
+	resp, err := fe.searchProducts(r.Context(), req)
+	if err != nil {
+		writeAPIError(w, r, fmt.Errorf("could not search products: %w", err))
+		return
+	}
+	out, err := fe.localizedProducts(r.Context(), resp.GetResults(), currency)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"products":        out,
+		"next_page_token": resp.GetNextPageToken(),
+		"total_size":      resp.GetTotalSize(),
+	})
+}
+
+// apiSearchRequest reads a search from the query parameters: q, any number of
+// category, min_price and max_price in USD, sort, page_size and page_token.
+func apiSearchRequest(r *http.Request) (*pb.SearchProductsRequest, error) {
+	params := r.URL.Query()
+	req := &pb.SearchProductsRequest{
+		Query:      strings.TrimSpace(params.Get("q")),
+		Categories: params["category"],
+		PageToken:  params.Get("page_token"),
+	}
+	if req.Query == "" {
+		return nil, errors.New("query parameter q is required")
+	}
+	var err error
+	if req.MinPrice, err = parseUSD(params.Get("min_price")); err != nil {
+		return nil, fmt.Errorf("min_price: %w", err)
+	}
+	if req.MaxPrice, err = parseUSD(params.Get("max_price")); err != nil {
+		return nil, fmt.Errorf("max_price: %w", err)
+	}
+	if v := params.Get("sort"); v != "" {
+		order, ok := pb.SearchSortOrder_value[strings.ToUpper(v)]
+		if !ok {
+			return nil, fmt.Errorf("unknown sort %q", v)
+		}
+		req.SortOrder = pb.SearchSortOrder(order)
+	}
+	if v := params.Get("page_size"); v != "" {
+		size, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("page_size %q is not a number of products", v)
+		}
+		req.PageSize = int32(size)
+	}
+	return req, nil
+}
+
+// parseUSD reads a price such as 12.50 in USD, nil when it is empty.
+func parseUSD(s string) (*pb.Money, error) {
+	if s == "" {
+		return nil, nil
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	units, err := strconv.ParseUint(whole, 10, 63)
+	if err != nil || len(frac) > 9 || strings.Trim(frac, "0123456789") != "" {
+		return nil, fmt.Errorf("%q is not a price", s)
+	}
+	nanos, _ := strconv.ParseInt((frac + "000000000")[:9], 10, 32)
+	return &pb.Money{CurrencyCode: "USD", Units: int64(units), Nanos: int32(nanos)}, nil
+}
+
+func (fe *frontendServer) apiGetProduct(w http.ResponseWriter, r *http.Request) {
+	currency, err := apiCurrency(r)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	p, err := fe.getProduct(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, r, fmt.Errorf("could not retrieve product: %w", err))
+		return
+	}
+	out, err := fe.localizedProducts(r.Context(), []*pb.Product{p}, currency)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	stock, err := fe.getStock(r.Context(), p.GetId())
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	if stock >= 0 {
+		out[0].Available = &stock
+	}
+	writeJSON(w, http.StatusOK, out[0])
+}
+
+// cartView prices the shopper's cart in the currency, with shipping to an
+// unknown address and the promotions, including the coupon if any.
+func (fe *frontendServer) cartView(ctx context.Context, userID, currency, coupon string) (*apiCart, error) {
+	cart, err := fe.getCart(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve cart: %w", err)
+	}
+	shippingCost, err := fe.getShippingQuote(ctx, nil, cart, currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipping quote: %w", err)
+	}
+	out := &apiCart{Items: []apiCartItem{}, Size: cartSize(cart), ShippingCost: toAPIMoney(shippingCost)}
+	total := &pb.Money{CurrencyCode: currency}
+	lines := make([]*pb.PromotionLine, 0, len(cart))
+	for _, item := range cart {
+		p, err := fe.getProduct(ctx, item.GetProductId())
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve product %s: %w", item.GetProductId(), err)
+		}
+		price, err := fe.convertCurrency(ctx, p.GetPriceUsd(), currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the price of product %s: %w", p.GetId(), err)
+		}
+		subtotal := money.MultiplySlow(price, uint32(item.GetQuantity()))
+		out.Items = append(out.Items, apiCartItem{
+			Product:  toAPIProduct(p, price),
+			Quantity: item.GetQuantity(),
+			Subtotal: toAPIMoney(subtotal),
+		})
+		lines = append(lines, promotionLine(p, item.GetQuantity()))
+		total = money.Must(money.Sum(total, subtotal))
+	}
+	discounts, err := fe.getDiscounts(ctx, lines, coupon, currency)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range discounts {
+		total = money.Must(money.Sum(total, money.Negate(d.GetAmount())))
+	}
+	out.Discounts = toAPIDiscounts(discounts)
+	out.Total = toAPIMoney(money.Must(money.Sum(total, shippingCost)))
+	return out, nil
+}
+
+func (fe *frontendServer) apiGetCart(w http.ResponseWriter, r *http.Request) {
+	currency, err := apiCurrency(r)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	cart, err := fe.cartView(r.Context(), sessionID(r), currency, r.URL.Query().Get("coupon"))
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, cart)
+}
+
+func (fe *frontendServer) apiEmptyCart(w http.ResponseWriter, r *http.Request) {
+	if err := fe.emptyCart(r.Context(), sessionID(r)); err != nil {
+		writeAPIError(w, r, fmt.Errorf("failed to empty cart: %w", err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (fe *frontendServer) apiAddToCart(w http.ResponseWriter, r *http.Request) {
+	var req apiItem
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.ProductID == "" || req.Quantity <= 0 {
+		writeAPIError(w, r, triple.NewError(triple.CodeInvalidArgument, errors.New("product_id and a positive quantity are required")))
+		return
+	}
+	currency, err := apiCurrency(r)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	if _, err := fe.getProduct(r.Context(), req.ProductID); err != nil {
+		writeAPIError(w, r, fmt.Errorf("could not retrieve product: %w", err))
+		return
+	}
+	if err := fe.checkStock(r.Context(), sessionID(r), req.ProductID, req.Quantity); err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	if err := fe.insertCart(r.Context(), sessionID(r), req.ProductID, req.Quantity); err != nil {
+		writeAPIError(w, r, fmt.Errorf("failed to add to cart: %w", err))
+		return
+	}
+	cart, err := fe.cartView(r.Context(), sessionID(r), currency, "")
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, cart)
+}
+
+func (fe *frontendServer) apiListCurrencies(w http.ResponseWriter, r *http.Request) {
+	currencies, err := fe.getCurrencies(r.Context())
+	if err != nil {
+		writeAPIError(w, r, fmt.Errorf("could not retrieve currencies: %w", err))
+		return
+	}
+	if currencies == nil {
+		currencies = []string{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"currencies": currencies,
+		"current":    currentCurrency(r),
+	})
+}
+
+// apiSetCurrency remembers the currency in the same cookie the pages use.
+func (fe *frontendServer) apiSetCurrency(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		CurrencyCode string `json:"currency_code"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if !whitelistedCurrencies[req.CurrencyCode] {
+		writeAPIError(w, r, triple.NewError(triple.CodeInvalidArgument, fmt.Errorf("unsupported currency %q", req.CurrencyCode)))
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:   cookieCurrency,
+		Value:  req.CurrencyCode,
+		MaxAge: cookieMaxAge,
+		Path:   "/",
+	})
+	writeJSON(w, http.StatusOK, map[string]string{"current": req.CurrencyCode})
+}
+
+// apiShippingQuote quotes the given items, or the cart when there are none.
+func (fe *frontendServer) apiShippingQuote(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Address *apiAddress `json:"address"`
+		Items   []apiItem   `json:"items"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	currency, err := apiCurrency(r)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	items := make([]*pb.CartItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		if item.ProductID == "" || item.Quantity <= 0 {
+			writeAPIError(w, r, triple.NewError(triple.CodeInvalidArgument, errors.New("every item needs a product_id and a positive quantity")))
+			return
+		}
+		items = append(items, &pb.CartItem{ProductId: item.ProductID, Quantity: item.Quantity})
+	}
+	if len(items) == 0 {
+		if items, err = fe.getCart(r.Context(), sessionID(r)); err != nil {
+			writeAPIError(w, r, fmt.Errorf("could not retrieve cart: %w", err))
+			return
+		}
+	}
+	cost, err := fe.getShippingQuote(r.Context(), req.Address.proto(), items, currency)
+	if err != nil {
+		writeAPIError(w, r, fmt.Errorf("failed to get shipping quote: %w", err))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"cost": toAPIMoney(cost)})
+}
+
+func (fe *frontendServer) apiCheckout(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Email      string      `json:"email"`
+		Address    *apiAddress `json:"address"`
+		CreditCard *struct {
+			Number          string `json:"number"`
+			CVV             int32  `json:"cvv"`
+			ExpirationYear  int32  `json:"expiration_year"`
+			ExpirationMonth int32  `json:"expiration_month"`
+			// Token of a card saved by the signed in user, used instead of
+			// the number and expiry.
+			Token string `json:"token"`
+		} `json:"credit_card"`
+		CouponCode string `json:"coupon_code"`
+	}
+	if !readJSON(w, r, &req) {
+		return
+	}
+	user := currentUser(r)
+	if req.Email == "" {
+		req.Email = user.GetEmail()
+	}
+	if req.Email == "" || req.Address == nil || req.CreditCard == nil {
+		writeAPIError(w, r, triple.NewError(triple.CodeInvalidArgument, errors.New("email, address and credit_card are required")))
+		return
+	}
+	currency, err := apiCurrency(r)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	card := &pb.CreditCardInfo{
+		CreditCardNumber:          strings.ReplaceAll(req.CreditCard.Number, "-", ""),
+		CreditCardCvv:             req.CreditCard.CVV,
+		CreditCardExpirationYear:  req.CreditCard.ExpirationYear,
+		CreditCardExpirationMonth: req.CreditCard.ExpirationMonth,
+	}
+	if req.CreditCard.Token != "" {
+		if user == nil {
+			writeAPIError(w, r, triple.NewError(triple.CodeUnauthenticated, errors.New("sign in to pay with a saved card")))
+			return
+		}
+		if card, err = fe.savedCard(r.Context(), req.CreditCard.Token, req.CreditCard.CVV); err != nil {
+			writeAPIError(w, r, err)
+			return
+		}
+	}
+
+	resp, err := fe.checkoutService.PlaceOrder(r.Context(), &pb.PlaceOrderRequest{
+		UserId:       sessionID(r),
+		UserCurrency: currency,
+		Address:      req.Address.proto(),
+		Email:        req.Email,
+		CreditCard:   card,
+		CouponCode:   req.CouponCode,
+	})
+	if err != nil {
+		writeAPIError(w, r, fmt.Errorf("failed to complete the order: %w", err))
+		return
+	}
+	order := resp.GetOrder()
+	logFrom(r).WithField("order", order.GetOrderId()).Info("order placed")
+
+	out := apiOrder{
+		OrderID:            order.GetOrderId(),
+		ShippingTrackingID: order.GetShippingTrackingId(),
+		ShippingCost:       toAPIMoney(order.GetShippingCost()),
+		ShippingAddress:    *req.Address,
+		Items:              []apiOrderItem{},
+	}
+	total := order.GetShippingCost()
+	for _, item := range order.GetItems() {
+		out.Items = append(out.Items, apiOrderItem{
+			ProductID: item.GetItem().GetProductId(),
+			Quantity:  item.GetItem().GetQuantity(),
+			Cost:      toAPIMoney(item.GetCost()),
+		})
+		total = money.Must(money.Sum(total, money.MultiplySlow(item.GetCost(), uint32(item.GetItem().GetQuantity()))))
+	}
+	for _, d := range order.GetDiscounts() {
+		total = money.Must(money.Sum(total, money.Negate(d.GetAmount())))
+	}
+	out.Discounts = toAPIDiscounts(order.GetDiscounts())
+	out.Total = toAPIMoney(total)
+	writeJSON(w, http.StatusCreated, out)
+}
+
+func (fe *frontendServer) apiRecommendations(w http.ResponseWriter, r *http.Request) {
+	currency, err := apiCurrency(r)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	var ids []string
+	for _, id := range strings.Split(r.URL.Query().Get("product_ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	products, err := fe.getRecommendations(r.Context(), sessionID(r), ids)
+	if err != nil {
+		writeAPIError(w, r, fmt.Errorf("failed to get product recommendations: %w", err))
+		return
+	}
+	out, err := fe.localizedProducts(r.Context(), products, currency)
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"products": out})
+}
+
+type apiSavedAddress struct {
+	ID      string     `json:"id"`
+	Label   string     `json:"label"`
+	Address apiAddress `json:"address"`
+}
+
+type apiSavedCard struct {
+	Token           string `json:"token"`
+	Brand           string `json:"brand"`
+	LastFour        string `json:"last_four"`
+	ExpirationYear  int32  `json:"expiration_year"`
+	ExpirationMonth int32  `json:"expiration_month"`
+}
+
+type apiUser struct {
+	ID        string            `json:"id"`
+	Email     string            `json:"email"`
+	Name      string            `json:"name"`
+	Addresses []apiSavedAddress `json:"addresses"`
+	Cards     []apiSavedCard    `json:"cards"`
+}
+
+func toAPIUser(u *pb.User) apiUser {
+	out := apiUser{
+		ID:        u.GetUserId(),
+		Email:     u.GetEmail(),
+		Name:      u.GetName(),
+		Addresses: []apiSavedAddress{},
+		Cards:     []apiSavedCard{},
+	}
+	for _, a := range u.GetAddresses() {
+		out.Addresses = append(out.Addresses, apiSavedAddress{
+			ID:    a.GetId(),
+			Label: a.GetLabel(),
+			Address: apiAddress{
+				StreetAddress: a.GetAddress().GetStreetAddress(),
+				City:          a.GetAddress().GetCity(),
+				State:         a.GetAddress().GetState(),
+				Country:       a.GetAddress().GetCountry(),
+				ZipCode:       a.GetAddress().GetZipCode(),
+			},
+		})
+	}
+	for _, c := range u.GetCards() {
+		out.Cards = append(out.Cards, apiSavedCard{
+			Token:           c.GetToken(),
+			Brand:           c.GetBrand(),
+			LastFour:        c.GetLastFour(),
+			ExpirationYear:  c.GetExpirationYear(),
+			ExpirationMonth: c.GetExpirationMonth(),
+		})
+	}
+	return out
+}
+
+type apiCredentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Name     string `json:"name"`
+}
+
+func (fe *frontendServer) apiRegister(w http.ResponseWriter, r *http.Request) {
+	var req apiCredentials
+	if !readJSON(w, r, &req) {
+		return
+	}
+	resp, err := fe.userService.Register(r.Context(), &pb.RegisterRequest{Email: req.Email, Password: req.Password, Name: req.Name})
+	fe.apiSignIn(w, r, resp, err, http.StatusCreated)
+}
+
+func (fe *frontendServer) apiLogin(w http.ResponseWriter, r *http.Request) {
+	var req apiCredentials
+	if !readJSON(w, r, &req) {
+		return
+	}
+	resp, err := fe.userService.Login(r.Context(), &pb.LoginRequest{Email: req.Email, Password: req.Password})
+	fe.apiSignIn(w, r, resp, err, http.StatusOK)
+}
+
+// apiSignIn answers a login or registration with the session token, after
+// merging the cart of the anonymous session into the user's cart.
+func (fe *frontendServer) apiSignIn(w http.ResponseWriter, r *http.Request, resp *pb.AuthResponse, err error, code int) {
+	if err != nil {
+		writeAPIError(w, r, err)
+		return
+	}
+	userID := resp.GetUser().GetUserId()
+	if anonymous := sessionID(r); anonymous != "" && currentUser(r) == nil {
+		if err := fe.mergeCart(r.Context(), anonymous, userSessionID(userID)); err != nil {
+			logFrom(r).WithField("error", err).Warn("failed to merge cart")
+		}
+	}
+	w.Header().Set(headerSessionID, userSessionID(userID))
+	writeJSON(w, code, map[string]interface{}{
+		"token":      resp.GetSessionToken(),
+		"expires_at": time.Unix(resp.GetExpiresAt(), 0).UTC(),
+		"user":       toAPIUser(resp.GetUser()),
+	})
+}
+
+func (fe *frontendServer) apiLogout(w http.ResponseWriter, r *http.Request) {
+	token, ok := bearerToken(r)
+	if !ok {
+		writeAPIError(w, r, triple.NewError(triple.CodeUnauthenticated, errors.New("no session token")))
+		return
+	}
+	if _, err := fe.userService.Logout(r.Context(), &pb.LogoutRequest{SessionToken: token}); err != nil {
+		writeAPIError(w, r, fmt.Errorf("failed to sign out: %w", err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (fe *frontendServer) apiAccount(w http.ResponseWriter, r *http.Request) {
+	user := currentUser(r)
+	if user == nil {
+		writeAPIError(w, r, triple.NewError(triple.CodeUnauthenticated, errors.New("not signed in")))
+		return
+	}
+	writeJSON(w, http.StatusOK, toAPIUser(user))
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPIBody)).Decode(v); err != nil {
+		writeAPIError(w, r, triple.NewError(triple.CodeInvalidArgument, fmt.Errorf("invalid JSON body: %w", err)))
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func logFrom(r *http.Request) logrus.FieldLogger {
+	if log, ok := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger); ok {
+		return log
+	}
+	return logrus.StandardLogger()
+}
+
+type apiErrorBody struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// writeAPIError answers with the error envelope. The HTTP status follows the
+// triple code of the error, errors without a code are internal.
+func writeAPIError(w http.ResponseWriter, r *http.Request, err error) {
+	code := triple.CodeOf(err)
+	if code == triple.CodeBizError {
+		code = triple.CodeUnknown
+	}
+	status := httpStatus(code)
+	log := logFrom(r).WithField("error", err)
+	if status >= http.StatusInternalServerError {
+		log.Error("request error")
+	} else {
+		log.Warn("request error")
+	}
+
+	requestID, _ := r.Context().Value(ctxKeyRequestID{}).(string)
+	writeJSON(w, status, map[string]apiErrorBody{"error": {
+		Code:      code.String(),
+		Message:   errorMessage(err),
+		RequestID: requestID,
+	}})
+}
+
+// errorMessage drops the code prefixes triple errors add to their text.
+func errorMessage(err error) string {
+	msg := err.Error()
+	for c := triple.CodeCanceled; c <= triple.CodeBizError; c++ {
+		msg = strings.ReplaceAll(msg, c.String()+": ", "")
+	}
+	return msg
+}
+
+func httpStatus(code triple.Code) int {
+	switch code {
+	case triple.CodeCanceled:
+		return 499 // client closed request
+	case triple.CodeInvalidArgument, triple.CodeFailedPrecondition, triple.CodeOutOfRange:
+		return http.StatusBadRequest
+	case triple.CodeDeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case triple.CodeNotFound:
+		return http.StatusNotFound
+	case triple.CodeAlreadyExists, triple.CodeAborted:
+		return http.StatusConflict
+	case triple.CodePermissionDenied:
+		return http.StatusForbidden
+	case triple.CodeUnauthenticated:
+		return http.StatusUnauthorized
+	case triple.CodeResourceExhausted:
+		return http.StatusTooManyRequests
+	case triple.CodeUnimplemented:
+		return http.StatusNotImplemented
+	case triple.CodeUnavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
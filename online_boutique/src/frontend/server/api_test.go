@@ -0,0 +1,513 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"dubbo.apache.org/dubbo-go/v3/client"
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+// fakeShop stands in for all the backends of the frontend.
+type fakeShop struct {
+	// The methods the frontend does not call are left to these.
+	pb.AdService
+	pb.ProductCatalogService
+	pb.RecommendationService
+	pb.ShippingService
+
+	mu       sync.Mutex
+	carts    map[string][]*pb.CartItem
+	products map[string]*pb.Product
+	placeErr error
+	placed   *pb.PlaceOrderRequest
+	searched *pb.SearchProductsRequest
+	lastCtx  context.Context  // of the last GetProduct call
+	stock    map[string]int32 // tracked only when set
+	coupons  bool             // SAVE5 takes 5 off when set
+}
+
+func newFakeShop() *fakeShop {
+	return &fakeShop{
+		carts: make(map[string][]*pb.CartItem),
+		products: map[string]*pb.Product{
+			"OLJCESPC7Z": {Id: "OLJCESPC7Z", Name: "Sunglasses", Categories: []string{"accessories"},
+				PriceUsd: &pb.Money{CurrencyCode: "USD", Units: 19, Nanos: 990000000}},
+			"66VCHSJNUP": {Id: "66VCHSJNUP", Name: "Tank Top",
+				PriceUsd: &pb.Money{CurrencyCode: "USD", Units: 18, Nanos: 990000000}},
+		},
+	}
+}
+
+func (f *fakeShop) ListProducts(context.Context, *pb.Empty, ...client.CallOption) (*pb.ListProductsResponse, error) {
+	return &pb.ListProductsResponse{Products: []*pb.Product{f.products["66VCHSJNUP"], f.products["OLJCESPC7Z"]}}, nil
+}
+
+func (f *fakeShop) GetProduct(ctx context.Context, req *pb.GetProductRequest, _ ...client.CallOption) (*pb.Product, error) {
+	f.lastCtx = ctx
+	p, ok := f.products[req.Id]
+	if !ok {
+		return nil, triple.NewError(triple.CodeNotFound, errors.New("Product not found with ID "+req.Id))
+	}
+	return p, nil
+}
+
+func (f *fakeShop) SearchProducts(_ context.Context, req *pb.SearchProductsRequest, _ ...client.CallOption) (*pb.SearchProductsResponse, error) {
+	f.searched = req
+	var out []*pb.Product
+	for _, p := range f.products {
+		if strings.Contains(strings.ToLower(p.Name), strings.ToLower(req.Query)) {
+			out = append(out, p)
+		}
+	}
+	return &pb.SearchProductsResponse{Results: out}, nil
+}
+
+func (f *fakeShop) GetSupportedCurrencies(context.Context, *pb.Empty, ...client.CallOption) (*pb.GetSupportedCurrenciesResponse, error) {
+	return &pb.GetSupportedCurrenciesResponse{CurrencyCodes: []string{"EUR", "USD", "XXX"}}, nil
+}
+
+// Convert doubles the price for any currency other than USD.
+func (f *fakeShop) Convert(_ context.Context, req *pb.CurrencyConversionRequest, _ ...client.CallOption) (*pb.Money, error) {
+	if req.ToCode == "USD" {
+		return req.From, nil
+	}
+	units, nanos := 2*req.From.Units, 2*req.From.Nanos
+	return &pb.Money{CurrencyCode: req.ToCode, Units: units + int64(nanos/1e9), Nanos: nanos % 1e9}, nil
+}
+
+func (f *fakeShop) AddItem(_ context.Context, req *pb.AddItemRequest, _ ...client.CallOption) (*pb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.carts[req.UserId] = append(f.carts[req.UserId], req.Item)
+	return &pb.Empty{}, nil
+}
+
+func (f *fakeShop) GetCart(_ context.Context, req *pb.GetCartRequest, _ ...client.CallOption) (*pb.Cart, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &pb.Cart{UserId: req.UserId, Items: f.carts[req.UserId]}, nil
+}
+
+func (f *fakeShop) EmptyCart(_ context.Context, req *pb.EmptyCartRequest, _ ...client.CallOption) (*pb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.carts, req.UserId)
+	return &pb.Empty{}, nil
+}
+
+func (f *fakeShop) GetQuote(context.Context, *pb.GetQuoteRequest, ...client.CallOption) (*pb.GetQuoteResponse, error) {
+	return &pb.GetQuoteResponse{CostUsd: &pb.Money{CurrencyCode: "USD", Units: 5}}, nil
+}
+
+func (f *fakeShop) ShipOrder(context.Context, *pb.ShipOrderRequest, ...client.CallOption) (*pb.ShipOrderResponse, error) {
+	return &pb.ShipOrderResponse{TrackingId: "TRACK"}, nil
+}
+
+func (f *fakeShop) PlaceOrder(_ context.Context, req *pb.PlaceOrderRequest, _ ...client.CallOption) (*pb.PlaceOrderResponse, error) {
+	if f.placeErr != nil {
+		return nil, f.placeErr
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.placed = req
+	order := &pb.OrderResult{OrderId: "ORDER", ShippingTrackingId: "TRACK",
+		ShippingCost: &pb.Money{CurrencyCode: req.UserCurrency, Units: 5}, ShippingAddress: req.Address}
+	for _, item := range f.carts[req.UserId] {
+		order.Items = append(order.Items, &pb.OrderItem{Item: item, Cost: f.products[item.ProductId].PriceUsd})
+	}
+	if req.CouponCode == "SAVE5" {
+		order.Discounts = []*pb.Discount{{PromotionId: "save5", Description: "5 off", Amount: &pb.Money{CurrencyCode: req.UserCurrency, Units: 5}}}
+	}
+	delete(f.carts, req.UserId)
+	return &pb.PlaceOrderResponse{Order: order}, nil
+}
+
+func (f *fakeShop) ListRecommendations(context.Context, *pb.ListRecommendationsRequest, ...client.CallOption) (*pb.ListRecommendationsResponse, error) {
+	return &pb.ListRecommendationsResponse{}, nil
+}
+
+func (f *fakeShop) GetAds(context.Context, *pb.AdRequest, ...client.CallOption) (*pb.AdResponse, error) {
+	return &pb.AdResponse{}, nil
+}
+
+func (f *fakeShop) GetStock(_ context.Context, req *pb.GetStockRequest, _ ...client.CallOption) (*pb.GetStockResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resp := &pb.GetStockResponse{}
+	for _, id := range req.GetProductIds() {
+		resp.Stock = append(resp.Stock, &pb.StockLevel{ProductId: id, Available: f.stock[id]})
+	}
+	return resp, nil
+}
+
+func (f *fakeShop) Reserve(context.Context, *pb.ReserveRequest, ...client.CallOption) (*pb.Reservation, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeShop) Commit(context.Context, *pb.CommitRequest, ...client.CallOption) (*pb.Empty, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeShop) Release(context.Context, *pb.ReleaseRequest, ...client.CallOption) (*pb.Empty, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeShop) Evaluate(_ context.Context, req *pb.EvaluateRequest, _ ...client.CallOption) (*pb.EvaluateResponse, error) {
+	switch req.CouponCode {
+	case "":
+		return &pb.EvaluateResponse{}, nil
+	case "SAVE5":
+		d := &pb.Discount{PromotionId: "save5", Description: "5 off", Amount: &pb.Money{CurrencyCode: "USD", Units: 5}}
+		return &pb.EvaluateResponse{Discounts: []*pb.Discount{d}, Total: d.Amount}, nil
+	}
+	return nil, triple.NewError(triple.CodeNotFound, errors.New("unknown coupon "+req.CouponCode))
+}
+
+func (f *fakeShop) Redeem(context.Context, *pb.RedeemRequest, ...client.CallOption) (*pb.EvaluateResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeShop) CancelRedemption(context.Context, *pb.CancelRedemptionRequest, ...client.CallOption) (*pb.Empty, error) {
+	return nil, errors.New("not implemented")
+}
+
+func newTestAPI(t *testing.T, shop *fakeShop) http.Handler {
+	t.Helper()
+	return newTestFrontend(t, shop, nil)
+}
+
+func newTestFrontend(t *testing.T, shop *fakeShop, users pb.UserService) http.Handler {
+	t.Helper()
+	log := logrus.New()
+	log.Out = io.Discard
+	var inventory pb.InventoryService
+	if shop.stock != nil {
+		inventory = shop
+	}
+	var promotion pb.PromotionService
+	if shop.coupons {
+		promotion = shop
+	}
+	h, err := NewHandler(Services{
+		Ad:             shop,
+		Cart:           shop,
+		Checkout:       shop,
+		Currency:       shop,
+		ProductCatalog: shop,
+		Recommendation: shop,
+		Shipping:       shop,
+		User:           users,
+		Inventory:      inventory,
+		Promotion:      promotion,
+	}, "..", log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+// shopper is the anonymous session of the tests.
+const shopper = "0b5bbd2e-5c84-4a8a-9b1e-5b9ad1d6a0f3"
+
+func call(t *testing.T, h http.Handler, method, path, body string, out interface{}) int {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(headerSessionID, shopper)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if got := rec.Header().Get(headerSessionID); got != shopper {
+		t.Errorf("%s %s: session header %q", method, path, got)
+	}
+	if out != nil && rec.Body.Len() > 0 {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: %v in %s", method, path, err, rec.Body)
+		}
+	}
+	return rec.Code
+}
+
+func TestAPIProducts(t *testing.T) {
+	h := newTestAPI(t, newFakeShop())
+
+	var list struct{ Products []apiProduct }
+	if code := call(t, h, http.MethodGet, "/api/v1/products?currency=EUR", "", &list); code != http.StatusOK {
+		t.Fatalf("list products: status %d", code)
+	}
+	if len(list.Products) != 2 || list.Products[1].Price != (apiMoney{"EUR", 39, 980000000}) {
+		t.Errorf("products %+v", list.Products)
+	}
+
+	var p apiProduct
+	if code := call(t, h, http.MethodGet, "/api/v1/products/OLJCESPC7Z", "", &p); code != http.StatusOK || p.Name != "Sunglasses" {
+		t.Errorf("get product: status %d, %+v", code, p)
+	}
+	if code := call(t, h, http.MethodGet, "/api/v1/products/search?q=tank", "", &list); code != http.StatusOK ||
+		len(list.Products) != 1 || list.Products[0].ID != "66VCHSJNUP" {
+		t.Errorf("search: status %d, %+v", code, list.Products)
+	}
+}
+
+func TestAPISearchParameters(t *testing.T) {
+	shop := newFakeShop()
+	h := newTestAPI(t, shop)
+
+	path := "/api/v1/products/search?q=top&category=tops&category=summer&min_price=10.5&max_price=20&sort=price_desc&page_size=2&page_token=abc"
+	if code := call(t, h, http.MethodGet, path, "", nil); code != http.StatusOK {
+		t.Fatalf("search: status %d", code)
+	}
+	want := &pb.SearchProductsRequest{
+		Query:      "top",
+		Categories: []string{"tops", "summer"},
+		MinPrice:   &pb.Money{CurrencyCode: "USD", Units: 10, Nanos: 500000000},
+		MaxPrice:   &pb.Money{CurrencyCode: "USD", Units: 20},
+		SortOrder:  pb.SearchSortOrder_PRICE_DESC,
+		PageSize:   2,
+		PageToken:  "abc",
+	}
+	if !proto.Equal(shop.searched, want) {
+		t.Errorf("searched %v, want %v", shop.searched, want)
+	}
+
+	for _, bad := range []string{"min_price=-1", "max_price=1.2.3", "sort=cheapest", "page_size=-2"} {
+		if code := call(t, h, http.MethodGet, "/api/v1/products/search?q=top&"+bad, "", nil); code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", bad, code)
+		}
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	shop := newFakeShop()
+	h := newTestAPI(t, shop)
+
+	tests := []struct {
+		name       string
+		method     string
+		path, body string
+		placeErr   error
+		wantStatus int
+		wantCode   string
+	}{
+		{"unknown product", http.MethodGet, "/api/v1/products/nope", "", nil, http.StatusNotFound, "not_found"},
+		{"unsupported currency", http.MethodGet, "/api/v1/products?currency=XXX", "", nil, http.StatusBadRequest, "invalid_argument"},
+		{"no query", http.MethodGet, "/api/v1/products/search", "", nil, http.StatusBadRequest, "invalid_argument"},
+		{"bad json", http.MethodPost, "/api/v1/cart/items", "{", nil, http.StatusBadRequest, "invalid_argument"},
+		{"no quantity", http.MethodPost, "/api/v1/cart/items", `{"product_id":"OLJCESPC7Z"}`, nil, http.StatusBadRequest, "invalid_argument"},
+		{"unknown endpoint", http.MethodGet, "/api/v1/orders", "", nil, http.StatusNotFound, "not_found"},
+		{"declined card", http.MethodPost, "/api/v1/checkout", checkoutBody,
+			triple.NewError(triple.CodeFailedPrecondition, errors.New("card declined")), http.StatusBadRequest, "failed_precondition"},
+		{"payment down", http.MethodPost, "/api/v1/checkout", checkoutBody,
+			triple.NewError(triple.CodeUnavailable, errors.New("processor unavailable")), http.StatusServiceUnavailable, "unavailable"},
+		{"business error", http.MethodPost, "/api/v1/checkout", checkoutBody,
+			triple.NewError(triple.CodeBizError, errors.New("boom")), http.StatusInternalServerError, "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shop.placeErr = tt.placeErr
+			var body struct{ Error apiErrorBody }
+			if code := call(t, h, tt.method, tt.path, tt.body, &body); code != tt.wantStatus {
+				t.Errorf("status %d, want %d", code, tt.wantStatus)
+			}
+			if body.Error.Code != tt.wantCode || body.Error.Message == "" || body.Error.RequestID == "" {
+				t.Errorf("error %+v, want code %s", body.Error, tt.wantCode)
+			}
+			if strings.Contains(body.Error.Message, tt.wantCode+":") {
+				t.Errorf("message %q repeats the code", body.Error.Message)
+			}
+		})
+	}
+}
+
+func TestAPIStock(t *testing.T) {
+	shop := newFakeShop()
+	shop.stock = map[string]int32{"OLJCESPC7Z": 3}
+	h := newTestAPI(t, shop)
+
+	var p apiProduct
+	if code := call(t, h, http.MethodGet, "/api/v1/products/OLJCESPC7Z", "", &p); code != http.StatusOK ||
+		p.Available == nil || *p.Available != 3 {
+		t.Errorf("get product: status %d, %+v", code, p)
+	}
+	if code := call(t, h, http.MethodPost, "/api/v1/cart/items", `{"product_id":"OLJCESPC7Z","quantity":2}`, nil); code != http.StatusCreated {
+		t.Fatalf("add 2 of 3: status %d", code)
+	}
+	for _, body := range []string{
+		`{"product_id":"OLJCESPC7Z","quantity":2}`, // 4 in the cart, 3 left
+		`{"product_id":"66VCHSJNUP","quantity":1}`, // out of stock
+	} {
+		var resp struct{ Error apiErrorBody }
+		if code := call(t, h, http.MethodPost, "/api/v1/cart/items", body, &resp); code != http.StatusBadRequest ||
+			resp.Error.Code != "failed_precondition" {
+			t.Errorf("add %s: status %d, %+v", body, code, resp.Error)
+		}
+	}
+	if n := len(shop.carts[shopper]); n != 1 || shop.carts[shopper][0].Quantity != 2 {
+		t.Errorf("cart %v", shop.carts[shopper])
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/cart", strings.NewReader("product_id=66VCHSJNUP&quantity=1"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("add out of stock from the product page: status %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/product/66VCHSJNUP", nil))
+	if !strings.Contains(rec.Body.String(), "Out of stock") {
+		t.Errorf("product page does not say out of stock:\n%s", rec.Body)
+	}
+}
+
+const checkoutBody = `{
+	"email": "someone@example.com",
+	"address": {"street_address": "1600 Amphitheatre Parkway", "city": "Mountain View", "state": "CA", "country": "United States", "zip_code": 94043},
+	"credit_card": {"number": "4432-8015-6152-0454", "cvv": 672, "expiration_year": 2039, "expiration_month": 1}
+}`
+
+func TestAPICartAndCheckout(t *testing.T) {
+	shop := newFakeShop()
+	h := newTestAPI(t, shop)
+
+	var cart apiCart
+	if code := call(t, h, http.MethodPost, "/api/v1/cart/items", `{"product_id":"OLJCESPC7Z","quantity":2}`, &cart); code != http.StatusCreated {
+		t.Fatalf("add to cart: status %d", code)
+	}
+	if cart.Size != 2 || cart.Total != (apiMoney{"USD", 44, 980000000}) {
+		t.Errorf("cart %+v, want 2 items for 44.98 with shipping", cart)
+	}
+
+	var quote struct{ Cost apiMoney }
+	if code := call(t, h, http.MethodPost, "/api/v1/shipping/quote?currency=EUR", `{}`, &quote); code != http.StatusOK ||
+		quote.Cost != (apiMoney{"EUR", 10, 0}) {
+		t.Errorf("quote: status %d, %+v", code, quote.Cost)
+	}
+
+	var order apiOrder
+	if code := call(t, h, http.MethodPost, "/api/v1/checkout", checkoutBody, &order); code != http.StatusCreated {
+		t.Fatalf("checkout: status %d", code)
+	}
+	if order.OrderID != "ORDER" || len(order.Items) != 1 || order.Total != (apiMoney{"USD", 44, 980000000}) ||
+		order.ShippingAddress.ZipCode != 94043 {
+		t.Errorf("order %+v", order)
+	}
+	if code := call(t, h, http.MethodGet, "/api/v1/cart", "", &cart); code != http.StatusOK || len(cart.Items) != 0 {
+		t.Errorf("cart after checkout: status %d, %+v", code, cart)
+	}
+}
+
+func TestCoupons(t *testing.T) {
+	shop := newFakeShop()
+	shop.coupons = true
+	h := newTestAPI(t, shop)
+
+	if code := call(t, h, http.MethodPost, "/api/v1/cart/items", `{"product_id":"OLJCESPC7Z","quantity":2}`, nil); code != http.StatusCreated {
+		t.Fatalf("add to cart: status %d", code)
+	}
+	var cart apiCart
+	if code := call(t, h, http.MethodGet, "/api/v1/cart?coupon=SAVE5", "", &cart); code != http.StatusOK ||
+		len(cart.Discounts) != 1 || cart.Total != (apiMoney{"USD", 39, 980000000}) {
+		t.Errorf("cart with coupon: status %d, %+v", code, cart)
+	}
+	var body struct{ Error apiErrorBody }
+	if code := call(t, h, http.MethodGet, "/api/v1/cart?coupon=NOPE", "", &body); code != http.StatusNotFound {
+		t.Errorf("cart with unknown coupon: status %d, %+v", code, body.Error)
+	}
+
+	// The cart page drops a coupon that does not apply, and says so.
+	for _, tt := range []struct{ coupon, want string }{
+		{"SAVE5", "5 off"},
+		{"NOPE", "Coupon NOPE is not valid."},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/cart", nil)
+		req.AddCookie(&http.Cookie{Name: cookieSessionID, Value: shopper})
+		req.AddCookie(&http.Cookie{Name: cookieCoupon, Value: tt.coupon})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), tt.want) {
+			t.Errorf("cart page with coupon %s: status %d, no %q", tt.coupon, rec.Code, tt.want)
+		}
+	}
+
+	var order apiOrder
+	withCoupon := strings.Replace(checkoutBody, "{\n", `{"coupon_code": "SAVE5",`, 1)
+	if code := call(t, h, http.MethodPost, "/api/v1/checkout", withCoupon, &order); code != http.StatusCreated {
+		t.Fatalf("checkout: status %d", code)
+	}
+	if shop.placed.CouponCode != "SAVE5" || len(order.Discounts) != 1 || order.Total != (apiMoney{"USD", 39, 980000000}) {
+		t.Errorf("order %+v placed with coupon %q", order, shop.placed.CouponCode)
+	}
+}
+
+func TestAPICurrency(t *testing.T) {
+	h := newTestAPI(t, newFakeShop())
+
+	var currencies struct {
+		Currencies []string
+		Current    string
+	}
+	if code := call(t, h, http.MethodGet, "/api/v1/currencies", "", &currencies); code != http.StatusOK ||
+		strings.Join(currencies.Currencies, ",") != "EUR,USD" || currencies.Current != "USD" {
+		t.Errorf("currencies: status %d, %+v", code, currencies)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/currency", strings.NewReader(`{"currency_code":"EUR"}`))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	// the pages read the cookie too, so it is not limited to the API path
+	cookies := make(map[string]*http.Cookie)
+	for _, c := range rec.Result().Cookies() {
+		cookies[c.Name] = c
+	}
+	if c := cookies[cookieCurrency]; rec.Code != http.StatusOK || c == nil || c.Value != "EUR" || c.Path != "/" {
+		t.Errorf("set currency: status %d, cookies %q", rec.Code, rec.Header()["Set-Cookie"])
+	}
+	if c := cookies[cookieSessionID]; c == nil || c.Path != "/" {
+		t.Errorf("session cookie set by the API is %v, want it for every path", c)
+	}
+}
+
+func TestOpenAPIDocumentsRoutes(t *testing.T) {
+	doc, err := os.ReadFile("openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{
+		"/products:", "/products/search:", "/products/{id}:", "/cart:", "/cart/items:",
+		"/currencies:", "/currency:", "/shipping/quote:", "/checkout:", "/recommendations:", "/openapi.yaml:",
+		"/users:", "/session:", "/account:",
+	} {
+		if !strings.Contains(string(doc), "\n  "+path+"\n") {
+			t.Errorf("openapi.yaml does not document %s", strings.TrimSuffix(path, ":"))
+		}
+	}
+}
@@ -0,0 +1,271 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+
+	"github.com/alibaba/sentinel-golang/core/base"
+	"github.com/alibaba/sentinel-golang/core/circuitbreaker"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+// The dependencies the pages can do without. While one of them is down the
+// pages fall back to what it last answered, or to nothing, and say so in a
+// banner.
+const (
+	depCurrency       = "currency"
+	depCart           = "cart"
+	depRecommendation = "recommendation"
+	depAd             = "ad"
+)
+
+type degradedDependency struct {
+	Name   string
+	Notice string
+}
+
+var dependencies = map[string]degradedDependency{
+	depCurrency:       {"Currency service", "Prices are converted at the last known exchange rates."},
+	depCart:           {"Cart service", "The catalog can be browsed, but nothing can be added to the cart."},
+	depRecommendation: {"Recommendation service", "Recommendations are not shown."},
+	depAd:             {"Ad service", "Ads are not shown."},
+}
+
+// breakerDependencies are the dependencies by the backend service behind
+// them, each with a circuit breaker.
+var breakerDependencies = map[string]string{
+	pb.CurrencyServiceName:       depCurrency,
+	pb.CartServiceName:           depCart,
+	pb.RecommendationServiceName: depRecommendation,
+	pb.AdServiceName:             depAd,
+}
+
+// BreakerFilter is the consumer filter that puts the calls to a backend
+// through its circuit breaker. The Currency, Cart, Recommendation and Ad
+// services are referenced with it.
+const BreakerFilter = constant.SentinelConsumerFilterKey
+
+var (
+	breakersMu   sync.Mutex
+	openBreakers = make(map[string]bool) // by dependency
+	listenOnce   sync.Once
+)
+
+// LoadCircuitBreakers opens the circuit breaker of a backend when half of its
+// calls fail, at least 10 of them in 10 seconds, and lets a call through again
+// after 5 seconds. Until then the calls fail right away and the pages fall
+// back without waiting for the backend.
+func LoadCircuitBreakers() error {
+	rules := make([]*circuitbreaker.Rule, 0, len(breakerDependencies))
+	for svc := range breakerDependencies {
+		rules = append(rules, &circuitbreaker.Rule{
+			Resource:         breakerResource(svc),
+			Strategy:         circuitbreaker.ErrorRatio,
+			RetryTimeoutMs:   5000,
+			MinRequestAmount: 10,
+			StatIntervalMs:   10000,
+			Threshold:        0.5,
+		})
+	}
+	if _, err := circuitbreaker.LoadRules(rules); err != nil {
+		return err
+	}
+	listenOnce.Do(func() { circuitbreaker.RegisterStateChangeListeners(breakerListener{}) })
+	return nil
+}
+
+// breakerResource is the resource the sentinel consumer filter counts the
+// calls to a service on: the service, group and version, the last two empty.
+func breakerResource(service string) string {
+	return service + "::"
+}
+
+// breakerListener keeps track of the open circuit breakers.
+type breakerListener struct{}
+
+func (breakerListener) OnTransformToClosed(_ circuitbreaker.State, rule circuitbreaker.Rule) {
+	setBreakerOpen(rule.Resource, false)
+}
+
+func (breakerListener) OnTransformToOpen(_ circuitbreaker.State, rule circuitbreaker.Rule, _ interface{}) {
+	setBreakerOpen(rule.Resource, true)
+}
+
+func (breakerListener) OnTransformToHalfOpen(circuitbreaker.State, circuitbreaker.Rule) {}
+
+func setBreakerOpen(resource string, open bool) {
+	for svc, dep := range breakerDependencies {
+		if breakerResource(svc) == resource {
+			breakersMu.Lock()
+			openBreakers[dep] = open
+			breakersMu.Unlock()
+		}
+	}
+}
+
+func breakerOpen(dep string) bool {
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+	return openBreakers[dep]
+}
+
+// unavailable tells whether err says that a dependency could not serve the
+// call, rather than that the call was wrong.
+func unavailable(err error) bool {
+	var blocked *base.BlockError
+	if errors.As(err, &blocked) {
+		return true // by its circuit breaker
+	}
+	switch triple.CodeOf(err) {
+	case triple.CodeInvalidArgument, triple.CodeNotFound, triple.CodeAlreadyExists, triple.CodeOutOfRange,
+		triple.CodeFailedPrecondition, triple.CodePermissionDenied, triple.CodeUnauthenticated:
+		return false
+	}
+	return true
+}
+
+// health remembers which dependencies are down, and what they last answered
+// for the fallbacks.
+type health struct {
+	mu         sync.Mutex
+	down       map[string]bool
+	currencies []string
+	rates      map[[2]string]*big.Rat // by the codes of the from and to currencies
+}
+
+func newHealth() *health {
+	return &health{down: make(map[string]bool), rates: make(map[[2]string]*big.Rat)}
+}
+
+// report records the outcome of a call to the dependency, and tells whether
+// the caller should fall back.
+func (h *health) report(dep string, err error) bool {
+	down := err != nil && unavailable(err)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if down {
+		h.down[dep] = true
+	} else if err == nil {
+		delete(h.down, dep)
+	}
+	return down
+}
+
+func (h *health) isDown(dep string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.down[dep] || breakerOpen(dep)
+}
+
+// degraded lists the dependencies that are down, for the banner.
+func (h *health) degraded() []degradedDependency {
+	var out []degradedDependency
+	for dep, d := range dependencies {
+		if h.isDown(dep) {
+			out = append(out, d)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func (h *health) rememberCurrencies(codes []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.currencies = append([]string(nil), codes...)
+}
+
+func (h *health) lastCurrencies() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.currencies...)
+}
+
+// rememberRate keeps the exchange rate of a conversion.
+func (h *health) rememberRate(from, to *pb.Money) {
+	n := nanos(from)
+	if n.Sign() == 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.rates[[2]string{from.GetCurrencyCode(), to.GetCurrencyCode()}] = new(big.Rat).SetFrac(nanos(to), n)
+}
+
+func (h *health) knowsRate(from, to string) bool {
+	if from == to {
+		return true
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.rates[[2]string{from, to}] != nil
+}
+
+// convert converts money at the last known rate, if there is one.
+func (h *health) convert(m *pb.Money, currency string) (*pb.Money, bool) {
+	if m.GetCurrencyCode() == currency {
+		return m, true
+	}
+	h.mu.Lock()
+	rate := h.rates[[2]string{m.GetCurrencyCode(), currency}]
+	h.mu.Unlock()
+	if rate == nil {
+		return nil, false
+	}
+	v := new(big.Rat).Mul(new(big.Rat).SetInt(nanos(m)), rate)
+	n := new(big.Int).Quo(v.Num(), v.Denom()) // rounded towards zero, as the currency service does
+	units, rest := new(big.Int).QuoRem(n, big.NewInt(1e9), new(big.Int))
+	return &pb.Money{CurrencyCode: currency, Units: units.Int64(), Nanos: int32(rest.Int64())}, true
+}
+
+func nanos(m *pb.Money) *big.Int {
+	n := new(big.Int).Mul(big.NewInt(m.GetUnits()), big.NewInt(1e9))
+	return n.Add(n, big.NewInt(int64(m.GetNanos())))
+}
+
+// displayCurrency is the currency the pages show prices in: the one of the
+// shopper, unless there is no way to convert to it with the currency service
+// down, then USD.
+func (fe *frontendServer) displayCurrency(ctx context.Context, currency string) string {
+	if fe.health.knowsRate(defaultCurrency, currency) {
+		return currency
+	}
+	if _, err := fe.convertCurrency(ctx, &pb.Money{CurrencyCode: defaultCurrency, Units: 1}, currency); err != nil && unavailable(err) {
+		return defaultCurrency
+	}
+	return currency
+}
+
+// cartOrNone returns the cart of the shopper for a page, or no cart while the
+// cart service is down and the shop is read-only.
+func (fe *frontendServer) cartOrNone(ctx context.Context, userID string) ([]*pb.CartItem, error) {
+	cart, err := fe.getCart(ctx, userID)
+	if err != nil && unavailable(err) {
+		return nil, nil
+	}
+	return cart, err
+}
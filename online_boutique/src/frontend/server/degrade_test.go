@@ -0,0 +1,257 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"dubbo.apache.org/dubbo-go/v3/client"
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	sentinel "github.com/alibaba/sentinel-golang/api"
+	"github.com/alibaba/sentinel-golang/core/circuitbreaker"
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+// flakyShop is a fakeShop whose optional backends can be taken down.
+type flakyShop struct {
+	*fakeShop
+
+	mu   sync.Mutex
+	down map[string]bool
+}
+
+func newFlakyShop() *flakyShop {
+	return &flakyShop{fakeShop: newFakeShop(), down: make(map[string]bool)}
+}
+
+func (f *flakyShop) setDown(dep string, down bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.down[dep] = down
+}
+
+func (f *flakyShop) fail(dep string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.down[dep] {
+		return triple.NewError(triple.CodeUnavailable, errors.New(dep+" service unavailable"))
+	}
+	return nil
+}
+
+func (f *flakyShop) GetSupportedCurrencies(ctx context.Context, in *pb.Empty, opts ...client.CallOption) (*pb.GetSupportedCurrenciesResponse, error) {
+	if err := f.fail(depCurrency); err != nil {
+		return nil, err
+	}
+	return f.fakeShop.GetSupportedCurrencies(ctx, in, opts...)
+}
+
+func (f *flakyShop) Convert(ctx context.Context, in *pb.CurrencyConversionRequest, opts ...client.CallOption) (*pb.Money, error) {
+	if err := f.fail(depCurrency); err != nil {
+		return nil, err
+	}
+	return f.fakeShop.Convert(ctx, in, opts...)
+}
+
+func (f *flakyShop) AddItem(ctx context.Context, in *pb.AddItemRequest, opts ...client.CallOption) (*pb.Empty, error) {
+	if err := f.fail(depCart); err != nil {
+		return nil, err
+	}
+	return f.fakeShop.AddItem(ctx, in, opts...)
+}
+
+func (f *flakyShop) GetCart(ctx context.Context, in *pb.GetCartRequest, opts ...client.CallOption) (*pb.Cart, error) {
+	if err := f.fail(depCart); err != nil {
+		return nil, err
+	}
+	return f.fakeShop.GetCart(ctx, in, opts...)
+}
+
+func (f *flakyShop) EmptyCart(ctx context.Context, in *pb.EmptyCartRequest, opts ...client.CallOption) (*pb.Empty, error) {
+	if err := f.fail(depCart); err != nil {
+		return nil, err
+	}
+	return f.fakeShop.EmptyCart(ctx, in, opts...)
+}
+
+func (f *flakyShop) ListRecommendations(ctx context.Context, in *pb.ListRecommendationsRequest, opts ...client.CallOption) (*pb.ListRecommendationsResponse, error) {
+	if err := f.fail(depRecommendation); err != nil {
+		return nil, err
+	}
+	return &pb.ListRecommendationsResponse{ProductIds: []string{"66VCHSJNUP"}}, nil
+}
+
+func (f *flakyShop) GetAds(ctx context.Context, in *pb.AdRequest, opts ...client.CallOption) (*pb.AdResponse, error) {
+	if err := f.fail(depAd); err != nil {
+		return nil, err
+	}
+	return f.fakeShop.GetAds(ctx, in, opts...)
+}
+
+func newFlakyFrontend(t *testing.T, shop *flakyShop) http.Handler {
+	t.Helper()
+	log := logrus.New()
+	log.Out = io.Discard
+	h, err := NewHandler(Services{
+		Ad:             shop,
+		Cart:           shop,
+		Checkout:       shop,
+		Currency:       shop,
+		ProductCatalog: shop,
+		Recommendation: shop,
+		Shipping:       shop,
+	}, "..", log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func getPage(h http.Handler, path, currency string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.AddCookie(&http.Cookie{Name: cookieSessionID, Value: shopper})
+	if currency != "" {
+		req.AddCookie(&http.Cookie{Name: cookieCurrency, Value: currency})
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPagesRenderWithDependencyDown(t *testing.T) {
+	for _, dep := range []string{depCurrency, depCart, depRecommendation, depAd} {
+		t.Run(dep, func(t *testing.T) {
+			shop := newFlakyShop()
+			shop.carts[shopper] = []*pb.CartItem{{ProductId: "OLJCESPC7Z", Quantity: 1}}
+			shop.setDown(dep, true)
+			h := newFlakyFrontend(t, shop)
+
+			// The product page calls all of them, the others show the outage
+			// of what they do not call from before.
+			for _, path := range []string{"/product/OLJCESPC7Z", "/", "/cart"} {
+				rec := getPage(h, path, "EUR")
+				if rec.Code != http.StatusOK {
+					t.Errorf("%s: status %d\n%s", path, rec.Code, rec.Body)
+					continue
+				}
+				if !strings.Contains(rec.Body.String(), dependencies[dep].Name) {
+					t.Errorf("%s: the banner does not list the %s", path, dependencies[dep].Name)
+				}
+			}
+
+			shop.setDown(dep, false)
+			if rec := getPage(h, "/product/OLJCESPC7Z", ""); strings.Contains(rec.Body.String(), "degraded-banner") {
+				t.Errorf("the banner is still shown after the %s service is back", dep)
+			}
+		})
+	}
+}
+
+func TestReadOnlyWithoutCart(t *testing.T) {
+	shop := newFlakyShop()
+	shop.setDown(depCart, true)
+	h := newFlakyFrontend(t, shop)
+
+	if rec := getPage(h, "/product/OLJCESPC7Z", ""); strings.Contains(rec.Body.String(), "Add To Cart") {
+		t.Error("the product page offers to add to the cart")
+	}
+	if rec := getPage(h, "/cart", ""); !strings.Contains(rec.Body.String(), "Your shopping cart is unavailable") {
+		t.Error("the cart page does not say the cart is unavailable")
+	}
+}
+
+func TestLastKnownCurrencies(t *testing.T) {
+	shop := newFlakyShop()
+	h := newFlakyFrontend(t, shop)
+
+	// The fake currency service doubles the prices.
+	if rec := getPage(h, "/product/OLJCESPC7Z", "EUR"); !strings.Contains(rec.Body.String(), "€39.98") {
+		t.Fatalf("product page in EUR: status %d, no €39.98", rec.Code)
+	}
+
+	shop.setDown(depCurrency, true)
+	rec := getPage(h, "/product/OLJCESPC7Z", "EUR")
+	if !strings.Contains(rec.Body.String(), "€39.98") {
+		t.Errorf("the product page is not priced at the last known rate")
+	}
+	if !strings.Contains(rec.Body.String(), `<option value="EUR" selected="selected">`) {
+		t.Errorf("the last known currencies are not offered")
+	}
+	// There is no known rate to GBP, the prices stay in USD.
+	rec = getPage(h, "/product/OLJCESPC7Z", "GBP")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "$19.99") {
+		t.Errorf("product page in GBP: status %d, no $19.99", rec.Code)
+	}
+}
+
+func TestConvertAtKnownRate(t *testing.T) {
+	h := newHealth()
+	h.rememberRate(&pb.Money{CurrencyCode: "USD", Units: 2}, &pb.Money{CurrencyCode: "JPY", Units: 301, Nanos: 500000000})
+
+	tests := []struct {
+		in   *pb.Money
+		want *pb.Money
+	}{
+		{&pb.Money{CurrencyCode: "USD", Units: 19, Nanos: 990000000}, &pb.Money{CurrencyCode: "JPY", Units: 3013, Nanos: 492500000}},
+		{&pb.Money{CurrencyCode: "USD", Units: -1, Nanos: -500000000}, &pb.Money{CurrencyCode: "JPY", Units: -226, Nanos: -125000000}},
+		{&pb.Money{CurrencyCode: "JPY", Units: 5}, &pb.Money{CurrencyCode: "JPY", Units: 5}},
+	}
+	for _, tt := range tests {
+		got, ok := h.convert(tt.in, "JPY")
+		if !ok || got.String() != tt.want.String() {
+			t.Errorf("convert(%v) = %v, %v; want %v", tt.in, got, ok, tt.want)
+		}
+	}
+	if _, ok := h.convert(&pb.Money{CurrencyCode: "USD", Units: 1}, "EUR"); ok {
+		t.Error("converted to EUR without a known rate")
+	}
+}
+
+func TestOpenBreakerIsDegraded(t *testing.T) {
+	if err := LoadCircuitBreakers(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		circuitbreaker.ClearRules()
+		setBreakerOpen(breakerResource(pb.AdServiceName), false)
+	})
+
+	h := newFlakyFrontend(t, newFlakyShop())
+	for i := 0; i < 20; i++ {
+		e, blocked := sentinel.Entry(breakerResource(pb.AdServiceName))
+		if blocked != nil {
+			break
+		}
+		sentinel.TraceError(e, errors.New("ad service unavailable"))
+		e.Exit()
+	}
+	// The ads come back, but the breaker is still open.
+	rec := getPage(h, "/", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), dependencies[depAd].Name) {
+		t.Errorf("home page: status %d, the banner does not list the open breaker", rec.Code)
+	}
+}
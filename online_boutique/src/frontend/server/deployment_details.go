@@ -15,18 +15,22 @@
  * limitations under the License.
  */
 
-package main
+package server
 
 import (
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/compute/metadata"
 	"github.com/sirupsen/logrus"
 )
 
-var deploymentDetailsMap map[string]string
+var (
+	deploymentDetailsMu  sync.RWMutex
+	deploymentDetailsMap map[string]string
+)
 var log *logrus.Logger
 
 func init() {
@@ -50,8 +54,16 @@ func initializeLogger() {
 	log.Out = os.Stdout
 }
 
+// deploymentDetails returns the details loaded so far, nil until they are.
+// The map is never changed once returned, the templates can range over it.
+func deploymentDetails() map[string]string {
+	deploymentDetailsMu.RLock()
+	defer deploymentDetailsMu.RUnlock()
+	return deploymentDetailsMap
+}
+
 func loadDeploymentDetails() {
-	deploymentDetailsMap = make(map[string]string)
+	details := make(map[string]string)
 	var metaServerClient = metadata.NewClient(&http.Client{})
 
 	podHostname, err := os.Hostname()
@@ -69,9 +81,12 @@ func loadDeploymentDetails() {
 		log.Error("Failed to fetch the Zone of the node where the pod is scheduled", err)
 	}
 
-	deploymentDetailsMap["HOSTNAME"] = podHostname
-	deploymentDetailsMap["CLUSTERNAME"] = podCluster
-	deploymentDetailsMap["ZONE"] = podZone
+	details["HOSTNAME"] = podHostname
+	details["CLUSTERNAME"] = podCluster
+	details["ZONE"] = podZone
+	deploymentDetailsMu.Lock()
+	deploymentDetailsMap = details
+	deploymentDetailsMu.Unlock()
 
 	log.WithFields(logrus.Fields{
 		"cluster":  podCluster,
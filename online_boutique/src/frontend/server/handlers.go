@@ -15,7 +15,7 @@
  * limitations under the License.
  */
 
-package main
+package server
 
 import (
 	"context"
@@ -29,12 +29,15 @@ import (
 	"strings"
 	"time"
 
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/apache/dubbo-go-samples/online_boutique_demo/frontend/money"
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/frontend/proto"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
 )
 
 type platformDetails struct {
@@ -44,12 +47,8 @@ type platformDetails struct {
 
 var (
 	isCymbalBrand = strings.ToLower(os.Getenv("CYMBAL_BRANDING")) == "true"
-	templates     = template.Must(template.New("").
-			Funcs(template.FuncMap{
-			"renderMoney":        renderMoney,
-			"renderCurrencyLogo": renderCurrencyLogo,
-		}).ParseGlob("templates/*.html"))
-	plat platformDetails
+	templates     *template.Template
+	plat          platformDetails
 )
 
 var validEnvs = []string{"local", "gcp", "azure", "aws", "onprem", "alibaba"}
@@ -57,6 +56,7 @@ var validEnvs = []string{"local", "gcp", "azure", "aws", "onprem", "alibaba"}
 func (fe *frontendServer) homeHandler(w http.ResponseWriter, r *http.Request) {
 	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
 	log.WithField("currency", currentCurrency(r)).Info("home")
+	currency := fe.displayCurrency(r.Context(), currentCurrency(r))
 	currencies, err := fe.getCurrencies(r.Context())
 	if err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve currencies"), http.StatusInternalServerError)
@@ -67,7 +67,7 @@ func (fe *frontendServer) homeHandler(w http.ResponseWriter, r *http.Request) {
 		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve products"), http.StatusInternalServerError)
 		return
 	}
-	cart, err := fe.getCart(r.Context(), sessionID(r))
+	cart, err := fe.cartOrNone(r.Context(), sessionID(r))
 	if err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
 		return
@@ -79,7 +79,7 @@ func (fe *frontendServer) homeHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	ps := make([]productView, len(products))
 	for i, p := range products {
-		price, err := fe.convertCurrency(r.Context(), p.GetPriceUsd(), currentCurrency(r))
+		price, err := fe.convertCurrency(r.Context(), p.GetPriceUsd(), currency)
 		if err != nil {
 			renderHTTPError(log, r, w, errors.Wrapf(err, "failed to do currency conversion for product %s", p.GetId()), http.StatusInternalServerError)
 			return
@@ -105,20 +105,25 @@ func (fe *frontendServer) homeHandler(w http.ResponseWriter, r *http.Request) {
 	plat = platformDetails{}
 	plat.setPlatformDetails(strings.ToLower(env))
 
+	ad := fe.chooseAd(r.Context(), cartCategories(cart, products), log)
 	if err := templates.ExecuteTemplate(w, "home", map[string]interface{}{
 		"session_id":        sessionID(r),
 		"request_id":        r.Context().Value(ctxKeyRequestID{}),
-		"user_currency":     currentCurrency(r),
+		"user_currency":     currency,
 		"show_currency":     true,
 		"currencies":        currencies,
 		"products":          ps,
 		"cart_size":         cartSize(cart),
 		"banner_color":      os.Getenv("BANNER_COLOR"), // illustrates canary deployments
-		"ad":                fe.chooseAd(r.Context(), []string{}, log),
+		"ad":                ad,
+		"read_only":         fe.health.isDown(depCart),
+		"degraded":          fe.health.degraded(),
 		"platform_css":      plat.css,
 		"platform_name":     plat.provider,
 		"is_cymbal_brand":   isCymbalBrand,
-		"deploymentDetails": deploymentDetailsMap,
+		"user":              currentUser(r),
+		"accounts":          fe.userService != nil,
+		"deploymentDetails": deploymentDetails(),
 	}); err != nil {
 		log.Error(err)
 	}
@@ -162,49 +167,62 @@ func (fe *frontendServer) productHandler(w http.ResponseWriter, r *http.Request)
 		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve product"), http.StatusInternalServerError)
 		return
 	}
+	currency := fe.displayCurrency(r.Context(), currentCurrency(r))
 	currencies, err := fe.getCurrencies(r.Context())
 	if err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve currencies"), http.StatusInternalServerError)
 		return
 	}
 
-	cart, err := fe.getCart(r.Context(), sessionID(r))
+	cart, err := fe.cartOrNone(r.Context(), sessionID(r))
 	if err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
 		return
 	}
 
-	price, err := fe.convertCurrency(r.Context(), p.GetPriceUsd(), currentCurrency(r))
+	price, err := fe.convertCurrency(r.Context(), p.GetPriceUsd(), currency)
 	if err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "failed to convert currency"), http.StatusInternalServerError)
 		return
 	}
 
+	// Recommendations are not critical, the page goes without them.
 	recommendations, err := fe.getRecommendations(r.Context(), sessionID(r), []string{id})
 	if err != nil {
-		renderHTTPError(log, r, w, errors.Wrap(err, "failed to get product recommendations"), http.StatusInternalServerError)
+		log.WithField("error", err).Warn("failed to get product recommendations")
+	}
+
+	stock, err := fe.getStock(r.Context(), id)
+	if err != nil {
+		renderHTTPError(log, r, w, err, http.StatusInternalServerError)
 		return
 	}
 
 	product := struct {
 		Item  *pb.Product
 		Price *pb.Money
-	}{p, price}
+		Stock int32
+	}{p, price, stock}
 
+	ad := fe.chooseAd(r.Context(), productAdKeys(p), log)
 	if err := templates.ExecuteTemplate(w, "product", map[string]interface{}{
 		"session_id":        sessionID(r),
 		"request_id":        r.Context().Value(ctxKeyRequestID{}),
-		"ad":                fe.chooseAd(r.Context(), p.Categories, log),
-		"user_currency":     currentCurrency(r),
+		"ad":                ad,
+		"user_currency":     currency,
 		"show_currency":     true,
 		"currencies":        currencies,
 		"product":           product,
 		"recommendations":   recommendations,
 		"cart_size":         cartSize(cart),
+		"read_only":         fe.health.isDown(depCart),
+		"degraded":          fe.health.degraded(),
 		"platform_css":      plat.css,
 		"platform_name":     plat.provider,
 		"is_cymbal_brand":   isCymbalBrand,
-		"deploymentDetails": deploymentDetailsMap,
+		"user":              currentUser(r),
+		"accounts":          fe.userService != nil,
+		"deploymentDetails": deploymentDetails(),
 	}); err != nil {
 		log.Println(err)
 	}
@@ -225,6 +243,10 @@ func (fe *frontendServer) addToCartHandler(w http.ResponseWriter, r *http.Reques
 		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve product"), http.StatusInternalServerError)
 		return
 	}
+	if err := fe.checkStock(r.Context(), sessionID(r), p.GetId(), int32(quantity)); err != nil {
+		renderHTTPError(log, r, w, err, httpStatus(triple.CodeOf(err)))
+		return
+	}
 
 	if err := fe.insertCart(r.Context(), sessionID(r), p.GetId(), int32(quantity)); err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "failed to add to cart"), http.StatusInternalServerError)
@@ -249,12 +271,13 @@ func (fe *frontendServer) emptyCartHandler(w http.ResponseWriter, r *http.Reques
 func (fe *frontendServer) viewCartHandler(w http.ResponseWriter, r *http.Request) {
 	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
 	log.Debug("view user cart")
+	currency := fe.displayCurrency(r.Context(), currentCurrency(r))
 	currencies, err := fe.getCurrencies(r.Context())
 	if err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve currencies"), http.StatusInternalServerError)
 		return
 	}
-	cart, err := fe.getCart(r.Context(), sessionID(r))
+	cart, err := fe.cartOrNone(r.Context(), sessionID(r))
 	if err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
 		return
@@ -262,11 +285,10 @@ func (fe *frontendServer) viewCartHandler(w http.ResponseWriter, r *http.Request
 
 	recommendations, err := fe.getRecommendations(r.Context(), sessionID(r), cartIDs(cart))
 	if err != nil {
-		renderHTTPError(log, r, w, errors.Wrap(err, "failed to get product recommendations"), http.StatusInternalServerError)
-		return
+		log.WithField("error", err).Warn("failed to get product recommendations")
 	}
 
-	shippingCost, err := fe.getShippingQuote(r.Context(), cart, currentCurrency(r))
+	shippingCost, err := fe.getShippingQuote(r.Context(), nil, cart, currency)
 	if err != nil {
 		renderHTTPError(log, r, w, errors.Wrap(err, "failed to get shipping quote"), http.StatusInternalServerError)
 		return
@@ -278,14 +300,15 @@ func (fe *frontendServer) viewCartHandler(w http.ResponseWriter, r *http.Request
 		Price    *pb.Money
 	}
 	items := make([]cartItemView, len(cart))
-	totalPrice := &pb.Money{CurrencyCode: currentCurrency(r)}
+	lines := make([]*pb.PromotionLine, len(cart))
+	totalPrice := &pb.Money{CurrencyCode: currency}
 	for i, item := range cart {
 		p, err := fe.getProduct(r.Context(), item.GetProductId())
 		if err != nil {
 			renderHTTPError(log, r, w, errors.Wrapf(err, "could not retrieve product #%s", item.GetProductId()), http.StatusInternalServerError)
 			return
 		}
-		price, err := fe.convertCurrency(r.Context(), p.GetPriceUsd(), currentCurrency(r))
+		price, err := fe.convertCurrency(r.Context(), p.GetPriceUsd(), currency)
 		if err != nil {
 			renderHTTPError(log, r, w, errors.Wrapf(err, "could not convert currency for product #%s", item.GetProductId()), http.StatusInternalServerError)
 			return
@@ -297,15 +320,33 @@ func (fe *frontendServer) viewCartHandler(w http.ResponseWriter, r *http.Request
 			Quantity: item.GetQuantity(),
 			Price:    multPrice,
 		}
+		lines[i] = promotionLine(p, item.GetQuantity())
 		totalPrice = money.Must(money.Sum(totalPrice, multPrice))
 	}
+
+	// A coupon that does not apply is dropped, with a word to the shopper.
+	coupon, couponError := currentCoupon(r), ""
+	discounts, err := fe.getDiscounts(r.Context(), lines, coupon, currency)
+	if err != nil && coupon != "" {
+		couponError = couponMessage(coupon, err)
+		coupon = ""
+		discounts, err = fe.getDiscounts(r.Context(), lines, "", currency)
+	}
+	if err != nil {
+		renderHTTPError(log, r, w, err, http.StatusInternalServerError)
+		return
+	}
+	for _, d := range discounts {
+		totalPrice = money.Must(money.Sum(totalPrice, money.Negate(d.GetAmount())))
+	}
 	totalPrice = money.Must(money.Sum(totalPrice, shippingCost))
 	year := time.Now().Year()
+	email, address := checkoutDefaults(currentUser(r), r.URL.Query().Get("address"))
 
 	if err := templates.ExecuteTemplate(w, "cart", map[string]interface{}{
 		"session_id":        sessionID(r),
 		"request_id":        r.Context().Value(ctxKeyRequestID{}),
-		"user_currency":     currentCurrency(r),
+		"user_currency":     currency,
 		"currencies":        currencies,
 		"recommendations":   recommendations,
 		"cart_size":         cartSize(cart),
@@ -313,11 +354,21 @@ func (fe *frontendServer) viewCartHandler(w http.ResponseWriter, r *http.Request
 		"show_currency":     true,
 		"total_cost":        totalPrice,
 		"items":             items,
+		"discounts":         discounts,
+		"coupon":            coupon,
+		"coupon_error":      couponError,
+		"promotions":        fe.promotionService != nil,
+		"read_only":         fe.health.isDown(depCart),
+		"degraded":          fe.health.degraded(),
 		"expiration_years":  []int{year, year + 1, year + 2, year + 3, year + 4},
+		"checkout_email":    email,
+		"checkout_address":  address,
 		"platform_css":      plat.css,
 		"platform_name":     plat.provider,
 		"is_cymbal_brand":   isCymbalBrand,
-		"deploymentDetails": deploymentDetailsMap,
+		"user":              currentUser(r),
+		"accounts":          fe.userService != nil,
+		"deploymentDetails": deploymentDetails(),
 	}); err != nil {
 		log.Println(err)
 	}
@@ -338,17 +389,29 @@ func (fe *frontendServer) placeOrderHandler(w http.ResponseWriter, r *http.Reque
 		ccMonth, _    = strconv.ParseInt(r.FormValue("credit_card_expiration_month"), 10, 32)
 		ccYear, _     = strconv.ParseInt(r.FormValue("credit_card_expiration_year"), 10, 32)
 		ccCVV, _      = strconv.ParseInt(r.FormValue("credit_card_cvv"), 10, 32)
+		cardToken     = r.FormValue("card_token")
+		coupon        = r.FormValue("coupon_code")
 	)
 
+	card := &pb.CreditCardInfo{
+		CreditCardNumber:          ccNumber,
+		CreditCardExpirationMonth: int32(ccMonth),
+		CreditCardExpirationYear:  int32(ccYear),
+		CreditCardCvv:             int32(ccCVV)}
+	if user := currentUser(r); user != nil && cardToken != "" {
+		var err error
+		if card, err = fe.savedCard(r.Context(), cardToken, int32(ccCVV)); err != nil {
+			renderHTTPError(log, r, w, err, http.StatusBadRequest)
+			return
+		}
+	}
+
 	order, err := fe.checkoutService.PlaceOrder(r.Context(), &pb.PlaceOrderRequest{
-		Email: email,
-		CreditCard: &pb.CreditCardInfo{
-			CreditCardNumber:          ccNumber,
-			CreditCardExpirationMonth: int32(ccMonth),
-			CreditCardExpirationYear:  int32(ccYear),
-			CreditCardCvv:             int32(ccCVV)},
+		Email:        email,
+		CreditCard:   card,
 		UserId:       sessionID(r),
 		UserCurrency: currentCurrency(r),
+		CouponCode:   coupon,
 		Address: &pb.Address{
 			StreetAddress: streetAddress,
 			City:          city,
@@ -357,10 +420,13 @@ func (fe *frontendServer) placeOrderHandler(w http.ResponseWriter, r *http.Reque
 			Country:       country},
 	})
 	if err != nil {
-		renderHTTPError(log, r, w, errors.Wrap(err, "failed to complete the order"), http.StatusInternalServerError)
+		renderHTTPError(log, r, w, errors.Wrap(err, "failed to complete the order"), httpStatus(triple.CodeOf(err)))
 		return
 	}
 	log.WithField("order", order.GetOrder().GetOrderId()).Info("order placed")
+	if coupon != "" {
+		http.SetCookie(w, &http.Cookie{Name: cookieCoupon, MaxAge: -1})
+	}
 
 	order.GetOrder().GetItems()
 	recommendations, _ := fe.getRecommendations(r.Context(), sessionID(r), nil)
@@ -370,6 +436,9 @@ func (fe *frontendServer) placeOrderHandler(w http.ResponseWriter, r *http.Reque
 		multPrice := money.MultiplySlow(v.GetCost(), uint32(v.GetItem().GetQuantity()))
 		totalPaid = money.Must(money.Sum(totalPaid, multPrice))
 	}
+	for _, d := range order.GetOrder().GetDiscounts() {
+		totalPaid = money.Must(money.Sum(totalPaid, money.Negate(d.GetAmount())))
+	}
 
 	currencies, err := fe.getCurrencies(r.Context())
 	if err != nil {
@@ -386,10 +455,13 @@ func (fe *frontendServer) placeOrderHandler(w http.ResponseWriter, r *http.Reque
 		"order":             order.GetOrder(),
 		"total_paid":        &totalPaid,
 		"recommendations":   recommendations,
+		"degraded":          fe.health.degraded(),
 		"platform_css":      plat.css,
 		"platform_name":     plat.provider,
 		"is_cymbal_brand":   isCymbalBrand,
-		"deploymentDetails": deploymentDetailsMap,
+		"user":              currentUser(r),
+		"accounts":          fe.userService != nil,
+		"deploymentDetails": deploymentDetails(),
 	}); err != nil {
 		log.Println(err)
 	}
@@ -398,6 +470,11 @@ func (fe *frontendServer) placeOrderHandler(w http.ResponseWriter, r *http.Reque
 func (fe *frontendServer) logoutHandler(w http.ResponseWriter, r *http.Request) {
 	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
 	log.Debug("logging out")
+	if c, err := r.Cookie(cookieAuth); err == nil && fe.userService != nil {
+		if _, err := fe.userService.Logout(r.Context(), &pb.LogoutRequest{SessionToken: c.Value}); err != nil {
+			log.WithField("error", err).Warn("failed to revoke session token")
+		}
+	}
 	for _, c := range r.Cookies() {
 		c.Expires = time.Now().Add(-time.Hour * 24 * 365)
 		c.MaxAge = -1
@@ -428,10 +505,48 @@ func (fe *frontendServer) setCurrencyHandler(w http.ResponseWriter, r *http.Requ
 	w.WriteHeader(http.StatusFound)
 }
 
+// setCouponHandler keeps the coupon code entered on the cart page for the
+// checkout, or forgets it when the code is empty.
+func (fe *frontendServer) setCouponHandler(w http.ResponseWriter, r *http.Request) {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+	code := strings.ToUpper(strings.TrimSpace(r.FormValue("coupon_code")))
+	log.WithField("coupon", code).Debug("setting coupon")
+
+	cookie := &http.Cookie{Name: cookieCoupon, Value: code, MaxAge: cookieMaxAge}
+	if code == "" {
+		cookie.MaxAge = -1
+	}
+	http.SetCookie(w, cookie)
+	w.Header().Set("Location", "/cart")
+	w.WriteHeader(http.StatusFound)
+}
+
+func currentCoupon(r *http.Request) string {
+	if c, _ := r.Cookie(cookieCoupon); c != nil {
+		return c.Value
+	}
+	return ""
+}
+
+// couponMessage tells the shopper why their coupon was not applied.
+func couponMessage(code string, err error) string {
+	switch triple.CodeOf(err) {
+	case triple.CodeNotFound:
+		return fmt.Sprintf("Coupon %s is not valid.", code)
+	case triple.CodeResourceExhausted:
+		return fmt.Sprintf("Coupon %s has been used up.", code)
+	case triple.CodeFailedPrecondition:
+		return fmt.Sprintf("Coupon %s does not apply to your cart.", code)
+	}
+	return fmt.Sprintf("Coupon %s could not be applied.", code)
+}
+
 // chooseAd queries for advertisements available and randomly chooses one, if
 // available. It ignores the error retrieving the ad since it is not critical.
+// The impression is reported in the background for the same reason.
 func (fe *frontendServer) chooseAd(ctx context.Context, ctxKeys []string, log logrus.FieldLogger) *pb.Ad {
-	ads, err := fe.getAd(ctx, ctxKeys)
+	session, _ := ctx.Value(ctxKeySessionID{}).(string)
+	ads, err := fe.getAd(ctx, session, ctxKeys)
 	if err != nil {
 		log.WithField("error", err).Warn("failed to retrieve ads")
 		return nil
@@ -439,7 +554,30 @@ func (fe *frontendServer) chooseAd(ctx context.Context, ctxKeys []string, log lo
 	if len(ads) == 0 {
 		return nil
 	}
-	return ads[rand.Intn(len(ads))]
+	ad := ads[rand.Intn(len(ads))]
+	// The report outlives the request, but still belongs to its trace.
+	reportCtx := trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(ctx))
+	go func() {
+		if err := fe.reportAdEvent(reportCtx, session, ad.GetAdId(), pb.AdEventType_IMPRESSION); err != nil {
+			log.WithField("error", err).Warn("failed to report ad impression")
+		}
+	}()
+	return ad
+}
+
+// adClickHandler records the click on an ad before sending the shopper on to
+// the ad's target, which must be a page of the shop.
+func (fe *frontendServer) adClickHandler(w http.ResponseWriter, r *http.Request) {
+	log := r.Context().Value(ctxKeyLog{}).(logrus.FieldLogger)
+	target := r.URL.Query().Get("to")
+	if !isLocalPath(target) {
+		renderHTTPError(log, r, w, errors.New("invalid ad target"), http.StatusBadRequest)
+		return
+	}
+	if err := fe.reportAdEvent(r.Context(), sessionID(r), mux.Vars(r)["id"], pb.AdEventType_CLICK); err != nil {
+		log.WithField("error", err).Warn("failed to report ad click")
+	}
+	http.Redirect(w, r, target, http.StatusFound)
 }
 
 func renderHTTPError(log logrus.FieldLogger, r *http.Request, w http.ResponseWriter, err error, code int) {
@@ -452,9 +590,10 @@ func renderHTTPError(log logrus.FieldLogger, r *http.Request, w http.ResponseWri
 		"session_id":        sessionID(r),
 		"request_id":        r.Context().Value(ctxKeyRequestID{}),
 		"error":             errMsg,
+		"user":              currentUser(r),
 		"status_code":       code,
 		"status":            http.StatusText(code),
-		"deploymentDetails": deploymentDetailsMap,
+		"deploymentDetails": deploymentDetails(),
 	}); templateErr != nil {
 		log.Println(templateErr)
 	}
@@ -468,6 +607,17 @@ func currentCurrency(r *http.Request) string {
 	return defaultCurrency
 }
 
+// isLocalPath tells whether target is a path of the shop, safe to redirect to.
+func isLocalPath(target string) bool {
+	return strings.HasPrefix(target, "/") && !strings.HasPrefix(target, "//") && !strings.HasPrefix(target, "/\\")
+}
+
+// currentUser returns the signed in user, or nil for anonymous shoppers.
+func currentUser(r *http.Request) *pb.User {
+	u, _ := r.Context().Value(ctxKeyUser{}).(*pb.User)
+	return u
+}
+
 func sessionID(r *http.Request) string {
 	v := r.Context().Value(ctxKeySessionID{})
 	if v != nil {
@@ -484,6 +634,27 @@ func cartIDs(c []*pb.CartItem) []string {
 	return out
 }
 
+// cartCategories returns the categories of the products in the cart.
+func cartCategories(c []*pb.CartItem, products []*pb.Product) []string {
+	inCart := make(map[string]bool, len(c))
+	for _, item := range c {
+		inCart[item.GetProductId()] = true
+	}
+	var out []string
+	for _, p := range products {
+		if inCart[p.GetId()] {
+			out = append(out, p.GetCategories()...)
+		}
+	}
+	return out
+}
+
+// productAdKeys describes a product page to the ad service: its categories
+// and the words of its name.
+func productAdKeys(p *pb.Product) []string {
+	return append(append([]string(nil), p.GetCategories()...), strings.Fields(strings.ToLower(p.GetName()))...)
+}
+
 // get total # of items in cart
 func cartSize(c []*pb.CartItem) int {
 	cartSize := 0
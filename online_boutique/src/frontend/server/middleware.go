@@ -0,0 +1,232 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+type ctxKeyLog struct{}
+type ctxKeyRequestID struct{}
+
+// baggageRequestID is the baggage member carrying the request ID to the
+// services along with the trace.
+const baggageRequestID = "request_id"
+
+const tracerName = "github.com/apache/dubbo-go-samples/online_boutique_demo/frontend/server"
+
+type logHandler struct {
+	log  *logrus.Logger
+	next http.Handler
+}
+
+type responseRecorder struct {
+	b      int
+	status int
+	w      http.ResponseWriter
+}
+
+func (r *responseRecorder) Header() http.Header { return r.w.Header() }
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.w.Write(p)
+	r.b += n
+	return n, err
+}
+
+func (r *responseRecorder) WriteHeader(statusCode int) {
+	r.status = statusCode
+	r.w.WriteHeader(statusCode)
+}
+
+func (lh *logHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID, _ := ctx.Value(ctxKeyRequestID{}).(string)
+
+	start := time.Now()
+	rr := &responseRecorder{w: w}
+	log := lh.log.WithFields(logrus.Fields{
+		"http.req.path":   r.URL.Path,
+		"http.req.method": r.Method,
+		"http.req.id":     requestID,
+	})
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		log = log.WithFields(logrus.Fields{
+			"trace_id": sc.TraceID().String(),
+			"span_id":  sc.SpanID().String(),
+		})
+	}
+	if v, ok := r.Context().Value(ctxKeySessionID{}).(string); ok {
+		log = log.WithField("session", v)
+	}
+	if u := currentUser(r); u != nil {
+		log = log.WithField("user", u.GetUserId())
+	}
+	log.Debug("request started")
+	defer func() {
+		log.WithFields(logrus.Fields{
+			"http.resp.took_ms": int64(time.Since(start) / time.Millisecond),
+			"http.resp.status":  rr.status,
+			"http.resp.bytes":   rr.b}).Debugf("request complete")
+	}()
+
+	ctx = context.WithValue(ctx, ctxKeyLog{}, log)
+	r = r.WithContext(ctx)
+	lh.next.ServeHTTP(rr, r)
+}
+
+// traceRequest starts the root span of a request, or continues the trace of a
+// caller that sent a W3C traceparent header. It also assigns the request ID,
+// which is returned in the X-Request-ID header and travels with the trace as
+// baggage so that the services can log it.
+func traceRequest(next http.Handler) http.HandlerFunc {
+	tracer := otel.Tracer(tracerName)
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, _ := uuid.NewRandom()
+		requestID := id.String()
+
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		if m, err := baggage.NewMember(baggageRequestID, requestID); err == nil {
+			if b, err := baggage.FromContext(ctx).SetMember(m); err == nil {
+				ctx = baggage.ContextWithBaggage(ctx, b)
+			}
+		}
+		ctx, span := tracer.Start(ctx, "HTTP "+r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest("frontend", "", r)...),
+			trace.WithAttributes(attribute.String("http.request_id", requestID)),
+		)
+		defer span.End()
+		ctx = context.WithValue(ctx, ctxKeyRequestID{}, requestID)
+
+		w.Header().Set("X-Request-ID", requestID)
+		rr := &responseRecorder{w: w}
+		next.ServeHTTP(rr, r.WithContext(ctx))
+
+		status := rr.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(status)...)
+		span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(status, trace.SpanKindServer))
+	}
+}
+
+// nameSpan names the root span after the route that matched, so that all the
+// product pages are one operation rather than one per product.
+func nameSpan(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if route := mux.CurrentRoute(r); route != nil {
+			if tmpl, err := route.GetPathTemplate(); err == nil {
+				span := trace.SpanFromContext(r.Context())
+				span.SetName(r.Method + " " + tmpl)
+				span.SetAttributes(semconv.HTTPRouteKey.String(tmpl))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// userSessionPrefix keeps the carts of signed in users apart from those of
+// anonymous sessions, whose IDs are UUIDs issued by ensureSessionID.
+const userSessionPrefix = "user:"
+
+func userSessionID(userID string) string {
+	return userSessionPrefix + userID
+}
+
+// validSessionID tells whether id is an anonymous session ID the frontend may
+// have issued. Anything else could name the cart of someone else.
+func validSessionID(id string) bool {
+	u, err := uuid.Parse(id)
+	return err == nil && u.String() == id
+}
+
+func ensureSessionID(next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var sessionID string
+		if c, err := r.Cookie(cookieSessionID); err == nil && validSessionID(c.Value) {
+			sessionID = c.Value
+		} else {
+			u, _ := uuid.NewRandom()
+			sessionID = u.String()
+			http.SetCookie(w, &http.Cookie{
+				Name:   cookieSessionID,
+				Value:  sessionID,
+				MaxAge: cookieMaxAge,
+				Path:   "/",
+			})
+		}
+		ctx := context.WithValue(r.Context(), ctxKeySessionID{}, sessionID)
+		r = r.WithContext(ctx)
+		next.ServeHTTP(w, r)
+	}
+}
+
+// authenticate signs the shopper in when the request carries a session token
+// of the user service. The cart, and everything else keyed by the session, then
+// belongs to the user rather than to the browser. A token the user service
+// rejects is dropped; while it cannot be reached the shopper stays anonymous.
+func (fe *frontendServer) authenticate(next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c, err := r.Cookie(cookieAuth)
+		if fe.userService == nil || err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		user, err := fe.verifyToken(r.Context(), c.Value)
+		switch {
+		case err == nil:
+			r = r.WithContext(withUser(r.Context(), user, c.Value))
+		case triple.CodeOf(err) == triple.CodeUnauthenticated:
+			http.SetCookie(w, &http.Cookie{Name: cookieAuth, Path: "/", MaxAge: -1})
+		}
+		next.ServeHTTP(w, r)
+	}
+}
+
+// withUser signs the user of the session token in for the request.
+func withUser(ctx context.Context, user *pb.User, token string) context.Context {
+	ctx = context.WithValue(ctx, ctxKeyUser{}, user)
+	ctx = context.WithValue(ctx, ctxKeySessionToken{}, token)
+	return context.WithValue(ctx, ctxKeySessionID{}, userSessionID(user.GetUserId()))
+}
+
+// sessionToken returns the session token the user was signed in with.
+func sessionToken(ctx context.Context) string {
+	token, _ := ctx.Value(ctxKeySessionToken{}).(string)
+	return token
+}
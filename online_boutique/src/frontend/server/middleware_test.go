@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordSpans installs a tracer provider keeping the spans ended during the
+// test in memory, as dubbo-go does with the exporter it is configured with.
+func recordSpans(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	t.Cleanup(func() { tp.Shutdown(context.Background()) })
+	return exp
+}
+
+func TestTraceRequest(t *testing.T) {
+	spans := recordSpans(t)
+	shop := newFakeShop()
+	h := newTestAPI(t, shop)
+
+	const parent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products/OLJCESPC7Z", nil)
+	req.Header.Set("traceparent", parent)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	requestID := rec.Header().Get("X-Request-ID")
+	if requestID == "" {
+		t.Fatal("no X-Request-ID in the response")
+	}
+
+	ended := spans.GetSpans()
+	if len(ended) != 1 {
+		t.Fatalf("got %d spans, want the root span only", len(ended))
+	}
+	root := ended[0]
+	if root.Name != "GET /api/v1/products/{id}" {
+		t.Errorf("span name = %q", root.Name)
+	}
+	if root.SpanKind != trace.SpanKindServer {
+		t.Errorf("span kind = %v", root.SpanKind)
+	}
+	if got := root.SpanContext.TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("trace ID = %s, want the caller's", got)
+	}
+	if got := root.Parent.SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("parent span = %s, want the caller's", got)
+	}
+	attrs := make(map[string]string)
+	for _, kv := range root.Attributes {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	for k, want := range map[string]string{
+		"http.request_id":  requestID,
+		"http.route":       "/api/v1/products/{id}",
+		"http.status_code": "200",
+	} {
+		if attrs[k] != want {
+			t.Errorf("attribute %s = %q, want %q", k, attrs[k], want)
+		}
+	}
+
+	// The backends are called within the root span, with the request ID.
+	ctx := shop.lastCtx
+	if sc := trace.SpanContextFromContext(ctx); sc.SpanID() != root.SpanContext.SpanID() {
+		t.Errorf("backend called in span %s, want %s", sc.SpanID(), root.SpanContext.SpanID())
+	}
+	if got := baggage.FromContext(ctx).Member(baggageRequestID).Value(); got != requestID {
+		t.Errorf("request ID baggage = %q, want %q", got, requestID)
+	}
+}
+
+func TestTraceRequestNotFound(t *testing.T) {
+	spans := recordSpans(t)
+	h := newTestAPI(t, newFakeShop())
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/products/NOPE", nil))
+	ended := spans.GetSpans()
+	if len(ended) != 1 {
+		t.Fatalf("got %d spans", len(ended))
+	}
+	if ended[0].Parent.IsValid() {
+		t.Error("a request without traceparent should start a new trace")
+	}
+	if ended[0].Status.Code.String() != "Unset" {
+		t.Errorf("a 404 left the server span with status %v", ended[0].Status.Code)
+	}
+}
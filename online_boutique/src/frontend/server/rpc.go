@@ -0,0 +1,280 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+
+	"github.com/pkg/errors"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+const (
+	avoidNoopCurrencyConversionRPC = false
+)
+
+// getCurrencies returns the supported currencies, or the last known ones
+// while the currency service is down, USD alone if there are none.
+func (fe *frontendServer) getCurrencies(ctx context.Context) ([]string, error) {
+	currs, err := fe.currencyService.GetSupportedCurrencies(ctx, &pb.Empty{})
+	if fe.health.report(depCurrency, err) {
+		if last := fe.health.lastCurrencies(); len(last) > 0 {
+			return last, nil
+		}
+		return []string{defaultCurrency}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, c := range currs.CurrencyCodes {
+		if _, ok := whitelistedCurrencies[c]; ok {
+			out = append(out, c)
+		}
+	}
+	fe.health.rememberCurrencies(out)
+	return out, nil
+}
+
+func (fe *frontendServer) getProducts(ctx context.Context) ([]*pb.Product, error) {
+	resp, err := fe.productCatalogService.ListProducts(ctx, &pb.Empty{})
+	return resp.GetProducts(), err
+}
+
+func (fe *frontendServer) getProduct(ctx context.Context, id string) (*pb.Product, error) {
+	resp, err := fe.productCatalogService.GetProduct(ctx, &pb.GetProductRequest{Id: id})
+	return resp, err
+}
+
+func (fe *frontendServer) searchProducts(ctx context.Context, req *pb.SearchProductsRequest) (*pb.SearchProductsResponse, error) {
+	return fe.productCatalogService.SearchProducts(ctx, req)
+}
+
+func (fe *frontendServer) getCart(ctx context.Context, userID string) ([]*pb.CartItem, error) {
+	resp, err := fe.cartService.GetCart(ctx, &pb.GetCartRequest{UserId: userID})
+	fe.health.report(depCart, err)
+	return resp.GetItems(), err
+}
+
+func (fe *frontendServer) emptyCart(ctx context.Context, userID string) error {
+	_, err := fe.cartService.EmptyCart(ctx, &pb.EmptyCartRequest{UserId: userID})
+	return err
+}
+
+func (fe *frontendServer) insertCart(ctx context.Context, userID, productID string, quantity int32) error {
+	_, err := fe.cartService.AddItem(ctx, &pb.AddItemRequest{
+		UserId: userID,
+		Item: &pb.CartItem{
+			ProductId: productID,
+			Quantity:  quantity,
+		},
+	})
+	return err
+}
+
+// convertCurrency converts money to the currency, at the last known rate
+// while the currency service is down.
+func (fe *frontendServer) convertCurrency(ctx context.Context, money *pb.Money, currency string) (*pb.Money, error) {
+	if avoidNoopCurrencyConversionRPC && money.GetCurrencyCode() == currency {
+		return money, nil
+	}
+	out, err := fe.currencyService.Convert(ctx, &pb.CurrencyConversionRequest{
+		From:   money,
+		ToCode: currency,
+	})
+	if fe.health.report(depCurrency, err) {
+		if out, ok := fe.health.convert(money, currency); ok {
+			return out, nil
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	fe.health.rememberRate(money, out)
+	return out, nil
+}
+
+func (fe *frontendServer) getShippingQuote(ctx context.Context, address *pb.Address, items []*pb.CartItem, currency string) (*pb.Money, error) {
+	quote, err := fe.shippingService.GetQuote(ctx, &pb.GetQuoteRequest{
+		Address: address,
+		Items:   items,
+	})
+	if err != nil {
+		return nil, err
+	}
+	localized, err := fe.convertCurrency(ctx, quote.GetCostUsd(), currency)
+	return localized, errors.Wrap(err, "failed to convert currency for shipping cost")
+}
+
+func (fe *frontendServer) getRecommendations(ctx context.Context, userID string, productIDs []string) ([]*pb.Product, error) {
+	resp, err := fe.recommendationService.ListRecommendations(ctx, &pb.ListRecommendationsRequest{
+		UserId:     userID,
+		ProductIds: productIDs,
+	})
+	if fe.health.report(depRecommendation, err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*pb.Product, len(resp.GetProductIds()))
+	for i, v := range resp.GetProductIds() {
+		p, err := fe.getProduct(ctx, v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get recommended product info (#%s)", v)
+		}
+		out[i] = p
+	}
+	if len(out) > 4 {
+		out = out[:4] // take only first four to fit the UI
+	}
+	return out, err
+}
+
+func (fe *frontendServer) getAd(ctx context.Context, sessionID string, ctxKeys []string) ([]*pb.Ad, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Millisecond*100)
+	defer cancel()
+
+	resp, err := fe.adService.GetAds(ctx, &pb.AdRequest{
+		ContextKeys: ctxKeys,
+		SessionId:   sessionID,
+	})
+	fe.health.report(depAd, err)
+	return resp.GetAds(), errors.Wrap(err, "failed to get ads")
+}
+
+func (fe *frontendServer) reportAdEvent(ctx context.Context, sessionID, adID string, typ pb.AdEventType) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Millisecond*100)
+	defer cancel()
+
+	_, err := fe.adService.ReportAdEvent(ctx, &pb.AdEventRequest{
+		AdId:      adID,
+		SessionId: sessionID,
+		Type:      typ,
+	})
+	return errors.Wrapf(err, "failed to report %s of ad %s", typ, adID)
+}
+
+func (fe *frontendServer) verifyToken(ctx context.Context, token string) (*pb.User, error) {
+	return fe.userService.VerifyToken(ctx, &pb.VerifyTokenRequest{SessionToken: token})
+}
+
+// mergeCart moves the items of the cart from into the cart to, which is how an
+// anonymous cart follows the shopper when they sign in.
+func (fe *frontendServer) mergeCart(ctx context.Context, from, to string) error {
+	items, err := fe.getCart(ctx, from)
+	if err != nil || len(items) == 0 {
+		return err
+	}
+	for _, item := range items {
+		if err := fe.insertCart(ctx, to, item.GetProductId(), item.GetQuantity()); err != nil {
+			return errors.Wrapf(err, "failed to move product %s", item.GetProductId())
+		}
+	}
+	return fe.emptyCart(ctx, from)
+}
+
+// savedCard looks up a card saved by the signed in user; the CVV is never
+// saved, so the shopper enters it again for every order.
+func (fe *frontendServer) savedCard(ctx context.Context, token string, cvv int32) (*pb.CreditCardInfo, error) {
+	card, err := fe.userService.GetCard(ctx, &pb.GetCardRequest{SessionToken: sessionToken(ctx), CardToken: token})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get saved card")
+	}
+	card.CreditCardCvv = cvv
+	return card, nil
+}
+
+// getStock returns how many units of the product are left, or -1 when the
+// shop does not track stock.
+func (fe *frontendServer) getStock(ctx context.Context, productID string) (int32, error) {
+	if fe.inventoryService == nil {
+		return -1, nil
+	}
+	resp, err := fe.inventoryService.GetStock(ctx, &pb.GetStockRequest{ProductIds: []string{productID}})
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get stock of product %s", productID)
+	}
+	for _, s := range resp.GetStock() {
+		if s.GetProductId() == productID {
+			return s.GetAvailable(), nil
+		}
+	}
+	return 0, nil
+}
+
+// checkStock fails with FailedPrecondition when there are not enough units of
+// the product left for quantity more in the cart of the user.
+func (fe *frontendServer) checkStock(ctx context.Context, userID, productID string, quantity int32) error {
+	available, err := fe.getStock(ctx, productID)
+	if err != nil || available < 0 {
+		return err
+	}
+	cart, err := fe.getCart(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve cart")
+	}
+	for _, item := range cart {
+		if item.GetProductId() == productID {
+			quantity += item.GetQuantity()
+		}
+	}
+	if quantity > available {
+		if available == 0 {
+			return triple.NewError(triple.CodeFailedPrecondition, fmt.Errorf("product %s is out of stock", productID))
+		}
+		return triple.NewError(triple.CodeFailedPrecondition, fmt.Errorf("only %d of product %s left", available, productID))
+	}
+	return nil
+}
+
+func promotionLine(p *pb.Product, quantity int32) *pb.PromotionLine {
+	return &pb.PromotionLine{
+		ProductId:  p.GetId(),
+		Categories: p.GetCategories(),
+		Quantity:   quantity,
+		UnitPrice:  p.GetPriceUsd(),
+	}
+}
+
+// getDiscounts prices the promotions of the cart lines with the coupon, if
+// any, in the currency. There are none when the shop has no promotions.
+func (fe *frontendServer) getDiscounts(ctx context.Context, lines []*pb.PromotionLine, coupon, currency string) ([]*pb.Discount, error) {
+	if fe.promotionService == nil {
+		if coupon != "" {
+			return nil, triple.NewError(triple.CodeUnimplemented, errors.New("this shop has no coupons"))
+		}
+		return nil, nil
+	}
+	resp, err := fe.promotionService.Evaluate(ctx, &pb.EvaluateRequest{Lines: lines, CouponCode: coupon})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to evaluate the promotions")
+	}
+	discounts := resp.GetDiscounts()
+	for _, d := range discounts {
+		if d.Amount, err = fe.convertCurrency(ctx, d.GetAmount(), currency); err != nil {
+			return nil, errors.Wrapf(err, "failed to convert discount %s", d.GetPromotionId())
+		}
+	}
+	return discounts, nil
+}
@@ -0,0 +1,148 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package server is the HTTP frontend of the shop. It renders the pages and
+// calls the backend services.
+package server
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"path/filepath"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+const (
+	defaultCurrency = "USD"
+	cookieMaxAge    = 60 * 60 * 48
+
+	cookiePrefix    = "shop_"
+	cookieSessionID = cookiePrefix + "session-id"
+	cookieCurrency  = cookiePrefix + "currency"
+	cookieAuth      = cookiePrefix + "auth"
+	cookieCoupon    = cookiePrefix + "coupon"
+)
+
+var (
+	whitelistedCurrencies = map[string]bool{
+		"USD": true,
+		"EUR": true,
+		"CAD": true,
+		"JPY": true,
+		"GBP": true,
+		"TRY": true,
+	}
+)
+
+type ctxKeySessionID struct{}
+type ctxKeyUser struct{}
+type ctxKeySessionToken struct{}
+
+// Services are the backends the frontend talks to.
+type Services struct {
+	Ad             pb.AdService
+	Cart           pb.CartService
+	Checkout       pb.CheckoutService
+	Currency       pb.CurrencyService
+	ProductCatalog pb.ProductCatalogService
+	Recommendation pb.RecommendationService
+	Shipping       pb.ShippingService
+	// User is optional; without it the shop has no accounts and every
+	// shopper stays anonymous.
+	User pb.UserService
+	// Inventory is optional; without it stock is not tracked and every
+	// product can be added to the cart.
+	Inventory pb.InventoryService
+	// Promotion is optional; without it there are no discounts.
+	Promotion pb.PromotionService
+}
+
+type frontendServer struct {
+	adService             pb.AdService
+	cartService           pb.CartService
+	checkoutService       pb.CheckoutService
+	currencyService       pb.CurrencyService
+	productCatalogService pb.ProductCatalogService
+	recommendationService pb.RecommendationService
+	shippingService       pb.ShippingService
+	userService           pb.UserService
+	inventoryService      pb.InventoryService
+	promotionService      pb.PromotionService
+
+	health *health
+}
+
+// NewHandler returns the handler serving the shop. The page templates and the
+// static files are read from the templates and static directories in dir.
+func NewHandler(services Services, dir string, log *logrus.Logger) (http.Handler, error) {
+	t, err := template.New("").
+		Funcs(template.FuncMap{
+			"renderMoney":        renderMoney,
+			"renderCurrencyLogo": renderCurrencyLogo,
+		}).ParseGlob(filepath.Join(dir, "templates", "*.html"))
+	if err != nil {
+		return nil, err
+	}
+	templates = t
+
+	svc := &frontendServer{
+		adService:             services.Ad,
+		cartService:           services.Cart,
+		checkoutService:       services.Checkout,
+		currencyService:       services.Currency,
+		productCatalogService: services.ProductCatalog,
+		recommendationService: services.Recommendation,
+		shippingService:       services.Shipping,
+		userService:           services.User,
+		inventoryService:      services.Inventory,
+		promotionService:      services.Promotion,
+		health:                newHealth(),
+	}
+
+	r := mux.NewRouter()
+	r.HandleFunc("/", svc.homeHandler).Methods(http.MethodGet, http.MethodHead)
+	r.HandleFunc("/product/{id}", svc.productHandler).Methods(http.MethodGet, http.MethodHead)
+	r.HandleFunc("/cart", svc.viewCartHandler).Methods(http.MethodGet, http.MethodHead)
+	r.HandleFunc("/cart", svc.addToCartHandler).Methods(http.MethodPost)
+	r.HandleFunc("/cart/empty", svc.emptyCartHandler).Methods(http.MethodPost)
+	r.HandleFunc("/cart/coupon", svc.setCouponHandler).Methods(http.MethodPost)
+	r.HandleFunc("/setCurrency", svc.setCurrencyHandler).Methods(http.MethodPost)
+	r.HandleFunc("/logout", svc.logoutHandler).Methods(http.MethodGet)
+	r.HandleFunc("/cart/checkout", svc.placeOrderHandler).Methods(http.MethodPost)
+	r.HandleFunc("/ad/{id}/click", svc.adClickHandler).Methods(http.MethodGet)
+	if svc.userService != nil {
+		svc.registerAccount(r)
+	}
+	svc.registerAPI(r)
+	r.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir(filepath.Join(dir, "static")))))
+	r.HandleFunc("/robots.txt", func(w http.ResponseWriter, _ *http.Request) { fmt.Fprint(w, "User-agent: *\nDisallow: /") })
+	r.HandleFunc("/_healthz", func(w http.ResponseWriter, _ *http.Request) { fmt.Fprint(w, "ok") })
+
+	r.Use(nameSpan)
+
+	var handler http.Handler = r
+	handler = &logHandler{log: log, next: handler} // add logging
+	handler = svc.authenticate(handler)            // add signed in user
+	handler = ensureSessionID(handler)             // add session ID
+	handler = traceRequest(handler)                // add root span and request ID
+	return handler, nil
+}
@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	"github.com/dubbogo/gost/log/logger"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+type InventoryService struct {
+	Stock *Stock
+}
+
+func NewInventoryService(stock *Stock) *InventoryService {
+	return &InventoryService{Stock: stock}
+}
+
+func (s *InventoryService) GetStock(_ context.Context, in *pb.GetStockRequest) (*pb.GetStockResponse, error) {
+	available, err := s.Stock.Available(in.ProductIds)
+	if err != nil {
+		return nil, stockError(err)
+	}
+	out := &pb.GetStockResponse{Stock: make([]*pb.StockLevel, 0, len(available))}
+	for id, n := range available {
+		out.Stock = append(out.Stock, &pb.StockLevel{ProductId: id, Available: n})
+	}
+	sort.Slice(out.Stock, func(i, j int) bool { return out.Stock[i].ProductId < out.Stock[j].ProductId })
+	return out, nil
+}
+
+func (s *InventoryService) Reserve(_ context.Context, in *pb.ReserveRequest) (*pb.Reservation, error) {
+	if in.OrderId == "" {
+		return nil, triple.NewError(triple.CodeInvalidArgument, errors.New("order_id is required"))
+	}
+	if len(in.Items) == 0 {
+		return nil, triple.NewError(triple.CodeInvalidArgument, errors.New("nothing to reserve"))
+	}
+	items := make(map[string]int32, len(in.Items))
+	for _, it := range in.Items {
+		if it.Quantity <= 0 {
+			return nil, triple.NewError(triple.CodeInvalidArgument, fmt.Errorf("quantity of %q must be positive", it.ProductId))
+		}
+		items[it.ProductId] += it.Quantity
+	}
+	expires, err := s.Stock.Reserve(in.OrderId, items)
+	if err != nil {
+		logger.Infof("[Reserve] order %s not reserved: %v", in.OrderId, err)
+		return nil, stockError(err)
+	}
+	logger.Infof("[Reserve] order %s reserved until %s", in.OrderId, expires.Format("15:04:05"))
+	return &pb.Reservation{OrderId: in.OrderId, ExpiresAt: expires.Unix()}, nil
+}
+
+func (s *InventoryService) Commit(_ context.Context, in *pb.CommitRequest) (*pb.Empty, error) {
+	if err := s.Stock.Commit(in.OrderId); err != nil {
+		logger.Warnf("[Commit] order %s not committed: %v", in.OrderId, err)
+		return nil, stockError(err)
+	}
+	logger.Infof("[Commit] order %s committed", in.OrderId)
+	return &pb.Empty{}, nil
+}
+
+func (s *InventoryService) Release(_ context.Context, in *pb.ReleaseRequest) (*pb.Empty, error) {
+	if err := s.Stock.Release(in.OrderId); err != nil {
+		logger.Warnf("[Release] order %s not released: %v", in.OrderId, err)
+		return nil, stockError(err)
+	}
+	logger.Infof("[Release] order %s released", in.OrderId)
+	return &pb.Empty{}, nil
+}
+
+func stockError(err error) error {
+	switch {
+	case errors.Is(err, errUnknownProduct), errors.Is(err, errNoReservation):
+		return triple.NewError(triple.CodeNotFound, err)
+	case errors.Is(err, errOtherItems):
+		return triple.NewError(triple.CodeAlreadyExists, err)
+	default:
+		return triple.NewError(triple.CodeFailedPrecondition, err)
+	}
+}
@@ -0,0 +1,186 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+func newTestService(now *time.Time) *InventoryService {
+	stock := NewStock(map[string]int32{"MUG": 3, "JAR": 1, "GONE": 0}, 10*time.Minute)
+	stock.now = func() time.Time { return *now }
+	return NewInventoryService(stock)
+}
+
+func wantCode(t *testing.T, err error, code triple.Code) {
+	t.Helper()
+	if got := triple.CodeOf(err); err == nil || got != code {
+		t.Fatalf("got error %v (%s), want %s", err, got, code)
+	}
+}
+
+func available(t *testing.T, s *InventoryService, id string) int32 {
+	t.Helper()
+	resp, err := s.GetStock(context.Background(), &pb.GetStockRequest{ProductIds: []string{id}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Stock[0].Available
+}
+
+func reserve(s *InventoryService, order string, items ...*pb.CartItem) (*pb.Reservation, error) {
+	return s.Reserve(context.Background(), &pb.ReserveRequest{OrderId: order, Items: items})
+}
+
+func item(id string, n int32) *pb.CartItem {
+	return &pb.CartItem{ProductId: id, Quantity: n}
+}
+
+func TestReserveCommitRelease(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestService(&now)
+
+	r, err := reserve(s, "o1", item("MUG", 2), item("JAR", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := now.Add(10 * time.Minute).Unix(); r.ExpiresAt != want {
+		t.Errorf("expires at %d, want %d", r.ExpiresAt, want)
+	}
+	if got := available(t, s, "MUG"); got != 1 {
+		t.Errorf("%d mugs available while 2 of 3 are reserved", got)
+	}
+	// A retry holds nothing more, other items for the same order conflict.
+	if _, err := reserve(s, "o1", item("MUG", 2), item("JAR", 1)); err != nil {
+		t.Fatal(err)
+	}
+	_, err = reserve(s, "o1", item("MUG", 1))
+	wantCode(t, err, triple.CodeAlreadyExists)
+
+	// All or nothing: the jar is gone, so no mug is held either.
+	_, err = reserve(s, "o2", item("MUG", 1), item("JAR", 1))
+	wantCode(t, err, triple.CodeFailedPrecondition)
+	if got := available(t, s, "MUG"); got != 1 {
+		t.Errorf("a failed reservation held mugs, %d available", got)
+	}
+	_, err = reserve(s, "o2", item("NOPE", 1))
+	wantCode(t, err, triple.CodeNotFound)
+	_, err = reserve(s, "o2", item("GONE", 1))
+	wantCode(t, err, triple.CodeFailedPrecondition)
+	_, err = reserve(s, "o2", item("MUG", 0))
+	wantCode(t, err, triple.CodeInvalidArgument)
+
+	// Committing takes the units out for good, and is idempotent.
+	for i := 0; i < 2; i++ {
+		if _, err := s.Commit(ctx, &pb.CommitRequest{OrderId: "o1"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := available(t, s, "MUG"); got != 1 {
+		t.Errorf("%d mugs available after selling 2 of 3", got)
+	}
+	_, err = s.Release(ctx, &pb.ReleaseRequest{OrderId: "o1"})
+	wantCode(t, err, triple.CodeFailedPrecondition)
+	_, err = reserve(s, "o1", item("MUG", 2), item("JAR", 1))
+	wantCode(t, err, triple.CodeFailedPrecondition)
+
+	// Releasing returns the units, and is idempotent too.
+	if _, err := reserve(s, "o3", item("MUG", 1)); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := s.Release(ctx, &pb.ReleaseRequest{OrderId: "o3"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := available(t, s, "MUG"); got != 1 {
+		t.Errorf("%d mugs available after releasing the reservation", got)
+	}
+	_, err = s.Commit(ctx, &pb.CommitRequest{OrderId: "o3"})
+	wantCode(t, err, triple.CodeFailedPrecondition)
+	_, err = s.Commit(ctx, &pb.CommitRequest{OrderId: "o4"})
+	wantCode(t, err, triple.CodeNotFound)
+}
+
+func TestReservationExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestService(&now)
+
+	if _, err := reserve(s, "o1", item("JAR", 1)); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(9 * time.Minute)
+	if got := available(t, s, "JAR"); got != 0 {
+		t.Errorf("%d jars available before the reservation expired", got)
+	}
+	now = now.Add(time.Minute)
+	if got := available(t, s, "JAR"); got != 1 {
+		t.Errorf("%d jars available after the reservation expired", got)
+	}
+	_, err := s.Commit(ctx, &pb.CommitRequest{OrderId: "o1"})
+	wantCode(t, err, triple.CodeFailedPrecondition)
+
+	// The expired order may reserve again.
+	if _, err := reserve(s, "o1", item("JAR", 1)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Commit(ctx, &pb.CommitRequest{OrderId: "o1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Closed reservations are forgotten after a day.
+	now = now.Add(forgetAfter + time.Minute)
+	_, err = s.Commit(ctx, &pb.CommitRequest{OrderId: "o1"})
+	wantCode(t, err, triple.CodeNotFound)
+}
+
+func TestLoadStock(t *testing.T) {
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		name, data string
+		ok         bool
+	}{
+		{"valid", `{"MUG": 3, "JAR": 0}`, true},
+		{"negative", `{"MUG": -1}`, false},
+		{"not an object", `[1, 2]`, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.name+".json")
+			if err := os.WriteFile(path, []byte(tt.data), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadStock(path, time.Minute)
+			if (err == nil) != tt.ok {
+				t.Errorf("LoadStock(%s) error = %v", tt.data, err)
+			}
+		})
+	}
+	if _, err := LoadStock(filepath.Join("..", "..", "productcatalogservice", "data", "stock.json"), time.Minute); err != nil {
+		t.Errorf("shipped stock: %v", err)
+	}
+}
@@ -0,0 +1,267 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	errUnknownProduct     = errors.New("unknown product")
+	errInsufficientStock  = errors.New("insufficient stock")
+	errNoReservation      = errors.New("no reservation for the order")
+	errReservationExpired = errors.New("reservation expired")
+	errReleased           = errors.New("reservation was released")
+	errCommitted          = errors.New("reservation was committed")
+	errOtherItems         = errors.New("order is reserved with other items")
+)
+
+// forgetAfter is how long a reservation is remembered once it is no longer
+// held, for late or repeated calls about it to be answered consistently.
+const forgetAfter = 24 * time.Hour
+
+type reservationState int
+
+const (
+	held reservationState = iota
+	committed
+	released
+	expired
+)
+
+type reservation struct {
+	items   map[string]int32
+	expires time.Time
+	state   reservationState
+	closed  time.Time // when it stopped being held
+}
+
+// Stock counts the units of each product. Units held by a reservation are
+// not available to others until the reservation is released or expires, and
+// leave the stock when it is committed.
+type Stock struct {
+	mu           sync.Mutex
+	onHand       map[string]int32
+	held         map[string]int32
+	reservations map[string]*reservation // by order ID
+	ttl          time.Duration
+	now          func() time.Time
+}
+
+// NewStock starts from the units on hand of each product; products that are
+// not listed are not sold. Reservations expire after ttl.
+func NewStock(onHand map[string]int32, ttl time.Duration) *Stock {
+	s := &Stock{
+		onHand:       make(map[string]int32, len(onHand)),
+		held:         make(map[string]int32),
+		reservations: make(map[string]*reservation),
+		ttl:          ttl,
+		now:          time.Now,
+	}
+	for id, n := range onHand {
+		s.onHand[id] = n
+	}
+	return s
+}
+
+// LoadStock reads the units on hand from a JSON object keyed by product ID,
+// kept next to the product catalog.
+func LoadStock(path string, ttl time.Duration) (*Stock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var onHand map[string]int32
+	if err := json.Unmarshal(data, &onHand); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	for id, n := range onHand {
+		if n < 0 {
+			return nil, fmt.Errorf("%s: negative stock for %s", path, id)
+		}
+	}
+	return NewStock(onHand, ttl), nil
+}
+
+// Available returns the units of each product that can still be reserved, of
+// all the products when ids is empty.
+func (s *Stock) Available(ids []string) (map[string]int32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	out := make(map[string]int32)
+	if len(ids) == 0 {
+		for id := range s.onHand {
+			out[id] = s.available(id)
+		}
+		return out, nil
+	}
+	for _, id := range ids {
+		if _, ok := s.onHand[id]; !ok {
+			return nil, fmt.Errorf("%w %q", errUnknownProduct, id)
+		}
+		out[id] = s.available(id)
+	}
+	return out, nil
+}
+
+func (s *Stock) available(id string) int32 {
+	return s.onHand[id] - s.held[id]
+}
+
+// Reserve holds the items of an order, all of them or none. Reserving the
+// same items for the same order again returns the reservation already held,
+// so that a retried call does not hold the items twice.
+func (s *Stock) Reserve(orderID string, items map[string]int32) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	if r, ok := s.reservations[orderID]; ok {
+		switch r.state {
+		case held:
+			if !sameItems(r.items, items) {
+				return time.Time{}, errOtherItems
+			}
+			return r.expires, nil
+		case committed:
+			return time.Time{}, errCommitted
+		}
+	}
+
+	var short []string
+	ids := make([]string, 0, len(items))
+	for id := range items {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if _, ok := s.onHand[id]; !ok {
+			return time.Time{}, fmt.Errorf("%w %q", errUnknownProduct, id)
+		}
+		if avail := s.available(id); avail < items[id] {
+			short = append(short, fmt.Sprintf("%s has %d left", id, avail))
+		}
+	}
+	if len(short) > 0 {
+		return time.Time{}, fmt.Errorf("%w: %s", errInsufficientStock, strings.Join(short, ", "))
+	}
+
+	r := &reservation{items: make(map[string]int32, len(items)), expires: s.now().Add(s.ttl)}
+	for id, n := range items {
+		r.items[id] = n
+		s.held[id] += n
+	}
+	s.reservations[orderID] = r
+	return r.expires, nil
+}
+
+// Commit takes the items of a held reservation out of the stock. Committing
+// again is a no-op.
+func (s *Stock) Commit(orderID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	r, ok := s.reservations[orderID]
+	if !ok {
+		return errNoReservation
+	}
+	switch r.state {
+	case committed:
+		return nil
+	case released:
+		return errReleased
+	case expired:
+		return errReservationExpired
+	}
+	for id, n := range r.items {
+		s.held[id] -= n
+		s.onHand[id] -= n
+	}
+	s.close(r, committed)
+	return nil
+}
+
+// Release makes the items of a held reservation available again. Releasing
+// a reservation that is gone already, or never was, is a no-op.
+func (s *Stock) Release(orderID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	r, ok := s.reservations[orderID]
+	if !ok {
+		return nil
+	}
+	switch r.state {
+	case committed:
+		return errCommitted
+	case released, expired:
+		return nil
+	}
+	s.unhold(r)
+	s.close(r, released)
+	return nil
+}
+
+// expire releases the reservations past their expiry and forgets the ones
+// closed long enough ago.
+func (s *Stock) expire() {
+	now := s.now()
+	for id, r := range s.reservations {
+		switch {
+		case r.state == held && !now.Before(r.expires):
+			s.unhold(r)
+			s.close(r, expired)
+		case r.state != held && now.Sub(r.closed) > forgetAfter:
+			delete(s.reservations, id)
+		}
+	}
+}
+
+func (s *Stock) unhold(r *reservation) {
+	for id, n := range r.items {
+		s.held[id] -= n
+	}
+}
+
+func (s *Stock) close(r *reservation, state reservationState) {
+	r.state = state
+	r.closed = s.now()
+}
+
+func sameItems(a, b map[string]int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for id, n := range a {
+		if b[id] != n {
+			return false
+		}
+	}
+	return true
+}
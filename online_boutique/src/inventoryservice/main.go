@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"os"
+	"time"
+
+	"dubbo.apache.org/dubbo-go/v3"
+	_ "dubbo.apache.org/dubbo-go/v3/imports"
+	"dubbo.apache.org/dubbo-go/v3/protocol"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+	"github.com/dubbogo/gost/log/logger"
+
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/inventoryservice/handler"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/proto/tracing"
+)
+
+const defaultReservationTTL = 10 * time.Minute
+
+func main() {
+	regAddr := os.Getenv("DUBBO_REGISTRY_ADDRESS")
+	if regAddr == "" {
+		regAddr = "127.0.0.1:2181"
+	}
+
+	// The stock is kept next to the product catalog and starts over from it
+	// on every restart.
+	stockPath := os.Getenv("INVENTORY_STOCK_PATH")
+	if stockPath == "" {
+		stockPath = "../productcatalogservice/data/stock.json"
+	}
+	ttl := defaultReservationTTL
+	if v := os.Getenv("INVENTORY_RESERVATION_TTL"); v != "" {
+		var err error
+		if ttl, err = time.ParseDuration(v); err != nil {
+			logger.Fatal(err)
+		}
+	}
+	stock, err := handler.LoadStock(stockPath, ttl)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	withTracing, err := tracing.Option()
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	ins, err := dubbo.NewInstance(
+		dubbo.WithName("inventoryservice"),
+		withTracing,
+		dubbo.WithRegistry(
+			registry.WithZookeeper(),
+			registry.WithAddress(regAddr),
+		),
+		dubbo.WithProtocol(
+			protocol.WithTriple(),
+			protocol.WithPort(20013),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+	//server
+	srv, err := ins.NewServer()
+	if err != nil {
+		panic(err)
+	}
+
+	if err := pb.RegisterInventoryServiceHandler(srv, handler.NewInventoryService(stock)); err != nil {
+		logger.Fatal(err)
+	}
+
+	if err := srv.Serve(); err != nil {
+		logger.Fatal(err)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadgen
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+// DubboTarget calls the services the way the frontend does for each page,
+// leaving the frontend itself out of the measurement.
+type DubboTarget struct {
+	Ad             pb.AdService
+	Cart           pb.CartService
+	Checkout       pb.CheckoutService
+	Currency       pb.CurrencyService
+	ProductCatalog pb.ProductCatalogService
+	Recommendation pb.RecommendationService
+	Shipping       pb.ShippingService
+}
+
+// NewSession starts a shopper with a fresh user ID.
+func (t *DubboTarget) NewSession(context.Context) (Session, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	return &dubboSession{t: t, userID: hex.EncodeToString(id), currency: "USD"}, nil
+}
+
+type dubboSession struct {
+	t        *DubboTarget
+	userID   string
+	currency string
+}
+
+func (s *dubboSession) convert(ctx context.Context, m *pb.Money) error {
+	if m.GetCurrencyCode() == s.currency {
+		return nil
+	}
+	_, err := s.t.Currency.Convert(ctx, &pb.CurrencyConversionRequest{From: m, ToCode: s.currency})
+	return err
+}
+
+func (s *dubboSession) Home(ctx context.Context) error {
+	if _, err := s.t.Currency.GetSupportedCurrencies(ctx, &pb.Empty{}); err != nil {
+		return err
+	}
+	products, err := s.t.ProductCatalog.ListProducts(ctx, &pb.Empty{})
+	if err != nil {
+		return err
+	}
+	if _, err := s.t.Cart.GetCart(ctx, &pb.GetCartRequest{UserId: s.userID}); err != nil {
+		return err
+	}
+	for _, p := range products.GetProducts() {
+		if err := s.convert(ctx, p.GetPriceUsd()); err != nil {
+			return err
+		}
+	}
+	_, err = s.t.Ad.GetAds(ctx, &pb.AdRequest{SessionId: s.userID})
+	return err
+}
+
+func (s *dubboSession) BrowseProduct(ctx context.Context, productID string) error {
+	p, err := s.t.ProductCatalog.GetProduct(ctx, &pb.GetProductRequest{Id: productID})
+	if err != nil {
+		return err
+	}
+	if _, err := s.t.Currency.GetSupportedCurrencies(ctx, &pb.Empty{}); err != nil {
+		return err
+	}
+	if _, err := s.t.Cart.GetCart(ctx, &pb.GetCartRequest{UserId: s.userID}); err != nil {
+		return err
+	}
+	if err := s.convert(ctx, p.GetPriceUsd()); err != nil {
+		return err
+	}
+	if _, err := s.t.Recommendation.ListRecommendations(ctx, &pb.ListRecommendationsRequest{
+		UserId:     s.userID,
+		ProductIds: []string{productID},
+	}); err != nil {
+		return err
+	}
+	_, err = s.t.Ad.GetAds(ctx, &pb.AdRequest{
+		ContextKeys: append(p.GetCategories(), strings.Fields(strings.ToLower(p.GetName()))...),
+		SessionId:   s.userID,
+	})
+	return err
+}
+
+// SetCurrency only changes the session, as the frontend keeps the
+// currency in a cookie.
+func (s *dubboSession) SetCurrency(_ context.Context, currency string) error {
+	s.currency = currency
+	return nil
+}
+
+func (s *dubboSession) ViewCart(ctx context.Context) error {
+	if _, err := s.t.Currency.GetSupportedCurrencies(ctx, &pb.Empty{}); err != nil {
+		return err
+	}
+	cart, err := s.t.Cart.GetCart(ctx, &pb.GetCartRequest{UserId: s.userID})
+	if err != nil {
+		return err
+	}
+	if _, err := s.t.Recommendation.ListRecommendations(ctx, &pb.ListRecommendationsRequest{UserId: s.userID}); err != nil {
+		return err
+	}
+	quote, err := s.t.Shipping.GetQuote(ctx, &pb.GetQuoteRequest{Items: cart.GetItems()})
+	if err != nil {
+		return err
+	}
+	if err := s.convert(ctx, quote.GetCostUsd()); err != nil {
+		return err
+	}
+	for _, item := range cart.GetItems() {
+		p, err := s.t.ProductCatalog.GetProduct(ctx, &pb.GetProductRequest{Id: item.GetProductId()})
+		if err != nil {
+			return err
+		}
+		if err := s.convert(ctx, p.GetPriceUsd()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *dubboSession) AddToCart(ctx context.Context, productID string, quantity int) error {
+	if _, err := s.t.ProductCatalog.GetProduct(ctx, &pb.GetProductRequest{Id: productID}); err != nil {
+		return err
+	}
+	_, err := s.t.Cart.AddItem(ctx, &pb.AddItemRequest{
+		UserId: s.userID,
+		Item:   &pb.CartItem{ProductId: productID, Quantity: int32(quantity)},
+	})
+	return err
+}
+
+func (s *dubboSession) Checkout(ctx context.Context, o Order) error {
+	_, err := s.t.Checkout.PlaceOrder(ctx, &pb.PlaceOrderRequest{
+		UserId:       s.userID,
+		UserCurrency: s.currency,
+		Email:        o.Email,
+		Address: &pb.Address{
+			StreetAddress: o.StreetAddress,
+			City:          o.City,
+			State:         o.State,
+			Country:       o.Country,
+			ZipCode:       int32(o.ZipCode),
+		},
+		CreditCard: &pb.CreditCardInfo{
+			CreditCardNumber:          o.CreditCardNumber,
+			CreditCardCvv:             int32(o.CreditCardCVV),
+			CreditCardExpirationYear:  int32(o.CreditCardExpirationYear),
+			CreditCardExpirationMonth: int32(o.CreditCardExpirationMonth),
+		},
+	})
+	return err
+}
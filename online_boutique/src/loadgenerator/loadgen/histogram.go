@@ -0,0 +1,169 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadgen
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	histogramMin    = 100 * time.Microsecond
+	histogramGrowth = 1.05
+	histogramSize   = 300 // up to about 4 minutes
+)
+
+// Histogram counts latencies in exponentially growing buckets, so that
+// quantiles are accurate to within 5% over the whole range.
+type Histogram struct {
+	counts   [histogramSize + 1]int64
+	count    int64
+	sum      time.Duration
+	min, max time.Duration
+}
+
+func bucketOf(d time.Duration) int {
+	if d <= histogramMin {
+		return 0
+	}
+	i := int(math.Ceil(math.Log(float64(d)/float64(histogramMin)) / math.Log(histogramGrowth)))
+	if i > histogramSize {
+		return histogramSize
+	}
+	return i
+}
+
+func bucketUpper(i int) time.Duration {
+	return time.Duration(float64(histogramMin) * math.Pow(histogramGrowth, float64(i)))
+}
+
+// Observe adds a latency.
+func (h *Histogram) Observe(d time.Duration) {
+	h.counts[bucketOf(d)]++
+	if h.count == 0 || d < h.min {
+		h.min = d
+	}
+	if d > h.max {
+		h.max = d
+	}
+	h.count++
+	h.sum += d
+}
+
+// Merge adds the latencies counted by o.
+func (h *Histogram) Merge(o *Histogram) {
+	if o.count == 0 {
+		return
+	}
+	for i, c := range o.counts {
+		h.counts[i] += c
+	}
+	if h.count == 0 || o.min < h.min {
+		h.min = o.min
+	}
+	if o.max > h.max {
+		h.max = o.max
+	}
+	h.count += o.count
+	h.sum += o.sum
+}
+
+// Count returns the number of latencies observed.
+func (h *Histogram) Count() int64 { return h.count }
+
+// Min returns the lowest latency observed.
+func (h *Histogram) Min() time.Duration { return h.min }
+
+// Max returns the highest latency observed.
+func (h *Histogram) Max() time.Duration { return h.max }
+
+// Mean returns the average latency.
+func (h *Histogram) Mean() time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	return h.sum / time.Duration(h.count)
+}
+
+// Quantile returns the latency below which the fraction q of the
+// observations fall.
+func (h *Histogram) Quantile(q float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	rank := int64(math.Ceil(q * float64(h.count)))
+	if rank < 1 {
+		rank = 1
+	}
+	var seen int64
+	for i, c := range h.counts {
+		seen += c
+		if seen >= rank {
+			return min(max(bucketUpper(i), h.min), h.max)
+		}
+	}
+	return h.max
+}
+
+// Bar is one row of a coarse latency distribution.
+type Bar struct {
+	Below time.Duration // 0 for the last, open ended row
+	Count int64
+}
+
+// Bars groups the observations by the given ascending upper bounds, with a
+// last row for everything slower.
+func (h *Histogram) Bars(bounds []time.Duration) []Bar {
+	bars := make([]Bar, len(bounds)+1)
+	for i, b := range bounds {
+		bars[i].Below = b
+	}
+	for i, c := range h.counts {
+		if c == 0 {
+			continue
+		}
+		// A bucket straddling a bound is counted in the row of its upper
+		// edge, so rows may overstate slightly.
+		upper := bucketUpper(i)
+		row := len(bounds)
+		for j, b := range bounds {
+			if upper <= b {
+				row = j
+				break
+			}
+		}
+		bars[row].Count += c
+	}
+	return bars
+}
+
+// DefaultBars are the rows printed in reports.
+var DefaultBars = []time.Duration{
+	time.Millisecond, 2 * time.Millisecond, 5 * time.Millisecond,
+	10 * time.Millisecond, 20 * time.Millisecond, 50 * time.Millisecond,
+	100 * time.Millisecond, 200 * time.Millisecond, 500 * time.Millisecond,
+	time.Second, 2 * time.Second, 5 * time.Second,
+}
+
+func (b Bar) String() string {
+	if b.Below == 0 {
+		return "slower"
+	}
+	return fmt.Sprintf("< %v", b.Below)
+}
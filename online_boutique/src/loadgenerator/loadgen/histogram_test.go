@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadgen
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistogramQuantile(t *testing.T) {
+	var h Histogram
+	for i := 1; i <= 1000; i++ {
+		h.Observe(time.Duration(i) * time.Millisecond)
+	}
+	tests := []struct {
+		q    float64
+		want time.Duration
+	}{
+		{0.5, 500 * time.Millisecond},
+		{0.9, 900 * time.Millisecond},
+		{0.99, 990 * time.Millisecond},
+		{1, time.Second},
+	}
+	for _, tt := range tests {
+		got := h.Quantile(tt.q)
+		if got < tt.want || float64(got) > float64(tt.want)*histogramGrowth {
+			t.Errorf("Quantile(%v) = %v, want within 5%% above %v", tt.q, got, tt.want)
+		}
+	}
+	if h.Min() != time.Millisecond || h.Max() != time.Second {
+		t.Errorf("min, max = %v, %v", h.Min(), h.Max())
+	}
+	if h.Mean() != 500500*time.Microsecond {
+		t.Errorf("Mean() = %v", h.Mean())
+	}
+}
+
+func TestHistogramMergeAndBars(t *testing.T) {
+	var a, b Histogram
+	a.Observe(500 * time.Microsecond)
+	a.Observe(3 * time.Millisecond)
+	b.Observe(3 * time.Millisecond)
+	b.Observe(10 * time.Second)
+	a.Merge(&b)
+	if a.Count() != 4 || a.Max() != 10*time.Second || a.Min() != 500*time.Microsecond {
+		t.Fatalf("merged count %d, min %v, max %v", a.Count(), a.Min(), a.Max())
+	}
+
+	bars := a.Bars([]time.Duration{time.Millisecond, 5 * time.Millisecond})
+	want := []int64{1, 2, 1}
+	for i, bar := range bars {
+		if bar.Count != want[i] {
+			t.Errorf("bar %v has %d, want %d", bar, bar.Count, want[i])
+		}
+	}
+}
+
+func TestParseStages(t *testing.T) {
+	stages, err := ParseStages("10s:5, 20s:25,10s:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profileDuration(stages) != 40*time.Second {
+		t.Errorf("duration %v", profileDuration(stages))
+	}
+	tests := []struct {
+		at   time.Duration
+		want float64
+	}{
+		{0, 5},
+		{5 * time.Second, 5},
+		{20 * time.Second, 15},
+		{30 * time.Second, 25},
+		{35 * time.Second, 12.5},
+		{time.Minute, 0},
+	}
+	for _, tt := range tests {
+		if got := profileRate(stages, tt.at); got != tt.want {
+			t.Errorf("rate at %v = %v, want %v", tt.at, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "10s", "ten:5", "10s:-1", "10s:x"} {
+		if _, err := ParseStages(bad); err == nil {
+			t.Errorf("ParseStages(%q) succeeded", bad)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// HTTPTarget drives the frontend like a browser, following redirects and
+// keeping the session cookie.
+type HTTPTarget struct {
+	// BaseURL is where the frontend is, e.g. http://localhost:8090.
+	BaseURL string
+	// Transport is shared by the sessions, http.DefaultTransport if nil.
+	Transport http.RoundTripper
+}
+
+// NewSession starts a shopper with its own cookies.
+func (t *HTTPTarget) NewSession(context.Context) (Session, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &httpSession{
+		base:   strings.TrimSuffix(t.BaseURL, "/"),
+		client: &http.Client{Jar: jar, Transport: t.Transport},
+	}, nil
+}
+
+type httpSession struct {
+	base   string
+	client *http.Client
+}
+
+func (s *httpSession) do(ctx context.Context, method, path string, form url.Values) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.base+path, body)
+	if err != nil {
+		return err
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s %s: %s", method, path, resp.Status)
+	}
+	return nil
+}
+
+func (s *httpSession) Home(ctx context.Context) error {
+	return s.do(ctx, http.MethodGet, "/", nil)
+}
+
+func (s *httpSession) BrowseProduct(ctx context.Context, productID string) error {
+	return s.do(ctx, http.MethodGet, "/product/"+url.PathEscape(productID), nil)
+}
+
+func (s *httpSession) SetCurrency(ctx context.Context, currency string) error {
+	return s.do(ctx, http.MethodPost, "/setCurrency", url.Values{"currency_code": {currency}})
+}
+
+func (s *httpSession) ViewCart(ctx context.Context) error {
+	return s.do(ctx, http.MethodGet, "/cart", nil)
+}
+
+func (s *httpSession) AddToCart(ctx context.Context, productID string, quantity int) error {
+	return s.do(ctx, http.MethodPost, "/cart", url.Values{
+		"product_id": {productID},
+		"quantity":   {strconv.Itoa(quantity)},
+	})
+}
+
+func (s *httpSession) Checkout(ctx context.Context, o Order) error {
+	return s.do(ctx, http.MethodPost, "/cart/checkout", url.Values{
+		"email":                        {o.Email},
+		"street_address":               {o.StreetAddress},
+		"zip_code":                     {strconv.Itoa(o.ZipCode)},
+		"city":                         {o.City},
+		"state":                        {o.State},
+		"country":                      {o.Country},
+		"credit_card_number":           {o.CreditCardNumber},
+		"credit_card_expiration_month": {strconv.Itoa(o.CreditCardExpirationMonth)},
+		"credit_card_expiration_year":  {strconv.Itoa(o.CreditCardExpirationYear)},
+		"credit_card_cvv":              {strconv.Itoa(o.CreditCardCVV)},
+	})
+}
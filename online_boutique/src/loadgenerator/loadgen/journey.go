@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadgen
+
+import (
+	"context"
+	"math/rand"
+)
+
+// Target is the shop under load, reached through the frontend or directly
+// through the services.
+type Target interface {
+	// NewSession starts a shopper with its own cart and currency.
+	NewSession(ctx context.Context) (Session, error)
+}
+
+// Session is what one shopper can do.
+type Session interface {
+	Home(ctx context.Context) error
+	BrowseProduct(ctx context.Context, productID string) error
+	SetCurrency(ctx context.Context, currency string) error
+	ViewCart(ctx context.Context) error
+	AddToCart(ctx context.Context, productID string, quantity int) error
+	Checkout(ctx context.Context, order Order) error
+}
+
+// Order is the form a shopper fills in at checkout.
+type Order struct {
+	Email                     string
+	StreetAddress             string
+	ZipCode                   int
+	City                      string
+	State                     string
+	Country                   string
+	CreditCardNumber          string
+	CreditCardExpirationMonth int
+	CreditCardExpirationYear  int
+	CreditCardCVV             int
+}
+
+// DefaultOrder is paid with a card the payment simulator accepts.
+var DefaultOrder = Order{
+	Email:                     "someone@example.com",
+	StreetAddress:             "1600 Amphitheatre Parkway",
+	ZipCode:                   94043,
+	City:                      "Mountain View",
+	State:                     "CA",
+	Country:                   "United States",
+	CreditCardNumber:          "4432-8015-6152-0454",
+	CreditCardExpirationMonth: 1,
+	CreditCardExpirationYear:  2039,
+	CreditCardCVV:             672,
+}
+
+// Visit is the state of one run of a journey.
+type Visit struct {
+	Rand *rand.Rand
+	// ProductID is the product browsed last, which add-to-cart puts in.
+	ProductID string
+}
+
+// Step is a single timed operation of a journey.
+type Step struct {
+	Name string
+	Do   func(ctx context.Context, s Session, v *Visit) error
+}
+
+// Journey is a sequence of steps a shopper goes through. Journeys are
+// picked in proportion to their weight.
+type Journey struct {
+	Name   string
+	Weight int
+	Steps  []Step
+}
+
+// DefaultProducts are the IDs in the default product catalog.
+var DefaultProducts = []string{
+	"0PUK6V6EV0", "1YMWWN1N4O", "2ZYFJ3GM2N", "66VCHSJNUP", "6E92ZMYYFZ",
+	"9SIQT8TOJO", "L9ECAV7KIM", "LS4PSXUNUM", "OLJCESPC7Z",
+}
+
+// DefaultCurrencies are the currencies shoppers switch to.
+var DefaultCurrencies = []string{"EUR", "USD", "JPY", "CAD"}
+
+// DefaultJourneys returns the journeys and weights of the locust file.
+func DefaultJourneys(products []string) []Journey {
+	home := Step{"home", func(ctx context.Context, s Session, _ *Visit) error {
+		return s.Home(ctx)
+	}}
+	setCurrency := Step{"set-currency", func(ctx context.Context, s Session, v *Visit) error {
+		return s.SetCurrency(ctx, DefaultCurrencies[v.Rand.Intn(len(DefaultCurrencies))])
+	}}
+	browse := Step{"browse", func(ctx context.Context, s Session, v *Visit) error {
+		v.ProductID = products[v.Rand.Intn(len(products))]
+		return s.BrowseProduct(ctx, v.ProductID)
+	}}
+	add := Step{"add-to-cart", func(ctx context.Context, s Session, v *Visit) error {
+		quantities := []int{1, 2, 3, 4, 5, 10}
+		return s.AddToCart(ctx, v.ProductID, quantities[v.Rand.Intn(len(quantities))])
+	}}
+	viewCart := Step{"view-cart", func(ctx context.Context, s Session, _ *Visit) error {
+		return s.ViewCart(ctx)
+	}}
+	checkout := Step{"checkout", func(ctx context.Context, s Session, _ *Visit) error {
+		return s.Checkout(ctx, DefaultOrder)
+	}}
+	return []Journey{
+		{Name: "home", Weight: 1, Steps: []Step{home}},
+		{Name: "set-currency", Weight: 2, Steps: []Step{setCurrency}},
+		{Name: "browse", Weight: 10, Steps: []Step{browse}},
+		{Name: "add-to-cart", Weight: 2, Steps: []Step{browse, add}},
+		{Name: "view-cart", Weight: 3, Steps: []Step{viewCart}},
+		{Name: "checkout", Weight: 1, Steps: []Step{browse, add, checkout}},
+	}
+}
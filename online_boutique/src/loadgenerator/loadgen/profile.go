@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadgen
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Stage is a step of a load profile. The rate of journeys moves linearly
+// from the target of the previous stage to Target over Duration; the first
+// stage holds its target.
+type Stage struct {
+	Duration time.Duration
+	Target   float64 // journeys per second
+}
+
+// ParseStages reads a profile written as comma separated duration:rate
+// pairs, e.g. "30s:10,2m:10,30s:50" to hold 10 journeys per second for
+// two and a half minutes, then ramp up to 50 over half a minute.
+func ParseStages(s string) ([]Stage, error) {
+	var stages []Stage
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		d, r, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("stage %q is not duration:rate", part)
+		}
+		duration, err := time.ParseDuration(d)
+		if err != nil || duration < 0 {
+			return nil, fmt.Errorf("stage %q has an invalid duration", part)
+		}
+		target, err := strconv.ParseFloat(r, 64)
+		if err != nil || target < 0 {
+			return nil, fmt.Errorf("stage %q has an invalid rate", part)
+		}
+		stages = append(stages, Stage{Duration: duration, Target: target})
+	}
+	if len(stages) == 0 {
+		return nil, fmt.Errorf("no stages in %q", s)
+	}
+	return stages, nil
+}
+
+// profileDuration returns how long the stages last in total.
+func profileDuration(stages []Stage) time.Duration {
+	var total time.Duration
+	for _, s := range stages {
+		total += s.Duration
+	}
+	return total
+}
+
+// profileRate returns the rate of journeys the stages ask for at elapsed.
+func profileRate(stages []Stage, elapsed time.Duration) float64 {
+	from := stages[0].Target
+	for _, s := range stages {
+		if elapsed < s.Duration {
+			return from + (s.Target-from)*float64(elapsed)/float64(s.Duration)
+		}
+		elapsed -= s.Duration
+		from = s.Target
+	}
+	return from
+}
@@ -0,0 +1,317 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadgen
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Stats is the outcome of a step or journey.
+type Stats struct {
+	Name    string
+	Errors  int64
+	Latency Histogram
+}
+
+// ErrorRate returns the fraction of runs that failed.
+func (s *Stats) ErrorRate() float64 {
+	if s.Latency.Count() == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Latency.Count())
+}
+
+// Report is the outcome of a run.
+type Report struct {
+	Elapsed time.Duration
+	// Steps and Journeys are sorted by name.
+	Steps    []*Stats
+	Journeys []*Stats
+	// Total merges all steps.
+	Total Stats
+	// Errors counts the distinct errors by step.
+	Errors map[string]int64
+}
+
+func newReport(elapsed time.Duration, steps, journeys map[string]*Stats, errors map[string]int64) *Report {
+	r := &Report{
+		Elapsed:  elapsed,
+		Steps:    sorted(steps),
+		Journeys: sorted(journeys),
+		Total:    Stats{Name: "total"},
+		Errors:   make(map[string]int64, len(errors)),
+	}
+	for _, s := range r.Steps {
+		r.Total.Errors += s.Errors
+		r.Total.Latency.Merge(&s.Latency)
+	}
+	for e, n := range errors {
+		r.Errors[e] = n
+	}
+	return r
+}
+
+func sorted(m map[string]*Stats) []*Stats {
+	list := make([]*Stats, 0, len(m))
+	for _, s := range m {
+		c := *s
+		list = append(list, &c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Step returns the stats of the named step, or the total for "".
+func (r *Report) Step(name string) *Stats {
+	if name == "" {
+		return &r.Total
+	}
+	for _, s := range r.Steps {
+		if s.Name == name {
+			return s
+		}
+	}
+	for _, s := range r.Journeys {
+		if "journey:"+s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// WriteText prints the report as tables followed by the latency
+// distribution of all steps.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	header := "\trequests\terrors\trps\tmean\tp50\tp90\tp95\tp99\tmax\t\n"
+	row := func(s *Stats) {
+		h := &s.Latency
+		fmt.Fprintf(tw, "%s\t%d\t%.2f%%\t%.1f\t%v\t%v\t%v\t%v\t%v\t%v\t\n",
+			s.Name, h.Count(), 100*s.ErrorRate(), float64(h.Count())/r.Elapsed.Seconds(),
+			round(h.Mean()), round(h.Quantile(0.5)), round(h.Quantile(0.9)),
+			round(h.Quantile(0.95)), round(h.Quantile(0.99)), round(h.Max()))
+	}
+
+	fmt.Fprintf(tw, "steps%s", header)
+	for _, s := range r.Steps {
+		row(s)
+	}
+	row(&r.Total)
+	fmt.Fprintf(tw, "\t\t\t\t\t\t\t\t\t\t\n")
+	fmt.Fprintf(tw, "journeys%s", header)
+	for _, s := range r.Journeys {
+		row(s)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nlatency of %d steps in %v\n", r.Total.Latency.Count(), round(r.Elapsed))
+	bars := r.Total.Latency.Bars(DefaultBars)
+	var most int64
+	for _, b := range bars {
+		most = max(most, b.Count)
+	}
+	for _, b := range bars {
+		width := 0
+		if most > 0 {
+			width = int(50 * b.Count / most)
+		}
+		fmt.Fprintf(w, "%10s %8d %s\n", b, b.Count, strings.Repeat("#", width))
+	}
+
+	if len(r.Errors) > 0 {
+		fmt.Fprintf(w, "\nerrors\n")
+		errs := make([]string, 0, len(r.Errors))
+		for e := range r.Errors {
+			errs = append(errs, e)
+		}
+		sort.Slice(errs, func(i, j int) bool { return r.Errors[errs[i]] > r.Errors[errs[j]] })
+		for _, e := range errs {
+			fmt.Fprintf(w, "%8d %s\n", r.Errors[e], e)
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+func round(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(10 * time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	}
+	return d.Round(time.Microsecond)
+}
+
+// Summary is the report in a form fit for JSON, with latencies in
+// milliseconds.
+type Summary struct {
+	ElapsedSeconds float64            `json:"elapsed_seconds"`
+	Steps          []StatsSummary     `json:"steps"`
+	Journeys       []StatsSummary     `json:"journeys"`
+	Total          StatsSummary       `json:"total"`
+	Errors         map[string]int64   `json:"errors,omitempty"`
+	Thresholds     []ThresholdOutcome `json:"thresholds,omitempty"`
+}
+
+// StatsSummary is a Stats in a Summary.
+type StatsSummary struct {
+	Name      string  `json:"name"`
+	Requests  int64   `json:"requests"`
+	Errors    int64   `json:"errors"`
+	ErrorRate float64 `json:"error_rate"`
+	RPS       float64 `json:"rps"`
+	Mean      float64 `json:"mean_ms"`
+	P50       float64 `json:"p50_ms"`
+	P90       float64 `json:"p90_ms"`
+	P95       float64 `json:"p95_ms"`
+	P99       float64 `json:"p99_ms"`
+	Max       float64 `json:"max_ms"`
+}
+
+// Summary returns the report with the outcome of the thresholds.
+func (r *Report) Summary(outcomes []ThresholdOutcome) Summary {
+	ms := func(d time.Duration) float64 { return float64(d) / float64(time.Millisecond) }
+	summarize := func(s *Stats) StatsSummary {
+		h := &s.Latency
+		return StatsSummary{
+			Name:      s.Name,
+			Requests:  h.Count(),
+			Errors:    s.Errors,
+			ErrorRate: s.ErrorRate(),
+			RPS:       float64(h.Count()) / r.Elapsed.Seconds(),
+			Mean:      ms(h.Mean()),
+			P50:       ms(h.Quantile(0.5)),
+			P90:       ms(h.Quantile(0.9)),
+			P95:       ms(h.Quantile(0.95)),
+			P99:       ms(h.Quantile(0.99)),
+			Max:       ms(h.Max()),
+		}
+	}
+	out := Summary{
+		ElapsedSeconds: r.Elapsed.Seconds(),
+		Total:          summarize(&r.Total),
+		Errors:         r.Errors,
+		Thresholds:     outcomes,
+	}
+	for _, s := range r.Steps {
+		out.Steps = append(out.Steps, summarize(s))
+	}
+	for _, s := range r.Journeys {
+		out.Journeys = append(out.Journeys, summarize(s))
+	}
+	return out
+}
+
+// Threshold is a limit a run must stay within, written like
+// "p99<500ms", "error_rate<0.01" or "checkout:p95<2s". The part before
+// the colon names a step, or a journey when prefixed with "journey:", and
+// defaults to all steps. The metrics are mean, max, p50, p90, p95, p99
+// and error_rate.
+type Threshold struct {
+	Step   string
+	Metric string
+	Limit  float64 // seconds for latencies
+	text   string
+}
+
+// ParseThreshold reads a threshold.
+func ParseThreshold(s string) (Threshold, error) {
+	t := Threshold{text: s}
+	expr, limit, ok := strings.Cut(s, "<")
+	if !ok {
+		return t, fmt.Errorf("threshold %q has no <", s)
+	}
+	if i := strings.LastIndex(expr, ":"); i >= 0 {
+		t.Step, expr = expr[:i], expr[i+1:]
+	}
+	t.Metric = strings.TrimSpace(expr)
+	limit = strings.TrimSpace(limit)
+	switch t.Metric {
+	case "error_rate":
+		v, err := strconv.ParseFloat(limit, 64)
+		if err != nil {
+			return t, fmt.Errorf("threshold %q: %w", s, err)
+		}
+		t.Limit = v
+	case "mean", "max", "p50", "p90", "p95", "p99":
+		d, err := time.ParseDuration(limit)
+		if err != nil {
+			return t, fmt.Errorf("threshold %q: %w", s, err)
+		}
+		t.Limit = d.Seconds()
+	default:
+		return t, fmt.Errorf("threshold %q has unknown metric %q", s, t.Metric)
+	}
+	return t, nil
+}
+
+func (t Threshold) String() string { return t.text }
+
+// ThresholdOutcome is how a run did against a threshold.
+type ThresholdOutcome struct {
+	Threshold string  `json:"threshold"`
+	Value     float64 `json:"value"`
+	Passed    bool    `json:"passed"`
+	Reason    string  `json:"reason,omitempty"`
+}
+
+// Check measures the report against the thresholds.
+func (r *Report) Check(thresholds []Threshold) []ThresholdOutcome {
+	outcomes := make([]ThresholdOutcome, 0, len(thresholds))
+	for _, t := range thresholds {
+		o := ThresholdOutcome{Threshold: t.text}
+		s := r.Step(t.Step)
+		switch {
+		case s == nil || s.Latency.Count() == 0:
+			o.Reason = fmt.Sprintf("no runs of %q", t.Step)
+		default:
+			o.Value = t.value(s)
+			o.Passed = o.Value < t.Limit
+		}
+		outcomes = append(outcomes, o)
+	}
+	return outcomes
+}
+
+func (t Threshold) value(s *Stats) float64 {
+	h := &s.Latency
+	switch t.Metric {
+	case "error_rate":
+		return s.ErrorRate()
+	case "mean":
+		return h.Mean().Seconds()
+	case "max":
+		return h.Max().Seconds()
+	case "p50":
+		return h.Quantile(0.5).Seconds()
+	case "p90":
+		return h.Quantile(0.9).Seconds()
+	case "p95":
+		return h.Quantile(0.95).Seconds()
+	}
+	return h.Quantile(0.99).Seconds()
+}
@@ -0,0 +1,296 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Options shape the load.
+type Options struct {
+	// Users is the number of shoppers running journeys concurrently.
+	Users int
+	// RampUp spreads the start of the shoppers over this long.
+	RampUp time.Duration
+	// Stages paces the journeys. Without stages the shoppers run journeys
+	// back to back for Duration.
+	Stages   []Stage
+	Duration time.Duration
+	// Think is the pause of a shopper between two journeys.
+	Think time.Duration
+	// Timeout bounds every step, 10 seconds if zero.
+	Timeout time.Duration
+	Seed    int64
+}
+
+// Run puts load on the target until the profile is over or ctx is done.
+func Run(ctx context.Context, target Target, journeys []Journey, opts Options) (*Report, error) {
+	if opts.Users <= 0 {
+		return nil, fmt.Errorf("need at least one user")
+	}
+	total := 0
+	for _, j := range journeys {
+		if j.Weight < 0 {
+			return nil, fmt.Errorf("journey %s has a negative weight", j.Name)
+		}
+		total += j.Weight
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("no journey to run")
+	}
+	duration := opts.Duration
+	if len(opts.Stages) > 0 {
+		duration = profileDuration(opts.Stages)
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("the run has no duration")
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = 10 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+	rec := newRecorder()
+	start := time.Now()
+
+	var tokens chan struct{}
+	if len(opts.Stages) > 0 {
+		tokens = make(chan struct{})
+		go pace(ctx, opts.Stages, opts.Users, tokens)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Users; i++ {
+		wg.Add(1)
+		delay := opts.RampUp * time.Duration(i) / time.Duration(opts.Users)
+		r := rand.New(rand.NewSource(opts.Seed + int64(i)))
+		go func() {
+			defer wg.Done()
+			if !sleep(ctx, delay) {
+				return
+			}
+			shop(ctx, target, journeys, total, opts, r, tokens, rec)
+		}()
+	}
+	wg.Wait()
+	return rec.report(time.Since(start)), nil
+}
+
+// The backoff of a shopper that fails to start a session.
+const (
+	sessionBackoff    = 100 * time.Millisecond
+	maxSessionBackoff = 5 * time.Second
+)
+
+// shop is the loop of a single shopper.
+func shop(ctx context.Context, target Target, journeys []Journey, total int, opts Options, r *rand.Rand, tokens <-chan struct{}, rec *recorder) {
+	session, ok := newSession(ctx, target, opts, rec)
+	if !ok {
+		return
+	}
+	for {
+		if tokens != nil {
+			select {
+			case <-tokens:
+			case <-ctx.Done():
+				return
+			}
+		} else if ctx.Err() != nil {
+			return
+		}
+
+		j := pick(journeys, total, r)
+		v := &Visit{Rand: r}
+		failed := false
+		elapsed := measure(func() {
+			for _, step := range j.Steps {
+				err := rec.time(ctx, step.Name, func() error {
+					stepCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+					defer cancel()
+					return step.Do(stepCtx, session, v)
+				})
+				if err != nil {
+					failed = true
+					return
+				}
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		rec.journey(j.Name, elapsed, failed)
+
+		if !sleep(ctx, opts.Think) {
+			return
+		}
+	}
+}
+
+// newSession starts the session of a shopper, trying again with backoff until
+// ctx is done, so that an outage does not leave the run with fewer shoppers.
+func newSession(ctx context.Context, target Target, opts Options, rec *recorder) (Session, bool) {
+	backoff := sessionBackoff
+	for {
+		var session Session
+		err := rec.time(ctx, "new-session", func() (err error) {
+			sessionCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+			defer cancel()
+			session, err = target.NewSession(sessionCtx)
+			return err
+		})
+		if err == nil {
+			return session, true
+		}
+		if !sleep(ctx, backoff) {
+			return nil, false
+		}
+		backoff = min(2*backoff, maxSessionBackoff)
+	}
+}
+
+func pick(journeys []Journey, total int, r *rand.Rand) Journey {
+	n := r.Intn(total)
+	for _, j := range journeys {
+		if n < j.Weight {
+			return j
+		}
+		n -= j.Weight
+	}
+	return journeys[len(journeys)-1]
+}
+
+// pace hands out tokens at the rate the stages ask for. Tokens the shoppers
+// are too busy to take are kept up to one per shopper.
+func pace(ctx context.Context, stages []Stage, users int, tokens chan<- struct{}) {
+	const tick = 10 * time.Millisecond
+	start := time.Now()
+	last := start
+	credit := 0.0
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for {
+		now := time.Now()
+		credit += profileRate(stages, now.Sub(start)) * now.Sub(last).Seconds()
+		credit = min(credit, float64(users))
+		last = now
+	send:
+		for credit >= 1 {
+			select {
+			case tokens <- struct{}{}:
+				credit--
+			case <-ctx.Done():
+				return
+			default:
+				break send
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func measure(f func()) time.Duration {
+	start := time.Now()
+	f()
+	return time.Since(start)
+}
+
+// recorder collects the outcome of steps and journeys across shoppers.
+type recorder struct {
+	mu       sync.Mutex
+	steps    map[string]*Stats
+	journeys map[string]*Stats
+	errors   map[string]int64
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		steps:    make(map[string]*Stats),
+		journeys: make(map[string]*Stats),
+		errors:   make(map[string]int64),
+	}
+}
+
+// time runs f and records how long it took, unless the run ended meanwhile.
+func (r *recorder) time(ctx context.Context, name string, f func() error) error {
+	var err error
+	elapsed := measure(func() { err = f() })
+	if ctx.Err() != nil {
+		if err == nil {
+			err = ctx.Err()
+		}
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	observe(r.steps, name, elapsed, err != nil)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = context.DeadlineExceeded
+		}
+		r.errors[name+": "+err.Error()]++
+	}
+	return err
+}
+
+func (r *recorder) journey(name string, elapsed time.Duration, failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	observe(r.journeys, name, elapsed, failed)
+}
+
+func observe(m map[string]*Stats, name string, elapsed time.Duration, failed bool) {
+	s, ok := m[name]
+	if !ok {
+		s = &Stats{Name: name}
+		m[name] = s
+	}
+	s.Latency.Observe(elapsed)
+	if failed {
+		s.Errors++
+	}
+}
+
+func (r *recorder) report(elapsed time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return newReport(elapsed, r.steps, r.journeys, r.errors)
+}
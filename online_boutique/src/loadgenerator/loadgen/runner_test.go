@@ -0,0 +1,249 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadgen
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeTarget struct {
+	mu    sync.Mutex
+	calls map[string]int
+	// down fails that many session starts.
+	down int
+}
+
+func (t *fakeTarget) NewSession(context.Context) (Session, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.down > 0 {
+		t.down--
+		return nil, errors.New("frontend down")
+	}
+	t.calls["session"]++
+	return fakeSession{t}, nil
+}
+
+type fakeSession struct{ t *fakeTarget }
+
+func (s fakeSession) call(name string) {
+	s.t.mu.Lock()
+	defer s.t.mu.Unlock()
+	s.t.calls[name]++
+}
+
+func (s fakeSession) Home(context.Context) error { s.call("home"); return nil }
+
+func (s fakeSession) BrowseProduct(_ context.Context, id string) error {
+	s.call("browse")
+	if id == "" {
+		return errors.New("no product")
+	}
+	return nil
+}
+
+func (s fakeSession) SetCurrency(context.Context, string) error { s.call("currency"); return nil }
+
+func (s fakeSession) ViewCart(context.Context) error { s.call("cart"); return nil }
+
+func (s fakeSession) AddToCart(_ context.Context, id string, _ int) error {
+	s.call("add")
+	if id == "" {
+		return errors.New("no product")
+	}
+	return nil
+}
+
+func (s fakeSession) Checkout(context.Context, Order) error {
+	s.call("checkout")
+	return errors.New("payment declined")
+}
+
+func TestRun(t *testing.T) {
+	target := &fakeTarget{calls: make(map[string]int)}
+	report, err := Run(context.Background(), target, DefaultJourneys(DefaultProducts), Options{
+		Users:  4,
+		Stages: []Stage{{Duration: 500 * time.Millisecond, Target: 200}},
+		Seed:   1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 100 journeys are asked for, the pacer may fall a little behind.
+	var journeys int64
+	for _, j := range report.Journeys {
+		journeys += j.Latency.Count()
+	}
+	if journeys < 70 || journeys > 110 {
+		t.Errorf("ran %d journeys, want about 100", journeys)
+	}
+
+	checkout := report.Step("checkout")
+	if checkout == nil || checkout.ErrorRate() != 1 {
+		t.Fatalf("checkout stats %+v, want every checkout failed", checkout)
+	}
+	if browse := report.Step("browse"); browse.Errors != 0 {
+		t.Errorf("browse failed %d times", browse.Errors)
+	}
+	if n := report.Errors["checkout: payment declined"]; n != checkout.Errors {
+		t.Errorf("recorded %d declines, want %d", n, checkout.Errors)
+	}
+	if target.calls["add"] < target.calls["checkout"] {
+		t.Errorf("calls %v, want an add to cart before every checkout", target.calls)
+	}
+
+	outcomes := report.Check([]Threshold{
+		mustThreshold(t, "browse:error_rate<0.01"),
+		mustThreshold(t, "error_rate<0.01"),
+		mustThreshold(t, "journey:browse:p99<1s"),
+		mustThreshold(t, "search:p99<1s"),
+	})
+	for i, want := range []bool{true, false, true, false} {
+		if outcomes[i].Passed != want {
+			t.Errorf("%s passed = %v, want %v", outcomes[i].Threshold, outcomes[i].Passed, want)
+		}
+	}
+
+	var b strings.Builder
+	if err := report.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "payment declined") {
+		t.Errorf("report misses the errors:\n%s", b.String())
+	}
+}
+
+func TestRunRetriesSessions(t *testing.T) {
+	target := &fakeTarget{calls: make(map[string]int), down: 6}
+	report, err := Run(context.Background(), target, DefaultJourneys(DefaultProducts), Options{
+		Users:    3,
+		Duration: time.Second,
+		Think:    10 * time.Millisecond,
+		Seed:     1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := target.calls["session"]; n != 3 {
+		t.Errorf("started %d sessions, want one per shopper", n)
+	}
+	if s := report.Step("new-session"); s == nil || s.Errors != 6 {
+		t.Errorf("new-session stats %+v, want 6 errors", s)
+	}
+}
+
+func mustThreshold(t *testing.T, s string) Threshold {
+	t.Helper()
+	th, err := ParseThreshold(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return th
+}
+
+func TestParseThreshold(t *testing.T) {
+	tests := []struct {
+		in     string
+		step   string
+		metric string
+		limit  float64
+	}{
+		{"p99<500ms", "", "p99", 0.5},
+		{"error_rate < 0.01", "", "error_rate", 0.01},
+		{"checkout:p95<2s", "checkout", "p95", 2},
+		{"journey:checkout:max<3s", "journey:checkout", "max", 3},
+	}
+	for _, tt := range tests {
+		th, err := ParseThreshold(tt.in)
+		if err != nil {
+			t.Errorf("ParseThreshold(%q): %v", tt.in, err)
+			continue
+		}
+		if th.Step != tt.step || th.Metric != tt.metric || th.Limit != tt.limit {
+			t.Errorf("ParseThreshold(%q) = %+v", tt.in, th)
+		}
+	}
+	for _, bad := range []string{"p99", "p42<1s", "p99<fast", "error_rate<low"} {
+		if _, err := ParseThreshold(bad); err == nil {
+			t.Errorf("ParseThreshold(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestHTTPTarget(t *testing.T) {
+	var mu sync.Mutex
+	var seen []string
+	frontend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		seen = append(seen, r.Method+" "+r.URL.Path+" "+r.PostForm.Encode())
+		if _, err := r.Cookie("session"); err != nil && r.URL.Path != "/" {
+			http.Error(w, "no session", http.StatusBadRequest)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "1"})
+		if r.Method == http.MethodPost && r.URL.Path == "/cart" {
+			http.Redirect(w, r, "/cart", http.StatusFound)
+			return
+		}
+		if r.URL.Path == "/cart/checkout" {
+			http.Error(w, "declined", http.StatusInternalServerError)
+		}
+	}))
+	defer frontend.Close()
+
+	ctx := context.Background()
+	s, err := (&HTTPTarget{BaseURL: frontend.URL + "/"}).NewSession(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Home(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddToCart(ctx, "OLJCESPC7Z", 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Checkout(ctx, DefaultOrder); err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("Checkout() = %v, want the 500 of the frontend", err)
+	}
+
+	want := []string{
+		"GET / ",
+		"POST /cart product_id=OLJCESPC7Z&quantity=2",
+		"GET /cart ",
+	}
+	for i, w := range want {
+		if seen[i] != w {
+			t.Errorf("request %d = %q, want %q", i, seen[i], w)
+		}
+	}
+	if !strings.HasPrefix(seen[3], "POST /cart/checkout ") || !strings.Contains(seen[3], "credit_card_number=4432-8015-6152-0454") {
+		t.Errorf("checkout request %q", seen[3])
+	}
+}
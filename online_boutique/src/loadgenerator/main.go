@@ -0,0 +1,215 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command loadgenerator puts the online boutique under the load of
+// shoppers going through weighted journeys, either through the frontend or
+// directly against the dubbo services, and reports latencies and error
+// rates. Thresholds turn the report into a pass or fail, e.g. for CI:
+//
+//	go run . -users 20 -stages 10s:0,1m:20 -threshold 'p99<500ms' -threshold 'error_rate<0.01'
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"dubbo.apache.org/dubbo-go/v3"
+	"dubbo.apache.org/dubbo-go/v3/client"
+	_ "dubbo.apache.org/dubbo-go/v3/imports"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/loadgenerator/loadgen"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+type thresholdsFlag []loadgen.Threshold
+
+func (t *thresholdsFlag) String() string { return fmt.Sprint(*t) }
+
+func (t *thresholdsFlag) Set(s string) error {
+	th, err := loadgen.ParseThreshold(s)
+	if err != nil {
+		return err
+	}
+	*t = append(*t, th)
+	return nil
+}
+
+func main() {
+	var (
+		target     = flag.String("target", "http", "http to go through the frontend, dubbo to call the services")
+		frontend   = flag.String("frontend", frontendAddr(), "URL of the frontend")
+		dubboURL   = flag.String("dubbo-url", "", "tri:// URL serving all services, e.g. the all-in-one launcher; the registry is used when empty")
+		users      = flag.Int("users", 10, "shoppers running journeys concurrently")
+		rampUp     = flag.Duration("ramp-up", 0, "spread the start of the shoppers over this long")
+		rps        = flag.Float64("rps", 0, "journeys per second, 0 to run them back to back")
+		duration   = flag.Duration("duration", time.Minute, "how long to run, unless -stages is set")
+		stages     = flag.String("stages", "", "load profile as duration:rps pairs, e.g. 30s:0,1m:50,30s:50")
+		think      = flag.Duration("think", 0, "pause of a shopper between journeys")
+		timeout    = flag.Duration("timeout", 10*time.Second, "timeout of every step")
+		products   = flag.String("products", strings.Join(loadgen.DefaultProducts, ","), "product IDs to browse")
+		seed       = flag.Int64("seed", time.Now().UnixNano(), "random seed")
+		jsonPath   = flag.String("json", "", "also write the report as JSON to this file")
+		thresholds thresholdsFlag
+	)
+	flag.Var(&thresholds, "threshold", "limit like p99<500ms, error_rate<0.01 or checkout:p95<2s, repeatable")
+	flag.Parse()
+
+	opts := loadgen.Options{
+		Users:    *users,
+		RampUp:   *rampUp,
+		Duration: *duration,
+		Think:    *think,
+		Timeout:  *timeout,
+		Seed:     *seed,
+	}
+	switch {
+	case *stages != "":
+		s, err := loadgen.ParseStages(*stages)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		opts.Stages = s
+	case *rps > 0:
+		opts.Stages = []loadgen.Stage{{Duration: *duration, Target: *rps}}
+	}
+
+	var t loadgen.Target
+	switch *target {
+	case "http":
+		t = &loadgen.HTTPTarget{BaseURL: *frontend}
+	case "dubbo":
+		dt, err := newDubboTarget(*dubboURL)
+		if err != nil {
+			fatalf("connecting to the services: %v", err)
+		}
+		t = dt
+	default:
+		fatalf("unknown target %q", *target)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	report, err := loadgen.Run(ctx, t, loadgen.DefaultJourneys(strings.Split(*products, ",")), opts)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	if err := report.WriteText(os.Stdout); err != nil {
+		fatalf("%v", err)
+	}
+
+	outcomes := report.Check(thresholds)
+	passed := true
+	for _, o := range outcomes {
+		status := "PASS"
+		if !o.Passed {
+			status, passed = "FAIL", false
+		}
+		if o.Reason != "" {
+			fmt.Printf("%s %s: %s\n", status, o.Threshold, o.Reason)
+		} else {
+			fmt.Printf("%s %s (%.4g)\n", status, o.Threshold, o.Value)
+		}
+	}
+
+	if *jsonPath != "" {
+		data, err := json.MarshalIndent(report.Summary(outcomes), "", "  ")
+		if err != nil {
+			fatalf("%v", err)
+		}
+		if err := os.WriteFile(*jsonPath, append(data, '\n'), 0o644); err != nil {
+			fatalf("%v", err)
+		}
+	}
+	if !passed {
+		os.Exit(1)
+	}
+}
+
+func frontendAddr() string {
+	if addr := os.Getenv("FRONTEND_ADDR"); addr != "" {
+		return "http://" + addr
+	}
+	return "http://localhost:8090"
+}
+
+func newDubboTarget(url string) (*loadgen.DubboTarget, error) {
+	var cli *client.Client
+	if url != "" {
+		ins, err := dubbo.NewInstance(dubbo.WithName("loadgenerator"))
+		if err != nil {
+			return nil, err
+		}
+		if cli, err = ins.NewClient(client.WithClientURL(url)); err != nil {
+			return nil, err
+		}
+	} else {
+		regAddr := os.Getenv("DUBBO_REGISTRY_ADDRESS")
+		if regAddr == "" {
+			regAddr = "127.0.0.1:2181"
+		}
+		ins, err := dubbo.NewInstance(
+			dubbo.WithName("loadgenerator"),
+			dubbo.WithRegistry(
+				registry.WithZookeeper(),
+				registry.WithAddress(regAddr),
+			),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if cli, err = ins.NewClient(); err != nil {
+			return nil, err
+		}
+	}
+
+	t := &loadgen.DubboTarget{}
+	var err error
+	if t.Ad, err = pb.NewAdService(cli); err != nil {
+		return nil, err
+	}
+	if t.Cart, err = pb.NewCartService(cli); err != nil {
+		return nil, err
+	}
+	if t.Checkout, err = pb.NewCheckoutService(cli); err != nil {
+		return nil, err
+	}
+	if t.Currency, err = pb.NewCurrencyService(cli); err != nil {
+		return nil, err
+	}
+	if t.ProductCatalog, err = pb.NewProductCatalogService(cli); err != nil {
+		return nil, err
+	}
+	if t.Recommendation, err = pb.NewRecommendationService(cli); err != nil {
+		return nil, err
+	}
+	if t.Shipping, err = pb.NewShippingService(cli); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "loadgenerator: "+format+"\n", args...)
+	os.Exit(2)
+}
@@ -19,11 +19,19 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
 	Port    int
 	Tracing TracingConfig
+	// AcceptedCardBrands lists the card brands, as named by go-credit-card,
+	// that Charge accepts.
+	AcceptedCardBrands []string
+	Simulator          SimulatorConfig
 }
 
 type TracingConfig struct {
@@ -35,8 +43,15 @@ type JaegerConfig struct {
 	URL string
 }
 
+// SimulatorConfig injects faults into the local payment processor.
+type SimulatorConfig struct {
+	Latency     time.Duration
+	FailureRate float64
+}
+
 var cfg *Config = &Config{
-	Port: 50051,
+	Port:               50051,
+	AcceptedCardBrands: []string{"visa", "mastercard"},
 }
 
 func Address() string {
@@ -47,7 +62,45 @@ func Tracing() TracingConfig {
 	return cfg.Tracing
 }
 
-// Load TODO:
+func AcceptedCardBrands() []string {
+	return cfg.AcceptedCardBrands
+}
+
+func Simulator() SimulatorConfig {
+	return cfg.Simulator
+}
+
+// Load overrides the defaults from the environment:
+//
+//	PAYMENT_ACCEPTED_CARDS            comma separated card brands, e.g. "visa,mastercard,amex"
+//	PAYMENT_SIMULATOR_LATENCY         latency added to every processor call, e.g. "200ms"
+//	PAYMENT_SIMULATOR_FAILURE_RATE    probability between 0 and 1 that a processor call fails
 func Load() error {
+	if v := os.Getenv("PAYMENT_ACCEPTED_CARDS"); v != "" {
+		var brands []string
+		for _, b := range strings.Split(v, ",") {
+			if b = strings.ToLower(strings.TrimSpace(b)); b != "" {
+				brands = append(brands, b)
+			}
+		}
+		if len(brands) == 0 {
+			return fmt.Errorf("PAYMENT_ACCEPTED_CARDS %q names no card brand", v)
+		}
+		cfg.AcceptedCardBrands = brands
+	}
+	if v := os.Getenv("PAYMENT_SIMULATOR_LATENCY"); v != "" {
+		latency, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid PAYMENT_SIMULATOR_LATENCY: %w", err)
+		}
+		cfg.Simulator.Latency = latency
+	}
+	if v := os.Getenv("PAYMENT_SIMULATOR_FAILURE_RATE"); v != "" {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid PAYMENT_SIMULATOR_FAILURE_RATE: %w", err)
+		}
+		cfg.Simulator.FailureRate = rate
+	}
 	return nil
 }
@@ -19,49 +19,115 @@ package handler
 
 import (
 	"context"
-	"dubbo.apache.org/dubbo-go/v3/config"
 	"errors"
-	payment "github.com/apache/dubbo-go-samples/online_boutique_demo/paymentservice/proto"
+	"fmt"
+	"strconv"
+	"strings"
+
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 	"github.com/dubbogo/gost/log/logger"
 	creditcard "github.com/durango/go-credit-card"
-	"github.com/google/uuid"
-	"strconv"
+
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/paymentservice/processor"
+	payment "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
 )
 
-type PaymentService struct{}
+type PaymentService struct {
+	Processor      processor.PaymentProcessor
+	AcceptedBrands map[string]bool
+}
 
+func NewPaymentService(p processor.PaymentProcessor, acceptedBrands []string) *PaymentService {
+	s := &PaymentService{
+		Processor:      p,
+		AcceptedBrands: make(map[string]bool, len(acceptedBrands)),
+	}
+	for _, b := range acceptedBrands {
+		s.AcceptedBrands[b] = true
+	}
+	return s
+}
+
+// Charge authorizes the amount on the card and captures it right away. If the
+// capture fails the authorization is voided so that no funds stay on hold.
 func (s *PaymentService) Charge(ctx context.Context, in *payment.ChargeRequest) (*payment.ChargeResponse, error) {
+	if in.CreditCard == nil {
+		return nil, triple.NewError(triple.CodeInvalidArgument, errors.New("credit card is required"))
+	}
+	if in.Amount == nil || in.Amount.Units < 0 || in.Amount.Nanos < 0 || (in.Amount.Units == 0 && in.Amount.Nanos == 0) {
+		return nil, triple.NewError(triple.CodeInvalidArgument, errors.New("amount must be positive"))
+	}
 	card := creditcard.Card{
-		Number: in.CreditCard.CreditCardNumber,
+		Number: strings.NewReplacer(" ", "", "-", "").Replace(in.CreditCard.CreditCardNumber),
 		Cvv:    strconv.FormatInt(int64(in.CreditCard.CreditCardCvv), 10),
 		Year:   strconv.FormatInt(int64(in.CreditCard.CreditCardExpirationYear), 10),
 		Month:  strconv.FormatInt(int64(in.CreditCard.CreditCardExpirationMonth), 10),
 	}
+	masked := processor.MaskPAN(card.Number)
 
-	//Verify credit card information
-	if err := card.Validate(); err != nil {
-		logger.Errorf("Invalid credit card: %v", err)
-		return nil, errors.New("invalid credit card")
+	// Verify credit card information, test numbers are welcome as the
+	// processor is a simulator.
+	if err := card.Validate(true); err != nil {
+		logger.Errorf("Invalid credit card %s: %v", masked, err)
+		return nil, triple.NewError(triple.CodeInvalidArgument, errors.New("invalid credit card"))
+	}
+	if err := card.Method(); err != nil || !s.AcceptedBrands[card.Company.Short] {
+		logger.Errorf("Unaccepted credit card type %q for %s", card.Company.Short, masked)
+		return nil, triple.NewError(triple.CodeInvalidArgument, fmt.Errorf("unaccepted credit card type %q", card.Company.Long))
 	}
 
-	// TODO:
-	// Only VISA and mastercard is accepted, other card types (AMEX, dinersclub) will
-	// throw UnacceptedCreditCard error.
-
-	//if card.Company.Short != "visa" && card.Company.Short != "mastercard" {
-	//	err := errors.New("unaccepted credit card type")
-	//	logger.Errorf("Unaccept credit card type: %s", card.Company.Short)
-	//	return nil, err
-	//}
-
-	logger.Infof(`Transaction processed: %s, Amount: %s%d.%d`, in.CreditCard.CreditCardNumber, in.Amount.CurrencyCode, in.Amount.Units, in.Amount.Nanos)
+	auth, err := s.Processor.Authorize(ctx, processor.Card{Number: card.Number, Brand: card.Company.Short}, in.Amount)
+	if err != nil {
+		logger.Errorf("Authorization failed for %s: %v", masked, err)
+		return nil, toTripleError(err)
+	}
+	transactionID, err := s.Processor.Capture(ctx, auth.ID)
+	if err != nil {
+		logger.Errorf("Capture of authorization %s failed: %v", auth.ID, err)
+		// the caller's context may be done already, the hold must be released anyway
+		if verr := s.Processor.Void(context.Background(), auth.ID); verr != nil {
+			logger.Warnf("Failed to void authorization %s: %v", auth.ID, verr)
+		}
+		return nil, toTripleError(err)
+	}
 
-	transactionID := uuid.New().String()
+	logger.Infof("Transaction processed: %s, card: %s, amount: %s %d.%09d",
+		transactionID, masked, in.Amount.CurrencyCode, in.Amount.Units, in.Amount.Nanos)
 	return &payment.ChargeResponse{
 		TransactionId: transactionID,
 	}, nil
 }
 
-func init() {
-	config.SetProviderService(new(PaymentService))
+// Refund gives the amount of a captured charge back to the card.
+func (s *PaymentService) Refund(ctx context.Context, in *payment.RefundRequest) (*payment.RefundResponse, error) {
+	if in.TransactionId == "" {
+		return nil, triple.NewError(triple.CodeInvalidArgument, errors.New("transaction id is required"))
+	}
+	if err := s.Processor.Refund(ctx, in.TransactionId); err != nil {
+		logger.Errorf("Refund of transaction %s failed: %v", in.TransactionId, err)
+		return nil, toTripleError(err)
+	}
+	logger.Infof("Transaction refunded: %s", in.TransactionId)
+	return &payment.RefundResponse{}, nil
+}
+
+// toTripleError gives a processor failure the triple code callers act on.
+func toTripleError(err error) error {
+	var decline *processor.DeclineError
+	switch {
+	case errors.As(err, &decline):
+		return triple.NewError(triple.CodeFailedPrecondition, decline)
+	case errors.Is(err, processor.ErrUnavailable):
+		return triple.NewError(triple.CodeUnavailable, err)
+	case errors.Is(err, processor.ErrNotFound):
+		return triple.NewError(triple.CodeNotFound, err)
+	case errors.Is(err, processor.ErrInvalidState):
+		return triple.NewError(triple.CodeFailedPrecondition, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return triple.NewError(triple.CodeDeadlineExceeded, err)
+	case errors.Is(err, context.Canceled):
+		return triple.NewError(triple.CodeCanceled, err)
+	default:
+		return triple.NewError(triple.CodeInternal, err)
+	}
 }
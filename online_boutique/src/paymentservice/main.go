@@ -22,8 +22,11 @@ import (
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
 	"dubbo.apache.org/dubbo-go/v3/protocol"
 	"dubbo.apache.org/dubbo-go/v3/registry"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/paymentservice/config"
 	"github.com/apache/dubbo-go-samples/online_boutique_demo/paymentservice/handler"
-	payment "github.com/apache/dubbo-go-samples/online_boutique_demo/paymentservice/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/paymentservice/processor"
+	payment "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/proto/tracing"
 	"github.com/dubbogo/gost/log/logger"
 	"os"
 )
@@ -34,8 +37,25 @@ func main() {
 		regAddr = "127.0.0.1:2181"
 	}
 
+	if err := config.Load(); err != nil {
+		panic(err)
+	}
+	simulator, err := processor.NewSimulator(processor.SimulatorOptions{
+		Latency:     config.Simulator().Latency,
+		FailureRate: config.Simulator().FailureRate,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	withTracing, err := tracing.Option()
+	if err != nil {
+		panic(err)
+	}
+
 	ins, err := dubbo.NewInstance(
 		dubbo.WithName("paymentservice"),
+		withTracing,
 		dubbo.WithRegistry(
 			registry.WithZookeeper(),
 			registry.WithAddress(regAddr),
@@ -55,7 +75,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := payment.RegisterPaymentServiceHandler(srv, &handler.PaymentService{}); err != nil {
+	if err := payment.RegisterPaymentServiceHandler(srv, handler.NewPaymentService(simulator, config.AcceptedCardBrands())); err != nil {
 		panic(err)
 	}
 	if err := srv.Serve(); err != nil {
@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package processor
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	payment "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+var (
+	// ErrUnavailable is returned when the processor cannot be reached. The
+	// operation may be retried.
+	ErrUnavailable = errors.New("payment processor unavailable")
+	// ErrNotFound is returned for unknown authorization or transaction IDs.
+	ErrNotFound = errors.New("payment not found")
+	// ErrInvalidState is returned when an operation does not apply to the
+	// current state of a payment, e.g. capturing a voided authorization.
+	ErrInvalidState = errors.New("invalid payment state")
+)
+
+// DeclineError is returned when the issuer refuses an authorization.
+type DeclineError struct {
+	Reason string
+}
+
+func (e *DeclineError) Error() string {
+	return "card declined: " + e.Reason
+}
+
+// Card is the payment method presented for an authorization. Number must be
+// the bare PAN, without spaces or dashes.
+type Card struct {
+	Number string
+	Brand  string
+}
+
+// Authorization is a hold on the funds of a card.
+type Authorization struct {
+	ID     string
+	Brand  string
+	Last4  string
+	Amount *payment.Money
+}
+
+// PaymentProcessor moves money on behalf of the shop. Funds are first
+// authorized, then either captured into a transaction or voided. A captured
+// transaction can be refunded.
+type PaymentProcessor interface {
+	Authorize(ctx context.Context, card Card, amount *payment.Money) (*Authorization, error)
+	// Capture settles an authorization and returns the transaction ID.
+	Capture(ctx context.Context, authorizationID string) (string, error)
+	Void(ctx context.Context, authorizationID string) error
+	Refund(ctx context.Context, transactionID string) error
+}
+
+// MaskPAN hides all but the last four digits of a card number so that it can
+// be logged.
+func MaskPAN(number string) string {
+	if len(number) <= 4 {
+		return strings.Repeat("*", len(number))
+	}
+	return strings.Repeat("*", len(number)-4) + lastFour(number)
+}
+
+func lastFour(number string) string {
+	if len(number) < 4 {
+		return ""
+	}
+	return number[len(number)-4:]
+}
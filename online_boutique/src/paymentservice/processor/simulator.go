@@ -0,0 +1,186 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package processor
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	payment "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+// Test card numbers the simulator always declines, with the decline reason.
+// Any other valid number is approved.
+var declinedCards = map[string]string{
+	"4000000000000002": "generic decline",
+	"4000000000009995": "insufficient funds",
+	"4000000000009987": "lost card",
+	"4000000000009979": "stolen card",
+	"5100000000000198": "do not honor",
+}
+
+type paymentState int
+
+const (
+	stateAuthorized paymentState = iota
+	stateCaptured
+	stateVoided
+	stateRefunded
+)
+
+func (s paymentState) String() string {
+	return [...]string{"authorized", "captured", "voided", "refunded"}[s]
+}
+
+type simulatedPayment struct {
+	auth          Authorization
+	state         paymentState
+	transactionID string
+}
+
+// SimulatorOptions injects faults into the simulator.
+type SimulatorOptions struct {
+	// Latency is added to every call.
+	Latency time.Duration
+	// FailureRate is the probability, between 0 and 1, that a call fails
+	// with ErrUnavailable.
+	FailureRate float64
+}
+
+// Simulator is an in-memory PaymentProcessor for local runs and tests.
+type Simulator struct {
+	opts SimulatorOptions
+
+	mu           sync.Mutex
+	rnd          *rand.Rand
+	payments     map[string]*simulatedPayment
+	transactions map[string]*simulatedPayment
+}
+
+func NewSimulator(opts SimulatorOptions) (*Simulator, error) {
+	if opts.Latency < 0 {
+		return nil, fmt.Errorf("negative simulator latency %s", opts.Latency)
+	}
+	if opts.FailureRate < 0 || opts.FailureRate > 1 {
+		return nil, fmt.Errorf("simulator failure rate %v is not between 0 and 1", opts.FailureRate)
+	}
+	return &Simulator{
+		opts:         opts,
+		rnd:          rand.New(rand.NewSource(time.Now().UnixNano())),
+		payments:     make(map[string]*simulatedPayment),
+		transactions: make(map[string]*simulatedPayment),
+	}, nil
+}
+
+func (s *Simulator) Authorize(ctx context.Context, card Card, amount *payment.Money) (*Authorization, error) {
+	if err := s.call(ctx); err != nil {
+		return nil, err
+	}
+	if reason, ok := declinedCards[card.Number]; ok {
+		return nil, &DeclineError{Reason: reason}
+	}
+	p := &simulatedPayment{
+		auth: Authorization{
+			ID:     uuid.New().String(),
+			Brand:  card.Brand,
+			Last4:  lastFour(card.Number),
+			Amount: amount,
+		},
+		state: stateAuthorized,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.payments[p.auth.ID] = p
+	auth := p.auth
+	return &auth, nil
+}
+
+func (s *Simulator) Capture(ctx context.Context, authorizationID string) (string, error) {
+	if err := s.call(ctx); err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, err := s.transition(s.payments[authorizationID], stateAuthorized, stateCaptured)
+	if err != nil {
+		return "", err
+	}
+	p.transactionID = uuid.New().String()
+	s.transactions[p.transactionID] = p
+	return p.transactionID, nil
+}
+
+func (s *Simulator) Void(ctx context.Context, authorizationID string) error {
+	if err := s.call(ctx); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.transition(s.payments[authorizationID], stateAuthorized, stateVoided)
+	return err
+}
+
+func (s *Simulator) Refund(ctx context.Context, transactionID string) error {
+	if err := s.call(ctx); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.transition(s.transactions[transactionID], stateCaptured, stateRefunded)
+	return err
+}
+
+// transition moves p from one state to another. It must be called with s.mu held.
+func (s *Simulator) transition(p *simulatedPayment, from, to paymentState) (*simulatedPayment, error) {
+	if p == nil {
+		return nil, ErrNotFound
+	}
+	if p.state != from {
+		return nil, fmt.Errorf("%w: cannot move a %s payment to %s", ErrInvalidState, p.state, to)
+	}
+	p.state = to
+	return p, nil
+}
+
+// call applies the configured latency and failure injection.
+func (s *Simulator) call(ctx context.Context) error {
+	if s.opts.Latency > 0 {
+		timer := time.NewTimer(s.opts.Latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if s.opts.FailureRate > 0 {
+		s.mu.Lock()
+		fail := s.rnd.Float64() < s.opts.FailureRate
+		s.mu.Unlock()
+		if fail {
+			return ErrUnavailable
+		}
+	}
+	return nil
+}
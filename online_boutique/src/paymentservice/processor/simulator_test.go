@@ -0,0 +1,127 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package processor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	payment "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+var amount = &payment.Money{CurrencyCode: "USD", Units: 42}
+
+func TestSimulatorLifecycle(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewSimulator(SimulatorOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	auth, err := s.Authorize(ctx, Card{Number: "4242424242424242", Brand: "visa"}, amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if auth.Last4 != "4242" {
+		t.Errorf("Last4 = %q, want 4242", auth.Last4)
+	}
+	txID, err := s.Capture(ctx, auth.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Void(ctx, auth.ID); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("Void after capture = %v, want ErrInvalidState", err)
+	}
+	if err := s.Refund(ctx, txID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Refund(ctx, txID); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("second Refund = %v, want ErrInvalidState", err)
+	}
+
+	auth, err = s.Authorize(ctx, Card{Number: "5555555555554444", Brand: "mastercard"}, amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Void(ctx, auth.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Capture(ctx, auth.ID); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("Capture after void = %v, want ErrInvalidState", err)
+	}
+	if _, err := s.Capture(ctx, "unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Capture of an unknown authorization = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSimulatorDeclines(t *testing.T) {
+	s, err := NewSimulator(SimulatorOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for number, reason := range declinedCards {
+		_, err := s.Authorize(context.Background(), Card{Number: number}, amount)
+		var decline *DeclineError
+		if !errors.As(err, &decline) || decline.Reason != reason {
+			t.Errorf("Authorize(%s) = %v, want decline %q", number, err, reason)
+		}
+	}
+}
+
+func TestSimulatorFaultInjection(t *testing.T) {
+	s, err := NewSimulator(SimulatorOptions{FailureRate: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Authorize(context.Background(), Card{Number: "4242424242424242"}, amount); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Authorize with failure rate 1 = %v, want ErrUnavailable", err)
+	}
+
+	s, err = NewSimulator(SimulatorOptions{Latency: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.Authorize(ctx, Card{Number: "4242424242424242"}, amount); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Authorize past the deadline = %v, want context.DeadlineExceeded", err)
+	}
+
+	if _, err := NewSimulator(SimulatorOptions{FailureRate: 1.5}); err == nil {
+		t.Errorf("NewSimulator accepted a failure rate above 1")
+	}
+}
+
+func TestMaskPAN(t *testing.T) {
+	tests := []struct {
+		number string
+		want   string
+	}{
+		{"4242424242424242", "************4242"},
+		{"378282246310005", "***********0005"},
+		{"123", "***"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := MaskPAN(tt.number); got != tt.want {
+			t.Errorf("MaskPAN(%q) = %q, want %q", tt.number, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/dubbogo/gost/log/logger"
+	"github.com/fsnotify/fsnotify"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// watchDebounce collapses the burst of events editors emit when saving a file
+// into a single reload.
+const watchDebounce = 200 * time.Millisecond
+
+var (
+	catalogVersionGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "productcatalog_catalog_version",
+		Help: "Version of the product catalog snapshot currently being served.",
+	})
+	catalogProductsGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "productcatalog_catalog_products",
+		Help: "Number of products in the catalog snapshot currently being served.",
+	})
+	catalogReloadsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "productcatalog_catalog_reloads_total",
+		Help: "Catalog reload attempts partitioned by result.",
+	}, []string{"result"})
+)
+
+func init() {
+	prometheus.MustRegister(catalogVersionGauge, catalogProductsGauge, catalogReloadsCounter)
+}
+
+// catalogSnapshot is one validated version of the catalog. Snapshots are never
+// modified after creation, a reload always produces a new one.
+type catalogSnapshot struct {
+	version  int64
+	checksum [sha256.Size]byte
+	loadedAt time.Time
+	*catalogIndex
+}
+
+// loadCatalog reads and validates the catalog file at path.
+func loadCatalog(path string) ([]*pb.Product, [sha256.Size]byte, error) {
+	var sum [sha256.Size]byte
+	catalogJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, sum, fmt.Errorf("failed to open product catalog json file: %w", err)
+	}
+	catalog := &pb.ListProductsResponse{}
+	if err := protojson.Unmarshal(catalogJSON, catalog); err != nil {
+		return nil, sum, fmt.Errorf("failed to parse the catalog JSON: %w", err)
+	}
+	if err := validateCatalog(catalog.Products); err != nil {
+		return nil, sum, err
+	}
+	return catalog.Products, sha256.Sum256(catalogJSON), nil
+}
+
+// validateCatalog rejects catalogs that would break lookups or pricing.
+func validateCatalog(products []*pb.Product) error {
+	if len(products) == 0 {
+		return fmt.Errorf("catalog contains no products")
+	}
+	seen := make(map[string]bool, len(products))
+	for i, p := range products {
+		if p.Id == "" {
+			return fmt.Errorf("product #%d has no id", i)
+		}
+		if seen[p.Id] {
+			return fmt.Errorf("duplicate product id %s", p.Id)
+		}
+		seen[p.Id] = true
+		if p.Name == "" {
+			return fmt.Errorf("product %s has no name", p.Id)
+		}
+		if p.PriceUsd == nil || p.PriceUsd.CurrencyCode != "USD" {
+			return fmt.Errorf("product %s has no USD price", p.Id)
+		}
+		if _, err := priceBound(p.PriceUsd); err != nil || toNanos(p.PriceUsd) < 0 {
+			return fmt.Errorf("product %s has an invalid price", p.Id)
+		}
+	}
+	return nil
+}
+
+// catalogWatcher reloads the catalog whenever its file changes on disk.
+type catalogWatcher struct {
+	watcher *fsnotify.Watcher
+	timer   *time.Timer
+	mu      sync.Mutex
+}
+
+func newCatalogWatcher(path string, reload func()) (*catalogWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	// watch the directory rather than the file, so that editors and config
+	// map updates which replace the file through a rename are noticed too
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	w := &catalogWatcher{watcher: watcher}
+	target := filepath.Clean(path)
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != target || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+					continue
+				}
+				w.schedule(reload)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				logger.Warnf("catalog watcher error: %v", err)
+			}
+		}
+	}()
+	return w, nil
+}
+
+func (w *catalogWatcher) schedule(reload func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(watchDebounce, reload)
+}
+
+func (w *catalogWatcher) Close() error {
+	w.mu.Lock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.mu.Unlock()
+	return w.watcher.Close()
+}
@@ -0,0 +1,107 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+const (
+	oneProduct = `{"products": [{"id": "A", "name": "Mug", "priceUsd": {"currencyCode": "USD", "units": 8}}]}`
+	twoProduct = `{"products": [{"id": "A", "name": "Mug", "priceUsd": {"currencyCode": "USD", "units": 8}},
+		{"id": "B", "name": "Watch", "priceUsd": {"currencyCode": "USD", "units": 109}}]}`
+	duplicateID = `{"products": [{"id": "A", "name": "Mug", "priceUsd": {"currencyCode": "USD", "units": 8}},
+		{"id": "A", "name": "Watch", "priceUsd": {"currencyCode": "USD", "units": 109}}]}`
+)
+
+func writeCatalog(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func adminContext(token string) context.Context {
+	return context.WithValue(context.Background(), constant.AttachmentKey, map[string]interface{}{
+		"authorization": []string{"Bearer " + token},
+	})
+}
+
+func TestReloadCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "products.json")
+	writeCatalog(t, path, oneProduct)
+	s, err := NewProductCatalogService(path, "secret")
+	if err != nil {
+		t.Fatalf("NewProductCatalogService failed: %v", err)
+	}
+
+	if _, err := s.ReloadCatalog(adminContext("wrong"), &pb.ReloadCatalogRequest{}); err == nil {
+		t.Errorf("ReloadCatalog with a wrong token succeeded")
+	}
+
+	// unchanged content keeps the version
+	resp, err := s.ReloadCatalog(adminContext("secret"), &pb.ReloadCatalogRequest{})
+	if err != nil || resp.CatalogVersion != 1 {
+		t.Fatalf("ReloadCatalog = %v, %v, want version 1", resp, err)
+	}
+
+	writeCatalog(t, path, twoProduct)
+	resp, err = s.ReloadCatalog(adminContext("secret"), &pb.ReloadCatalogRequest{})
+	if err != nil || resp.CatalogVersion != 2 || resp.ProductCount != 2 {
+		t.Fatalf("ReloadCatalog = %v, %v, want version 2 with 2 products", resp, err)
+	}
+
+	// an invalid catalog is rejected and version 2 keeps being served
+	for _, content := range []string{duplicateID, `{"products": [`, `{"products": []}`} {
+		writeCatalog(t, path, content)
+		if _, err := s.ReloadCatalog(adminContext("secret"), &pb.ReloadCatalogRequest{}); err == nil {
+			t.Errorf("ReloadCatalog accepted invalid catalog %s", content)
+		}
+	}
+	list, _ := s.ListProducts(context.Background(), &pb.Empty{})
+	if list.CatalogVersion != 2 || len(list.Products) != 2 {
+		t.Errorf("ListProducts = version %d with %d products, want version 2 with 2 products", list.CatalogVersion, len(list.Products))
+	}
+}
+
+func TestSearchRejectsStalePageToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "products.json")
+	writeCatalog(t, path, twoProduct)
+	s, err := NewProductCatalogService(path, "")
+	if err != nil {
+		t.Fatalf("NewProductCatalogService failed: %v", err)
+	}
+	first, err := s.SearchProducts(context.Background(), &pb.SearchProductsRequest{PageSize: 1})
+	if err != nil || first.NextPageToken == "" {
+		t.Fatalf("SearchProducts = %v, %v, want a next page", first, err)
+	}
+
+	writeCatalog(t, path, oneProduct)
+	if _, err := s.reload(); err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	if _, err := s.SearchProducts(context.Background(), &pb.SearchProductsRequest{PageSize: 1, PageToken: first.NextPageToken}); err == nil {
+		t.Errorf("SearchProducts accepted a page token from an older catalog version")
+	}
+}
@@ -0,0 +1,317 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+const (
+	// Scores a token earns per occurrence in a product's name or description.
+	nameWeight        = 3
+	descriptionWeight = 1
+
+	nanosPerUnit = 1000000000
+)
+
+var errInvalidPageToken = errors.New("invalid page token")
+
+// catalogIndex is an immutable inverted index over the products of a catalog
+// snapshot. A new index is built for every snapshot, so readers never observe
+// a partially built index.
+type catalogIndex struct {
+	products []*pb.Product
+	byID     map[string]*pb.Product
+	// postings maps a token to the score it contributes to each product,
+	// keyed by the product's position in products.
+	postings map[string]map[int]int
+	// terms holds the keys of postings in sorted order for prefix lookups.
+	terms []string
+}
+
+// searchQuery is the normalized form of a SearchProductsRequest.
+type searchQuery struct {
+	tokens     []string
+	categories map[string]bool
+	minNanos   *int64
+	maxNanos   *int64
+	order      pb.SearchSortOrder
+	pageSize   int
+	// version and offset are decoded from the page token, a zero version
+	// means the query starts at the first page.
+	version int64
+	offset  int
+}
+
+type searchHit struct {
+	pos   int
+	score int
+}
+
+func newCatalogIndex(products []*pb.Product) *catalogIndex {
+	idx := &catalogIndex{
+		products: products,
+		byID:     make(map[string]*pb.Product, len(products)),
+		postings: make(map[string]map[int]int),
+	}
+	for i, p := range products {
+		idx.byID[p.Id] = p
+		idx.add(i, p.Name, nameWeight)
+		idx.add(i, p.Description, descriptionWeight)
+	}
+	idx.terms = make([]string, 0, len(idx.postings))
+	for term := range idx.postings {
+		idx.terms = append(idx.terms, term)
+	}
+	sort.Strings(idx.terms)
+	return idx
+}
+
+func (idx *catalogIndex) add(pos int, text string, weight int) {
+	for _, token := range tokenize(text) {
+		posting, ok := idx.postings[token]
+		if !ok {
+			posting = make(map[int]int)
+			idx.postings[token] = posting
+		}
+		posting[pos] += weight
+	}
+}
+
+func (idx *catalogIndex) get(id string) (*pb.Product, bool) {
+	p, ok := idx.byID[id]
+	return p, ok
+}
+
+// search returns one page of products matching q along with the total number
+// of matches and the offset of the next page, or -1 when this is the last one.
+func (idx *catalogIndex) search(q *searchQuery) ([]*pb.Product, int, int) {
+	hits := idx.match(q.tokens)
+	filtered := hits[:0]
+	for _, h := range hits {
+		if q.accepts(idx.products[h.pos]) {
+			filtered = append(filtered, h)
+		}
+	}
+	idx.sortHits(filtered, q.order)
+
+	total := len(filtered)
+	if q.offset >= total {
+		return []*pb.Product{}, total, -1
+	}
+	end := total
+	if q.pageSize > 0 && q.offset+q.pageSize < total {
+		end = q.offset + q.pageSize
+	}
+	page := make([]*pb.Product, 0, end-q.offset)
+	for _, h := range filtered[q.offset:end] {
+		page = append(page, idx.products[h.pos])
+	}
+	next := -1
+	if end < total {
+		next = end
+	}
+	return page, total, next
+}
+
+// match returns the products containing every query token. A query token
+// matches any indexed term it is a prefix of, so "sun" finds "sunglasses".
+func (idx *catalogIndex) match(tokens []string) []searchHit {
+	if len(tokens) == 0 {
+		hits := make([]searchHit, len(idx.products))
+		for i := range idx.products {
+			hits[i] = searchHit{pos: i}
+		}
+		return hits
+	}
+
+	var scores map[int]int
+	for _, token := range tokens {
+		current := make(map[int]int)
+		for i := sort.SearchStrings(idx.terms, token); i < len(idx.terms) && strings.HasPrefix(idx.terms[i], token); i++ {
+			for pos, score := range idx.postings[idx.terms[i]] {
+				current[pos] += score
+			}
+		}
+		if scores == nil {
+			scores = current
+			continue
+		}
+		for pos := range scores {
+			if score, ok := current[pos]; ok {
+				scores[pos] += score
+			} else {
+				delete(scores, pos)
+			}
+		}
+	}
+
+	hits := make([]searchHit, 0, len(scores))
+	for pos, score := range scores {
+		hits = append(hits, searchHit{pos: pos, score: score})
+	}
+	return hits
+}
+
+func (idx *catalogIndex) sortHits(hits []searchHit, order pb.SearchSortOrder) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		a, b := idx.products[hits[i].pos], idx.products[hits[j].pos]
+		switch order {
+		case pb.SearchSortOrder_PRICE_ASC:
+			if x, y := toNanos(a.PriceUsd), toNanos(b.PriceUsd); x != y {
+				return x < y
+			}
+		case pb.SearchSortOrder_PRICE_DESC:
+			if x, y := toNanos(a.PriceUsd), toNanos(b.PriceUsd); x != y {
+				return x > y
+			}
+		case pb.SearchSortOrder_NAME_ASC:
+			if x, y := strings.ToLower(a.Name), strings.ToLower(b.Name); x != y {
+				return x < y
+			}
+		default:
+			if hits[i].score != hits[j].score {
+				return hits[i].score > hits[j].score
+			}
+		}
+		// fall back to catalog order so pages stay stable between calls
+		return hits[i].pos < hits[j].pos
+	})
+}
+
+// newSearchQuery validates a request and converts it to a searchQuery.
+func newSearchQuery(in *pb.SearchProductsRequest) (*searchQuery, error) {
+	q := &searchQuery{
+		tokens:   tokenize(in.Query),
+		order:    in.SortOrder,
+		pageSize: int(in.PageSize),
+	}
+	if q.pageSize < 0 {
+		return nil, errors.New("page size must not be negative")
+	}
+	if _, ok := pb.SearchSortOrder_name[int32(in.SortOrder)]; !ok {
+		return nil, errors.New("unknown sort order")
+	}
+	if len(in.Categories) > 0 {
+		q.categories = make(map[string]bool, len(in.Categories))
+		for _, c := range in.Categories {
+			q.categories[strings.ToLower(c)] = true
+		}
+	}
+	var err error
+	if q.minNanos, err = priceBound(in.MinPrice); err != nil {
+		return nil, err
+	}
+	if q.maxNanos, err = priceBound(in.MaxPrice); err != nil {
+		return nil, err
+	}
+	if q.minNanos != nil && q.maxNanos != nil && *q.minNanos > *q.maxNanos {
+		return nil, errors.New("min price must not exceed max price")
+	}
+	if q.version, q.offset, err = decodePageToken(in.PageToken); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+func (q *searchQuery) accepts(p *pb.Product) bool {
+	if q.categories != nil {
+		found := false
+		for _, c := range p.Categories {
+			if q.categories[strings.ToLower(c)] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	price := toNanos(p.PriceUsd)
+	if q.minNanos != nil && price < *q.minNanos {
+		return false
+	}
+	if q.maxNanos != nil && price > *q.maxNanos {
+		return false
+	}
+	return true
+}
+
+func priceBound(m *pb.Money) (*int64, error) {
+	if m == nil {
+		return nil, nil
+	}
+	if m.CurrencyCode != "" && m.CurrencyCode != "USD" {
+		return nil, errors.New("price bounds must be in USD")
+	}
+	if m.Nanos <= -nanosPerUnit || m.Nanos >= nanosPerUnit ||
+		(m.Units > 0 && m.Nanos < 0) || (m.Units < 0 && m.Nanos > 0) {
+		return nil, errors.New("invalid price bound")
+	}
+	v := toNanos(m)
+	return &v, nil
+}
+
+func toNanos(m *pb.Money) int64 {
+	return m.GetUnits()*nanosPerUnit + int64(m.GetNanos())
+}
+
+// tokenize lowercases text and splits it into letter and digit runs.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// encodePageToken ties a result offset to the catalog version it was computed
+// against, so that a cursor cannot silently skip or repeat products after a
+// reload.
+func encodePageToken(version int64, offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", version, offset)))
+}
+
+func decodePageToken(token string) (int64, int, error) {
+	if token == "" {
+		return 0, 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, 0, errInvalidPageToken
+	}
+	versionPart, offsetPart, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return 0, 0, errInvalidPageToken
+	}
+	version, err := strconv.ParseInt(versionPart, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, 0, errInvalidPageToken
+	}
+	offset, err := strconv.Atoi(offsetPart)
+	if err != nil || offset < 0 {
+		return 0, 0, errInvalidPageToken
+	}
+	return version, offset, nil
+}
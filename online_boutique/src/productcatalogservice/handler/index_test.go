@@ -0,0 +1,123 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"reflect"
+	"testing"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+func usd(u int64, n int32) *pb.Money { return &pb.Money{CurrencyCode: "USD", Units: u, Nanos: n} }
+
+func testIndex() *catalogIndex {
+	return newCatalogIndex([]*pb.Product{
+		{Id: "sg", Name: "Sunglasses", Description: "Sleek aviator sunglasses.", PriceUsd: usd(19, 990000000), Categories: []string{"accessories"}},
+		{Id: "tt", Name: "Tank Top", Description: "Cotton tank with a scooped neckline.", PriceUsd: usd(18, 990000000), Categories: []string{"clothing", "tops"}},
+		{Id: "wa", Name: "Watch", Description: "Gold-tone watch for most outfits.", PriceUsd: usd(109, 990000000), Categories: []string{"accessories"}},
+		{Id: "mg", Name: "Mug", Description: "A simple mug, pairs with the sunglasses.", PriceUsd: usd(8, 990000000), Categories: []string{"kitchen"}},
+	})
+}
+
+func ids(products []*pb.Product) []string {
+	out := make([]string, 0, len(products))
+	for _, p := range products {
+		out = append(out, p.Id)
+	}
+	return out
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *pb.SearchProductsRequest
+		want []string
+	}{
+		{"empty query keeps catalog order", &pb.SearchProductsRequest{}, []string{"sg", "tt", "wa", "mg"}},
+		{"name matches outrank description", &pb.SearchProductsRequest{Query: "sunglasses"}, []string{"sg", "mg"}},
+		{"prefix match", &pb.SearchProductsRequest{Query: "SUN"}, []string{"sg", "mg"}},
+		{"all tokens required", &pb.SearchProductsRequest{Query: "cotton tank"}, []string{"tt"}},
+		{"no match", &pb.SearchProductsRequest{Query: "bicycle"}, []string{}},
+		{"category filter", &pb.SearchProductsRequest{Categories: []string{"Accessories"}}, []string{"sg", "wa"}},
+		{"price range", &pb.SearchProductsRequest{MinPrice: usd(10, 0), MaxPrice: usd(20, 0)}, []string{"sg", "tt"}},
+		{"price ascending", &pb.SearchProductsRequest{SortOrder: pb.SearchSortOrder_PRICE_ASC}, []string{"mg", "tt", "sg", "wa"}},
+		{"price descending", &pb.SearchProductsRequest{SortOrder: pb.SearchSortOrder_PRICE_DESC}, []string{"wa", "sg", "tt", "mg"}},
+		{"name ascending", &pb.SearchProductsRequest{SortOrder: pb.SearchSortOrder_NAME_ASC}, []string{"mg", "sg", "tt", "wa"}},
+	}
+	idx := testIndex()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := newSearchQuery(tt.in)
+			if err != nil {
+				t.Fatalf("newSearchQuery(%v) failed: %v", tt.in, err)
+			}
+			got, total, _ := idx.search(q)
+			if !reflect.DeepEqual(ids(got), tt.want) {
+				t.Errorf("search(%v) = %v, want %v", tt.in, ids(got), tt.want)
+			}
+			if total != len(tt.want) {
+				t.Errorf("search(%v) total = %d, want %d", tt.in, total, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestSearchPaging(t *testing.T) {
+	idx := testIndex()
+	in := &pb.SearchProductsRequest{SortOrder: pb.SearchSortOrder_NAME_ASC, PageSize: 3}
+	var got []string
+	for pages := 0; ; pages++ {
+		if pages > 2 {
+			t.Fatalf("paging did not terminate, got %v", got)
+		}
+		q, err := newSearchQuery(in)
+		if err != nil {
+			t.Fatalf("newSearchQuery(%v) failed: %v", in, err)
+		}
+		page, _, next := idx.search(q)
+		got = append(got, ids(page)...)
+		if next < 0 {
+			break
+		}
+		in.PageToken = encodePageToken(1, next)
+	}
+	if want := []string{"mg", "sg", "tt", "wa"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("paged results = %v, want %v", got, want)
+	}
+}
+
+func TestNewSearchQueryRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *pb.SearchProductsRequest
+	}{
+		{"negative page size", &pb.SearchProductsRequest{PageSize: -1}},
+		{"bad page token", &pb.SearchProductsRequest{PageToken: "not a token"}},
+		{"non-USD bound", &pb.SearchProductsRequest{MinPrice: &pb.Money{CurrencyCode: "EUR", Units: 1}}},
+		{"inverted range", &pb.SearchProductsRequest{MinPrice: usd(20, 0), MaxPrice: usd(10, 0)}},
+		{"unknown sort order", &pb.SearchProductsRequest{SortOrder: 42}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newSearchQuery(tt.in); err == nil {
+				t.Errorf("newSearchQuery(%v) succeeded, want error", tt.in)
+			}
+		})
+	}
+}
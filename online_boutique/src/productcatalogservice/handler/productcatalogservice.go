@@ -19,44 +19,77 @@ package handler
 
 import (
 	"context"
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/productcatalogservice/proto"
-	"github.com/dubbogo/gost/log/logger"
-	"github.com/dubbogo/grpc-go/codes"
-	"github.com/dubbogo/grpc-go/status"
-	"google.golang.org/protobuf/encoding/protojson"
-	"io/ioutil"
-	"os"
-	"os/signal"
+	"crypto/subtle"
+	"errors"
+	"fmt"
 	"strings"
 	"sync"
-	"syscall"
+	"sync/atomic"
+	"time"
+
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/dubbogo/gost/log/logger"
 )
 
-var reloadCatalog bool
+var errCatalogUnchanged = errors.New("catalog unchanged")
 
 type ProductCatalogService struct {
-	sync.Mutex
-	products []*pb.Product
+	path       string
+	adminToken string
+	// reloadMu serializes reloads, readers only ever touch snapshot.
+	reloadMu sync.Mutex
+	snapshot atomic.Pointer[catalogSnapshot]
+	watcher  *catalogWatcher
+}
+
+// NewProductCatalogService loads the catalog at path and serves it until the
+// next successful reload. ReloadCatalog is only accepted from callers that
+// present adminToken, an empty token disables the RPC.
+func NewProductCatalogService(path, adminToken string) (*ProductCatalogService, error) {
+	s := &ProductCatalogService{path: path, adminToken: adminToken}
+	if _, err := s.reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Watch reloads the catalog whenever its file changes until Close is called.
+func (s *ProductCatalogService) Watch() error {
+	w, err := newCatalogWatcher(s.path, func() {
+		if _, err := s.reload(); err != nil && !errors.Is(err, errCatalogUnchanged) {
+			logger.Warnf("catalog file changed but was not reloaded: %v", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	s.watcher = w
+	return nil
+}
+
+func (s *ProductCatalogService) Close() error {
+	if s.watcher == nil {
+		return nil
+	}
+	return s.watcher.Close()
 }
 
 func (s *ProductCatalogService) ListProducts(ctx context.Context, in *pb.Empty) (*pb.ListProductsResponse, error) {
+	catalog := s.catalog()
 	out := &pb.ListProductsResponse{}
-	out.Products = s.parseCatalog()
+	out.Products = catalog.products
+	out.CatalogVersion = catalog.version
 	return out, nil
 }
 
 func (s *ProductCatalogService) GetProduct(ctx context.Context, in *pb.GetProductRequest) (*pb.Product, error) {
-	var found *pb.Product
-	out := &pb.Product{}
-	products := s.parseCatalog()
-	for _, p := range products {
-		if in.Id == p.Id {
-			found = p
-		}
-	}
-	if found == nil {
-		return nil, status.Errorf(codes.NotFound, "Product not found with ID %s", in.Id)
+	found, ok := s.catalog().get(in.Id)
+	if !ok {
+		return nil, triple.NewError(triple.CodeNotFound, fmt.Errorf("Product not found with ID %s", in.Id))
 	}
+	out := &pb.Product{}
 	out.Id = found.Id
 	out.Name = found.Name
 	out.Categories = found.Categories
@@ -67,61 +100,99 @@ func (s *ProductCatalogService) GetProduct(ctx context.Context, in *pb.GetProduc
 }
 
 func (s *ProductCatalogService) SearchProducts(ctx context.Context, in *pb.SearchProductsRequest) (*pb.SearchProductsResponse, error) {
-	var ps []*pb.Product
+	q, err := newSearchQuery(in)
+	if err != nil {
+		return nil, triple.NewError(triple.CodeInvalidArgument, err)
+	}
+	catalog := s.catalog()
+	if q.version != 0 && q.version != catalog.version {
+		return nil, triple.NewError(triple.CodeFailedPrecondition, fmt.Errorf("page token was issued for catalog version %d, current version is %d", q.version, catalog.version))
+	}
+	results, total, next := catalog.search(q)
 	out := &pb.SearchProductsResponse{}
-	products := s.parseCatalog()
-	for _, p := range products {
-		if strings.Contains(strings.ToLower(p.Name), strings.ToLower(in.Query)) ||
-			strings.Contains(strings.ToLower(p.Description), strings.ToLower(in.Query)) {
-			ps = append(ps, p)
-		}
+	out.Results = results
+	out.TotalSize = int32(total)
+	out.CatalogVersion = catalog.version
+	if next >= 0 {
+		out.NextPageToken = encodePageToken(catalog.version, next)
 	}
-	out.Results = ps
 	return out, nil
 }
 
-func (s *ProductCatalogService) readCatalogFile() (*pb.ListProductsResponse, error) {
-	s.Lock()
-	defer s.Unlock()
-	catalogJSON, err := ioutil.ReadFile("data/products.json")
-	if err != nil {
-		logger.Errorf("failed to open product catalog json file: %v", err)
+func (s *ProductCatalogService) ReloadCatalog(ctx context.Context, in *pb.ReloadCatalogRequest) (*pb.ReloadCatalogResponse, error) {
+	if err := s.authorize(ctx); err != nil {
 		return nil, err
 	}
-	catalog := &pb.ListProductsResponse{}
-	if err := protojson.Unmarshal(catalogJSON, catalog); err != nil {
-		logger.Warnf("failed to parse the catalog JSON: %v", err)
-		return nil, err
+	catalog, err := s.reload()
+	if err != nil && !errors.Is(err, errCatalogUnchanged) {
+		return nil, triple.NewError(triple.CodeFailedPrecondition, fmt.Errorf("catalog rejected, still serving version %d: %v", s.catalog().version, err))
 	}
-	logger.Infof("successfully parsed product catalog json")
-	return catalog, nil
+	return &pb.ReloadCatalogResponse{
+		CatalogVersion: catalog.version,
+		ProductCount:   int32(len(catalog.products)),
+	}, nil
 }
 
-func (s *ProductCatalogService) parseCatalog() []*pb.Product {
-	if reloadCatalog || len(s.products) == 0 {
-		catalog, err := s.readCatalogFile()
-		if err != nil {
-			return []*pb.Product{}
+// authorize checks the "authorization" attachment against the admin token.
+func (s *ProductCatalogService) authorize(ctx context.Context) error {
+	if s.adminToken == "" {
+		return triple.NewError(triple.CodePermissionDenied, errors.New("catalog reloading is disabled"))
+	}
+	var token string
+	if attachments, ok := ctx.Value(constant.AttachmentKey).(map[string]interface{}); ok {
+		// triple delivers attachments as header values
+		switch v := attachments["authorization"].(type) {
+		case []string:
+			if len(v) > 0 {
+				token = v[0]
+			}
+		case string:
+			token = v
 		}
-		s.products = catalog.Products
 	}
-	return s.products
+	token = strings.TrimPrefix(token, "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+		return triple.NewError(triple.CodeUnauthenticated, errors.New("invalid admin token"))
+	}
+	return nil
 }
 
-func init() {
-	sigs := make(chan os.Signal, 1)
-	signal.Notify(sigs, syscall.SIGUSR1, syscall.SIGUSR2)
-	go func() {
-		for {
-			sig := <-sigs
-			logger.Infof("Received signal: %s", sig)
-			if sig == syscall.SIGUSR1 {
-				reloadCatalog = true
-				logger.Infof("Enable catalog reloading")
-			} else {
-				reloadCatalog = false
-				logger.Infof("Disable catalog reloading")
-			}
-		}
-	}()
+func (s *ProductCatalogService) catalog() *catalogSnapshot {
+	return s.snapshot.Load()
+}
+
+// reload reads the catalog file and atomically swaps in a new snapshot. When
+// the file is invalid the current snapshot is kept and an error is returned,
+// errCatalogUnchanged signals that the content is identical to what is served.
+func (s *ProductCatalogService) reload() (*catalogSnapshot, error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	current := s.snapshot.Load()
+	products, checksum, err := loadCatalog(s.path)
+	if err != nil {
+		catalogReloadsCounter.WithLabelValues("failure").Inc()
+		logger.Warnf("failed to reload product catalog: %v", err)
+		return current, err
+	}
+	if current != nil && current.checksum == checksum {
+		return current, errCatalogUnchanged
+	}
+
+	next := &catalogSnapshot{
+		version:      1,
+		checksum:     checksum,
+		loadedAt:     time.Now(),
+		catalogIndex: newCatalogIndex(products),
+	}
+	if current != nil {
+		next.version = current.version + 1
+	}
+	s.snapshot.Store(next)
+
+	catalogReloadsCounter.WithLabelValues("success").Inc()
+	catalogVersionGauge.Set(float64(next.version))
+	catalogProductsGauge.Set(float64(len(products)))
+	logger.Infof("successfully loaded product catalog version %d with %d products", next.version, len(products))
+	return next, nil
 }
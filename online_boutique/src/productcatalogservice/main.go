@@ -20,10 +20,12 @@ package main
 import (
 	"dubbo.apache.org/dubbo-go/v3"
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
 	"dubbo.apache.org/dubbo-go/v3/protocol"
 	"dubbo.apache.org/dubbo-go/v3/registry"
 	"github.com/apache/dubbo-go-samples/online_boutique_demo/productcatalogservice/handler"
-	hipstershop "github.com/apache/dubbo-go-samples/online_boutique_demo/productcatalogservice/proto"
+	hipstershop "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/proto/tracing"
 	"github.com/dubbogo/gost/log/logger"
 	"os"
 )
@@ -33,9 +35,28 @@ func main() {
 	if regAddr == "" {
 		regAddr = "127.0.0.1:2181"
 	}
+	catalogPath := os.Getenv("PRODUCT_CATALOG_PATH")
+	if catalogPath == "" {
+		catalogPath = "data/products.json"
+	}
+
+	catalog, err := handler.NewProductCatalogService(catalogPath, os.Getenv("PRODUCT_CATALOG_ADMIN_TOKEN"))
+	if err != nil {
+		panic(err)
+	}
+	if err := catalog.Watch(); err != nil {
+		panic(err)
+	}
+	defer catalog.Close()
+
+	withTracing, err := tracing.Option()
+	if err != nil {
+		panic(err)
+	}
 
 	ins, err := dubbo.NewInstance(
 		dubbo.WithName("productcatalogservice"),
+		withTracing,
 		dubbo.WithRegistry(
 			registry.WithZookeeper(),
 			registry.WithAddress(regAddr),
@@ -44,6 +65,12 @@ func main() {
 			protocol.WithTriple(),
 			protocol.WithPort(20006),
 		),
+		dubbo.WithMetrics(
+			metrics.WithEnabled(),
+			metrics.WithPrometheus(),
+			metrics.WithPrometheusExporterEnabled(),
+			metrics.WithPort(9096),
+		),
 	)
 	if err != nil {
 		panic(err)
@@ -54,7 +81,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := hipstershop.RegisterProductCatalogServiceHandler(srv, &handler.ProductCatalogService{}); err != nil {
+	if err := hipstershop.RegisterProductCatalogServiceHandler(srv, catalog); err != nil {
 		panic(err)
 	}
 
@@ -0,0 +1,349 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/promotionservice/money"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+// Promotions are priced in USD, the currency of the catalog.
+const currency = "USD"
+
+const (
+	KindPercentOff = "percent_off"
+	KindAmountOff  = "amount_off"
+	KindBuyGet     = "buy_get"
+)
+
+var (
+	errInvalidLine         = errors.New("invalid cart line")
+	errUnknownCoupon       = errors.New("unknown coupon")
+	errCouponUsedUp        = errors.New("coupon is used up")
+	errCouponNotApplicable = errors.New("coupon does not apply to the cart")
+	errOtherCoupon         = errors.New("order was redeemed with another coupon")
+)
+
+// Promotion is a discount rule, either applied to every cart it matches or,
+// with a coupon, only to the carts of the shoppers entering the code.
+type Promotion struct {
+	ID          string `yaml:"id"`
+	Description string `yaml:"description"`
+	Kind        string `yaml:"kind"`
+	// Percent is taken off the matching products by a percent_off promotion.
+	Percent uint32 `yaml:"percent"`
+	// Amount is taken off the matching products by an amount_off promotion,
+	// a decimal number of USD.
+	Amount string `yaml:"amount"`
+	// Buy and Get make a buy_get promotion: of every Buy+Get units of a
+	// product, Get are free.
+	Buy uint32 `yaml:"buy"`
+	Get uint32 `yaml:"get"`
+	// ProductIDs and Categories select the matching products, all of them
+	// when both are empty.
+	ProductIDs []string `yaml:"product_ids"`
+	Categories []string `yaml:"categories"`
+	// MinSubtotal is what the whole cart must be worth at least, in USD.
+	MinSubtotal string `yaml:"min_subtotal"`
+	// Coupon is the code to enter, case insensitive. MaxUses is the number of
+	// orders it may be redeemed for, zero means no limit.
+	Coupon  string `yaml:"coupon"`
+	MaxUses int    `yaml:"max_uses"`
+	// Start and End bound when the promotion runs, zero means unbounded.
+	Start time.Time `yaml:"start"`
+	End   time.Time `yaml:"end"`
+
+	amount      *pb.Money
+	minSubtotal *pb.Money
+	products    map[string]bool
+	categories  map[string]bool
+}
+
+type PromotionConfig struct {
+	Promotions []*Promotion `yaml:"promotions"`
+
+	byCoupon map[string]*Promotion
+}
+
+// ParsePromotions reads a promotions file in YAML, or JSON which is a subset of it.
+func ParsePromotions(data []byte) (*PromotionConfig, error) {
+	cfg := &PromotionConfig{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid promotions: %w", err)
+	}
+	ids := make(map[string]bool, len(cfg.Promotions))
+	cfg.byCoupon = make(map[string]*Promotion)
+	for i, p := range cfg.Promotions {
+		switch {
+		case p.ID == "":
+			return nil, fmt.Errorf("promotion %d has no id", i)
+		case ids[p.ID]:
+			return nil, fmt.Errorf("duplicate promotion id %q", p.ID)
+		case p.Description == "":
+			return nil, fmt.Errorf("promotion %q needs a description", p.ID)
+		case p.MaxUses < 0:
+			return nil, fmt.Errorf("promotion %q has a negative max_uses", p.ID)
+		case p.MaxUses > 0 && p.Coupon == "":
+			return nil, fmt.Errorf("promotion %q limits the uses of no coupon", p.ID)
+		case !p.End.IsZero() && !p.End.After(p.Start):
+			return nil, fmt.Errorf("promotion %q ends before it starts", p.ID)
+		}
+		ids[p.ID] = true
+
+		var err error
+		switch p.Kind {
+		case KindPercentOff:
+			if p.Percent == 0 || p.Percent > 100 {
+				return nil, fmt.Errorf("promotion %q needs a percent between 1 and 100", p.ID)
+			}
+		case KindAmountOff:
+			if p.amount, err = parseUSD(p.Amount); err != nil || !money.IsPositive(p.amount) {
+				return nil, fmt.Errorf("promotion %q needs a positive amount: %q", p.ID, p.Amount)
+			}
+		case KindBuyGet:
+			if p.Buy == 0 || p.Get == 0 {
+				return nil, fmt.Errorf("promotion %q needs buy and get", p.ID)
+			}
+		default:
+			return nil, fmt.Errorf("promotion %q has unknown kind %q", p.ID, p.Kind)
+		}
+		if p.MinSubtotal != "" {
+			if p.minSubtotal, err = parseUSD(p.MinSubtotal); err != nil {
+				return nil, fmt.Errorf("promotion %q: invalid min_subtotal: %w", p.ID, err)
+			}
+		}
+		if p.Coupon != "" {
+			code := normalizeCoupon(p.Coupon)
+			if cfg.byCoupon[code] != nil {
+				return nil, fmt.Errorf("coupon %s is used by two promotions", code)
+			}
+			cfg.byCoupon[code] = p
+		}
+		p.products = set(p.ProductIDs)
+		p.categories = set(p.Categories)
+	}
+	return cfg, nil
+}
+
+// parseUSD reads a non-negative decimal number like 12.5 as an amount of USD.
+func parseUSD(s string) (*pb.Money, error) {
+	whole, frac, _ := strings.Cut(strings.TrimSpace(s), ".")
+	units, err := strconv.ParseUint(whole, 10, 63)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	var nanos uint64
+	if frac != "" {
+		if len(frac) > 9 {
+			return nil, fmt.Errorf("amount %q has more than 9 decimals", s)
+		}
+		if nanos, err = strconv.ParseUint(frac+strings.Repeat("0", 9-len(frac)), 10, 32); err != nil {
+			return nil, fmt.Errorf("invalid amount %q", s)
+		}
+	}
+	return &pb.Money{CurrencyCode: currency, Units: int64(units), Nanos: int32(nanos)}, nil
+}
+
+func normalizeCoupon(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func set(values []string) map[string]bool {
+	out := make(map[string]bool, len(values))
+	for _, v := range values {
+		out[strings.ToLower(v)] = true
+	}
+	return out
+}
+
+func (p *Promotion) running(now time.Time) bool {
+	return !now.Before(p.Start) && (p.End.IsZero() || now.Before(p.End))
+}
+
+func (p *Promotion) matches(line *pb.PromotionLine) bool {
+	if len(p.products) == 0 && len(p.categories) == 0 {
+		return true
+	}
+	if p.products[strings.ToLower(line.ProductId)] {
+		return true
+	}
+	for _, c := range line.Categories {
+		if p.categories[strings.ToLower(c)] {
+			return true
+		}
+	}
+	return false
+}
+
+// discount works out what the promotion takes off the lines, which are worth
+// subtotal together.
+func (p *Promotion) discount(lines []*pb.PromotionLine, subtotal *pb.Money) *pb.Money {
+	zero := &pb.Money{CurrencyCode: currency}
+	if p.minSubtotal != nil && less(subtotal, p.minSubtotal) {
+		return zero
+	}
+	matched, free := zero, zero
+	for _, l := range lines {
+		if !p.matches(l) {
+			continue
+		}
+		matched = money.Must(money.Sum(matched, lineTotal(l)))
+		if p.Kind == KindBuyGet {
+			if n := uint32(l.Quantity) / (p.Buy + p.Get) * p.Get; n > 0 {
+				free = money.Must(money.Sum(free, money.MultiplySlow(l.UnitPrice, n)))
+			}
+		}
+	}
+	switch p.Kind {
+	case KindPercentOff:
+		return money.Percent(matched, p.Percent)
+	case KindAmountOff:
+		return minMoney(p.amount, matched)
+	default:
+		return free
+	}
+}
+
+func lineTotal(l *pb.PromotionLine) *pb.Money {
+	return money.MultiplySlow(l.UnitPrice, uint32(l.Quantity))
+}
+
+func less(l, r *pb.Money) bool {
+	return money.IsNegative(money.Must(money.Sum(l, money.Negate(r))))
+}
+
+func minMoney(l, r *pb.Money) *pb.Money {
+	if less(r, l) {
+		return r
+	}
+	return l
+}
+
+// Promotions prices the discounts of carts and counts the orders each coupon
+// was redeemed for. The counts are kept in memory and start over on restart.
+type Promotions struct {
+	cfg *PromotionConfig
+
+	mu       sync.Mutex
+	uses     map[string]int    // by coupon
+	redeemed map[string]string // coupon by order ID
+	now      func() time.Time
+}
+
+func NewPromotions(cfg *PromotionConfig) *Promotions {
+	return &Promotions{
+		cfg:      cfg,
+		uses:     make(map[string]int),
+		redeemed: make(map[string]string),
+		now:      time.Now,
+	}
+}
+
+// Evaluate returns the discounts of the lines with the coupon, if any, in the
+// order of the promotions file. Together they are never worth more than the
+// lines.
+func (p *Promotions) Evaluate(lines []*pb.PromotionLine, coupon string) ([]*pb.Discount, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.evaluate(lines, normalizeCoupon(coupon), "")
+}
+
+// Redeem evaluates the lines of an order and uses up the coupon for it.
+// Redeeming an order again with the same coupon does not use it twice.
+func (p *Promotions) Redeem(orderID string, lines []*pb.PromotionLine, coupon string) ([]*pb.Discount, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	code := normalizeCoupon(coupon)
+	if prev, ok := p.redeemed[orderID]; ok && prev != code {
+		return nil, fmt.Errorf("%w %s", errOtherCoupon, prev)
+	}
+	discounts, err := p.evaluate(lines, code, orderID)
+	if err != nil || code == "" {
+		return discounts, err
+	}
+	if _, ok := p.redeemed[orderID]; !ok {
+		p.redeemed[orderID] = code
+		p.uses[code]++
+	}
+	return discounts, nil
+}
+
+// CancelRedemption gives the coupon redeemed for an order back. Orders
+// redeemed without a coupon, or not at all, have nothing to give back.
+func (p *Promotions) CancelRedemption(orderID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if code, ok := p.redeemed[orderID]; ok {
+		delete(p.redeemed, orderID)
+		p.uses[code]--
+	}
+}
+
+// evaluate prices the discounts; the coupon is not counted as used up by
+// the order it was already redeemed for.
+func (p *Promotions) evaluate(lines []*pb.PromotionLine, code, orderID string) ([]*pb.Discount, error) {
+	subtotal := &pb.Money{CurrencyCode: currency}
+	for _, l := range lines {
+		if l.Quantity <= 0 || l.UnitPrice.GetCurrencyCode() != currency || !money.IsValid(l.UnitPrice) || money.IsNegative(l.UnitPrice) {
+			return nil, fmt.Errorf("%w: %d of %q at %v", errInvalidLine, l.Quantity, l.ProductId, l.UnitPrice)
+		}
+		subtotal = money.Must(money.Sum(subtotal, lineTotal(l)))
+	}
+
+	now := p.now()
+	var coupon *Promotion
+	if code != "" {
+		coupon = p.cfg.byCoupon[code]
+		if coupon == nil || !coupon.running(now) {
+			return nil, fmt.Errorf("%w %s", errUnknownCoupon, code)
+		}
+		if coupon.MaxUses > 0 && p.uses[code] >= coupon.MaxUses && p.redeemed[orderID] != code {
+			return nil, fmt.Errorf("%w %s", errCouponUsedUp, code)
+		}
+	}
+
+	var out []*pb.Discount
+	left := subtotal
+	for _, promo := range p.cfg.Promotions {
+		if !promo.running(now) || (promo.Coupon != "" && promo != coupon) {
+			continue
+		}
+		d := minMoney(promo.discount(lines, subtotal), left)
+		if !money.IsPositive(d) {
+			continue
+		}
+		left = money.Must(money.Sum(left, money.Negate(d)))
+		out = append(out, &pb.Discount{PromotionId: promo.ID, Description: promo.Description, Amount: d})
+		if promo == coupon {
+			coupon = nil
+		}
+	}
+	if coupon != nil {
+		return nil, fmt.Errorf("%w: %s", errCouponNotApplicable, code)
+	}
+	return out, nil
+}
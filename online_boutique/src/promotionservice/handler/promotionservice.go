@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"errors"
+
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	"github.com/dubbogo/gost/log/logger"
+
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/promotionservice/money"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+type PromotionService struct {
+	Promotions *Promotions
+}
+
+func NewPromotionService(promotions *Promotions) *PromotionService {
+	return &PromotionService{Promotions: promotions}
+}
+
+func (s *PromotionService) Evaluate(_ context.Context, in *pb.EvaluateRequest) (*pb.EvaluateResponse, error) {
+	discounts, err := s.Promotions.Evaluate(in.Lines, in.CouponCode)
+	if err != nil {
+		return nil, promotionError(err)
+	}
+	return evaluateResponse(discounts), nil
+}
+
+func (s *PromotionService) Redeem(_ context.Context, in *pb.RedeemRequest) (*pb.EvaluateResponse, error) {
+	if in.OrderId == "" {
+		return nil, triple.NewError(triple.CodeInvalidArgument, errors.New("order_id is required"))
+	}
+	discounts, err := s.Promotions.Redeem(in.OrderId, in.Lines, in.CouponCode)
+	if err != nil {
+		logger.Infof("[Redeem] order %s not redeemed: %v", in.OrderId, err)
+		return nil, promotionError(err)
+	}
+	out := evaluateResponse(discounts)
+	logger.Infof("[Redeem] order %s redeemed %d discounts worth %d.%09d %s", in.OrderId, len(discounts),
+		out.Total.Units, out.Total.Nanos, out.Total.CurrencyCode)
+	return out, nil
+}
+
+func (s *PromotionService) CancelRedemption(_ context.Context, in *pb.CancelRedemptionRequest) (*pb.Empty, error) {
+	s.Promotions.CancelRedemption(in.OrderId)
+	logger.Infof("[CancelRedemption] order %s cancelled", in.OrderId)
+	return &pb.Empty{}, nil
+}
+
+func evaluateResponse(discounts []*pb.Discount) *pb.EvaluateResponse {
+	total := &pb.Money{CurrencyCode: currency}
+	for _, d := range discounts {
+		total = money.Must(money.Sum(total, d.Amount))
+	}
+	return &pb.EvaluateResponse{Discounts: discounts, Total: total}
+}
+
+func promotionError(err error) error {
+	switch {
+	case errors.Is(err, errInvalidLine):
+		return triple.NewError(triple.CodeInvalidArgument, err)
+	case errors.Is(err, errUnknownCoupon):
+		return triple.NewError(triple.CodeNotFound, err)
+	case errors.Is(err, errCouponUsedUp):
+		return triple.NewError(triple.CodeResourceExhausted, err)
+	case errors.Is(err, errOtherCoupon):
+		return triple.NewError(triple.CodeAlreadyExists, err)
+	default:
+		return triple.NewError(triple.CodeFailedPrecondition, err)
+	}
+}
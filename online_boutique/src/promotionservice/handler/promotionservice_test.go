@@ -0,0 +1,180 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+const testPromotions = `
+promotions:
+  - {id: hairdryer, description: Hairdryer 50% off, kind: percent_off, percent: 50, product_ids: [HAIR]}
+  - {id: mugs, description: Mugs 3 for 2, kind: buy_get, buy: 2, get: 1, product_ids: [MUG]}
+  - {id: welcome, description: $10 off $50, kind: amount_off, amount: "10", min_subtotal: "50", coupon: welcome10, max_uses: 1}
+  - {id: kitchen, description: Kitchen 15% off, kind: percent_off, percent: 15, categories: [kitchen], coupon: KITCHEN15}
+  - {id: huge, description: $1000 off, kind: amount_off, amount: "1000", coupon: HUGE}
+`
+
+func usd(units int64, nanos int32) *pb.Money {
+	return &pb.Money{CurrencyCode: "USD", Units: units, Nanos: nanos}
+}
+
+func line(id string, quantity int32, price *pb.Money, categories ...string) *pb.PromotionLine {
+	return &pb.PromotionLine{ProductId: id, Quantity: quantity, UnitPrice: price, Categories: categories}
+}
+
+func newTestService(t *testing.T) *PromotionService {
+	t.Helper()
+	cfg, err := ParsePromotions([]byte(testPromotions))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewPromotionService(NewPromotions(cfg))
+}
+
+func wantCode(t *testing.T, err error, code triple.Code) {
+	t.Helper()
+	if got := triple.CodeOf(err); err == nil || got != code {
+		t.Fatalf("got error %v (%s), want %s", err, got, code)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	s := newTestService(t)
+	hairdryer := line("HAIR", 1, usd(65, 500000000), "hair")
+	mugs := line("MUG", 5, usd(8, 990000000), "kitchen")
+	tests := []struct {
+		name   string
+		lines  []*pb.PromotionLine
+		coupon string
+		want   map[string]*pb.Money
+		total  *pb.Money
+	}{
+		{"no promotion", []*pb.PromotionLine{line("JAR", 1, usd(5, 0))}, "", map[string]*pb.Money{}, usd(0, 0)},
+		{"percent off", []*pb.PromotionLine{hairdryer}, "", map[string]*pb.Money{"hairdryer": usd(32, 750000000)}, usd(32, 750000000)},
+		{"one free mug of five", []*pb.PromotionLine{mugs}, "", map[string]*pb.Money{"mugs": usd(8, 990000000)}, usd(8, 990000000)},
+		{"stacked with a coupon", []*pb.PromotionLine{hairdryer, mugs}, " welcome10 ",
+			map[string]*pb.Money{"hairdryer": usd(32, 750000000), "mugs": usd(8, 990000000), "welcome": usd(10, 0)}, usd(51, 740000000)},
+		{"category coupon", []*pb.PromotionLine{hairdryer, mugs}, "kitchen15",
+			map[string]*pb.Money{"hairdryer": usd(32, 750000000), "mugs": usd(8, 990000000), "kitchen": usd(6, 742500000)}, usd(48, 482500000)},
+		{"never more than the cart", []*pb.PromotionLine{hairdryer}, "HUGE",
+			map[string]*pb.Money{"hairdryer": usd(32, 750000000), "huge": usd(32, 750000000)}, usd(65, 500000000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.Evaluate(context.Background(), &pb.EvaluateRequest{Lines: tt.lines, CouponCode: tt.coupon})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]*pb.Money)
+			for _, d := range resp.Discounts {
+				got[d.PromotionId] = d.Amount
+			}
+			if !reflect.DeepEqual(got, tt.want) || !reflect.DeepEqual(resp.Total, tt.total) {
+				t.Errorf("discounts %v total %v, want %v total %v", got, resp.Total, tt.want, tt.total)
+			}
+		})
+	}
+
+	for _, tt := range []struct {
+		name   string
+		lines  []*pb.PromotionLine
+		coupon string
+		code   triple.Code
+	}{
+		{"unknown coupon", []*pb.PromotionLine{hairdryer}, "NOPE", triple.CodeNotFound},
+		{"under the minimum", []*pb.PromotionLine{mugs}, "WELCOME10", triple.CodeFailedPrecondition},
+		{"out of the category", []*pb.PromotionLine{hairdryer}, "KITCHEN15", triple.CodeFailedPrecondition},
+		{"other currency", []*pb.PromotionLine{line("HAIR", 1, &pb.Money{CurrencyCode: "EUR", Units: 60})}, "", triple.CodeInvalidArgument},
+		{"no quantity", []*pb.PromotionLine{line("HAIR", 0, usd(65, 0))}, "", triple.CodeInvalidArgument},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Evaluate(context.Background(), &pb.EvaluateRequest{Lines: tt.lines, CouponCode: tt.coupon})
+			wantCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestRedeem(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t)
+	lines := []*pb.PromotionLine{line("HAIR", 1, usd(65, 500000000))}
+
+	_, err := s.Redeem(ctx, &pb.RedeemRequest{Lines: lines, CouponCode: "WELCOME10"})
+	wantCode(t, err, triple.CodeInvalidArgument)
+	if _, err := s.Redeem(ctx, &pb.RedeemRequest{OrderId: "o1", Lines: lines, CouponCode: "WELCOME10"}); err != nil {
+		t.Fatal(err)
+	}
+	// Retried, the order keeps its coupon; the coupon was redeemed once.
+	if _, err := s.Redeem(ctx, &pb.RedeemRequest{OrderId: "o1", Lines: lines, CouponCode: "welcome10"}); err != nil {
+		t.Fatalf("redeem again: %v", err)
+	}
+	_, err = s.Redeem(ctx, &pb.RedeemRequest{OrderId: "o1", Lines: lines, CouponCode: "HUGE"})
+	wantCode(t, err, triple.CodeAlreadyExists)
+	_, err = s.Redeem(ctx, &pb.RedeemRequest{OrderId: "o2", Lines: lines, CouponCode: "WELCOME10"})
+	wantCode(t, err, triple.CodeResourceExhausted)
+	_, err = s.Evaluate(ctx, &pb.EvaluateRequest{Lines: lines, CouponCode: "WELCOME10"})
+	wantCode(t, err, triple.CodeResourceExhausted)
+
+	// Without a coupon there is nothing to use up.
+	if _, err := s.Redeem(ctx, &pb.RedeemRequest{OrderId: "o2", Lines: lines}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.CancelRedemption(ctx, &pb.CancelRedemptionRequest{OrderId: "o1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.CancelRedemption(ctx, &pb.CancelRedemptionRequest{OrderId: "o1"}); err != nil {
+		t.Fatalf("cancel again: %v", err)
+	}
+	if _, err := s.Redeem(ctx, &pb.RedeemRequest{OrderId: "o3", Lines: lines, CouponCode: "WELCOME10"}); err != nil {
+		t.Errorf("coupon not given back: %v", err)
+	}
+}
+
+func TestParsePromotions(t *testing.T) {
+	for _, bad := range []string{
+		`promotions: [{description: x, kind: percent_off, percent: 5}]`,
+		`promotions: [{id: a, description: x, kind: percent_off, percent: 101}]`,
+		`promotions: [{id: a, description: x, kind: amount_off, amount: "-1"}]`,
+		`promotions: [{id: a, description: x, kind: amount_off, amount: "1.0000000001"}]`,
+		`promotions: [{id: a, description: x, kind: buy_get, buy: 1}]`,
+		`promotions: [{id: a, description: x, kind: free_lunch}]`,
+		`promotions: [{id: a, description: x, kind: percent_off, percent: 5, max_uses: 3}]`,
+		`promotions: [{id: a, description: x, kind: percent_off, percent: 5, coupon: A}, {id: b, description: y, kind: percent_off, percent: 5, coupon: a}]`,
+	} {
+		if _, err := ParsePromotions([]byte(bad)); err == nil {
+			t.Errorf("ParsePromotions(%s) succeeded", bad)
+		}
+	}
+
+	data, err := os.ReadFile("../data/promotions.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParsePromotions(data); err != nil {
+		t.Errorf("shipped promotions: %v", err)
+	}
+}
@@ -5,6 +5,7 @@ package hipstershop
 
 import (
 	"context"
+	"net/http"
 )
 
 import (
@@ -58,6 +59,8 @@ const (
 const (
 	// RecommendationServiceListRecommendationsProcedure is the fully-qualified name of the RecommendationService's ListRecommendations RPC.
 	RecommendationServiceListRecommendationsProcedure = "/hipstershop.RecommendationService/ListRecommendations"
+	// RecommendationServiceRecordPurchaseProcedure is the fully-qualified name of the RecommendationService's RecordPurchase RPC.
+	RecommendationServiceRecordPurchaseProcedure = "/hipstershop.RecommendationService/RecordPurchase"
 )
 const (
 	// ProductCatalogServiceName is the fully-qualified name of the ProductCatalogService service.
@@ -78,6 +81,8 @@ const (
 	ProductCatalogServiceGetProductProcedure = "/hipstershop.ProductCatalogService/GetProduct"
 	// ProductCatalogServiceSearchProductsProcedure is the fully-qualified name of the ProductCatalogService's SearchProducts RPC.
 	ProductCatalogServiceSearchProductsProcedure = "/hipstershop.ProductCatalogService/SearchProducts"
+	// ProductCatalogServiceReloadCatalogProcedure is the fully-qualified name of the ProductCatalogService's ReloadCatalog RPC.
+	ProductCatalogServiceReloadCatalogProcedure = "/hipstershop.ProductCatalogService/ReloadCatalog"
 )
 const (
 	// ShippingServiceName is the fully-qualified name of the ShippingService service.
@@ -96,6 +101,10 @@ const (
 	ShippingServiceGetQuoteProcedure = "/hipstershop.ShippingService/GetQuote"
 	// ShippingServiceShipOrderProcedure is the fully-qualified name of the ShippingService's ShipOrder RPC.
 	ShippingServiceShipOrderProcedure = "/hipstershop.ShippingService/ShipOrder"
+	// ShippingServiceTrackShipmentProcedure is the fully-qualified name of the ShippingService's TrackShipment RPC.
+	ShippingServiceTrackShipmentProcedure = "/hipstershop.ShippingService/TrackShipment"
+	// ShippingServiceWatchShipmentProcedure is the fully-qualified name of the ShippingService's WatchShipment RPC.
+	ShippingServiceWatchShipmentProcedure = "/hipstershop.ShippingService/WatchShipment"
 )
 const (
 	// CurrencyServiceName is the fully-qualified name of the CurrencyService service.
@@ -130,6 +139,8 @@ const (
 const (
 	// PaymentServiceChargeProcedure is the fully-qualified name of the PaymentService's Charge RPC.
 	PaymentServiceChargeProcedure = "/hipstershop.PaymentService/Charge"
+	// PaymentServiceRefundProcedure is the fully-qualified name of the PaymentService's Refund RPC.
+	PaymentServiceRefundProcedure = "/hipstershop.PaymentService/Refund"
 )
 const (
 	// EmailServiceName is the fully-qualified name of the EmailService service.
@@ -178,6 +189,86 @@ const (
 const (
 	// AdServiceGetAdsProcedure is the fully-qualified name of the AdService's GetAds RPC.
 	AdServiceGetAdsProcedure = "/hipstershop.AdService/GetAds"
+	// AdServiceReportAdEventProcedure is the fully-qualified name of the AdService's ReportAdEvent RPC.
+	AdServiceReportAdEventProcedure = "/hipstershop.AdService/ReportAdEvent"
+	// AdServiceGetCampaignStatsProcedure is the fully-qualified name of the AdService's GetCampaignStats RPC.
+	AdServiceGetCampaignStatsProcedure = "/hipstershop.AdService/GetCampaignStats"
+)
+const (
+	// UserServiceName is the fully-qualified name of the UserService service.
+	UserServiceName = "hipstershop.UserService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// UserServiceRegisterProcedure is the fully-qualified name of the UserService's Register RPC.
+	UserServiceRegisterProcedure = "/hipstershop.UserService/Register"
+	// UserServiceLoginProcedure is the fully-qualified name of the UserService's Login RPC.
+	UserServiceLoginProcedure = "/hipstershop.UserService/Login"
+	// UserServiceLogoutProcedure is the fully-qualified name of the UserService's Logout RPC.
+	UserServiceLogoutProcedure = "/hipstershop.UserService/Logout"
+	// UserServiceVerifyTokenProcedure is the fully-qualified name of the UserService's VerifyToken RPC.
+	UserServiceVerifyTokenProcedure = "/hipstershop.UserService/VerifyToken"
+	// UserServiceGetProfileProcedure is the fully-qualified name of the UserService's GetProfile RPC.
+	UserServiceGetProfileProcedure = "/hipstershop.UserService/GetProfile"
+	// UserServiceAddAddressProcedure is the fully-qualified name of the UserService's AddAddress RPC.
+	UserServiceAddAddressProcedure = "/hipstershop.UserService/AddAddress"
+	// UserServiceDeleteAddressProcedure is the fully-qualified name of the UserService's DeleteAddress RPC.
+	UserServiceDeleteAddressProcedure = "/hipstershop.UserService/DeleteAddress"
+	// UserServiceAddCardProcedure is the fully-qualified name of the UserService's AddCard RPC.
+	UserServiceAddCardProcedure = "/hipstershop.UserService/AddCard"
+	// UserServiceDeleteCardProcedure is the fully-qualified name of the UserService's DeleteCard RPC.
+	UserServiceDeleteCardProcedure = "/hipstershop.UserService/DeleteCard"
+	// UserServiceGetCardProcedure is the fully-qualified name of the UserService's GetCard RPC.
+	UserServiceGetCardProcedure = "/hipstershop.UserService/GetCard"
+)
+const (
+	// InventoryServiceName is the fully-qualified name of the InventoryService service.
+	InventoryServiceName = "hipstershop.InventoryService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// InventoryServiceGetStockProcedure is the fully-qualified name of the InventoryService's GetStock RPC.
+	InventoryServiceGetStockProcedure = "/hipstershop.InventoryService/GetStock"
+	// InventoryServiceReserveProcedure is the fully-qualified name of the InventoryService's Reserve RPC.
+	InventoryServiceReserveProcedure = "/hipstershop.InventoryService/Reserve"
+	// InventoryServiceCommitProcedure is the fully-qualified name of the InventoryService's Commit RPC.
+	InventoryServiceCommitProcedure = "/hipstershop.InventoryService/Commit"
+	// InventoryServiceReleaseProcedure is the fully-qualified name of the InventoryService's Release RPC.
+	InventoryServiceReleaseProcedure = "/hipstershop.InventoryService/Release"
+)
+const (
+	// PromotionServiceName is the fully-qualified name of the PromotionService service.
+	PromotionServiceName = "hipstershop.PromotionService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// PromotionServiceEvaluateProcedure is the fully-qualified name of the PromotionService's Evaluate RPC.
+	PromotionServiceEvaluateProcedure = "/hipstershop.PromotionService/Evaluate"
+	// PromotionServiceRedeemProcedure is the fully-qualified name of the PromotionService's Redeem RPC.
+	PromotionServiceRedeemProcedure = "/hipstershop.PromotionService/Redeem"
+	// PromotionServiceCancelRedemptionProcedure is the fully-qualified name of the PromotionService's CancelRedemption RPC.
+	PromotionServiceCancelRedemptionProcedure = "/hipstershop.PromotionService/CancelRedemption"
 )
 
 var (
@@ -189,6 +280,10 @@ var (
 
 	_ ShippingService = (*ShippingServiceImpl)(nil)
 
+	_ ShippingService_WatchShipmentClient = (*ShippingServiceWatchShipmentClient)(nil)
+
+	_ ShippingService_WatchShipmentServer = (*ShippingServiceWatchShipmentServer)(nil)
+
 	_ CurrencyService = (*CurrencyServiceImpl)(nil)
 
 	_ PaymentService = (*PaymentServiceImpl)(nil)
@@ -198,6 +293,12 @@ var (
 	_ CheckoutService = (*CheckoutServiceImpl)(nil)
 
 	_ AdService = (*AdServiceImpl)(nil)
+
+	_ UserService = (*UserServiceImpl)(nil)
+
+	_ InventoryService = (*InventoryServiceImpl)(nil)
+
+	_ PromotionService = (*PromotionServiceImpl)(nil)
 )
 
 // CartService is a client for the hipstershop.CartService service.
@@ -210,6 +311,7 @@ type CartService interface {
 // RecommendationService is a client for the hipstershop.RecommendationService service.
 type RecommendationService interface {
 	ListRecommendations(ctx context.Context, req *ListRecommendationsRequest, opts ...client.CallOption) (*ListRecommendationsResponse, error)
+	RecordPurchase(ctx context.Context, req *RecordPurchaseRequest, opts ...client.CallOption) (*Empty, error)
 }
 
 // ProductCatalogService is a client for the hipstershop.ProductCatalogService service.
@@ -217,12 +319,15 @@ type ProductCatalogService interface {
 	ListProducts(ctx context.Context, req *Empty, opts ...client.CallOption) (*ListProductsResponse, error)
 	GetProduct(ctx context.Context, req *GetProductRequest, opts ...client.CallOption) (*Product, error)
 	SearchProducts(ctx context.Context, req *SearchProductsRequest, opts ...client.CallOption) (*SearchProductsResponse, error)
+	ReloadCatalog(ctx context.Context, req *ReloadCatalogRequest, opts ...client.CallOption) (*ReloadCatalogResponse, error)
 }
 
 // ShippingService is a client for the hipstershop.ShippingService service.
 type ShippingService interface {
 	GetQuote(ctx context.Context, req *GetQuoteRequest, opts ...client.CallOption) (*GetQuoteResponse, error)
 	ShipOrder(ctx context.Context, req *ShipOrderRequest, opts ...client.CallOption) (*ShipOrderResponse, error)
+	TrackShipment(ctx context.Context, req *TrackShipmentRequest, opts ...client.CallOption) (*Shipment, error)
+	WatchShipment(ctx context.Context, req *TrackShipmentRequest, opts ...client.CallOption) (ShippingService_WatchShipmentClient, error)
 }
 
 // CurrencyService is a client for the hipstershop.CurrencyService service.
@@ -234,11 +339,12 @@ type CurrencyService interface {
 // PaymentService is a client for the hipstershop.PaymentService service.
 type PaymentService interface {
 	Charge(ctx context.Context, req *ChargeRequest, opts ...client.CallOption) (*ChargeResponse, error)
+	Refund(ctx context.Context, req *RefundRequest, opts ...client.CallOption) (*RefundResponse, error)
 }
 
 // EmailService is a client for the hipstershop.EmailService service.
 type EmailService interface {
-	SendOrderConfirmation(ctx context.Context, req *SendOrderConfirmationRequest, opts ...client.CallOption) (*Empty, error)
+	SendOrderConfirmation(ctx context.Context, req *SendOrderConfirmationRequest, opts ...client.CallOption) (*SendOrderConfirmationResponse, error)
 }
 
 // CheckoutService is a client for the hipstershop.CheckoutService service.
@@ -249,6 +355,37 @@ type CheckoutService interface {
 // AdService is a client for the hipstershop.AdService service.
 type AdService interface {
 	GetAds(ctx context.Context, req *AdRequest, opts ...client.CallOption) (*AdResponse, error)
+	ReportAdEvent(ctx context.Context, req *AdEventRequest, opts ...client.CallOption) (*AdEventResponse, error)
+	GetCampaignStats(ctx context.Context, req *CampaignStatsRequest, opts ...client.CallOption) (*CampaignStatsResponse, error)
+}
+
+// UserService is a client for the hipstershop.UserService service.
+type UserService interface {
+	Register(ctx context.Context, req *RegisterRequest, opts ...client.CallOption) (*AuthResponse, error)
+	Login(ctx context.Context, req *LoginRequest, opts ...client.CallOption) (*AuthResponse, error)
+	Logout(ctx context.Context, req *LogoutRequest, opts ...client.CallOption) (*Empty, error)
+	VerifyToken(ctx context.Context, req *VerifyTokenRequest, opts ...client.CallOption) (*User, error)
+	GetProfile(ctx context.Context, req *GetProfileRequest, opts ...client.CallOption) (*User, error)
+	AddAddress(ctx context.Context, req *AddAddressRequest, opts ...client.CallOption) (*User, error)
+	DeleteAddress(ctx context.Context, req *DeleteAddressRequest, opts ...client.CallOption) (*User, error)
+	AddCard(ctx context.Context, req *AddCardRequest, opts ...client.CallOption) (*User, error)
+	DeleteCard(ctx context.Context, req *DeleteCardRequest, opts ...client.CallOption) (*User, error)
+	GetCard(ctx context.Context, req *GetCardRequest, opts ...client.CallOption) (*CreditCardInfo, error)
+}
+
+// InventoryService is a client for the hipstershop.InventoryService service.
+type InventoryService interface {
+	GetStock(ctx context.Context, req *GetStockRequest, opts ...client.CallOption) (*GetStockResponse, error)
+	Reserve(ctx context.Context, req *ReserveRequest, opts ...client.CallOption) (*Reservation, error)
+	Commit(ctx context.Context, req *CommitRequest, opts ...client.CallOption) (*Empty, error)
+	Release(ctx context.Context, req *ReleaseRequest, opts ...client.CallOption) (*Empty, error)
+}
+
+// PromotionService is a client for the hipstershop.PromotionService service.
+type PromotionService interface {
+	Evaluate(ctx context.Context, req *EvaluateRequest, opts ...client.CallOption) (*EvaluateResponse, error)
+	Redeem(ctx context.Context, req *RedeemRequest, opts ...client.CallOption) (*EvaluateResponse, error)
+	CancelRedemption(ctx context.Context, req *CancelRedemptionRequest, opts ...client.CallOption) (*Empty, error)
 }
 
 // NewCartService constructs a client for the hipstershop.CartService service.
@@ -323,6 +460,14 @@ func (c *RecommendationServiceImpl) ListRecommendations(ctx context.Context, req
 	return resp, nil
 }
 
+func (c *RecommendationServiceImpl) RecordPurchase(ctx context.Context, req *RecordPurchaseRequest, opts ...client.CallOption) (*Empty, error) {
+	resp := new(Empty)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "RecordPurchase", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // NewProductCatalogService constructs a client for the hipstershop.ProductCatalogService service.
 func NewProductCatalogService(cli *client.Client, opts ...client.ReferenceOption) (ProductCatalogService, error) {
 	conn, err := cli.DialWithInfo("hipstershop.ProductCatalogService", &ProductCatalogService_ClientInfo, opts...)
@@ -367,6 +512,14 @@ func (c *ProductCatalogServiceImpl) SearchProducts(ctx context.Context, req *Sea
 	return resp, nil
 }
 
+func (c *ProductCatalogServiceImpl) ReloadCatalog(ctx context.Context, req *ReloadCatalogRequest, opts ...client.CallOption) (*ReloadCatalogResponse, error) {
+	resp := new(ReloadCatalogResponse)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "ReloadCatalog", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // NewShippingService constructs a client for the hipstershop.ShippingService service.
 func NewShippingService(cli *client.Client, opts ...client.ReferenceOption) (ShippingService, error) {
 	conn, err := cli.DialWithInfo("hipstershop.ShippingService", &ShippingService_ClientInfo, opts...)
@@ -403,6 +556,54 @@ func (c *ShippingServiceImpl) ShipOrder(ctx context.Context, req *ShipOrderReque
 	return resp, nil
 }
 
+func (c *ShippingServiceImpl) TrackShipment(ctx context.Context, req *TrackShipmentRequest, opts ...client.CallOption) (*Shipment, error) {
+	resp := new(Shipment)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "TrackShipment", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *ShippingServiceImpl) WatchShipment(ctx context.Context, req *TrackShipmentRequest, opts ...client.CallOption) (ShippingService_WatchShipmentClient, error) {
+	stream, err := c.conn.CallServerStream(ctx, req, "WatchShipment", opts...)
+	if err != nil {
+		return nil, err
+	}
+	rawStream := stream.(*triple_protocol.ServerStreamForClient)
+	return &ShippingServiceWatchShipmentClient{rawStream}, nil
+}
+
+type ShippingService_WatchShipmentClient interface {
+	Recv() bool
+	ResponseHeader() http.Header
+	ResponseTrailer() http.Header
+	Msg() *Shipment
+	Err() error
+	Conn() (triple_protocol.StreamingClientConn, error)
+	Close() error
+}
+
+type ShippingServiceWatchShipmentClient struct {
+	*triple_protocol.ServerStreamForClient
+}
+
+func (cli *ShippingServiceWatchShipmentClient) Recv() bool {
+	msg := new(Shipment)
+	return cli.ServerStreamForClient.Receive(msg)
+}
+
+func (cli *ShippingServiceWatchShipmentClient) Msg() *Shipment {
+	msg := cli.ServerStreamForClient.Msg()
+	if msg == nil {
+		return new(Shipment)
+	}
+	return msg.(*Shipment)
+}
+
+func (cli *ShippingServiceWatchShipmentClient) Conn() (triple_protocol.StreamingClientConn, error) {
+	return cli.ServerStreamForClient.Conn()
+}
+
 // NewCurrencyService constructs a client for the hipstershop.CurrencyService service.
 func NewCurrencyService(cli *client.Client, opts ...client.ReferenceOption) (CurrencyService, error) {
 	conn, err := cli.DialWithInfo("hipstershop.CurrencyService", &CurrencyService_ClientInfo, opts...)
@@ -467,6 +668,14 @@ func (c *PaymentServiceImpl) Charge(ctx context.Context, req *ChargeRequest, opt
 	return resp, nil
 }
 
+func (c *PaymentServiceImpl) Refund(ctx context.Context, req *RefundRequest, opts ...client.CallOption) (*RefundResponse, error) {
+	resp := new(RefundResponse)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "Refund", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // NewEmailService constructs a client for the hipstershop.EmailService service.
 func NewEmailService(cli *client.Client, opts ...client.ReferenceOption) (EmailService, error) {
 	conn, err := cli.DialWithInfo("hipstershop.EmailService", &EmailService_ClientInfo, opts...)
@@ -487,8 +696,8 @@ type EmailServiceImpl struct {
 	conn *client.Connection
 }
 
-func (c *EmailServiceImpl) SendOrderConfirmation(ctx context.Context, req *SendOrderConfirmationRequest, opts ...client.CallOption) (*Empty, error) {
-	resp := new(Empty)
+func (c *EmailServiceImpl) SendOrderConfirmation(ctx context.Context, req *SendOrderConfirmationRequest, opts ...client.CallOption) (*SendOrderConfirmationResponse, error) {
+	resp := new(SendOrderConfirmationResponse)
 	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "SendOrderConfirmation", opts...); err != nil {
 		return nil, err
 	}
@@ -551,6 +760,218 @@ func (c *AdServiceImpl) GetAds(ctx context.Context, req *AdRequest, opts ...clie
 	return resp, nil
 }
 
+func (c *AdServiceImpl) ReportAdEvent(ctx context.Context, req *AdEventRequest, opts ...client.CallOption) (*AdEventResponse, error) {
+	resp := new(AdEventResponse)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "ReportAdEvent", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *AdServiceImpl) GetCampaignStats(ctx context.Context, req *CampaignStatsRequest, opts ...client.CallOption) (*CampaignStatsResponse, error) {
+	resp := new(CampaignStatsResponse)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "GetCampaignStats", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// NewUserService constructs a client for the hipstershop.UserService service.
+func NewUserService(cli *client.Client, opts ...client.ReferenceOption) (UserService, error) {
+	conn, err := cli.DialWithInfo("hipstershop.UserService", &UserService_ClientInfo, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &UserServiceImpl{
+		conn: conn,
+	}, nil
+}
+
+func SetConsumerUserService(srv common.RPCService) {
+	dubbo.SetConsumerServiceWithInfo(srv, &UserService_ClientInfo)
+}
+
+// UserServiceImpl implements UserService.
+type UserServiceImpl struct {
+	conn *client.Connection
+}
+
+func (c *UserServiceImpl) Register(ctx context.Context, req *RegisterRequest, opts ...client.CallOption) (*AuthResponse, error) {
+	resp := new(AuthResponse)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "Register", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *UserServiceImpl) Login(ctx context.Context, req *LoginRequest, opts ...client.CallOption) (*AuthResponse, error) {
+	resp := new(AuthResponse)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "Login", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *UserServiceImpl) Logout(ctx context.Context, req *LogoutRequest, opts ...client.CallOption) (*Empty, error) {
+	resp := new(Empty)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "Logout", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *UserServiceImpl) VerifyToken(ctx context.Context, req *VerifyTokenRequest, opts ...client.CallOption) (*User, error) {
+	resp := new(User)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "VerifyToken", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *UserServiceImpl) GetProfile(ctx context.Context, req *GetProfileRequest, opts ...client.CallOption) (*User, error) {
+	resp := new(User)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "GetProfile", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *UserServiceImpl) AddAddress(ctx context.Context, req *AddAddressRequest, opts ...client.CallOption) (*User, error) {
+	resp := new(User)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "AddAddress", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *UserServiceImpl) DeleteAddress(ctx context.Context, req *DeleteAddressRequest, opts ...client.CallOption) (*User, error) {
+	resp := new(User)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "DeleteAddress", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *UserServiceImpl) AddCard(ctx context.Context, req *AddCardRequest, opts ...client.CallOption) (*User, error) {
+	resp := new(User)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "AddCard", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *UserServiceImpl) DeleteCard(ctx context.Context, req *DeleteCardRequest, opts ...client.CallOption) (*User, error) {
+	resp := new(User)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "DeleteCard", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *UserServiceImpl) GetCard(ctx context.Context, req *GetCardRequest, opts ...client.CallOption) (*CreditCardInfo, error) {
+	resp := new(CreditCardInfo)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "GetCard", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// NewInventoryService constructs a client for the hipstershop.InventoryService service.
+func NewInventoryService(cli *client.Client, opts ...client.ReferenceOption) (InventoryService, error) {
+	conn, err := cli.DialWithInfo("hipstershop.InventoryService", &InventoryService_ClientInfo, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &InventoryServiceImpl{
+		conn: conn,
+	}, nil
+}
+
+func SetConsumerInventoryService(srv common.RPCService) {
+	dubbo.SetConsumerServiceWithInfo(srv, &InventoryService_ClientInfo)
+}
+
+// InventoryServiceImpl implements InventoryService.
+type InventoryServiceImpl struct {
+	conn *client.Connection
+}
+
+func (c *InventoryServiceImpl) GetStock(ctx context.Context, req *GetStockRequest, opts ...client.CallOption) (*GetStockResponse, error) {
+	resp := new(GetStockResponse)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "GetStock", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *InventoryServiceImpl) Reserve(ctx context.Context, req *ReserveRequest, opts ...client.CallOption) (*Reservation, error) {
+	resp := new(Reservation)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "Reserve", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *InventoryServiceImpl) Commit(ctx context.Context, req *CommitRequest, opts ...client.CallOption) (*Empty, error) {
+	resp := new(Empty)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "Commit", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *InventoryServiceImpl) Release(ctx context.Context, req *ReleaseRequest, opts ...client.CallOption) (*Empty, error) {
+	resp := new(Empty)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "Release", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// NewPromotionService constructs a client for the hipstershop.PromotionService service.
+func NewPromotionService(cli *client.Client, opts ...client.ReferenceOption) (PromotionService, error) {
+	conn, err := cli.DialWithInfo("hipstershop.PromotionService", &PromotionService_ClientInfo, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &PromotionServiceImpl{
+		conn: conn,
+	}, nil
+}
+
+func SetConsumerPromotionService(srv common.RPCService) {
+	dubbo.SetConsumerServiceWithInfo(srv, &PromotionService_ClientInfo)
+}
+
+// PromotionServiceImpl implements PromotionService.
+type PromotionServiceImpl struct {
+	conn *client.Connection
+}
+
+func (c *PromotionServiceImpl) Evaluate(ctx context.Context, req *EvaluateRequest, opts ...client.CallOption) (*EvaluateResponse, error) {
+	resp := new(EvaluateResponse)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "Evaluate", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *PromotionServiceImpl) Redeem(ctx context.Context, req *RedeemRequest, opts ...client.CallOption) (*EvaluateResponse, error) {
+	resp := new(EvaluateResponse)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "Redeem", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *PromotionServiceImpl) CancelRedemption(ctx context.Context, req *CancelRedemptionRequest, opts ...client.CallOption) (*Empty, error) {
+	resp := new(Empty)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "CancelRedemption", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 var CartService_ClientInfo = client.ClientInfo{
 	InterfaceName: "hipstershop.CartService",
 	MethodNames:   []string{"AddItem", "GetCart", "EmptyCart"},
@@ -561,7 +982,7 @@ var CartService_ClientInfo = client.ClientInfo{
 }
 var RecommendationService_ClientInfo = client.ClientInfo{
 	InterfaceName: "hipstershop.RecommendationService",
-	MethodNames:   []string{"ListRecommendations"},
+	MethodNames:   []string{"ListRecommendations", "RecordPurchase"},
 	ConnectionInjectFunc: func(dubboCliRaw interface{}, conn *client.Connection) {
 		dubboCli := dubboCliRaw.(*RecommendationServiceImpl)
 		dubboCli.conn = conn
@@ -569,7 +990,7 @@ var RecommendationService_ClientInfo = client.ClientInfo{
 }
 var ProductCatalogService_ClientInfo = client.ClientInfo{
 	InterfaceName: "hipstershop.ProductCatalogService",
-	MethodNames:   []string{"ListProducts", "GetProduct", "SearchProducts"},
+	MethodNames:   []string{"ListProducts", "GetProduct", "SearchProducts", "ReloadCatalog"},
 	ConnectionInjectFunc: func(dubboCliRaw interface{}, conn *client.Connection) {
 		dubboCli := dubboCliRaw.(*ProductCatalogServiceImpl)
 		dubboCli.conn = conn
@@ -577,7 +998,7 @@ var ProductCatalogService_ClientInfo = client.ClientInfo{
 }
 var ShippingService_ClientInfo = client.ClientInfo{
 	InterfaceName: "hipstershop.ShippingService",
-	MethodNames:   []string{"GetQuote", "ShipOrder"},
+	MethodNames:   []string{"GetQuote", "ShipOrder", "TrackShipment", "WatchShipment"},
 	ConnectionInjectFunc: func(dubboCliRaw interface{}, conn *client.Connection) {
 		dubboCli := dubboCliRaw.(*ShippingServiceImpl)
 		dubboCli.conn = conn
@@ -593,7 +1014,7 @@ var CurrencyService_ClientInfo = client.ClientInfo{
 }
 var PaymentService_ClientInfo = client.ClientInfo{
 	InterfaceName: "hipstershop.PaymentService",
-	MethodNames:   []string{"Charge"},
+	MethodNames:   []string{"Charge", "Refund"},
 	ConnectionInjectFunc: func(dubboCliRaw interface{}, conn *client.Connection) {
 		dubboCli := dubboCliRaw.(*PaymentServiceImpl)
 		dubboCli.conn = conn
@@ -617,12 +1038,36 @@ var CheckoutService_ClientInfo = client.ClientInfo{
 }
 var AdService_ClientInfo = client.ClientInfo{
 	InterfaceName: "hipstershop.AdService",
-	MethodNames:   []string{"GetAds"},
+	MethodNames:   []string{"GetAds", "ReportAdEvent", "GetCampaignStats"},
 	ConnectionInjectFunc: func(dubboCliRaw interface{}, conn *client.Connection) {
 		dubboCli := dubboCliRaw.(*AdServiceImpl)
 		dubboCli.conn = conn
 	},
 }
+var UserService_ClientInfo = client.ClientInfo{
+	InterfaceName: "hipstershop.UserService",
+	MethodNames:   []string{"Register", "Login", "Logout", "VerifyToken", "GetProfile", "AddAddress", "DeleteAddress", "AddCard", "DeleteCard", "GetCard"},
+	ConnectionInjectFunc: func(dubboCliRaw interface{}, conn *client.Connection) {
+		dubboCli := dubboCliRaw.(*UserServiceImpl)
+		dubboCli.conn = conn
+	},
+}
+var InventoryService_ClientInfo = client.ClientInfo{
+	InterfaceName: "hipstershop.InventoryService",
+	MethodNames:   []string{"GetStock", "Reserve", "Commit", "Release"},
+	ConnectionInjectFunc: func(dubboCliRaw interface{}, conn *client.Connection) {
+		dubboCli := dubboCliRaw.(*InventoryServiceImpl)
+		dubboCli.conn = conn
+	},
+}
+var PromotionService_ClientInfo = client.ClientInfo{
+	InterfaceName: "hipstershop.PromotionService",
+	MethodNames:   []string{"Evaluate", "Redeem", "CancelRedemption"},
+	ConnectionInjectFunc: func(dubboCliRaw interface{}, conn *client.Connection) {
+		dubboCli := dubboCliRaw.(*PromotionServiceImpl)
+		dubboCli.conn = conn
+	},
+}
 
 // CartServiceHandler is an implementation of the hipstershop.CartService service.
 type CartServiceHandler interface {
@@ -642,6 +1087,7 @@ func SetProviderCartService(srv common.RPCService) {
 // RecommendationServiceHandler is an implementation of the hipstershop.RecommendationService service.
 type RecommendationServiceHandler interface {
 	ListRecommendations(context.Context, *ListRecommendationsRequest) (*ListRecommendationsResponse, error)
+	RecordPurchase(context.Context, *RecordPurchaseRequest) (*Empty, error)
 }
 
 func RegisterRecommendationServiceHandler(srv *server.Server, hdlr RecommendationServiceHandler, opts ...server.ServiceOption) error {
@@ -657,6 +1103,7 @@ type ProductCatalogServiceHandler interface {
 	ListProducts(context.Context, *Empty) (*ListProductsResponse, error)
 	GetProduct(context.Context, *GetProductRequest) (*Product, error)
 	SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error)
+	ReloadCatalog(context.Context, *ReloadCatalogRequest) (*ReloadCatalogResponse, error)
 }
 
 func RegisterProductCatalogServiceHandler(srv *server.Server, hdlr ProductCatalogServiceHandler, opts ...server.ServiceOption) error {
@@ -671,6 +1118,8 @@ func SetProviderProductCatalogService(srv common.RPCService) {
 type ShippingServiceHandler interface {
 	GetQuote(context.Context, *GetQuoteRequest) (*GetQuoteResponse, error)
 	ShipOrder(context.Context, *ShipOrderRequest) (*ShipOrderResponse, error)
+	TrackShipment(context.Context, *TrackShipmentRequest) (*Shipment, error)
+	WatchShipment(context.Context, *TrackShipmentRequest, ShippingService_WatchShipmentServer) error
 }
 
 func RegisterShippingServiceHandler(srv *server.Server, hdlr ShippingServiceHandler, opts ...server.ServiceOption) error {
@@ -681,6 +1130,21 @@ func SetProviderShippingService(srv common.RPCService) {
 	dubbo.SetProviderServiceWithInfo(srv, &ShippingService_ServiceInfo)
 }
 
+type ShippingService_WatchShipmentServer interface {
+	Send(*Shipment) error
+	ResponseHeader() http.Header
+	ResponseTrailer() http.Header
+	Conn() triple_protocol.StreamingHandlerConn
+}
+
+type ShippingServiceWatchShipmentServer struct {
+	*triple_protocol.ServerStream
+}
+
+func (g *ShippingServiceWatchShipmentServer) Send(msg *Shipment) error {
+	return g.ServerStream.Send(msg)
+}
+
 // CurrencyServiceHandler is an implementation of the hipstershop.CurrencyService service.
 type CurrencyServiceHandler interface {
 	GetSupportedCurrencies(context.Context, *Empty) (*GetSupportedCurrenciesResponse, error)
@@ -698,6 +1162,7 @@ func SetProviderCurrencyService(srv common.RPCService) {
 // PaymentServiceHandler is an implementation of the hipstershop.PaymentService service.
 type PaymentServiceHandler interface {
 	Charge(context.Context, *ChargeRequest) (*ChargeResponse, error)
+	Refund(context.Context, *RefundRequest) (*RefundResponse, error)
 }
 
 func RegisterPaymentServiceHandler(srv *server.Server, hdlr PaymentServiceHandler, opts ...server.ServiceOption) error {
@@ -710,7 +1175,7 @@ func SetProviderPaymentService(srv common.RPCService) {
 
 // EmailServiceHandler is an implementation of the hipstershop.EmailService service.
 type EmailServiceHandler interface {
-	SendOrderConfirmation(context.Context, *SendOrderConfirmationRequest) (*Empty, error)
+	SendOrderConfirmation(context.Context, *SendOrderConfirmationRequest) (*SendOrderConfirmationResponse, error)
 }
 
 func RegisterEmailServiceHandler(srv *server.Server, hdlr EmailServiceHandler, opts ...server.ServiceOption) error {
@@ -737,6 +1202,8 @@ func SetProviderCheckoutService(srv common.RPCService) {
 // AdServiceHandler is an implementation of the hipstershop.AdService service.
 type AdServiceHandler interface {
 	GetAds(context.Context, *AdRequest) (*AdResponse, error)
+	ReportAdEvent(context.Context, *AdEventRequest) (*AdEventResponse, error)
+	GetCampaignStats(context.Context, *CampaignStatsRequest) (*CampaignStatsResponse, error)
 }
 
 func RegisterAdServiceHandler(srv *server.Server, hdlr AdServiceHandler, opts ...server.ServiceOption) error {
@@ -747,6 +1214,59 @@ func SetProviderAdService(srv common.RPCService) {
 	dubbo.SetProviderServiceWithInfo(srv, &AdService_ServiceInfo)
 }
 
+// UserServiceHandler is an implementation of the hipstershop.UserService service.
+type UserServiceHandler interface {
+	Register(context.Context, *RegisterRequest) (*AuthResponse, error)
+	Login(context.Context, *LoginRequest) (*AuthResponse, error)
+	Logout(context.Context, *LogoutRequest) (*Empty, error)
+	VerifyToken(context.Context, *VerifyTokenRequest) (*User, error)
+	GetProfile(context.Context, *GetProfileRequest) (*User, error)
+	AddAddress(context.Context, *AddAddressRequest) (*User, error)
+	DeleteAddress(context.Context, *DeleteAddressRequest) (*User, error)
+	AddCard(context.Context, *AddCardRequest) (*User, error)
+	DeleteCard(context.Context, *DeleteCardRequest) (*User, error)
+	GetCard(context.Context, *GetCardRequest) (*CreditCardInfo, error)
+}
+
+func RegisterUserServiceHandler(srv *server.Server, hdlr UserServiceHandler, opts ...server.ServiceOption) error {
+	return srv.Register(hdlr, &UserService_ServiceInfo, opts...)
+}
+
+func SetProviderUserService(srv common.RPCService) {
+	dubbo.SetProviderServiceWithInfo(srv, &UserService_ServiceInfo)
+}
+
+// InventoryServiceHandler is an implementation of the hipstershop.InventoryService service.
+type InventoryServiceHandler interface {
+	GetStock(context.Context, *GetStockRequest) (*GetStockResponse, error)
+	Reserve(context.Context, *ReserveRequest) (*Reservation, error)
+	Commit(context.Context, *CommitRequest) (*Empty, error)
+	Release(context.Context, *ReleaseRequest) (*Empty, error)
+}
+
+func RegisterInventoryServiceHandler(srv *server.Server, hdlr InventoryServiceHandler, opts ...server.ServiceOption) error {
+	return srv.Register(hdlr, &InventoryService_ServiceInfo, opts...)
+}
+
+func SetProviderInventoryService(srv common.RPCService) {
+	dubbo.SetProviderServiceWithInfo(srv, &InventoryService_ServiceInfo)
+}
+
+// PromotionServiceHandler is an implementation of the hipstershop.PromotionService service.
+type PromotionServiceHandler interface {
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	Redeem(context.Context, *RedeemRequest) (*EvaluateResponse, error)
+	CancelRedemption(context.Context, *CancelRedemptionRequest) (*Empty, error)
+}
+
+func RegisterPromotionServiceHandler(srv *server.Server, hdlr PromotionServiceHandler, opts ...server.ServiceOption) error {
+	return srv.Register(hdlr, &PromotionService_ServiceInfo, opts...)
+}
+
+func SetProviderPromotionService(srv common.RPCService) {
+	dubbo.SetProviderServiceWithInfo(srv, &PromotionService_ServiceInfo)
+}
+
 var CartService_ServiceInfo = server.ServiceInfo{
 	InterfaceName: "hipstershop.CartService",
 	ServiceType:   (*CartServiceHandler)(nil),
@@ -817,6 +1337,21 @@ var RecommendationService_ServiceInfo = server.ServiceInfo{
 				return triple_protocol.NewResponse(res), nil
 			},
 		},
+		{
+			Name: "RecordPurchase",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(RecordPurchaseRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*RecordPurchaseRequest)
+				res, err := handler.(RecommendationServiceHandler).RecordPurchase(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
 	},
 }
 var ProductCatalogService_ServiceInfo = server.ServiceInfo{
@@ -868,6 +1403,21 @@ var ProductCatalogService_ServiceInfo = server.ServiceInfo{
 				return triple_protocol.NewResponse(res), nil
 			},
 		},
+		{
+			Name: "ReloadCatalog",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(ReloadCatalogRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*ReloadCatalogRequest)
+				res, err := handler.(ProductCatalogServiceHandler).ReloadCatalog(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
 	},
 }
 var ShippingService_ServiceInfo = server.ServiceInfo{
@@ -904,6 +1454,39 @@ var ShippingService_ServiceInfo = server.ServiceInfo{
 				return triple_protocol.NewResponse(res), nil
 			},
 		},
+		{
+			Name: "TrackShipment",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(TrackShipmentRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*TrackShipmentRequest)
+				res, err := handler.(ShippingServiceHandler).TrackShipment(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "WatchShipment",
+			Type: constant.CallServerStream,
+			ReqInitFunc: func() interface{} {
+				return new(TrackShipmentRequest)
+			},
+			StreamInitFunc: func(baseStream interface{}) interface{} {
+				return &ShippingServiceWatchShipmentServer{baseStream.(*triple_protocol.ServerStream)}
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*TrackShipmentRequest)
+				stream := args[1].(ShippingService_WatchShipmentServer)
+				if err := handler.(ShippingServiceHandler).WatchShipment(ctx, req, stream); err != nil {
+					return nil, err
+				}
+				return nil, nil
+			},
+		},
 	},
 }
 var CurrencyService_ServiceInfo = server.ServiceInfo{
@@ -961,6 +1544,21 @@ var PaymentService_ServiceInfo = server.ServiceInfo{
 				return triple_protocol.NewResponse(res), nil
 			},
 		},
+		{
+			Name: "Refund",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(RefundRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*RefundRequest)
+				res, err := handler.(PaymentServiceHandler).Refund(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
 	},
 }
 var EmailService_ServiceInfo = server.ServiceInfo{
@@ -1024,5 +1622,308 @@ var AdService_ServiceInfo = server.ServiceInfo{
 				return triple_protocol.NewResponse(res), nil
 			},
 		},
+		{
+			Name: "ReportAdEvent",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(AdEventRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*AdEventRequest)
+				res, err := handler.(AdServiceHandler).ReportAdEvent(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "GetCampaignStats",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(CampaignStatsRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*CampaignStatsRequest)
+				res, err := handler.(AdServiceHandler).GetCampaignStats(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+	},
+}
+var UserService_ServiceInfo = server.ServiceInfo{
+	InterfaceName: "hipstershop.UserService",
+	ServiceType:   (*UserServiceHandler)(nil),
+	Methods: []server.MethodInfo{
+		{
+			Name: "Register",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(RegisterRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*RegisterRequest)
+				res, err := handler.(UserServiceHandler).Register(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "Login",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(LoginRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*LoginRequest)
+				res, err := handler.(UserServiceHandler).Login(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "Logout",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(LogoutRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*LogoutRequest)
+				res, err := handler.(UserServiceHandler).Logout(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "VerifyToken",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(VerifyTokenRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*VerifyTokenRequest)
+				res, err := handler.(UserServiceHandler).VerifyToken(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "GetProfile",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(GetProfileRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*GetProfileRequest)
+				res, err := handler.(UserServiceHandler).GetProfile(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "AddAddress",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(AddAddressRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*AddAddressRequest)
+				res, err := handler.(UserServiceHandler).AddAddress(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "DeleteAddress",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(DeleteAddressRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*DeleteAddressRequest)
+				res, err := handler.(UserServiceHandler).DeleteAddress(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "AddCard",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(AddCardRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*AddCardRequest)
+				res, err := handler.(UserServiceHandler).AddCard(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "DeleteCard",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(DeleteCardRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*DeleteCardRequest)
+				res, err := handler.(UserServiceHandler).DeleteCard(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "GetCard",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(GetCardRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*GetCardRequest)
+				res, err := handler.(UserServiceHandler).GetCard(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+	},
+}
+var InventoryService_ServiceInfo = server.ServiceInfo{
+	InterfaceName: "hipstershop.InventoryService",
+	ServiceType:   (*InventoryServiceHandler)(nil),
+	Methods: []server.MethodInfo{
+		{
+			Name: "GetStock",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(GetStockRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*GetStockRequest)
+				res, err := handler.(InventoryServiceHandler).GetStock(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "Reserve",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(ReserveRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*ReserveRequest)
+				res, err := handler.(InventoryServiceHandler).Reserve(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "Commit",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(CommitRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*CommitRequest)
+				res, err := handler.(InventoryServiceHandler).Commit(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "Release",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(ReleaseRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*ReleaseRequest)
+				res, err := handler.(InventoryServiceHandler).Release(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+	},
+}
+var PromotionService_ServiceInfo = server.ServiceInfo{
+	InterfaceName: "hipstershop.PromotionService",
+	ServiceType:   (*PromotionServiceHandler)(nil),
+	Methods: []server.MethodInfo{
+		{
+			Name: "Evaluate",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(EvaluateRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*EvaluateRequest)
+				res, err := handler.(PromotionServiceHandler).Evaluate(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "Redeem",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(RedeemRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*RedeemRequest)
+				res, err := handler.(PromotionServiceHandler).Redeem(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "CancelRedemption",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(CancelRedemptionRequest)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*CancelRedemptionRequest)
+				res, err := handler.(PromotionServiceHandler).CancelRedemption(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
 	},
 }
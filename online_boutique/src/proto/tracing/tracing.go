@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tracing configures the tracing of the boutique services from the
+// environment and carries their spans across the calls they make, see the
+// OpenTelemetry section of the README.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"dubbo.apache.org/dubbo-go/v3"
+	dubbotrace "dubbo.apache.org/dubbo-go/v3/otel/trace"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option configures tracing from TRACING_EXPORTER, TRACING_ENDPOINT and
+// TRACING_SAMPLE_RATIO. Besides the exporters of dubbo-go, it accepts the ones
+// in extraExporters, which the caller registers.
+func Option(extraExporters ...string) (dubbo.InstanceOption, error) {
+	exporter := os.Getenv("TRACING_EXPORTER")
+	switch exporter {
+	case "", "none":
+		return dubbo.WithTracing(), nil
+	case "stdout", "otlp-http", "otlp-grpc", "jaeger", "zipkin":
+	default:
+		if !contains(extraExporters, exporter) {
+			return nil, fmt.Errorf("unknown TRACING_EXPORTER %q", exporter)
+		}
+	}
+	opts := []dubbotrace.Option{
+		dubbotrace.WithEnabled(),
+		dubbotrace.WithExporter(exporter),
+		dubbotrace.WithEndpoint(os.Getenv("TRACING_ENDPOINT")),
+		dubbotrace.WithW3cPropagator(),
+		dubbotrace.WithAlwaysMode(),
+	}
+	if v := os.Getenv("TRACING_SAMPLE_RATIO"); v != "" {
+		ratio, err := strconv.ParseFloat(v, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("TRACING_SAMPLE_RATIO %q is not a ratio between 0 and 1", v)
+		}
+		opts = append(opts, dubbotrace.WithRatioMode(), dubbotrace.WithRatio(ratio))
+	}
+	return dubbo.WithTracing(opts...), nil
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// OutgoingContext returns the context for the calls made while serving a
+// call: its deadline and cancellation, its span and baggage, and nothing else.
+// dubbo-go would otherwise pass the headers the call came with on to these
+// calls and collect the headers of each call in them, so that the downstream
+// spans would hang off the caller's span or off an earlier call's.
+func OutgoingContext(ctx context.Context) context.Context {
+	values := trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ctx))
+	values = baggage.ContextWithBaggage(values, baggage.FromContext(ctx))
+	return &callContext{Context: ctx, values: values}
+}
+
+// DetachedContext returns a context with the values of ctx, its span among
+// them, but without its deadline and cancellation.
+func DetachedContext(ctx context.Context) context.Context {
+	return &callContext{Context: context.Background(), values: ctx}
+}
+
+// callContext is a context that is done with the one it embeds, but carries
+// the values of another.
+type callContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c *callContext) Value(key interface{}) interface{} {
+	return c.values.Value(key)
+}
@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOption(t *testing.T) {
+	tests := []struct {
+		exporter, ratio string
+		extra           []string
+		wantErr         bool
+	}{
+		{"", "", nil, false},
+		{"zipkin", "0.5", nil, false},
+		{"memory", "", nil, true},
+		{"memory", "", []string{"memory"}, false},
+		{"zipkin", "2", nil, true},
+		{"zipkin", "half", nil, true},
+	}
+	for _, tt := range tests {
+		t.Setenv("TRACING_EXPORTER", tt.exporter)
+		t.Setenv("TRACING_SAMPLE_RATIO", tt.ratio)
+		if _, err := Option(tt.extra...); (err != nil) != tt.wantErr {
+			t.Errorf("exporter %q ratio %q extra %v: error %v, want error %v", tt.exporter, tt.ratio, tt.extra, err, tt.wantErr)
+		}
+	}
+}
+
+type key struct{}
+
+func TestDetachedContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "v"))
+	detached := DetachedContext(ctx)
+	cancel()
+	if detached.Err() != nil {
+		t.Errorf("detached context done with its parent: %v", detached.Err())
+	}
+	if detached.Value(key{}) != "v" {
+		t.Errorf("detached context lost the values of its parent")
+	}
+}
@@ -19,66 +19,55 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
+
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 	"github.com/dubbogo/gost/log/logger"
-	"math/rand"
 
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/recommendationservice/proto"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/proto/tracing"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/recommendationservice/recommender"
 )
 
+const maxResponsesCount = 5
+
 type RecommendationService struct {
 	ProductCatalogService pb.ProductCatalogService
+	Recommender           *recommender.Recommender
+	Purchases             *recommender.PurchaseStore
 }
 
-func NewRecommendationServiceImpl(service pb.ProductCatalogService) *RecommendationService {
-	return &RecommendationService{ProductCatalogService: service}
+func NewRecommendationServiceImpl(service pb.ProductCatalogService, strategy string) (*RecommendationService, error) {
+	purchases := recommender.NewPurchaseStore()
+	r, err := recommender.New(strategy, purchases)
+	if err != nil {
+		return nil, err
+	}
+	return &RecommendationService{
+		ProductCatalogService: service,
+		Recommender:           r,
+		Purchases:             purchases,
+	}, nil
 }
 
 func (s *RecommendationService) ListRecommendations(ctx context.Context, in *pb.ListRecommendationsRequest) (*pb.ListRecommendationsResponse, error) {
-	maxResponsesCount := 5
-	out := &pb.ListRecommendationsResponse{}
 	// # fetch list of products from product catalog stub
-	catalog, err := s.ProductCatalogService.ListProducts(ctx, &pb.Empty{})
+	catalog, err := s.ProductCatalogService.ListProducts(tracing.OutgoingContext(ctx), &pb.Empty{})
 	if err != nil {
-		return nil, nil
-	}
-	filteredProductsIDs := make([]string, 0, len(catalog.Products))
-	for _, p := range catalog.Products {
-		if contains(p.Id, in.ProductIds) {
-			continue
-		}
-		filteredProductsIDs = append(filteredProductsIDs, p.Id)
+		logger.Errorf("[ListRecommendations] failed to list products: %v", err)
+		return nil, triple.NewError(triple.CodeUnavailable, fmt.Errorf("failed to list products: %v", err))
 	}
-	productIDs := sample(filteredProductsIDs, maxResponsesCount)
-	logger.Infof("[Recv ListRecommendations] product_ids=%v", productIDs)
-	out.ProductIds = productIDs
-	return out, nil
+	productIDs := s.Recommender.Recommend(catalog.Products, in.ProductIds, maxResponsesCount)
+	logger.Infof("[Recv ListRecommendations] strategy=%s product_ids=%v", s.Recommender.Strategy(), productIDs)
+	return &pb.ListRecommendationsResponse{ProductIds: productIDs}, nil
 }
 
-func contains(target string, source []string) bool {
-	for _, s := range source {
-		if target == s {
-			return true
-		}
-	}
-	return false
-}
-
-func sample(source []string, c int) []string {
-	n := len(source)
-	if n <= c {
-		return source
-	}
-	indices := make([]int, n)
-	for i := 0; i < n; i++ {
-		indices[i] = i
-	}
-	for i := n - 1; i > 0; i-- {
-		j := rand.Intn(i + 1)
-		indices[i], indices[j] = indices[j], indices[i]
-	}
-	result := make([]string, 0, c)
-	for i := 0; i < c; i++ {
-		result = append(result, source[indices[i]])
+func (s *RecommendationService) RecordPurchase(ctx context.Context, in *pb.RecordPurchaseRequest) (*pb.Empty, error) {
+	if len(in.ProductIds) == 0 {
+		return nil, triple.NewError(triple.CodeInvalidArgument, errors.New("purchase contains no products"))
 	}
-	return result
+	s.Purchases.Record(in.ProductIds)
+	logger.Infof("[Recv RecordPurchase] user_id=%s product_ids=%v", in.UserId, in.ProductIds)
+	return &pb.Empty{}, nil
 }
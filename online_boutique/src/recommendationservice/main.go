@@ -22,8 +22,10 @@ import (
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
 	"dubbo.apache.org/dubbo-go/v3/protocol"
 	"dubbo.apache.org/dubbo-go/v3/registry"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/proto/tracing"
 	"github.com/apache/dubbo-go-samples/online_boutique_demo/recommendationservice/handler"
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/recommendationservice/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/recommendationservice/recommender"
 	"github.com/dubbogo/gost/log/logger"
 	"os"
 )
@@ -33,9 +35,19 @@ func main() {
 	if regAddr == "" {
 		regAddr = "127.0.0.1:2181"
 	}
+	strategy := os.Getenv("RECOMMENDATION_STRATEGY")
+	if strategy == "" {
+		strategy = recommender.StrategyHybrid
+	}
+
+	withTracing, err := tracing.Option()
+	if err != nil {
+		panic(err)
+	}
 
 	ins, err := dubbo.NewInstance(
 		dubbo.WithName("recommendationservice"),
+		withTracing,
 		dubbo.WithRegistry(
 			registry.WithZookeeper(),
 			registry.WithAddress(regAddr),
@@ -50,10 +62,17 @@ func main() {
 	}
 
 	client, err := ins.NewClient()
+	if err != nil {
+		panic(err)
+	}
 	productCatalogService, err := pb.NewProductCatalogService(client)
 	if err != nil {
 		panic(err)
 	}
+	recommendationService, err := handler.NewRecommendationServiceImpl(productCatalogService, strategy)
+	if err != nil {
+		panic(err)
+	}
 
 	//server
 	srv, err := ins.NewServer()
@@ -61,7 +80,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := pb.RegisterRecommendationServiceHandler(srv, handler.NewRecommendationServiceImpl(productCatalogService)); err != nil {
+	if err := pb.RegisterRecommendationServiceHandler(srv, recommendationService); err != nil {
 		panic(err)
 	}
 
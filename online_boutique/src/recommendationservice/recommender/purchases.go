@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recommender
+
+import (
+	"sync"
+)
+
+// PurchaseStore keeps in memory how often two products were bought in the
+// same order.
+type PurchaseStore struct {
+	sync.RWMutex
+
+	pairs map[string]map[string]int
+}
+
+func NewPurchaseStore() *PurchaseStore {
+	return &PurchaseStore{
+		pairs: make(map[string]map[string]int),
+	}
+}
+
+// Record adds one order. Duplicate product IDs within the order are ignored.
+func (s *PurchaseStore) Record(productIDs []string) {
+	unique := make([]string, 0, len(productIDs))
+	seen := make(map[string]bool, len(productIDs))
+	for _, id := range productIDs {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	for _, a := range unique {
+		for _, b := range unique {
+			if a == b {
+				continue
+			}
+			counts, ok := s.pairs[a]
+			if !ok {
+				counts = make(map[string]int)
+				s.pairs[a] = counts
+			}
+			counts[b]++
+		}
+	}
+}
+
+// Count returns the number of orders containing both a and b.
+func (s *PurchaseStore) Count(a, b string) int {
+	s.RLock()
+	defer s.RUnlock()
+	return s.pairs[a][b]
+}
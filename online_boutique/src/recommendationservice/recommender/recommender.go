@@ -0,0 +1,143 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recommender
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+const (
+	StrategyRandom     = "random"
+	StrategyCategory   = "category"
+	StrategyCoPurchase = "copurchase"
+	StrategyHybrid     = "hybrid"
+)
+
+// Scorer rates how well a candidate fits the products a user is looking at.
+// A score of zero means the scorer has no opinion about the candidate.
+type Scorer interface {
+	Score(seen []*pb.Product, candidate *pb.Product) float64
+}
+
+// Recommender ranks catalog products with a Scorer. Slots the scorer cannot
+// fill are sampled at random, so a cold start behaves like plain sampling.
+type Recommender struct {
+	strategy string
+	scorer   Scorer
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// New returns a Recommender for the named strategy. Co-purchase based
+// strategies read their statistics from purchases.
+func New(strategy string, purchases *PurchaseStore) (*Recommender, error) {
+	return NewWithSeed(strategy, purchases, time.Now().UnixNano())
+}
+
+// NewWithSeed is like New but makes the random fallback reproducible.
+func NewWithSeed(strategy string, purchases *PurchaseStore, seed int64) (*Recommender, error) {
+	r := &Recommender{strategy: strategy, rnd: rand.New(rand.NewSource(seed))}
+	switch strategy {
+	case StrategyRandom:
+	case StrategyCategory:
+		r.scorer = categoryScorer{}
+	case StrategyCoPurchase:
+		r.scorer = coPurchaseScorer{purchases}
+	case StrategyHybrid:
+		r.scorer = hybridScorer{
+			{categoryScorer{}, categoryWeight},
+			{coPurchaseScorer{purchases}, coPurchaseWeight},
+		}
+	default:
+		return nil, fmt.Errorf("unknown recommendation strategy %q", strategy)
+	}
+	return r, nil
+}
+
+func (r *Recommender) Strategy() string {
+	return r.strategy
+}
+
+// Recommend returns up to limit product IDs from catalog, excluding the
+// products in seenIDs.
+func (r *Recommender) Recommend(catalog []*pb.Product, seenIDs []string, limit int) []string {
+	excluded := make(map[string]bool, len(seenIDs))
+	for _, id := range seenIDs {
+		excluded[id] = true
+	}
+	seen := make([]*pb.Product, 0, len(seenIDs))
+	candidates := make([]*pb.Product, 0, len(catalog))
+	for _, p := range catalog {
+		if excluded[p.Id] {
+			seen = append(seen, p)
+			continue
+		}
+		candidates = append(candidates, p)
+	}
+
+	type scored struct {
+		id    string
+		score float64
+	}
+	var ranked []scored
+	var rest []string
+	for _, p := range candidates {
+		score := 0.0
+		if r.scorer != nil && len(seen) > 0 {
+			score = r.scorer.Score(seen, p)
+		}
+		if score > 0 {
+			ranked = append(ranked, scored{p.Id, score})
+		} else {
+			rest = append(rest, p.Id)
+		}
+	}
+	// stable so that equally scored products keep catalog order
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+
+	out := make([]string, 0, limit)
+	for _, s := range ranked {
+		if len(out) == limit {
+			return out
+		}
+		out = append(out, s.id)
+	}
+	return append(out, r.sample(rest, limit-len(out))...)
+}
+
+func (r *Recommender) sample(source []string, c int) []string {
+	n := len(source)
+	if n <= c {
+		return source
+	}
+	r.mu.Lock()
+	indices := r.rnd.Perm(n)
+	r.mu.Unlock()
+	result := make([]string, 0, c)
+	for i := 0; i < c; i++ {
+		result = append(result, source[indices[i]])
+	}
+	return result
+}
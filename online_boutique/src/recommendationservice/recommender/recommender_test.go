@@ -0,0 +1,145 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recommender
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+const (
+	sunglasses   = "OLJCESPC7Z"
+	tankTop      = "66VCHSJNUP"
+	watch        = "1YMWWN1N4O"
+	loafers      = "L9ECAV7KIM"
+	hairdryer    = "2ZYFJ3GM2N"
+	candleHolder = "0PUK6V6EV0"
+	saltPepper   = "LS4PSXUNUM"
+	bambooJar    = "9SIQT8TOJO"
+	mug          = "6E92ZMYYFZ"
+)
+
+// loadFixture reads the catalog and the recorded orders from testdata.
+func loadFixture(t *testing.T) ([]*pb.Product, *PurchaseStore) {
+	t.Helper()
+	raw, err := os.ReadFile("testdata/products.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	catalog := &pb.ListProductsResponse{}
+	if err := protojson.Unmarshal(raw, catalog); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err = os.ReadFile("testdata/orders.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var orders struct {
+		Orders [][]string `json:"orders"`
+	}
+	if err := json.Unmarshal(raw, &orders); err != nil {
+		t.Fatal(err)
+	}
+	purchases := NewPurchaseStore()
+	for _, o := range orders.Orders {
+		purchases.Record(o)
+	}
+	return catalog.Products, purchases
+}
+
+func TestRecommendRanking(t *testing.T) {
+	catalog, purchases := loadFixture(t)
+	tests := []struct {
+		name     string
+		strategy string
+		seen     []string
+		// wantTop is the ranked prefix, the remaining slots are sampled
+		wantTop []string
+	}{
+		{"category", StrategyCategory, []string{sunglasses}, []string{watch}},
+		{"category with several seen products", StrategyCategory, []string{mug, saltPepper}, []string{bambooJar}},
+		{"copurchase", StrategyCoPurchase, []string{sunglasses}, []string{tankTop, loafers}},
+		{"hybrid", StrategyHybrid, []string{sunglasses}, []string{tankTop, loafers, watch}},
+		{"hybrid ties keep catalog order", StrategyHybrid, []string{mug}, []string{candleHolder, hairdryer, saltPepper, bambooJar}},
+		{"nothing seen", StrategyHybrid, nil, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewWithSeed(tt.strategy, purchases, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := r.Recommend(catalog, tt.seen, 5)
+			if len(got) != 5 {
+				t.Fatalf("Recommend(%v) returned %d products, want 5", tt.seen, len(got))
+			}
+			if top := got[:len(tt.wantTop)]; !reflect.DeepEqual(top, tt.wantTop) {
+				t.Errorf("Recommend(%v) = %v, want it to start with %v", tt.seen, got, tt.wantTop)
+			}
+			assertValid(t, got, tt.seen)
+		})
+	}
+}
+
+func TestRecommendRandom(t *testing.T) {
+	catalog, purchases := loadFixture(t)
+	r, err := NewWithSeed(StrategyRandom, purchases, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := []string{sunglasses, mug}
+	got := r.Recommend(catalog, seen, 5)
+	if len(got) != 5 {
+		t.Fatalf("Recommend(%v) returned %d products, want 5", seen, len(got))
+	}
+	assertValid(t, got, seen)
+
+	// a catalog smaller than the limit is returned whole
+	if got := r.Recommend(catalog[:3], []string{sunglasses}, 5); len(got) != 2 {
+		t.Errorf("Recommend on a small catalog = %v, want 2 products", got)
+	}
+}
+
+func TestNewRejectsUnknownStrategy(t *testing.T) {
+	if _, err := New("popularity", NewPurchaseStore()); err == nil {
+		t.Errorf("New accepted an unknown strategy")
+	}
+}
+
+func assertValid(t *testing.T, got, seen []string) {
+	t.Helper()
+	ids := make(map[string]bool, len(got))
+	for _, id := range got {
+		if ids[id] {
+			t.Errorf("product %s recommended twice in %v", id, got)
+		}
+		ids[id] = true
+	}
+	for _, id := range seen {
+		if ids[id] {
+			t.Errorf("seen product %s recommended in %v", id, got)
+		}
+	}
+}
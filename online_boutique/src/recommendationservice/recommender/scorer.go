@@ -0,0 +1,81 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package recommender
+
+import (
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+// Weights of the hybrid strategy. Co-purchases reflect what shoppers actually
+// bought together, so they count more than a shared category.
+const (
+	categoryWeight   = 1.0
+	coPurchaseWeight = 2.0
+)
+
+// categoryScorer counts the categories a candidate shares with each of the
+// seen products.
+type categoryScorer struct{}
+
+func (categoryScorer) Score(seen []*pb.Product, candidate *pb.Product) float64 {
+	score := 0
+	for _, p := range seen {
+		for _, c := range candidate.Categories {
+			for _, other := range p.Categories {
+				if c == other {
+					score++
+					break
+				}
+			}
+		}
+	}
+	return float64(score)
+}
+
+// coPurchaseScorer counts how often a candidate was ordered together with
+// each of the seen products.
+type coPurchaseScorer struct {
+	purchases *PurchaseStore
+}
+
+func (s coPurchaseScorer) Score(seen []*pb.Product, candidate *pb.Product) float64 {
+	if s.purchases == nil {
+		return 0
+	}
+	score := 0
+	for _, p := range seen {
+		score += s.purchases.Count(p.Id, candidate.Id)
+	}
+	return float64(score)
+}
+
+type weightedScorer struct {
+	Scorer
+	weight float64
+}
+
+// hybridScorer sums the weighted scores of several scorers.
+type hybridScorer []weightedScorer
+
+func (h hybridScorer) Score(seen []*pb.Product, candidate *pb.Product) float64 {
+	score := 0.0
+	for _, s := range h {
+		score += s.weight * s.Score(seen, candidate)
+	}
+	return score
+}
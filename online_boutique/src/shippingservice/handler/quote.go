@@ -18,8 +18,12 @@
 package handler
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"strings"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
 )
 
 // Quote represents a currency value.
@@ -30,12 +34,7 @@ type Quote struct {
 
 // String representation of the Quote.
 func (q Quote) String() string {
-	return fmt.Sprintf("$%d.%d", q.Dollars, q.Cents)
-}
-
-// CreateQuoteFromCount takes a number of items and returns a Price struct.
-func CreateQuoteFromCount(count int) Quote {
-	return CreateQuoteFromFloat(8.99)
+	return fmt.Sprintf("$%d.%02d", q.Dollars, q.Cents)
 }
 
 // CreateQuoteFromFloat takes a price represented as a float and creates a Price struct.
@@ -43,6 +42,97 @@ func CreateQuoteFromFloat(value float64) Quote {
 	units, fraction := math.Modf(value)
 	return Quote{
 		uint32(units),
-		uint32(math.Trunc(fraction * 100)),
+		uint32(math.Round(fraction * 100)),
+	}
+}
+
+// CreateQuoteFromCents takes a price in cents and creates a Price struct.
+func CreateQuoteFromCents(cents int64) Quote {
+	return Quote{
+		uint32(cents / 100),
+		uint32(cents % 100),
 	}
 }
+
+// Rate is the price of shipping to one zone. A shipment costs the base price
+// plus a fee per item and per started kilogram.
+type Rate struct {
+	BaseCents    int64 `json:"base_cents"`
+	PerItemCents int64 `json:"per_item_cents"`
+	PerKgCents   int64 `json:"per_kg_cents"`
+}
+
+// Zone groups destination countries that share a rate.
+type Zone struct {
+	Name      string   `json:"name"`
+	Countries []string `json:"countries"`
+}
+
+// RateTable prices shipments by weight and destination zone.
+type RateTable struct {
+	DefaultItemWeightGrams int64            `json:"default_item_weight_grams"`
+	ItemWeightGrams        map[string]int64 `json:"item_weight_grams"`
+	Zones                  []Zone           `json:"zones"`
+	DefaultZone            string           `json:"default_zone"`
+	Rates                  map[string]Rate  `json:"rates"`
+
+	countryZones map[string]string
+}
+
+// ParseRateTable reads a rate table from its JSON representation.
+func ParseRateTable(data []byte) (*RateTable, error) {
+	t := &RateTable{}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, fmt.Errorf("failed to parse rate table: %w", err)
+	}
+	if _, ok := t.Rates[t.DefaultZone]; !ok {
+		return nil, fmt.Errorf("rate table has no rate for default zone %q", t.DefaultZone)
+	}
+	if t.DefaultItemWeightGrams <= 0 {
+		return nil, fmt.Errorf("rate table default item weight must be positive")
+	}
+	t.countryZones = make(map[string]string)
+	for _, z := range t.Zones {
+		if _, ok := t.Rates[z.Name]; !ok {
+			return nil, fmt.Errorf("rate table has no rate for zone %q", z.Name)
+		}
+		for _, c := range z.Countries {
+			t.countryZones[normalizeCountry(c)] = z.Name
+		}
+	}
+	return t, nil
+}
+
+// Zone returns the name of the zone the address belongs to.
+func (t *RateTable) Zone(address *pb.Address) string {
+	if zone, ok := t.countryZones[normalizeCountry(address.GetCountry())]; ok {
+		return zone
+	}
+	return t.DefaultZone
+}
+
+// Quote prices shipping the items to the address. Nothing to ship is free.
+func (t *RateTable) Quote(address *pb.Address, items []*pb.CartItem) Quote {
+	var count, grams int64
+	for _, item := range items {
+		if item.GetQuantity() <= 0 {
+			continue
+		}
+		weight, ok := t.ItemWeightGrams[item.GetProductId()]
+		if !ok {
+			weight = t.DefaultItemWeightGrams
+		}
+		count += int64(item.GetQuantity())
+		grams += weight * int64(item.GetQuantity())
+	}
+	if count == 0 {
+		return Quote{}
+	}
+	rate := t.Rates[t.Zone(address)]
+	kilograms := (grams + 999) / 1000
+	return CreateQuoteFromCents(rate.BaseCents + rate.PerItemCents*count + rate.PerKgCents*kilograms)
+}
+
+func normalizeCountry(country string) string {
+	return strings.ToLower(strings.TrimSpace(country))
+}
@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+func TestRateTableQuote(t *testing.T) {
+	data, err := os.ReadFile("../data/rates.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rates, err := ParseRateTable(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		country string
+		items   []*pb.CartItem
+		want    string
+	}{
+		{"domestic", "United States", []*pb.CartItem{{ProductId: "OLJCESPC7Z", Quantity: 2}}, "$9.99"},
+		{"country code is case insensitive", " de ", []*pb.CartItem{{ProductId: "L9ECAV7KIM", Quantity: 1}}, "$30.99"},
+		{"unknown country and product", "Japan", []*pb.CartItem{{ProductId: "UNKNOWN", Quantity: 3}}, "$45.49"},
+		{"nothing to ship", "United States", nil, "$0.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rates.Quote(&pb.Address{Country: tt.country}, tt.items)
+			if got.String() != tt.want {
+				t.Errorf("Quote(%q) = %s, want %s", tt.country, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeline(t *testing.T) {
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	r := newShipmentRecord("AB-1-2", &pb.Address{Country: "US"}, nil, created)
+	tests := []struct {
+		after      time.Duration
+		wantStatus pb.ShipmentStatus
+		wantEvents int
+		wantNext   time.Duration
+	}{
+		{0, pb.ShipmentStatus_LABEL_CREATED, 1, time.Minute},
+		{2 * time.Minute, pb.ShipmentStatus_IN_TRANSIT, 2, 5 * time.Minute},
+		{5 * time.Minute, pb.ShipmentStatus_DELIVERED, 3, -1},
+	}
+	for _, tt := range tests {
+		now := created.Add(tt.after)
+		got := DefaultTimeline.shipment(r, now)
+		if got.Status != tt.wantStatus || len(got.Events) != tt.wantEvents {
+			t.Errorf("after %s: status %s with %d events, want %s with %d", tt.after, got.Status, len(got.Events), tt.wantStatus, tt.wantEvents)
+		}
+		next, ok := DefaultTimeline.nextChange(r, now)
+		if tt.wantNext < 0 {
+			if ok {
+				t.Errorf("after %s: next change at %s, want none", tt.after, next)
+			}
+		} else if !ok || !next.Equal(created.Add(tt.wantNext)) {
+			t.Errorf("after %s: next change at %s, want %s", tt.after, next, created.Add(tt.wantNext))
+		}
+	}
+}
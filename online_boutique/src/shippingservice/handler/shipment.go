@@ -0,0 +1,217 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+// Timeline is the simulated progress of a shipment: the label is created when
+// the order ships, the parcel goes in transit after InTransitAfter and is
+// delivered after DeliveredAfter, both measured from the label creation.
+type Timeline struct {
+	InTransitAfter time.Duration
+	DeliveredAfter time.Duration
+}
+
+var DefaultTimeline = Timeline{
+	InTransitAfter: time.Minute,
+	DeliveredAfter: 5 * time.Minute,
+}
+
+func (t Timeline) Validate() error {
+	if t.InTransitAfter < 0 || t.DeliveredAfter < t.InTransitAfter {
+		return fmt.Errorf("invalid shipment timeline: in transit after %s, delivered after %s", t.InTransitAfter, t.DeliveredAfter)
+	}
+	return nil
+}
+
+// shipment renders the state of r as observed at now.
+func (t Timeline) shipment(r *shipmentRecord, now time.Time) *pb.Shipment {
+	out := &pb.Shipment{
+		TrackingId: r.TrackingID,
+		Address: &pb.Address{
+			StreetAddress: r.Address.StreetAddress,
+			City:          r.Address.City,
+			State:         r.Address.State,
+			Country:       r.Address.Country,
+			ZipCode:       r.Address.ZipCode,
+		},
+		EstimatedDelivery: timestamppb.New(r.CreatedAt.Add(t.DeliveredAfter)),
+	}
+	for _, item := range r.Items {
+		out.Items = append(out.Items, &pb.CartItem{ProductId: item.ProductID, Quantity: item.Quantity})
+	}
+	steps := []struct {
+		status pb.ShipmentStatus
+		after  time.Duration
+	}{
+		{pb.ShipmentStatus_LABEL_CREATED, 0},
+		{pb.ShipmentStatus_IN_TRANSIT, t.InTransitAfter},
+		{pb.ShipmentStatus_DELIVERED, t.DeliveredAfter},
+	}
+	for _, step := range steps {
+		at := r.CreatedAt.Add(step.after)
+		if at.After(now) {
+			break
+		}
+		out.Status = step.status
+		out.Events = append(out.Events, &pb.ShipmentEvent{Status: step.status, Time: timestamppb.New(at)})
+	}
+	return out
+}
+
+// nextChange returns when the shipment reaches its next status, or false when
+// it has already been delivered.
+func (t Timeline) nextChange(r *shipmentRecord, now time.Time) (time.Time, bool) {
+	for _, after := range []time.Duration{t.InTransitAfter, t.DeliveredAfter} {
+		if at := r.CreatedAt.Add(after); at.After(now) {
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+type addressRecord struct {
+	StreetAddress string `json:"street_address"`
+	City          string `json:"city"`
+	State         string `json:"state"`
+	Country       string `json:"country"`
+	ZipCode       int32  `json:"zip_code"`
+}
+
+type itemRecord struct {
+	ProductID string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
+
+type shipmentRecord struct {
+	TrackingID string        `json:"tracking_id"`
+	CreatedAt  time.Time     `json:"created_at"`
+	Address    addressRecord `json:"address"`
+	Items      []itemRecord  `json:"items"`
+}
+
+func newShipmentRecord(trackingID string, address *pb.Address, items []*pb.CartItem, createdAt time.Time) *shipmentRecord {
+	r := &shipmentRecord{
+		TrackingID: trackingID,
+		CreatedAt:  createdAt.UTC(),
+		Address: addressRecord{
+			StreetAddress: address.GetStreetAddress(),
+			City:          address.GetCity(),
+			State:         address.GetState(),
+			Country:       address.GetCountry(),
+			ZipCode:       address.GetZipCode(),
+		},
+	}
+	for _, item := range items {
+		r.Items = append(r.Items, itemRecord{ProductID: item.GetProductId(), Quantity: item.GetQuantity()})
+	}
+	return r
+}
+
+// ShipmentStore keeps shipments in memory and mirrors them to a JSON file, so
+// that tracking survives restarts. An empty path keeps them in memory only.
+type ShipmentStore struct {
+	sync.RWMutex
+
+	path      string
+	shipments map[string]*shipmentRecord
+}
+
+func NewShipmentStore(path string) (*ShipmentStore, error) {
+	s := &ShipmentStore{
+		path:      path,
+		shipments: make(map[string]*shipmentRecord),
+	}
+	if path == "" {
+		return s, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shipments: %w", err)
+	}
+	var records []*shipmentRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse shipments: %w", err)
+	}
+	for _, r := range records {
+		s.shipments[r.TrackingID] = r
+	}
+	return s, nil
+}
+
+func (s *ShipmentStore) add(r *shipmentRecord) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.shipments[r.TrackingID]; ok {
+		return fmt.Errorf("duplicate tracking id %s", r.TrackingID)
+	}
+	s.shipments[r.TrackingID] = r
+	if err := s.save(); err != nil {
+		delete(s.shipments, r.TrackingID)
+		return err
+	}
+	return nil
+}
+
+func (s *ShipmentStore) get(trackingID string) (*shipmentRecord, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	r, ok := s.shipments[trackingID]
+	return r, ok
+}
+
+// save writes all shipments to a temporary file and renames it over the store,
+// so a crash never leaves a truncated file behind. Callers hold the lock.
+func (s *ShipmentStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+	records := make([]*shipmentRecord, 0, len(s.shipments))
+	for _, r := range s.shipments {
+		records = append(records, r)
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -19,20 +19,37 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
+
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 	"github.com/dubbogo/gost/log/logger"
 
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/shippingservice/proto"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
 )
 
-type ShippingService struct{}
+type ShippingService struct {
+	Rates    *RateTable
+	Store    *ShipmentStore
+	Timeline Timeline
+}
 
-func (s *ShippingService) GetQuote(context.Context, *pb.GetQuoteRequest) (*pb.GetQuoteResponse, error) {
+func NewShippingService(rates *RateTable, store *ShipmentStore, timeline Timeline) *ShippingService {
+	return &ShippingService{
+		Rates:    rates,
+		Store:    store,
+		Timeline: timeline,
+	}
+}
+
+func (s *ShippingService) GetQuote(ctx context.Context, in *pb.GetQuoteRequest) (*pb.GetQuoteResponse, error) {
 	logger.Info("[GetQuote] received request")
 	defer logger.Info("[GetQuote] completed request")
 
-	// 1. Generate a quote based on the total number of items to be shipped.
-	quote := CreateQuoteFromCount(0)
+	// 1. Generate a quote based on the items to be shipped and their destination.
+	quote := s.Rates.Quote(in.Address, in.Items)
+	logger.Infof("[GetQuote] zone=%s items=%d quote=%s", s.Rates.Zone(in.Address), len(in.Items), quote)
 
 	// 2. Generate a response.
 	return &pb.GetQuoteResponse{
@@ -47,12 +64,53 @@ func (s *ShippingService) GetQuote(context.Context, *pb.GetQuoteRequest) (*pb.Ge
 func (s *ShippingService) ShipOrder(ctx context.Context, in *pb.ShipOrderRequest) (*pb.ShipOrderResponse, error) {
 	logger.Info("[ShipOrder] received request")
 	defer logger.Info("[ShipOrder] completed request")
+	if in.Address == nil {
+		return nil, triple.NewError(triple.CodeInvalidArgument, errors.New("shipping address is required"))
+	}
 	// 1. Create a Tracking ID
 	baseAddress := fmt.Sprintf("%s, %s, %s", in.Address.StreetAddress, in.Address.City, in.Address.State)
 	id := CreateTrackingId(baseAddress)
 
-	// 2. Generate a response.
+	// 2. Record the shipment so that it can be tracked.
+	if err := s.Store.add(newShipmentRecord(id, in.Address, in.Items, time.Now())); err != nil {
+		logger.Errorf("[ShipOrder] failed to store shipment %s: %v", id, err)
+		return nil, triple.NewError(triple.CodeInternal, errors.New("failed to store shipment"))
+	}
+
+	// 3. Generate a response.
 	return &pb.ShipOrderResponse{
 		TrackingId: id,
 	}, nil
 }
+
+func (s *ShippingService) TrackShipment(ctx context.Context, in *pb.TrackShipmentRequest) (*pb.Shipment, error) {
+	r, ok := s.Store.get(in.TrackingId)
+	if !ok {
+		return nil, triple.NewError(triple.CodeNotFound, fmt.Errorf("Shipment not found with tracking ID %s", in.TrackingId))
+	}
+	return s.Timeline.shipment(r, time.Now()), nil
+}
+
+func (s *ShippingService) WatchShipment(ctx context.Context, in *pb.TrackShipmentRequest, stream pb.ShippingService_WatchShipmentServer) error {
+	r, ok := s.Store.get(in.TrackingId)
+	if !ok {
+		return triple.NewError(triple.CodeNotFound, fmt.Errorf("Shipment not found with tracking ID %s", in.TrackingId))
+	}
+	for {
+		now := time.Now()
+		if err := stream.Send(s.Timeline.shipment(r, now)); err != nil {
+			return err
+		}
+		next, ok := s.Timeline.nextChange(r, now)
+		if !ok {
+			return nil
+		}
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -20,18 +20,21 @@ package handler
 import (
 	"fmt"
 	"math/rand"
+	"sync"
 	"time"
 )
 
-// seeded determines if the random number generator is ready.
-var seeded bool = false
+var (
+	// rnd is private to the tracker, so that ID generation neither depends on
+	// nor reseeds the global source.
+	rnd   = rand.New(rand.NewSource(time.Now().UnixNano()))
+	rndMu sync.Mutex
+)
 
 // CreateTrackingId generates a tracking ID.
 func CreateTrackingId(salt string) string {
-	if !seeded {
-		rand.Seed(time.Now().UnixNano())
-		seeded = true
-	}
+	rndMu.Lock()
+	defer rndMu.Unlock()
 
 	return fmt.Sprintf("%c%c-%d%s-%d%s",
 		getRandomLetterCode(),
@@ -45,14 +48,14 @@ func CreateTrackingId(salt string) string {
 
 // getRandomLetterCode generates a code point value for a capital letter.
 func getRandomLetterCode() uint32 {
-	return 65 + uint32(rand.Intn(25))
+	return 65 + uint32(rnd.Intn(26))
 }
 
 // getRandomNumber generates a string representation of a number with the requested number of digits.
 func getRandomNumber(digits int) string {
 	str := ""
 	for i := 0; i < digits; i++ {
-		str = fmt.Sprintf("%s%d", str, rand.Intn(10))
+		str = fmt.Sprintf("%s%d", str, rnd.Intn(10))
 	}
 
 	return str
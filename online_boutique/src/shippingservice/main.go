@@ -18,14 +18,18 @@
 package main
 
 import (
+	_ "embed"
+	"os"
+	"time"
+
 	"dubbo.apache.org/dubbo-go/v3"
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
 	"dubbo.apache.org/dubbo-go/v3/protocol"
 	"dubbo.apache.org/dubbo-go/v3/registry"
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/proto/tracing"
 	"github.com/apache/dubbo-go-samples/online_boutique_demo/shippingservice/handler"
-	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/shippingservice/proto"
 	"github.com/dubbogo/gost/log/logger"
-	"os"
 )
 
 var (
@@ -33,14 +37,40 @@ var (
 	version = "1.0.0"
 )
 
+//go:embed data/rates.json
+var defaultRates []byte
+
 func main() {
 	regAddr := os.Getenv("DUBBO_REGISTRY_ADDRESS")
 	if regAddr == "" {
 		regAddr = "127.0.0.1:2181"
 	}
 
+	rates, err := loadRateTable(os.Getenv("SHIPPING_RATES_PATH"))
+	if err != nil {
+		logger.Fatal(err)
+	}
+	storePath := os.Getenv("SHIPPING_STORE_PATH")
+	if storePath == "" {
+		storePath = "data/shipments.json"
+	}
+	store, err := handler.NewShipmentStore(storePath)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	timeline, err := loadTimeline()
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	withTracing, err := tracing.Option()
+	if err != nil {
+		logger.Fatal(err)
+	}
+
 	ins, err := dubbo.NewInstance(
 		dubbo.WithName(name),
+		withTracing,
 		dubbo.WithRegistry(
 			registry.WithZookeeper(),
 			registry.WithAddress(regAddr),
@@ -59,7 +89,7 @@ func main() {
 		panic(err)
 	}
 
-	if err := pb.RegisterShippingServiceHandler(srv, new(handler.ShippingService)); err != nil {
+	if err := pb.RegisterShippingServiceHandler(srv, handler.NewShippingService(rates, store, timeline)); err != nil {
 		logger.Fatal(err)
 	}
 
@@ -68,3 +98,34 @@ func main() {
 		logger.Fatal(err)
 	}
 }
+
+// loadRateTable reads the rate table at path, or the built-in one when path is empty.
+func loadRateTable(path string) (*handler.RateTable, error) {
+	data := defaultRates
+	if path != "" {
+		var err error
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	return handler.ParseRateTable(data)
+}
+
+// loadTimeline lets the simulated shipment progress be sped up or slowed down
+// through SHIPPING_IN_TRANSIT_AFTER and SHIPPING_DELIVERED_AFTER.
+func loadTimeline() (handler.Timeline, error) {
+	timeline := handler.DefaultTimeline
+	for env, d := range map[string]*time.Duration{
+		"SHIPPING_IN_TRANSIT_AFTER": &timeline.InTransitAfter,
+		"SHIPPING_DELIVERED_AFTER":  &timeline.DeliveredAfter,
+	} {
+		if v := os.Getenv(env); v != "" {
+			parsed, err := time.ParseDuration(v)
+			if err != nil {
+				return timeline, err
+			}
+			*d = parsed
+		}
+	}
+	return timeline, timeline.Validate()
+}
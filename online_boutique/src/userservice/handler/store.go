@@ -0,0 +1,207 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	errEmailTaken   = errors.New("email already registered")
+	errUserNotFound = errors.New("user not found")
+)
+
+type addressRecord struct {
+	ID            string `json:"id"`
+	Label         string `json:"label,omitempty"`
+	StreetAddress string `json:"street_address"`
+	City          string `json:"city"`
+	State         string `json:"state"`
+	Country       string `json:"country"`
+	ZipCode       int32  `json:"zip_code"`
+}
+
+// cardRecord is a card in the vault. The card number is only kept sealed, the
+// brand and last four digits are enough to show it to its owner.
+type cardRecord struct {
+	Token           string `json:"token"`
+	Brand           string `json:"brand"`
+	LastFour        string `json:"last_four"`
+	ExpirationYear  int32  `json:"expiration_year"`
+	ExpirationMonth int32  `json:"expiration_month"`
+	Sealed          []byte `json:"sealed"`
+}
+
+type userRecord struct {
+	ID           string          `json:"id"`
+	Email        string          `json:"email"`
+	Name         string          `json:"name"`
+	PasswordHash string          `json:"password_hash"`
+	CreatedAt    time.Time       `json:"created_at"`
+	Addresses    []addressRecord `json:"addresses,omitempty"`
+	Cards        []cardRecord    `json:"cards,omitempty"`
+}
+
+func (r *userRecord) clone() *userRecord {
+	c := *r
+	c.Addresses = append([]addressRecord(nil), r.Addresses...)
+	c.Cards = append([]cardRecord(nil), r.Cards...)
+	return &c
+}
+
+// normalizeEmail is the form emails are looked up by, so that the same
+// address typed in another case finds the same account.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// UserStore keeps the accounts in memory and mirrors them to a JSON file, so
+// that they survive restarts. An empty path keeps them in memory only. Records
+// handed out by the store are copies; changes go through update.
+type UserStore struct {
+	sync.RWMutex
+
+	path    string
+	users   map[string]*userRecord
+	byEmail map[string]string
+}
+
+func NewUserStore(path string) (*UserStore, error) {
+	s := &UserStore{
+		path:    path,
+		users:   make(map[string]*userRecord),
+		byEmail: make(map[string]string),
+	}
+	if path == "" {
+		return s, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read users: %w", err)
+	}
+	var records []*userRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse users: %w", err)
+	}
+	for _, r := range records {
+		s.users[r.ID] = r
+		s.byEmail[normalizeEmail(r.Email)] = r.ID
+	}
+	return s, nil
+}
+
+func (s *UserStore) create(r *userRecord) error {
+	s.Lock()
+	defer s.Unlock()
+
+	email := normalizeEmail(r.Email)
+	if _, ok := s.byEmail[email]; ok {
+		return errEmailTaken
+	}
+	if _, ok := s.users[r.ID]; ok {
+		return fmt.Errorf("duplicate user id %s", r.ID)
+	}
+	s.users[r.ID] = r.clone()
+	s.byEmail[email] = r.ID
+	if err := s.save(); err != nil {
+		delete(s.users, r.ID)
+		delete(s.byEmail, email)
+		return err
+	}
+	return nil
+}
+
+func (s *UserStore) get(id string) (*userRecord, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	r, ok := s.users[id]
+	if !ok {
+		return nil, false
+	}
+	return r.clone(), true
+}
+
+func (s *UserStore) getByEmail(email string) (*userRecord, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	id, ok := s.byEmail[normalizeEmail(email)]
+	if !ok {
+		return nil, false
+	}
+	return s.users[id].clone(), true
+}
+
+// update applies fn to a copy of the user and stores the result, unless fn
+// fails or the store cannot be saved. It returns the updated user.
+func (s *UserStore) update(id string, fn func(*userRecord) error) (*userRecord, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	old, ok := s.users[id]
+	if !ok {
+		return nil, errUserNotFound
+	}
+	r := old.clone()
+	if err := fn(r); err != nil {
+		return nil, err
+	}
+	s.users[id] = r
+	if err := s.save(); err != nil {
+		s.users[id] = old
+		return nil, err
+	}
+	return r.clone(), nil
+}
+
+// save writes all users to a temporary file and renames it over the store, so
+// a crash never leaves a truncated file behind. The file holds password hashes
+// and sealed cards, so only its owner may read it. Callers hold the lock.
+func (s *UserStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+	records := make([]*userRecord, 0, len(s.users))
+	for _, r := range s.users {
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].CreatedAt.Before(records[j].CreatedAt) })
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,167 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dubbogo/gost/log/logger"
+)
+
+var (
+	errInvalidToken = errors.New("invalid session token")
+	errExpiredToken = errors.New("session token expired")
+	errRevokedToken = errors.New("session token revoked")
+)
+
+// claims are the contents of a session token.
+type claims struct {
+	UserID    string `json:"sub"`
+	ID        string `json:"jti"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// tokenSigner issues session tokens as the base64 encoded claims followed by
+// their HMAC-SHA256, so that any replica holding the key can verify a token
+// without a lookup. Revocations are not shared though: a revoked token is
+// remembered, until it would have expired anyway, only by the replica that
+// revoked it, and stays valid on the others and across a restart.
+type tokenSigner struct {
+	key []byte
+	ttl time.Duration
+
+	mu      sync.Mutex
+	revoked map[string]int64
+}
+
+func newTokenSigner(key []byte, ttl time.Duration) *tokenSigner {
+	return &tokenSigner{
+		key:     key,
+		ttl:     ttl,
+		revoked: make(map[string]int64),
+	}
+}
+
+func (s *tokenSigner) issue(userID string, now time.Time) (string, claims, error) {
+	c := claims{
+		UserID:    userID,
+		ID:        randomID(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(s.ttl).Unix(),
+	}
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", claims{}, err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded)), c, nil
+}
+
+func (s *tokenSigner) verify(token string, now time.Time) (claims, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return claims{}, errInvalidToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, s.sign(encoded)) {
+		return claims{}, errInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return claims{}, errInvalidToken
+	}
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil || c.UserID == "" {
+		return claims{}, errInvalidToken
+	}
+	if now.Unix() >= c.ExpiresAt {
+		return claims{}, errExpiredToken
+	}
+	s.mu.Lock()
+	_, revoked := s.revoked[c.ID]
+	s.mu.Unlock()
+	if revoked {
+		return claims{}, errRevokedToken
+	}
+	return c, nil
+}
+
+func (s *tokenSigner) revoke(c claims, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, exp := range s.revoked {
+		if now.Unix() >= exp {
+			delete(s.revoked, id)
+		}
+	}
+	s.revoked[c.ID] = c.ExpiresAt
+}
+
+func (s *tokenSigner) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// randomID returns 16 random bytes in hex, used for user, address, card and
+// token IDs.
+func randomID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// deriveKey derives a key for one purpose from the service secret, so that
+// tokens and the card vault never share a key.
+func deriveKey(secret []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// LoadSecret reads the secret kept in path, generating one on first use, so
+// that sessions and saved cards stay valid across restarts.
+func LoadSecret(path string) ([]byte, error) {
+	secret, err := os.ReadFile(path)
+	if err == nil || !errors.Is(err, os.ErrNotExist) {
+		return secret, err
+	}
+	secret = make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	logger.Warnf("keeping a generated user service secret in %s", path)
+	return secret, os.WriteFile(path, secret, 0o600)
+}
@@ -0,0 +1,344 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	"github.com/dubbogo/gost/log/logger"
+	"golang.org/x/crypto/bcrypt"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+const (
+	minPasswordLength = 8
+	// bcrypt ignores everything after the first 72 bytes of a password.
+	maxPasswordLength = 72
+
+	maxAddresses = 10
+	maxCards     = 10
+)
+
+var errBadCredentials = errors.New("invalid email or password")
+
+// dummyHash is compared against when nobody is registered with the email of a
+// login, so that unknown emails take as long to reject as wrong passwords.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("correct horse battery staple"), bcrypt.DefaultCost)
+
+type UserService struct {
+	Store *UserStore
+
+	tokens *tokenSigner
+	vault  *vault
+	now    func() time.Time
+}
+
+// NewUserService serves the accounts in store. Session tokens are signed and
+// saved cards sealed with keys derived from secret, so the same secret must be
+// used across restarts and by every replica; tokens expire after sessionTTL.
+func NewUserService(store *UserStore, secret []byte, sessionTTL time.Duration) (*UserService, error) {
+	if len(secret) < 16 {
+		return nil, errors.New("user service secret must be at least 16 bytes")
+	}
+	v, err := newVault(deriveKey(secret, "card vault"))
+	if err != nil {
+		return nil, err
+	}
+	return &UserService{
+		Store:  store,
+		tokens: newTokenSigner(deriveKey(secret, "session token"), sessionTTL),
+		vault:  v,
+		now:    time.Now,
+	}, nil
+}
+
+func (s *UserService) Register(ctx context.Context, in *pb.RegisterRequest) (*pb.AuthResponse, error) {
+	logger.Info("[Register] received request")
+	defer logger.Info("[Register] completed request")
+
+	addr, err := mail.ParseAddress(in.Email)
+	if err != nil || addr.Address != strings.TrimSpace(in.Email) {
+		return nil, triple.NewError(triple.CodeInvalidArgument, fmt.Errorf("invalid email %q", in.Email))
+	}
+	if n := utf8.RuneCountInString(in.Password); n < minPasswordLength || len(in.Password) > maxPasswordLength {
+		return nil, triple.NewError(triple.CodeInvalidArgument,
+			fmt.Errorf("password must be %d to %d characters long", minPasswordLength, maxPasswordLength))
+	}
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		name, _, _ = strings.Cut(addr.Address, "@")
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(in.Password), bcrypt.DefaultCost)
+	if err != nil {
+		logger.Errorf("[Register] failed to hash password: %v", err)
+		return nil, triple.NewError(triple.CodeInternal, errors.New("failed to register"))
+	}
+
+	r := &userRecord{
+		ID:           randomID(),
+		Email:        addr.Address,
+		Name:         name,
+		PasswordHash: string(hash),
+		CreatedAt:    s.now().UTC(),
+	}
+	if err := s.Store.create(r); err != nil {
+		if errors.Is(err, errEmailTaken) {
+			return nil, triple.NewError(triple.CodeAlreadyExists, err)
+		}
+		logger.Errorf("[Register] failed to store user: %v", err)
+		return nil, triple.NewError(triple.CodeInternal, errors.New("failed to register"))
+	}
+	logger.Infof("[Register] registered user %s", r.ID)
+	return s.signIn(r)
+}
+
+func (s *UserService) Login(ctx context.Context, in *pb.LoginRequest) (*pb.AuthResponse, error) {
+	logger.Info("[Login] received request")
+	defer logger.Info("[Login] completed request")
+
+	r, ok := s.Store.getByEmail(in.Email)
+	if !ok {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(in.Password))
+		return nil, triple.NewError(triple.CodeUnauthenticated, errBadCredentials)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(r.PasswordHash), []byte(in.Password)); err != nil {
+		return nil, triple.NewError(triple.CodeUnauthenticated, errBadCredentials)
+	}
+	return s.signIn(r)
+}
+
+func (s *UserService) signIn(r *userRecord) (*pb.AuthResponse, error) {
+	token, c, err := s.tokens.issue(r.ID, s.now())
+	if err != nil {
+		logger.Errorf("[signIn] failed to issue token: %v", err)
+		return nil, triple.NewError(triple.CodeInternal, errors.New("failed to issue session token"))
+	}
+	return &pb.AuthResponse{
+		User:         toUser(r),
+		SessionToken: token,
+		ExpiresAt:    c.ExpiresAt,
+	}, nil
+}
+
+// Logout succeeds for tokens that are already expired or revoked, so that
+// signing out twice is not an error. The token is only revoked on this
+// replica; the other replicas accept it until it expires.
+func (s *UserService) Logout(ctx context.Context, in *pb.LogoutRequest) (*pb.Empty, error) {
+	now := s.now()
+	c, err := s.tokens.verify(in.SessionToken, now)
+	switch {
+	case errors.Is(err, errInvalidToken):
+		return nil, triple.NewError(triple.CodeUnauthenticated, err)
+	case err == nil:
+		s.tokens.revoke(c, now)
+	}
+	return &pb.Empty{}, nil
+}
+
+func (s *UserService) VerifyToken(ctx context.Context, in *pb.VerifyTokenRequest) (*pb.User, error) {
+	c, err := s.tokens.verify(in.SessionToken, s.now())
+	if err != nil {
+		return nil, triple.NewError(triple.CodeUnauthenticated, err)
+	}
+	r, ok := s.Store.get(c.UserID)
+	if !ok {
+		return nil, triple.NewError(triple.CodeUnauthenticated, errUserNotFound)
+	}
+	return toUser(r), nil
+}
+
+// GetProfile returns the user of the session token, as VerifyToken does.
+func (s *UserService) GetProfile(ctx context.Context, in *pb.GetProfileRequest) (*pb.User, error) {
+	return s.VerifyToken(ctx, &pb.VerifyTokenRequest{SessionToken: in.SessionToken})
+}
+
+func (s *UserService) AddAddress(ctx context.Context, in *pb.AddAddressRequest) (*pb.User, error) {
+	a := in.Address
+	if a == nil || strings.TrimSpace(a.StreetAddress) == "" || strings.TrimSpace(a.City) == "" || strings.TrimSpace(a.Country) == "" {
+		return nil, triple.NewError(triple.CodeInvalidArgument, errors.New("street address, city and country are required"))
+	}
+	return s.update("AddAddress", in.SessionToken, func(r *userRecord) error {
+		if len(r.Addresses) >= maxAddresses {
+			return triple.NewError(triple.CodeFailedPrecondition, fmt.Errorf("at most %d addresses can be saved", maxAddresses))
+		}
+		r.Addresses = append(r.Addresses, addressRecord{
+			ID:            randomID(),
+			Label:         strings.TrimSpace(in.Label),
+			StreetAddress: strings.TrimSpace(a.StreetAddress),
+			City:          strings.TrimSpace(a.City),
+			State:         strings.TrimSpace(a.State),
+			Country:       strings.TrimSpace(a.Country),
+			ZipCode:       a.ZipCode,
+		})
+		return nil
+	})
+}
+
+func (s *UserService) DeleteAddress(ctx context.Context, in *pb.DeleteAddressRequest) (*pb.User, error) {
+	return s.update("DeleteAddress", in.SessionToken, func(r *userRecord) error {
+		for i, a := range r.Addresses {
+			if a.ID == in.AddressId {
+				r.Addresses = append(r.Addresses[:i], r.Addresses[i+1:]...)
+				return nil
+			}
+		}
+		return triple.NewError(triple.CodeNotFound, fmt.Errorf("Address not found with ID %s", in.AddressId))
+	})
+}
+
+func (s *UserService) AddCard(ctx context.Context, in *pb.AddCardRequest) (*pb.User, error) {
+	card := in.CreditCard
+	if card == nil {
+		return nil, triple.NewError(triple.CodeInvalidArgument, errors.New("credit card is required"))
+	}
+	number, err := normalizeCardNumber(card.CreditCardNumber)
+	if err != nil {
+		return nil, triple.NewError(triple.CodeInvalidArgument, err)
+	}
+	month, year := card.CreditCardExpirationMonth, card.CreditCardExpirationYear
+	now := s.now()
+	if month < 1 || month > 12 {
+		return nil, triple.NewError(triple.CodeInvalidArgument, fmt.Errorf("invalid expiration month %d", month))
+	}
+	if int(year) < now.Year() || int(year) == now.Year() && time.Month(month) < now.Month() {
+		return nil, triple.NewError(triple.CodeInvalidArgument, fmt.Errorf("card expired on %02d/%d", month, year))
+	}
+
+	token := randomID()
+	sealed, err := s.vault.seal(token, number)
+	if err != nil {
+		logger.Errorf("[AddCard] failed to seal card: %v", err)
+		return nil, triple.NewError(triple.CodeInternal, errors.New("failed to save card"))
+	}
+	return s.update("AddCard", in.SessionToken, func(r *userRecord) error {
+		if len(r.Cards) >= maxCards {
+			return triple.NewError(triple.CodeFailedPrecondition, fmt.Errorf("at most %d cards can be saved", maxCards))
+		}
+		r.Cards = append(r.Cards, cardRecord{
+			Token:           token,
+			Brand:           cardBrand(number),
+			LastFour:        number[len(number)-4:],
+			ExpirationYear:  year,
+			ExpirationMonth: month,
+			Sealed:          sealed,
+		})
+		return nil
+	})
+}
+
+func (s *UserService) DeleteCard(ctx context.Context, in *pb.DeleteCardRequest) (*pb.User, error) {
+	return s.update("DeleteCard", in.SessionToken, func(r *userRecord) error {
+		for i, c := range r.Cards {
+			if c.Token == in.CardToken {
+				r.Cards = append(r.Cards[:i], r.Cards[i+1:]...)
+				return nil
+			}
+		}
+		return triple.NewError(triple.CodeNotFound, fmt.Errorf("Card not found with token %s", in.CardToken))
+	})
+}
+
+// GetCard returns the full card number, so it only answers for the user of a
+// valid session token.
+func (s *UserService) GetCard(ctx context.Context, in *pb.GetCardRequest) (*pb.CreditCardInfo, error) {
+	c, err := s.tokens.verify(in.SessionToken, s.now())
+	if err != nil {
+		return nil, triple.NewError(triple.CodeUnauthenticated, err)
+	}
+	r, ok := s.Store.get(c.UserID)
+	if !ok {
+		return nil, triple.NewError(triple.CodeUnauthenticated, errUserNotFound)
+	}
+	for _, c := range r.Cards {
+		if c.Token != in.CardToken {
+			continue
+		}
+		number, err := s.vault.open(c.Token, c.Sealed)
+		if err != nil {
+			logger.Errorf("[GetCard] failed to open card %s of user %s: %v", c.Token, r.ID, err)
+			return nil, triple.NewError(triple.CodeInternal, errors.New("failed to read card"))
+		}
+		return &pb.CreditCardInfo{
+			CreditCardNumber:          number,
+			CreditCardExpirationYear:  c.ExpirationYear,
+			CreditCardExpirationMonth: c.ExpirationMonth,
+		}, nil
+	}
+	return nil, triple.NewError(triple.CodeNotFound, fmt.Errorf("Card not found with token %s", in.CardToken))
+}
+
+// update changes the user of the session token through the store, keeping the
+// triple errors returned by fn and hiding the store failures from the caller.
+func (s *UserService) update(method, sessionToken string, fn func(*userRecord) error) (*pb.User, error) {
+	c, err := s.tokens.verify(sessionToken, s.now())
+	if err != nil {
+		return nil, triple.NewError(triple.CodeUnauthenticated, err)
+	}
+	userID := c.UserID
+	r, err := s.Store.update(userID, fn)
+	var tripleErr *triple.Error
+	switch {
+	case err == nil:
+		return toUser(r), nil
+	case errors.Is(err, errUserNotFound):
+		return nil, triple.NewError(triple.CodeUnauthenticated, errUserNotFound)
+	case errors.As(err, &tripleErr):
+		return nil, err
+	default:
+		logger.Errorf("[%s] failed to store user %s: %v", method, userID, err)
+		return nil, triple.NewError(triple.CodeInternal, errors.New("failed to save user"))
+	}
+}
+
+func toUser(r *userRecord) *pb.User {
+	u := &pb.User{
+		UserId: r.ID,
+		Email:  r.Email,
+		Name:   r.Name,
+	}
+	for _, a := range r.Addresses {
+		u.Addresses = append(u.Addresses, &pb.SavedAddress{
+			Id:    a.ID,
+			Label: a.Label,
+			Address: &pb.Address{
+				StreetAddress: a.StreetAddress,
+				City:          a.City,
+				State:         a.State,
+				Country:       a.Country,
+				ZipCode:       a.ZipCode,
+			},
+		})
+	}
+	for _, c := range r.Cards {
+		u.Cards = append(u.Cards, &pb.SavedCard{
+			Token:           c.Token,
+			Brand:           c.Brand,
+			LastFour:        c.LastFour,
+			ExpirationYear:  c.ExpirationYear,
+			ExpirationMonth: c.ExpirationMonth,
+		})
+	}
+	return u
+}
@@ -0,0 +1,223 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package handler
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newTestService(t *testing.T, path string, now *time.Time) *UserService {
+	t.Helper()
+	store, err := NewUserStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewUserService(store, testSecret, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func wantCode(t *testing.T, err error, code triple.Code) {
+	t.Helper()
+	if got := triple.CodeOf(err); err == nil || got != code {
+		t.Fatalf("got error %v (%s), want %s", err, got, code)
+	}
+}
+
+func TestRegisterAndLogin(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestService(t, "", &now)
+
+	for _, tt := range []struct {
+		name string
+		req  *pb.RegisterRequest
+		code triple.Code
+	}{
+		{"bad email", &pb.RegisterRequest{Email: "nobody", Password: "long enough"}, triple.CodeInvalidArgument},
+		{"display name in email", &pb.RegisterRequest{Email: "Ann <ann@example.com>", Password: "long enough"}, triple.CodeInvalidArgument},
+		{"short password", &pb.RegisterRequest{Email: "ann@example.com", Password: "short"}, triple.CodeInvalidArgument},
+		{"long password", &pb.RegisterRequest{Email: "ann@example.com", Password: strings.Repeat("x", 73)}, triple.CodeInvalidArgument},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Register(ctx, tt.req)
+			wantCode(t, err, tt.code)
+		})
+	}
+
+	reg, err := s.Register(ctx, &pb.RegisterRequest{Email: "ann@example.com", Password: "long enough"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reg.User.Name != "ann" || reg.ExpiresAt != now.Add(time.Hour).Unix() {
+		t.Errorf("registered %v expiring at %d", reg.User, reg.ExpiresAt)
+	}
+	_, err = s.Register(ctx, &pb.RegisterRequest{Email: "ANN@example.com", Password: "another one"})
+	wantCode(t, err, triple.CodeAlreadyExists)
+
+	_, err = s.Login(ctx, &pb.LoginRequest{Email: "ann@example.com", Password: "wrong password"})
+	wantCode(t, err, triple.CodeUnauthenticated)
+	_, err = s.Login(ctx, &pb.LoginRequest{Email: "bob@example.com", Password: "long enough"})
+	wantCode(t, err, triple.CodeUnauthenticated)
+	login, err := s.Login(ctx, &pb.LoginRequest{Email: " Ann@Example.com", Password: "long enough"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if login.User.UserId != reg.User.UserId {
+		t.Errorf("logged in as %s, registered %s", login.User.UserId, reg.User.UserId)
+	}
+}
+
+func TestSessionTokens(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestService(t, "", &now)
+	reg, err := s.Register(ctx, &pb.RegisterRequest{Email: "ann@example.com", Password: "long enough"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := reg.SessionToken
+
+	u, err := s.VerifyToken(ctx, &pb.VerifyTokenRequest{SessionToken: token})
+	if err != nil || u.UserId != reg.User.UserId {
+		t.Fatalf("VerifyToken = %v, %v", u, err)
+	}
+
+	payload, sig, _ := strings.Cut(token, ".")
+	for _, forged := range []string{"", "garbage", payload + ".AAAA", "e30." + sig} {
+		_, err := s.VerifyToken(ctx, &pb.VerifyTokenRequest{SessionToken: forged})
+		wantCode(t, err, triple.CodeUnauthenticated)
+	}
+	other, err := NewUserService(s.Store, []byte("another secret of 32 bytes......"), time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.VerifyToken(ctx, &pb.VerifyTokenRequest{SessionToken: token}); err == nil {
+		t.Error("token verified with another secret")
+	}
+
+	second, err := s.Login(ctx, &pb.LoginRequest{Email: "ann@example.com", Password: "long enough"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Logout(ctx, &pb.LogoutRequest{SessionToken: token}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Logout(ctx, &pb.LogoutRequest{SessionToken: token}); err != nil {
+		t.Errorf("second logout: %v", err)
+	}
+	_, err = s.VerifyToken(ctx, &pb.VerifyTokenRequest{SessionToken: token})
+	wantCode(t, err, triple.CodeUnauthenticated)
+	if _, err := s.VerifyToken(ctx, &pb.VerifyTokenRequest{SessionToken: second.SessionToken}); err != nil {
+		t.Errorf("logout revoked the other session: %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	_, err = s.VerifyToken(ctx, &pb.VerifyTokenRequest{SessionToken: second.SessionToken})
+	wantCode(t, err, triple.CodeUnauthenticated)
+}
+
+func TestAddressesAndCards(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	path := filepath.Join(t.TempDir(), "users.json")
+	s := newTestService(t, path, &now)
+	reg, err := s.Register(ctx, &pb.RegisterRequest{Email: "ann@example.com", Password: "long enough", Name: "Ann"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, token := reg.User.UserId, reg.SessionToken
+
+	_, err = s.AddAddress(ctx, &pb.AddAddressRequest{SessionToken: token, Address: &pb.Address{City: "Berlin"}})
+	wantCode(t, err, triple.CodeInvalidArgument)
+	_, err = s.AddAddress(ctx, &pb.AddAddressRequest{SessionToken: id, Address: &pb.Address{StreetAddress: "1 Main St", City: "Berlin", Country: "Germany"}})
+	wantCode(t, err, triple.CodeUnauthenticated)
+	u, err := s.AddAddress(ctx, &pb.AddAddressRequest{SessionToken: token, Label: "Home",
+		Address: &pb.Address{StreetAddress: "1 Main St", City: "Berlin", Country: "Germany", ZipCode: 10115}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(u.Addresses) != 1 || u.Addresses[0].Label != "Home" || u.Addresses[0].Address.ZipCode != 10115 {
+		t.Fatalf("addresses = %v", u.Addresses)
+	}
+
+	for _, tt := range []struct {
+		name string
+		card *pb.CreditCardInfo
+	}{
+		{"bad checksum", &pb.CreditCardInfo{CreditCardNumber: "4432-8015-6152-0455", CreditCardExpirationYear: 2030, CreditCardExpirationMonth: 1}},
+		{"expired", &pb.CreditCardInfo{CreditCardNumber: "4432-8015-6152-0454", CreditCardExpirationYear: 2024, CreditCardExpirationMonth: 4}},
+		{"bad month", &pb.CreditCardInfo{CreditCardNumber: "4432-8015-6152-0454", CreditCardExpirationYear: 2030, CreditCardExpirationMonth: 13}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.AddCard(ctx, &pb.AddCardRequest{SessionToken: token, CreditCard: tt.card})
+			wantCode(t, err, triple.CodeInvalidArgument)
+		})
+	}
+	u, err = s.AddCard(ctx, &pb.AddCardRequest{SessionToken: token, CreditCard: &pb.CreditCardInfo{
+		CreditCardNumber: "4432 8015 6152 0454", CreditCardCvv: 672, CreditCardExpirationYear: 2024, CreditCardExpirationMonth: 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	card := u.Cards[0]
+	if card.Brand != "visa" || card.LastFour != "0454" {
+		t.Errorf("saved card %v", card)
+	}
+
+	// The accounts survive a restart, with the card number sealed on disk.
+	s = newTestService(t, path, &now)
+	u, err = s.GetProfile(ctx, &pb.GetProfileRequest{SessionToken: token})
+	if err != nil || len(u.Addresses) != 1 || len(u.Cards) != 1 {
+		t.Fatalf("GetProfile after restart = %v, %v", u, err)
+	}
+	got, err := s.GetCard(ctx, &pb.GetCardRequest{SessionToken: token, CardToken: card.Token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.CreditCardNumber != "4432801561520454" || got.CreditCardCvv != 0 || got.CreditCardExpirationMonth != 5 {
+		t.Errorf("GetCard = %v", got)
+	}
+	other, err := s.Register(ctx, &pb.RegisterRequest{Email: "bob@example.com", Password: "long enough"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.GetCard(ctx, &pb.GetCardRequest{SessionToken: other.SessionToken, CardToken: card.Token})
+	wantCode(t, err, triple.CodeNotFound)
+	_, err = s.GetCard(ctx, &pb.GetCardRequest{SessionToken: id, CardToken: card.Token})
+	wantCode(t, err, triple.CodeUnauthenticated)
+
+	_, err = s.DeleteCard(ctx, &pb.DeleteCardRequest{SessionToken: other.SessionToken, CardToken: card.Token})
+	wantCode(t, err, triple.CodeNotFound)
+	if u, err = s.DeleteCard(ctx, &pb.DeleteCardRequest{SessionToken: token, CardToken: card.Token}); err != nil || len(u.Cards) != 0 {
+		t.Errorf("DeleteCard = %v, %v", u, err)
+	}
+	_, err = s.DeleteAddress(ctx, &pb.DeleteAddressRequest{SessionToken: token, AddressId: "missing"})
+	wantCode(t, err, triple.CodeNotFound)
+}
@@ -0,0 +1,108 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package handler
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"strings"
+)
+
+var errInvalidCard = errors.New("invalid credit card number")
+
+// vault seals card numbers with AES-GCM, using the card token as additional
+// data so that a sealed number cannot be moved to another card.
+type vault struct {
+	aead cipher.AEAD
+}
+
+func newVault(key []byte) (*vault, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &vault{aead: aead}, nil
+}
+
+func (v *vault) seal(token, number string) ([]byte, error) {
+	nonce := make([]byte, v.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return v.aead.Seal(nonce, nonce, []byte(number), []byte(token)), nil
+}
+
+func (v *vault) open(token string, sealed []byte) (string, error) {
+	n := v.aead.NonceSize()
+	if len(sealed) < n {
+		return "", errors.New("sealed card is too short")
+	}
+	number, err := v.aead.Open(nil, sealed[:n], sealed[n:], []byte(token))
+	if err != nil {
+		return "", err
+	}
+	return string(number), nil
+}
+
+// normalizeCardNumber strips the spaces and dashes shoppers type between the
+// digit groups and checks the Luhn checksum of what is left.
+func normalizeCardNumber(number string) (string, error) {
+	number = strings.NewReplacer(" ", "", "-", "").Replace(number)
+	if len(number) < 12 || len(number) > 19 {
+		return "", errInvalidCard
+	}
+	sum := 0
+	for i := range number {
+		d := int(number[len(number)-1-i] - '0')
+		if d < 0 || d > 9 {
+			return "", errInvalidCard
+		}
+		if i%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	if sum%10 != 0 {
+		return "", errInvalidCard
+	}
+	return number, nil
+}
+
+// cardBrand tells the brand of a card from its leading digits.
+func cardBrand(number string) string {
+	switch {
+	case strings.HasPrefix(number, "4"):
+		return "visa"
+	case strings.HasPrefix(number, "34"), strings.HasPrefix(number, "37"):
+		return "amex"
+	case number[0] == '5' && number[1] >= '1' && number[1] <= '5',
+		number[:4] >= "2221" && number[:4] <= "2720":
+		return "mastercard"
+	case strings.HasPrefix(number, "6011"), strings.HasPrefix(number, "65"):
+		return "discover"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"dubbo.apache.org/dubbo-go/v3"
+	_ "dubbo.apache.org/dubbo-go/v3/imports"
+	"dubbo.apache.org/dubbo-go/v3/protocol"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+	"github.com/dubbogo/gost/log/logger"
+
+	pb "github.com/apache/dubbo-go-samples/online_boutique_demo/proto"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/proto/tracing"
+	"github.com/apache/dubbo-go-samples/online_boutique_demo/userservice/handler"
+)
+
+const defaultSessionTTL = 7 * 24 * time.Hour
+
+func main() {
+	regAddr := os.Getenv("DUBBO_REGISTRY_ADDRESS")
+	if regAddr == "" {
+		regAddr = "127.0.0.1:2181"
+	}
+
+	storePath := os.Getenv("USER_STORE_PATH")
+	if storePath == "" {
+		storePath = "data/users.json"
+	}
+	store, err := handler.NewUserStore(storePath)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	// Without USER_SECRET, a generated secret is kept next to the users.
+	secret := []byte(os.Getenv("USER_SECRET"))
+	if len(secret) == 0 {
+		if secret, err = handler.LoadSecret(filepath.Join(filepath.Dir(storePath), "secret.key")); err != nil {
+			logger.Fatal(err)
+		}
+	}
+	ttl := defaultSessionTTL
+	if v := os.Getenv("USER_SESSION_TTL"); v != "" {
+		if ttl, err = time.ParseDuration(v); err != nil {
+			logger.Fatal(err)
+		}
+	}
+	userService, err := handler.NewUserService(store, secret, ttl)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	withTracing, err := tracing.Option()
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	ins, err := dubbo.NewInstance(
+		dubbo.WithName("userservice"),
+		withTracing,
+		dubbo.WithRegistry(
+			registry.WithZookeeper(),
+			registry.WithAddress(regAddr),
+		),
+		dubbo.WithProtocol(
+			protocol.WithTriple(),
+			protocol.WithPort(20012),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+	//server
+	srv, err := ins.NewServer()
+	if err != nil {
+		panic(err)
+	}
+
+	if err := pb.RegisterUserServiceHandler(srv, userService); err != nil {
+		logger.Fatal(err)
+	}
+
+	if err := srv.Serve(); err != nil {
+		logger.Fatal(err)
+	}
+}
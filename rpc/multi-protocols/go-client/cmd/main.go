@@ -18,15 +18,23 @@ package main
 
 import (
 	"context"
+	"os"
+)
+
+import (
 	"dubbo.apache.org/dubbo-go/v3"
 	"dubbo.apache.org/dubbo-go/v3/client"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
 	"dubbo.apache.org/dubbo-go/v3/registry"
-	greet2 "github.com/apache/dubbo-go-samples/rpc/multi-protocols/proto"
+
+	greet "github.com/apache/dubbo-go-samples/rpc/multi-protocols/proto"
+
 	"github.com/dubbogo/gost/log/logger"
 )
 
+const name = "hello world"
+
 func main() {
 	ins, err := dubbo.NewInstance(
 		dubbo.WithName("dubbo_multirpc_client"),
@@ -39,24 +47,26 @@ func main() {
 		panic(err)
 	}
 
+	greetings := map[string]string{}
+
 	//Triple
 	cli, err := ins.NewClient(
 		client.WithClientProtocolTriple())
 	if err != nil {
 		panic(err)
 	}
-	svc, err := greet2.NewGreetService(cli)
+	svc, err := greet.NewGreetService(cli)
 	if err != nil {
 		panic(err)
 	}
-
-	respTriple, err := svc.Greet(context.Background(), &greet2.GreetRequest{Name: "hello world"})
+	respTriple, err := svc.Greet(context.Background(), &greet.GreetRequest{Name: name})
 	if err != nil {
-		logger.Error(err)
+		logger.Errorf("GreetService.Greet over triple err: %s", err)
+		os.Exit(1)
 	}
-	logger.Infof("Greet triple response: %s", respTriple.Greeting)
+	greetings["triple"] = respTriple.Greeting
 
-	//Duboo
+	//Dubbo
 	cliDubbo, err := ins.NewClient(
 		client.WithClientProtocolDubbo(),
 		client.WithClientSerialization(constant.Hessian2Serialization),
@@ -64,17 +74,7 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-
-	connDubbo, err := cliDubbo.Dial("GreetProvider")
-	if err != nil {
-		panic(err)
-	}
-	var respDubbo string
-	if err = connDubbo.CallUnary(context.Background(), []interface{}{"hello", "new", "dubbo"}, &respDubbo, "SayHello"); err != nil {
-		logger.Errorf("GreetProvider.Greet err: %s", err)
-		return
-	}
-	logger.Infof("Get dubbo Response: %s", respDubbo)
+	greetings["dubbo"] = callGreet(cliDubbo, "dubbo")
 
 	//JsonRpc
 	cliJsonRpc, err := ins.NewClient(
@@ -84,14 +84,29 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	connJsonRpc, err := cliJsonRpc.Dial("GreetProvider")
+	greetings["jsonrpc"] = callGreet(cliJsonRpc, "jsonrpc")
+
+	for protocol, greeting := range greetings {
+		logger.Infof("Greet %s response: %s", protocol, greeting)
+		if greeting != greetings["triple"] {
+			logger.Errorf("GreetService answers %q over %s but %q over triple", greeting, protocol, greetings["triple"])
+			os.Exit(1)
+		}
+	}
+	logger.Infof("GreetService answers identically over triple, dubbo and jsonrpc")
+}
+
+// callGreet calls GreetService.Greet with the plain name, as dubbo and JSON-RPC
+// carry it, and exits when the call fails.
+func callGreet(cli *client.Client, protocol string) string {
+	conn, err := cli.Dial(greet.GreetServiceName)
 	if err != nil {
 		panic(err)
 	}
-	var respJsonRpc string
-	if err := connJsonRpc.CallUnary(context.Background(), []interface{}{"hello", "new", "jsonrpc"}, &respJsonRpc, "SayHello"); err != nil {
-		logger.Errorf("GreetProvider.Greet err: %s", err)
-		return
+	var greeting string
+	if err := conn.CallUnary(context.Background(), []interface{}{name}, &greeting, "Greet"); err != nil {
+		logger.Errorf("GreetService.Greet over %s err: %s", protocol, err)
+		os.Exit(1)
 	}
-	logger.Infof("Get jsonrpc Response: %s", respJsonRpc)
+	return greeting
 }
@@ -18,83 +18,98 @@ package main
 
 import (
 	"context"
-	"dubbo.apache.org/dubbo-go/v3"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+import (
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
-	"dubbo.apache.org/dubbo-go/v3/protocol"
+
+	"dubbo.apache.org/dubbo-go/v3"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/registry"
 	"dubbo.apache.org/dubbo-go/v3/server"
-	greet2 "github.com/apache/dubbo-go-samples/rpc/multi-protocols/proto"
+
+	greet "github.com/apache/dubbo-go-samples/rpc/multi-protocols/proto"
+	"github.com/apache/dubbo-go-samples/rpc/multi-protocols/runner"
+
 	"github.com/dubbogo/gost/log/logger"
 )
 
 type GreetMultiRPCServer struct {
 }
 
-func (srv *GreetMultiRPCServer) Greet(ctx context.Context, req *greet2.GreetRequest) (*greet2.GreetResponse, error) {
-	resp := &greet2.GreetResponse{Greeting: req.Name}
+func (srv *GreetMultiRPCServer) Greet(ctx context.Context, req *greet.GreetRequest) (*greet.GreetResponse, error) {
+	resp := &greet.GreetResponse{Greeting: req.Name}
 	return resp, nil
 }
 
+// GreetProvider serves the GreetService over dubbo and JSON-RPC, which carry
+// the name as a plain string instead of the protobuf messages of triple.
 type GreetProvider struct {
+	svc greet.GreetServiceHandler
 }
 
-func (*GreetProvider) SayHello(req string, req1 string, req2 string) (string, error) {
-	return req + req1 + req2, nil
+func (p *GreetProvider) Greet(ctx context.Context, name string) (string, error) {
+	resp, err := p.svc.Greet(ctx, &greet.GreetRequest{Name: name})
+	if err != nil {
+		return "", err
+	}
+	return resp.Greeting, nil
+}
+
+// GreetProviderServiceInfo returns the service info of GreetProvider, a new
+// one for every protocol: serving a service adds methods to its info.
+func GreetProviderServiceInfo() *server.ServiceInfo {
+	return &server.ServiceInfo{
+		InterfaceName: greet.GreetServiceName,
+		ServiceType:   (*GreetProvider)(nil),
+		Methods: []server.MethodInfo{
+			{
+				Name: "Greet",
+				Type: constant.CallUnary,
+				ReqInitFunc: func() interface{} {
+					return new(string)
+				},
+				MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+					name := args[0].(string)
+					return handler.(*GreetProvider).Greet(ctx, name)
+				},
+			},
+		},
+	}
 }
 
 func main() {
-	ins, err := dubbo.NewInstance(
+	svc := &GreetMultiRPCServer{}
+	provider := &GreetProvider{svc: svc}
+	r := runner.New(
+		runner.Export{Protocol: "tri", Port: 20000, Register: func(srv *server.Server) error {
+			return greet.RegisterGreetServiceHandler(srv, svc)
+		}},
+		runner.Export{Protocol: "dubbo", Port: 20001, Register: func(srv *server.Server) error {
+			return srv.Register(provider, GreetProviderServiceInfo())
+		}},
+		runner.Export{Protocol: "jsonrpc", Port: 20002, Register: func(srv *server.Server) error {
+			return srv.Register(provider, GreetProviderServiceInfo())
+		}},
+	)
+	_, err := r.NewInstance(
 		dubbo.WithName("dubbo_multirpc_server"),
 		dubbo.WithRegistry(
 			registry.WithZookeeper(),
 			registry.WithAddress("127.0.0.1:2181"),
 		),
-		dubbo.WithProtocol(
-			protocol.WithTriple(),
-			protocol.WithPort(20000)),
-		dubbo.WithProtocol(
-			protocol.WithDubbo(),
-			protocol.WithPort(20001)),
-		dubbo.WithProtocol(
-			protocol.WithJSONRPC(),
-			protocol.WithPort(20002)),
 	)
 	if err != nil {
 		panic(err)
 	}
-	//Triple
-	srvTriple, err := ins.NewServer()
-	if err != nil {
-		panic(err)
-	}
-	if err = greet2.RegisterGreetServiceHandler(srvTriple, &GreetMultiRPCServer{}); err != nil {
-		panic(err)
-	}
-	if err = srvTriple.Serve(); err != nil {
-		logger.Error(err)
-	}
 
-	//Dubbo
-	srvDubbo, err := ins.NewServer()
-	if err != nil {
-		panic(err)
-	}
-	if err = srvDubbo.Register(&GreetProvider{}, nil, server.WithInterface("GreetProvider")); err != nil {
-		panic(err)
-	}
-	if err = srvDubbo.Serve(); err != nil {
-		logger.Error(err)
-	}
-
-	//JsonRpc
-	srvJsonRpc, err := ins.NewServer()
-	if err != nil {
-		panic(err)
-	}
-	if err = srvJsonRpc.Register(&GreetProvider{}, nil, server.WithInterface("GreetProvider")); err != nil {
-		panic(err)
-	}
-	if err := srvJsonRpc.Serve(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := r.Run(ctx); err != nil {
 		logger.Error(err)
+		os.Exit(1)
 	}
 }
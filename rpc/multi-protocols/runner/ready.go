@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runner
+
+import (
+	"context"
+	"math"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/server"
+)
+
+// ReadyServiceName is the interface of the service every server of the
+// process exports after all the others.
+const ReadyServiceName = "org.apache.dubbo.samples.runner.Ready"
+
+// exported is told when a server has exported its services, its own and the
+// internal ones of dubbo-go. Serve listens before it has exported them all,
+// and exporting writes the service map of the process without a lock, so
+// Start serves the next export only once told. A server of the process is
+// therefore to be served by a runner, another one would wait forever.
+var exported = make(chan error)
+
+// Ready is the handler of the ready service. It only marks the end of the
+// exports of a server, and tells whether the server is up to anyone asking.
+type Ready struct{}
+
+// Ready returns true: a server answering has exported all its services.
+func (*Ready) Ready(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+func readyInfo() *server.ServiceInfo {
+	return &server.ServiceInfo{
+		InterfaceName: ReadyServiceName,
+		ServiceType:   (*Ready)(nil),
+		Methods: []server.MethodInfo{{
+			Name: "Ready",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return nil
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				return handler.(*Ready).Ready(ctx)
+			},
+		}},
+	}
+}
+
+func init() {
+	server.SetProServices(&server.InternalService{
+		Name: "runnerReady",
+		Init: func(*server.ServiceOptions) (*server.ServiceDefinition, bool) {
+			return &server.ServiceDefinition{
+				Handler: &Ready{},
+				Info:    readyInfo(),
+				Opts: []server.ServiceOption{
+					server.WithNotRegister(),
+					server.WithInterface(ReadyServiceName),
+				},
+			}, true
+		},
+		AfterExport: func(_ *server.ServiceOptions, err error) {
+			exported <- err
+		},
+		// after the internal services of dubbo-go
+		Priority: math.MaxInt32,
+	})
+}
@@ -0,0 +1,216 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package runner serves services over several protocols of one dubbo instance
+// at the same time.
+//
+// server.Server.Serve exports its services and then blocks forever, so a
+// program calling it once per protocol never gets past the first one. The
+// runner gives every protocol its own server, serves them one after the
+// other, reports when each of them listens, and shuts them down together.
+package runner
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/graceful_shutdown"
+	"dubbo.apache.org/dubbo-go/v3/protocol"
+	"dubbo.apache.org/dubbo-go/v3/server"
+
+	"github.com/dubbogo/gost/log/logger"
+)
+
+// DefaultReadyTimeout is how long Start waits for a protocol to listen.
+const DefaultReadyTimeout = 10 * time.Second
+
+// Export serves services over one protocol.
+type Export struct {
+	// Protocol is the name of the protocol, such as "tri", "dubbo" or
+	// "jsonrpc". It is also the ID of the protocol in the instance.
+	Protocol string
+	// Port is the port the protocol listens on.
+	Port int
+	// Register registers the services of the export on its server.
+	Register func(srv *server.Server) error
+}
+
+// Status is the readiness of an export.
+type Status struct {
+	Protocol string
+	Addr     string
+	// Took is how long the export took to listen.
+	Took time.Duration
+	// Err is why the export is not ready, nil when it is.
+	Err error
+}
+
+func (s Status) String() string {
+	if s.Err != nil {
+		return fmt.Sprintf("%s on %s: %v", s.Protocol, s.Addr, s.Err)
+	}
+	return fmt.Sprintf("%s on %s: ready in %s", s.Protocol, s.Addr, s.Took.Round(time.Millisecond))
+}
+
+// Runner serves the exports of an instance.
+type Runner struct {
+	// ReadyTimeout is how long Start waits for each export to listen.
+	ReadyTimeout time.Duration
+
+	exports []Export
+	ins     *dubbo.Instance
+}
+
+// New returns a runner of the exports.
+func New(exports ...Export) *Runner {
+	return &Runner{ReadyTimeout: DefaultReadyTimeout, exports: exports}
+}
+
+// NewInstance creates the instance of the runner with a protocol for every
+// export. The runner handles the signals itself, so that a signal shuts all
+// the exports down together.
+func (r *Runner) NewInstance(opts ...dubbo.InstanceOption) (*dubbo.Instance, error) {
+	for _, e := range r.exports {
+		opts = append(opts, dubbo.WithProtocol(
+			protocol.WithProtocol(e.Protocol),
+			protocol.WithPort(e.Port),
+		))
+	}
+	opts = append(opts, dubbo.WithShutdown(graceful_shutdown.WithoutInternalSignal()))
+	ins, err := dubbo.NewInstance(opts...)
+	if err != nil {
+		return nil, err
+	}
+	r.ins = ins
+	return ins, nil
+}
+
+// Start serves the exports one after the other, each once the previous one
+// has exported its services and listens: exporting a service writes the
+// service map of the process, which has no lock. It returns the status of the
+// exports, in their order, and an error when one of them is not ready; the
+// exports after it are not served.
+func (r *Runner) Start() ([]Status, error) {
+	if r.ins == nil {
+		return nil, fmt.Errorf("runner: the instance has not been created, call NewInstance first")
+	}
+	servers := make([]*server.Server, len(r.exports))
+	for i, e := range r.exports {
+		srv, err := r.ins.NewServer(server.WithServerProtocolIDs([]string{e.Protocol}))
+		if err != nil {
+			return nil, fmt.Errorf("runner: %s: %w", e.Protocol, err)
+		}
+		if err := e.Register(srv); err != nil {
+			return nil, fmt.Errorf("runner: %s: %w", e.Protocol, err)
+		}
+		servers[i] = srv
+	}
+
+	all := make([]Status, 0, len(r.exports))
+	for i, e := range r.exports {
+		srv := servers[i]
+		served := make(chan error, 1)
+		go func() {
+			// Serve only returns when it fails to export.
+			served <- srv.Serve()
+		}()
+		s := r.wait(e, served)
+		all = append(all, s)
+		if s.Err != nil {
+			return all, fmt.Errorf("runner: %s", s)
+		}
+	}
+	return all, nil
+}
+
+// wait waits until the export has exported its services and listens, fails
+// to serve or times out.
+func (r *Runner) wait(e Export, served <-chan error) Status {
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(e.Port))
+	s := Status{Protocol: e.Protocol, Addr: addr}
+	start := time.Now()
+	deadline := time.NewTimer(r.ReadyTimeout)
+	defer deadline.Stop()
+	select {
+	case err := <-served:
+		s.Err = err
+		return s
+	case err := <-exported:
+		if err != nil {
+			s.Err = err
+			return s
+		}
+	case <-deadline.C:
+		s.Err = fmt.Errorf("not exported after %s", r.ReadyTimeout)
+		return s
+	}
+	tick := time.NewTicker(20 * time.Millisecond)
+	defer tick.Stop()
+	for {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		if err == nil {
+			conn.Close()
+			s.Took = time.Since(start)
+			return s
+		}
+		select {
+		case <-deadline.C:
+			s.Err = fmt.Errorf("not listening after %s", r.ReadyTimeout)
+			return s
+		case <-tick.C:
+		}
+	}
+}
+
+// Shutdown shuts all the exports down: it first unregisters them so that the
+// consumers stop calling them, and then closes their protocols.
+func (r *Runner) Shutdown() {
+	extension.GetProtocol(constant.RegistryProtocol).Destroy()
+	for _, e := range r.exports {
+		extension.GetProtocol(e.Protocol).Destroy()
+	}
+}
+
+// Run starts the exports, logs their statuses and serves them until the
+// context is done, then shuts them all down. Any export failing to start
+// shuts the others down too.
+func (r *Runner) Run(ctx context.Context) error {
+	statuses, err := r.Start()
+	for _, s := range statuses {
+		if s.Err != nil {
+			logger.Errorf("Runner: %s", s)
+		} else {
+			logger.Infof("Runner: %s", s)
+		}
+	}
+	if err != nil {
+		r.Shutdown()
+		return err
+	}
+	<-ctx.Done()
+	logger.Infof("Runner: shutting %d exports down", len(r.exports))
+	r.Shutdown()
+	return nil
+}
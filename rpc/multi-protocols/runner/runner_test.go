@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package runner
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3"
+	"dubbo.apache.org/dubbo-go/v3/client"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	_ "dubbo.apache.org/dubbo-go/v3/imports"
+	"dubbo.apache.org/dubbo-go/v3/server"
+
+	greet "github.com/apache/dubbo-go-samples/rpc/multi-protocols/proto"
+)
+
+type Greeter struct{}
+
+func (*Greeter) Greet(ctx context.Context, req *greet.GreetRequest) (*greet.GreetResponse, error) {
+	return &greet.GreetResponse{Greeting: req.Name}, nil
+}
+
+// PlainGreeter serves the greet service over the protocols without protobuf.
+type PlainGreeter struct{}
+
+func (*PlainGreeter) Greet(ctx context.Context, name string) (string, error) {
+	return name, nil
+}
+
+// plainGreeterInfo returns the service info of PlainGreeter, a new one each
+// time: serving a service adds methods to its info.
+func plainGreeterInfo() *server.ServiceInfo {
+	return &server.ServiceInfo{
+		InterfaceName: greet.GreetServiceName,
+		ServiceType:   (*PlainGreeter)(nil),
+		Methods: []server.MethodInfo{{
+			Name: "Greet",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(string)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				return handler.(*PlainGreeter).Greet(ctx, args[0].(string))
+			},
+		}},
+	}
+}
+
+func freePort(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// TestStart serves triple, dubbo and JSON-RPC without a registry. Run it with
+// -race: the exports of a process share its service map.
+func TestStart(t *testing.T) {
+	registerPlain := func(srv *server.Server) error {
+		return srv.Register(&PlainGreeter{}, plainGreeterInfo())
+	}
+	triplePort := freePort(t)
+	r := New(
+		Export{Protocol: "tri", Port: triplePort, Register: func(srv *server.Server) error {
+			return greet.RegisterGreetServiceHandler(srv, &Greeter{})
+		}},
+		Export{Protocol: "dubbo", Port: freePort(t), Register: registerPlain},
+		Export{Protocol: "jsonrpc", Port: freePort(t), Register: registerPlain},
+	)
+	if _, err := r.Start(); err == nil {
+		t.Fatal("Start without an instance did not fail")
+	}
+	if _, err := r.NewInstance(dubbo.WithName("runner_test")); err != nil {
+		t.Fatal(err)
+	}
+	statuses, err := r.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Shutdown()
+	if len(statuses) != 3 {
+		t.Fatalf("got %d statuses, want 3", len(statuses))
+	}
+	for i, s := range statuses {
+		if s.Protocol != r.exports[i].Protocol || s.Err != nil {
+			t.Errorf("status %d: %s", i, s)
+		}
+	}
+
+	cli, err := client.NewClient(client.WithClientURL("tri://127.0.0.1:" + strconv.Itoa(triplePort)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc, err := greet.NewGreetService(cli)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := svc.Greet(context.Background(), &greet.GreetRequest{Name: "runner"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Greeting != "runner" {
+		t.Errorf("got greeting %q, want runner", resp.Greeting)
+	}
+}
@@ -19,12 +19,14 @@ package main
 
 import (
 	"context"
+	"strings"
 )
 
 import (
 	"dubbo.apache.org/dubbo-go/v3"
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
 	"dubbo.apache.org/dubbo-go/v3/protocol"
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 	"dubbo.apache.org/dubbo-go/v3/server"
 
 	greet "github.com/apache/dubbo-go-samples/rpc/triple/reflection/proto"
@@ -40,6 +42,49 @@ func (srv *GreetTripleServer) Greet(ctx context.Context, req *greet.GreetRequest
 	return resp, nil
 }
 
+func (srv *GreetTripleServer) GreetStream(ctx context.Context, stream greet.GreetService_GreetStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			if triple.IsEnded(err) {
+				break
+			}
+			logger.Errorf("triple BidiStream recv error: %s", err)
+			return err
+		}
+		if err := stream.Send(&greet.GreetStreamResponse{Greeting: req.Name}); err != nil {
+			logger.Errorf("triple BidiStream send error: %s", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (srv *GreetTripleServer) GreetClientStream(ctx context.Context, stream greet.GreetService_GreetClientStreamServer) (*greet.GreetClientStreamResponse, error) {
+	var reqs []string
+	for stream.Recv() {
+		reqs = append(reqs, stream.Msg().Name)
+	}
+	if stream.Err() != nil && !triple.IsEnded(stream.Err()) {
+		logger.Errorf("triple ClientStream recv err: %s", stream.Err())
+		return nil, stream.Err()
+	}
+	resp := &greet.GreetClientStreamResponse{
+		Greeting: strings.Join(reqs, ","),
+	}
+	return resp, nil
+}
+
+func (srv *GreetTripleServer) GreetServerStream(ctx context.Context, req *greet.GreetServerStreamRequest, stream greet.GreetService_GreetServerStreamServer) error {
+	for i := 0; i < 5; i++ {
+		if err := stream.Send(&greet.GreetServerStreamResponse{Greeting: req.Name}); err != nil {
+			logger.Errorf("triple ServerStream send err: %s", err)
+			return err
+		}
+	}
+	return nil
+}
+
 func main() {
 	ins, err := dubbo.NewInstance(
 		dubbo.WithName("dubbo_rpc_triple_reflection_server"),
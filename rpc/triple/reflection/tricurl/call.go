@@ -0,0 +1,205 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tricurl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/client"
+	triple "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Call calls the method on the server of the client. It reads the requests
+// from in as a sequence of JSON objects and writes every response to out as
+// JSON. Unary and server streaming methods take exactly one request, which is
+// empty when in has none.
+func Call(ctx context.Context, cli *client.Client, md protoreflect.MethodDescriptor, in io.Reader, out io.Writer, opts ...client.ReferenceOption) error {
+	service, method := string(md.Parent().FullName()), string(md.Name())
+	conn, err := cli.DialWithInfo(service, &client.ClientInfo{
+		InterfaceName: service,
+		MethodNames:   []string{method},
+	}, opts...)
+	if err != nil {
+		return err
+	}
+	reqs := &requests{dec: json.NewDecoder(in), md: md.Input()}
+	resps := &responses{w: out, md: md.Output()}
+
+	switch {
+	case md.IsStreamingClient() && md.IsStreamingServer():
+		raw, err := conn.CallBidiStream(ctx, method)
+		if err != nil {
+			return err
+		}
+		stream := raw.(*triple.BidiStreamForClient)
+		sent := make(chan error, 1)
+		go func() {
+			err := reqs.each(stream.Send)
+			if cerr := stream.CloseRequest(); err == nil {
+				err = cerr
+			}
+			sent <- err
+		}()
+		for {
+			resp := resps.next()
+			if err := stream.Receive(resp); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return err
+			}
+			if err := resps.write(resp); err != nil {
+				return err
+			}
+		}
+		if err := stream.CloseResponse(); err != nil {
+			return err
+		}
+		return <-sent
+
+	case md.IsStreamingClient():
+		raw, err := conn.CallClientStream(ctx, method)
+		if err != nil {
+			return err
+		}
+		stream := raw.(*triple.ClientStreamForClient)
+		if err := reqs.each(stream.Send); err != nil {
+			return err
+		}
+		resp := resps.next()
+		if err := stream.CloseAndReceive(triple.NewResponse(resp)); err != nil {
+			return err
+		}
+		return resps.write(resp)
+
+	case md.IsStreamingServer():
+		req, err := reqs.one()
+		if err != nil {
+			return err
+		}
+		raw, err := conn.CallServerStream(ctx, req, method)
+		if err != nil {
+			return err
+		}
+		stream := raw.(*triple.ServerStreamForClient)
+		for resp := resps.next(); stream.Receive(resp); resp = resps.next() {
+			if err := resps.write(resp); err != nil {
+				return err
+			}
+		}
+		if err := stream.Err(); err != nil {
+			return err
+		}
+		return stream.Close()
+
+	default:
+		req, err := reqs.one()
+		if err != nil {
+			return err
+		}
+		resp := resps.next()
+		if err := conn.CallUnary(ctx, []interface{}{req}, resp, method); err != nil {
+			return err
+		}
+		return resps.write(resp)
+	}
+}
+
+// requests reads the JSON requests of a call.
+type requests struct {
+	dec *json.Decoder
+	md  protoreflect.MessageDescriptor
+}
+
+// next returns the next request, and io.EOF after the last one.
+func (r *requests) next() (proto.Message, error) {
+	var raw json.RawMessage
+	if err := r.dec.Decode(&raw); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("bad request: %w", err)
+	}
+	msg := dynamicpb.NewMessage(r.md)
+	if err := protojson.Unmarshal(raw, msg); err != nil {
+		return nil, fmt.Errorf("bad %s: %w", r.md.FullName(), err)
+	}
+	return msg, nil
+}
+
+// one returns the only request, an empty one when there is none.
+func (r *requests) one() (proto.Message, error) {
+	msg, err := r.next()
+	if errors.Is(err, io.EOF) {
+		return dynamicpb.NewMessage(r.md), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.next(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("the call takes one request")
+	}
+	return msg, nil
+}
+
+// each sends every request.
+func (r *requests) each(send func(interface{}) error) error {
+	for {
+		msg, err := r.next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := send(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// responses writes the JSON responses of a call.
+type responses struct {
+	w  io.Writer
+	md protoreflect.MessageDescriptor
+}
+
+// next returns an empty response to receive into.
+func (r *responses) next() *dynamicpb.Message {
+	return dynamicpb.NewMessage(r.md)
+}
+
+func (r *responses) write(msg proto.Message) error {
+	b, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(r.w, "%s\n", b)
+	return err
+}
@@ -0,0 +1,266 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command tricurl lists, describes and calls the services of triple servers
+// through their reflection service, like grpcurl does for gRPC servers.
+//
+//	tricurl [flags] <target> list [service]
+//	tricurl [flags] <target> describe [symbol]
+//	tricurl [flags] -d <json> <target> call <service/method>
+//
+// The target is a triple server, "tri://host:port" or "host:port", or a
+// registry, such as "zookeeper://127.0.0.1:2181", in which a provider of the
+// service is looked up.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/client"
+	_ "dubbo.apache.org/dubbo-go/v3/imports"
+
+	"github.com/apache/dubbo-go-samples/rpc/triple/reflection/tricurl"
+
+	"github.com/dubbogo/gost/log/logger"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	data    = flag.String("d", "", "the JSON requests of call, one object per request; @ reads them from stdin")
+	timeout = flag.Duration("timeout", 10*time.Second, "the timeout of the command, including the registry lookup")
+	group   = flag.String("group", "", "the group of the service")
+	version = flag.String("version", "", "the version of the service")
+	service = flag.String("service", "", "the service to look up in a registry target, the one of the symbol by default")
+	verbose = flag.Bool("v", false, "log what dubbo-go does")
+)
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	initLogger()
+	if flag.NArg() < 2 || flag.NArg() > 3 {
+		usage()
+		os.Exit(2)
+	}
+	target, err := tricurl.ParseTarget(flag.Arg(0))
+	if err != nil {
+		fail(err)
+	}
+	cmd, symbol := flag.Arg(1), flag.Arg(2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	switch cmd {
+	case "list":
+		err = list(ctx, target, symbol)
+	case "describe":
+		err = describe(ctx, target, symbol)
+	case "call":
+		if symbol == "" {
+			usage()
+			os.Exit(2)
+		}
+		err = call(ctx, target, symbol)
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+// initLogger sends the logs of dubbo-go to stderr, so that stdout holds only
+// the output of the command, and keeps only the errors without -v.
+func initLogger() {
+	level := zapcore.ErrorLevel
+	if *verbose {
+		level = zapcore.InfoLevel
+	}
+	encoder := zap.NewDevelopmentEncoderConfig()
+	encoder.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	logger.InitLogger(&logger.Config{ZapConfig: &zap.Config{
+		Level:            zap.NewAtomicLevelAt(level),
+		Encoding:         "console",
+		EncoderConfig:    encoder,
+		OutputPaths:      []string{"stderr"},
+		ErrorOutputPaths: []string{"stderr"},
+	}})
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), `Usage:
+  tricurl [flags] <target> list [service]
+  tricurl [flags] <target> describe [symbol]
+  tricurl [flags] -d <json> <target> call <service/method>
+
+The target is a triple server, tri://host:port or host:port, or a registry,
+such as zookeeper://127.0.0.1:2181, in which a provider of the service is
+looked up.
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "tricurl:", err)
+	os.Exit(1)
+}
+
+// list prints the services of the server, or the methods of the service.
+func list(ctx context.Context, target tricurl.Target, svc string) error {
+	cli, err := connect(ctx, target, svc)
+	if err != nil {
+		return err
+	}
+	src, err := tricurl.NewSource(ctx, cli)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	if svc == "" {
+		names, err := src.ListServices()
+		if err != nil {
+			return err
+		}
+		fmt.Println(strings.Join(names, "\n"))
+		return nil
+	}
+	sd, err := src.FindService(svc)
+	if err != nil {
+		return err
+	}
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		fmt.Println(methods.Get(i).FullName())
+	}
+	return nil
+}
+
+// describe prints the definition of the symbol, or of every service of the
+// server.
+func describe(ctx context.Context, target tricurl.Target, symbol string) error {
+	lookups := []string{symbol}
+	if svc, _, ok := tricurl.SplitMethod(symbol); ok {
+		// The symbol may be a method, whose service is what is registered.
+		lookups = append(lookups, svc)
+	}
+	cli, err := connect(ctx, target, lookups...)
+	if err != nil {
+		return err
+	}
+	src, err := tricurl.NewSource(ctx, cli)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	symbols := []string{symbol}
+	if symbol == "" {
+		if symbols, err = src.ListServices(); err != nil {
+			return err
+		}
+	}
+	for _, name := range symbols {
+		d, err := src.FindSymbol(name)
+		if err != nil && symbol == "" {
+			// Services exported without IDL, such as the metadata service,
+			// have no descriptor to show.
+			fmt.Fprintf(os.Stderr, "%s has no descriptor: %v\n", name, err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s is a %s:\n%s", d.FullName(), tricurl.Kind(d), tricurl.Describe(d))
+	}
+	return nil
+}
+
+// call calls the method with the requests of -d.
+func call(ctx context.Context, target tricurl.Target, method string) error {
+	svc, _, ok := tricurl.SplitMethod(method)
+	if !ok {
+		return fmt.Errorf("%s is not a method, use service/method", method)
+	}
+	cli, err := connect(ctx, target, svc)
+	if err != nil {
+		return err
+	}
+	src, err := tricurl.NewSource(ctx, cli)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	md, err := src.FindMethod(method)
+	if err != nil {
+		return err
+	}
+	var in io.Reader = strings.NewReader(*data)
+	if *data == "@" {
+		in = os.Stdin
+	}
+	return tricurl.Call(ctx, cli, md, in, os.Stdout, references()...)
+}
+
+// connect returns a client of the server of the target. In a registry, it
+// looks up the providers of the -service flag, or of the first of the
+// services that has providers, sharing the time left between them.
+func connect(ctx context.Context, target tricurl.Target, services ...string) (*client.Client, error) {
+	if *service != "" {
+		services = []string{*service}
+	}
+	var url string
+	var err error
+	for i, svc := range services {
+		wait := *timeout
+		if deadline, ok := ctx.Deadline(); ok {
+			wait = time.Until(deadline) / time.Duration(len(services)-i)
+		}
+		if url, err = target.Resolve(svc, wait, references()...); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return client.NewClient(
+		client.WithClientURL(url),
+		client.WithClientRequestTimeout(*timeout),
+	)
+}
+
+func references() []client.ReferenceOption {
+	var opts []client.ReferenceOption
+	if *group != "" {
+		opts = append(opts, client.WithGroup(*group))
+	}
+	if *version != "" {
+		opts = append(opts, client.WithVersion(*version))
+	}
+	return opts
+}
@@ -0,0 +1,184 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tricurl
+
+import (
+	"fmt"
+	"strings"
+)
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Kind names the kind of the descriptor, such as "service" or "message".
+func Kind(d protoreflect.Descriptor) string {
+	switch d := d.(type) {
+	case protoreflect.ServiceDescriptor:
+		return "service"
+	case protoreflect.MethodDescriptor:
+		return "method"
+	case protoreflect.MessageDescriptor:
+		return "message"
+	case protoreflect.EnumDescriptor:
+		return "enum"
+	case protoreflect.EnumValueDescriptor:
+		return "enum value"
+	case protoreflect.FieldDescriptor:
+		if d.IsExtension() {
+			return "extension"
+		}
+		return "field"
+	case protoreflect.OneofDescriptor:
+		return "oneof"
+	default:
+		return "symbol"
+	}
+}
+
+// Describe returns the definition of the descriptor in the protobuf language.
+// Types are written fully-qualified, with a leading dot.
+func Describe(d protoreflect.Descriptor) string {
+	var b strings.Builder
+	switch d := d.(type) {
+	case protoreflect.ServiceDescriptor:
+		writeService(&b, d)
+	case protoreflect.MethodDescriptor:
+		writeMethod(&b, d, "")
+	case protoreflect.MessageDescriptor:
+		writeMessage(&b, d, "")
+	case protoreflect.EnumDescriptor:
+		writeEnum(&b, d, "")
+	case protoreflect.EnumValueDescriptor:
+		fmt.Fprintf(&b, "%s = %d;\n", d.Name(), d.Number())
+	case protoreflect.FieldDescriptor:
+		writeField(&b, d, "")
+	case protoreflect.OneofDescriptor:
+		writeOneof(&b, d, "")
+	default:
+		fmt.Fprintf(&b, "%s\n", d.FullName())
+	}
+	return b.String()
+}
+
+func writeService(b *strings.Builder, sd protoreflect.ServiceDescriptor) {
+	fmt.Fprintf(b, "service %s {\n", sd.Name())
+	methods := sd.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		writeMethod(b, methods.Get(i), "  ")
+	}
+	b.WriteString("}\n")
+}
+
+func writeMethod(b *strings.Builder, md protoreflect.MethodDescriptor, indent string) {
+	fmt.Fprintf(b, "%srpc %s ( %s%s ) returns ( %s%s );\n", indent, md.Name(),
+		stream(md.IsStreamingClient()), typeName(md.Input()),
+		stream(md.IsStreamingServer()), typeName(md.Output()))
+}
+
+func writeMessage(b *strings.Builder, md protoreflect.MessageDescriptor, indent string) {
+	fmt.Fprintf(b, "%smessage %s {\n", indent, md.Name())
+	inner := indent + "  "
+	fields := md.Fields()
+	written := make(map[protoreflect.FullName]bool)
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			if !written[od.FullName()] {
+				written[od.FullName()] = true
+				writeOneof(b, od, inner)
+			}
+			continue
+		}
+		writeField(b, fd, inner)
+	}
+	messages := md.Messages()
+	for i := 0; i < messages.Len(); i++ {
+		if nested := messages.Get(i); !nested.IsMapEntry() {
+			writeMessage(b, nested, inner)
+		}
+	}
+	enums := md.Enums()
+	for i := 0; i < enums.Len(); i++ {
+		writeEnum(b, enums.Get(i), inner)
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+func writeOneof(b *strings.Builder, od protoreflect.OneofDescriptor, indent string) {
+	fmt.Fprintf(b, "%soneof %s {\n", indent, od.Name())
+	fields := od.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		writeField(b, fields.Get(i), indent+"  ")
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+func writeField(b *strings.Builder, fd protoreflect.FieldDescriptor, indent string) {
+	fmt.Fprintf(b, "%s%s%s %s = %d;\n", indent, label(fd), fieldType(fd), fd.Name(), fd.Number())
+}
+
+func writeEnum(b *strings.Builder, ed protoreflect.EnumDescriptor, indent string) {
+	fmt.Fprintf(b, "%senum %s {\n", indent, ed.Name())
+	values := ed.Values()
+	for i := 0; i < values.Len(); i++ {
+		v := values.Get(i)
+		fmt.Fprintf(b, "%s  %s = %d;\n", indent, v.Name(), v.Number())
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+func label(fd protoreflect.FieldDescriptor) string {
+	switch {
+	case fd.IsMap():
+		return ""
+	case fd.Cardinality() == protoreflect.Repeated:
+		return "repeated "
+	case fd.Cardinality() == protoreflect.Required:
+		return "required "
+	case fd.HasOptionalKeyword():
+		return "optional "
+	default:
+		return ""
+	}
+}
+
+func fieldType(fd protoreflect.FieldDescriptor) string {
+	if fd.IsMap() {
+		return fmt.Sprintf("map<%s, %s>", fieldType(fd.MapKey()), fieldType(fd.MapValue()))
+	}
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return typeName(fd.Message())
+	case protoreflect.EnumKind:
+		return typeName(fd.Enum())
+	default:
+		return fd.Kind().String()
+	}
+}
+
+func typeName(d protoreflect.Descriptor) string {
+	return "." + string(d.FullName())
+}
+
+func stream(streaming bool) string {
+	if streaming {
+		return "stream "
+	}
+	return ""
+}
@@ -0,0 +1,26 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tricurl inspects and calls the services of triple servers through
+// their reflection service, without the generated code of the services.
+//
+// A Source lists the services of a server and resolves the descriptors of
+// their methods and messages, Describe writes them in the protobuf language,
+// and Call calls any unary or streaming method with JSON requests and
+// responses. A Target is a triple server or a registry, in which the tool
+// looks up a provider of the service.
+package tricurl
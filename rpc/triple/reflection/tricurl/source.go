@@ -0,0 +1,232 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tricurl
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/client"
+	reflection "dubbo.apache.org/dubbo-go/v3/protocol/triple/reflection/triple_reflection"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Source resolves the services of a triple server and their descriptors
+// through the reflection service of the server.
+type Source struct {
+	stream reflection.ServerReflection_ServerReflectionInfoClient
+	// protos holds the files the server returned, by name.
+	protos map[string]*descriptorpb.FileDescriptorProto
+	files  *protoregistry.Files
+}
+
+// NewSource opens a reflection stream to the server of the client.
+func NewSource(ctx context.Context, cli *client.Client) (*Source, error) {
+	svc, err := reflection.NewServerReflection(cli)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := svc.ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Source{
+		stream: stream,
+		protos: make(map[string]*descriptorpb.FileDescriptorProto),
+		files:  new(protoregistry.Files),
+	}, nil
+}
+
+// Close closes the reflection stream.
+func (s *Source) Close() error {
+	if err := s.stream.CloseRequest(); err != nil {
+		return err
+	}
+	return s.stream.CloseResponse()
+}
+
+// ListServices returns the names of the services of the server, sorted.
+func (s *Source) ListServices() ([]string, error) {
+	resp, err := s.request(&reflection.ServerReflectionRequest{
+		MessageRequest: &reflection.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, svc := range resp.GetListServicesResponse().GetService() {
+		names = append(names, svc.GetName())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// FindSymbol returns the descriptor of a service, method, message, enum or
+// field by its fully-qualified name.
+func (s *Source) FindSymbol(name string) (protoreflect.Descriptor, error) {
+	name = strings.TrimPrefix(name, ".")
+	if d, err := s.files.FindDescriptorByName(protoreflect.FullName(name)); err == nil {
+		return d, nil
+	}
+	resp, err := s.request(&reflection.ServerReflectionRequest{
+		MessageRequest: &reflection.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: name},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("symbol %s: %w", name, err)
+	}
+	if err := s.load(resp.GetFileDescriptorResponse().GetFileDescriptorProto()); err != nil {
+		return nil, err
+	}
+	d, err := s.files.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("symbol %s: %w", name, err)
+	}
+	return d, nil
+}
+
+// FindService returns the descriptor of a service.
+func (s *Source) FindService(name string) (protoreflect.ServiceDescriptor, error) {
+	d, err := s.FindSymbol(name)
+	if err != nil {
+		return nil, err
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", name)
+	}
+	return sd, nil
+}
+
+// FindMethod returns the descriptor of a method, named "service/method" or
+// "service.method".
+func (s *Source) FindMethod(name string) (protoreflect.MethodDescriptor, error) {
+	svc, method, ok := SplitMethod(name)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a method, use service/method", name)
+	}
+	sd, err := s.FindService(svc)
+	if err != nil {
+		return nil, err
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, fmt.Errorf("service %s has no method %s", svc, method)
+	}
+	return md, nil
+}
+
+// SplitMethod splits "service/method" or "service.method" into the service
+// and the method.
+func SplitMethod(name string) (service, method string, ok bool) {
+	name = strings.TrimPrefix(name, ".")
+	i := strings.LastIndexAny(name, "/.")
+	if i <= 0 || i == len(name)-1 {
+		return "", "", false
+	}
+	return name[:i], name[i+1:], true
+}
+
+// load adds the files, and the files they import, to the files of the source.
+func (s *Source) load(raw [][]byte) error {
+	var pending []string
+	for _, b := range raw {
+		fdp := new(descriptorpb.FileDescriptorProto)
+		if err := proto.Unmarshal(b, fdp); err != nil {
+			return fmt.Errorf("bad file descriptor: %w", err)
+		}
+		if _, ok := s.protos[fdp.GetName()]; !ok {
+			s.protos[fdp.GetName()] = fdp
+			pending = append(pending, fdp.GetDependency()...)
+		}
+	}
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if _, ok := s.protos[name]; ok {
+			continue
+		}
+		fdp, err := s.fileByName(name)
+		if err != nil {
+			return err
+		}
+		s.protos[name] = fdp
+		pending = append(pending, fdp.GetDependency()...)
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, fdp := range s.protos {
+		set.File = append(set.File, fdp)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return fmt.Errorf("bad file descriptors: %w", err)
+	}
+	s.files = files
+	return nil
+}
+
+// fileByName asks the server for a file, falling back to the files linked
+// into this binary, such as the well-known types, when the server does not
+// know it.
+func (s *Source) fileByName(name string) (*descriptorpb.FileDescriptorProto, error) {
+	resp, err := s.request(&reflection.ServerReflectionRequest{
+		MessageRequest: &reflection.ServerReflectionRequest_FileByFilename{FileByFilename: name},
+	})
+	if err == nil {
+		for _, b := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fdp := new(descriptorpb.FileDescriptorProto)
+			if err := proto.Unmarshal(b, fdp); err != nil {
+				return nil, fmt.Errorf("bad file descriptor: %w", err)
+			}
+			if fdp.GetName() == name {
+				return fdp, nil
+			}
+		}
+	}
+	fd, gerr := protoregistry.GlobalFiles.FindFileByPath(name)
+	if gerr != nil {
+		if err == nil {
+			err = gerr
+		}
+		return nil, fmt.Errorf("file %s: %w", name, err)
+	}
+	return protodesc.ToFileDescriptorProto(fd), nil
+}
+
+func (s *Source) request(req *reflection.ServerReflectionRequest) (*reflection.ServerReflectionResponse, error) {
+	if err := s.stream.Send(req); err != nil {
+		return nil, fmt.Errorf("reflection: %w", err)
+	}
+	resp, err := s.stream.Recv()
+	if err != nil {
+		return nil, fmt.Errorf("reflection: %w", err)
+	}
+	if e := resp.GetErrorResponse(); e != nil {
+		return nil, fmt.Errorf("%s", e.GetErrorMessage())
+	}
+	return resp, nil
+}
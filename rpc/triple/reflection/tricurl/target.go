@@ -0,0 +1,184 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tricurl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/client"
+	"dubbo.apache.org/dubbo-go/v3/cluster/cluster"
+	"dubbo.apache.org/dubbo-go/v3/cluster/directory"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/protocol"
+	invocation_impl "dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+)
+
+// triScheme is the scheme of the URLs of triple servers.
+const triScheme = "tri://"
+
+// Target is where the triple server is: a server itself, or a registry whose
+// providers of a service are used.
+type Target struct {
+	// Server is the URL of a triple server, empty for a registry.
+	Server string
+	// Registry is the address of a registry, such as
+	// "zookeeper://127.0.0.1:2181", empty for a server.
+	Registry string
+}
+
+// ParseTarget parses "tri://host:port" or "host:port" as a server, and any
+// other URL, such as "zookeeper://127.0.0.1:2181" or "nacos://127.0.0.1:8848",
+// as a registry.
+func ParseTarget(s string) (Target, error) {
+	switch i := strings.Index(s, "://"); {
+	case s == "":
+		return Target{}, fmt.Errorf("empty target")
+	case i < 0:
+		return Target{Server: triScheme + s}, nil
+	case i == 0 || i+3 == len(s):
+		return Target{}, fmt.Errorf("bad target %s", s)
+	case s[:i+3] == triScheme:
+		return Target{Server: s}, nil
+	default:
+		return Target{Registry: s}, nil
+	}
+}
+
+// Resolve returns the URL of a server of the service: the server of the target,
+// or a triple provider of the service in the registry of the target.
+func (t Target) Resolve(service string, timeout time.Duration, opts ...client.ReferenceOption) (string, error) {
+	if t.Registry == "" {
+		return t.Server, nil
+	}
+	if service == "" {
+		return "", fmt.Errorf("a registry target needs a service to look up")
+	}
+	providers, err := t.Providers(service, timeout, opts...)
+	if err != nil {
+		return "", err
+	}
+	return providers[0], nil
+}
+
+// Providers returns the URLs of the triple providers of the service in the
+// registry of the target, sorted. It waits for the registry to notify them
+// until the timeout.
+//
+// The providers are looked up the way a consumer of the service looks them
+// up, so that both interface-level and application-level registrations are
+// found.
+func (t Target) Providers(service string, timeout time.Duration, opts ...client.ReferenceOption) ([]string, error) {
+	if t.Registry == "" {
+		return nil, fmt.Errorf("%s is not a registry", t.Server)
+	}
+	cli, err := client.NewClient(
+		client.WithClientRegistry(
+			registry.WithAddress(t.Registry),
+			registry.WithRegisterServiceAndInterface(),
+			registry.WithTimeout(timeout),
+		),
+		client.WithClientProtocolTriple(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	dirs, err := join(func() error {
+		_, err := cli.Dial(service, append(opts, client.WithCluster(lookupCluster))...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	inv := invocation_impl.NewRPCInvocationWithOptions(invocation_impl.WithMethodName(""))
+	deadline := time.Now().Add(timeout)
+	for {
+		found := make(map[string]bool)
+		for _, dir := range dirs {
+			for _, ivk := range dir.List(inv) {
+				if u := ivk.GetURL(); u.Protocol == constant.TriProtocol {
+					found[triScheme+u.Location] = true
+				}
+			}
+		}
+		if len(found) > 0 {
+			providers := make([]string, 0, len(found))
+			for p := range found {
+				providers = append(providers, p)
+			}
+			sort.Strings(providers)
+			return providers, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("no triple provider of %s in %s after %s", service, t.Registry, timeout.Round(time.Millisecond))
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// lookupCluster is the cluster a lookup refers the service with, to get hold
+// of the directories of the providers.
+const lookupCluster = "tricurl-lookup"
+
+var (
+	// lookupMu serializes the lookups, which share joined.
+	lookupMu sync.Mutex
+	joined   []directory.Directory
+)
+
+func init() {
+	extension.SetCluster(lookupCluster, func() cluster.Cluster {
+		return lookup{}
+	})
+}
+
+// join returns the directories the lookup cluster joins while refer runs.
+func join(refer func() error) ([]directory.Directory, error) {
+	lookupMu.Lock()
+	defer lookupMu.Unlock()
+	joined = nil
+	if err := refer(); err != nil {
+		return nil, err
+	}
+	dirs := joined
+	joined = nil
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no registry directory was created")
+	}
+	return dirs, nil
+}
+
+// lookup records the directories it joins. The lookupMu is held by join.
+type lookup struct{}
+
+func (lookup) Join(dir directory.Directory) protocol.Invoker {
+	joined = append(joined, dir)
+	failfast, err := extension.GetCluster(constant.ClusterKeyFailfast)
+	if err != nil {
+		panic(err)
+	}
+	return failfast.Join(dir)
+}
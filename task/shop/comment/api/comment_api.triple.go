@@ -55,6 +55,10 @@ const (
 const (
 	// CommentGetCommentProcedure is the fully-qualified name of the Comment's GetComment RPC.
 	CommentGetCommentProcedure = "/org.apache.dubbogo.samples.shop.comment.api.Comment/GetComment"
+	// CommentAddCommentProcedure is the fully-qualified name of the Comment's AddComment RPC.
+	CommentAddCommentProcedure = "/org.apache.dubbogo.samples.shop.comment.api.Comment/AddComment"
+	// CommentListCommentsProcedure is the fully-qualified name of the Comment's ListComments RPC.
+	CommentListCommentsProcedure = "/org.apache.dubbogo.samples.shop.comment.api.Comment/ListComments"
 )
 
 var (
@@ -64,6 +68,8 @@ var (
 // Comment is a client for the org.apache.dubbogo.samples.shop.comment.api.Comment service.
 type Comment interface {
 	GetComment(ctx context.Context, req *CommentReq, opts ...client.CallOption) (*CommentResp, error)
+	AddComment(ctx context.Context, req *AddCommentReq, opts ...client.CallOption) (*CommentInfo, error)
+	ListComments(ctx context.Context, req *ListCommentsReq, opts ...client.CallOption) (*ListCommentsResp, error)
 }
 
 // NewComment constructs a client for the api.Comment service.
@@ -94,9 +100,25 @@ func (c *CommentImpl) GetComment(ctx context.Context, req *CommentReq, opts ...c
 	return resp, nil
 }
 
+func (c *CommentImpl) AddComment(ctx context.Context, req *AddCommentReq, opts ...client.CallOption) (*CommentInfo, error) {
+	resp := new(CommentInfo)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "AddComment", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *CommentImpl) ListComments(ctx context.Context, req *ListCommentsReq, opts ...client.CallOption) (*ListCommentsResp, error) {
+	resp := new(ListCommentsResp)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "ListComments", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 var Comment_ClientInfo = client.ClientInfo{
 	InterfaceName: "org.apache.dubbogo.samples.shop.comment.api.Comment",
-	MethodNames:   []string{"GetComment"},
+	MethodNames:   []string{"GetComment", "AddComment", "ListComments"},
 	ConnectionInjectFunc: func(dubboCliRaw interface{}, conn *client.Connection) {
 		dubboCli := dubboCliRaw.(*CommentImpl)
 		dubboCli.conn = conn
@@ -106,6 +128,8 @@ var Comment_ClientInfo = client.ClientInfo{
 // CommentHandler is an implementation of the org.apache.dubbogo.samples.shop.comment.api.Comment service.
 type CommentHandler interface {
 	GetComment(context.Context, *CommentReq) (*CommentResp, error)
+	AddComment(context.Context, *AddCommentReq) (*CommentInfo, error)
+	ListComments(context.Context, *ListCommentsReq) (*ListCommentsResp, error)
 }
 
 func RegisterCommentHandler(srv *server.Server, hdlr CommentHandler, opts ...server.ServiceOption) error {
@@ -135,5 +159,35 @@ var Comment_ServiceInfo = server.ServiceInfo{
 				return triple_protocol.NewResponse(res), nil
 			},
 		},
+		{
+			Name: "AddComment",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(AddCommentReq)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*AddCommentReq)
+				res, err := handler.(CommentHandler).AddComment(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "ListComments",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(ListCommentsReq)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*ListCommentsReq)
+				res, err := handler.(CommentHandler).ListComments(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
 	},
 }
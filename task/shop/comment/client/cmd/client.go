@@ -47,10 +47,21 @@ func main() {
 	}
 	svc, err := api.NewComment(cli)
 	logger.Info("start to test dubbo")
-	req := &api.CommentReq{
-		ItemName: "comment test",
+	comment, err := svc.AddComment(context.Background(), &api.AddCommentReq{
+		Sku:    1,
+		Author: "dubbo",
+		Rating: 5,
+		Text:   "comment test",
+	})
+	if err != nil {
+		logger.Info(err)
 	}
-	reply, err := svc.GetComment(context.Background(), req)
+	logger.Info(comment)
+	reply, err := svc.ListComments(context.Background(), &api.ListCommentsReq{
+		Sku:      1,
+		Page:     1,
+		PageSize: 10,
+	})
 	if err != nil {
 		logger.Info(err)
 	}
@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider is the comment service, whichever its release.
+package provider
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	"dubbo.apache.org/dubbo-go/v3/server"
+
+	"github.com/apache/dubbo-go-samples/task/shop/comment/api"
+	"github.com/apache/dubbo-go-samples/task/shop/comment/store"
+	"github.com/apache/dubbo-go-samples/task/shop/routing"
+)
+
+// maxCommentLength is the longest comment the moderation lets through.
+const maxCommentLength = 500
+
+// CommentProvider is the provider of comment service
+type CommentProvider struct {
+	release string
+	store   *store.Store
+}
+
+// openStore opens the comments in COMMENT_STORE_PATH, moderated by the words
+// of COMMENT_BANNED_WORDS, comma separated. Every version of the comment
+// server uses the same file by default, so they all show the same comments.
+func openStore() (*store.Store, error) {
+	path := os.Getenv("COMMENT_STORE_PATH")
+	if path == "" {
+		path = filepath.Join(os.TempDir(), "shop-comments.json")
+	}
+	filters := []store.Filter{store.MaxLength(maxCommentLength)}
+	if words := os.Getenv("COMMENT_BANNED_WORDS"); words != "" {
+		filters = append(filters, store.BannedWords(strings.Split(words, ",")...))
+	}
+	return store.Open(path, filters...)
+}
+
+func (c *CommentProvider) GetComment(ctx context.Context, req *api.CommentReq) (*api.CommentResp, error) {
+	page, err := c.store.List(req.Sku, 1, 1)
+	if err != nil {
+		return nil, commentError(err)
+	}
+	if len(page.Comments) == 0 {
+		return &api.CommentResp{Msg: "No comments yet (comment from " + c.release + ")."}, nil
+	}
+	return &api.CommentResp{Msg: page.Comments[0].Text + " (comment from " + c.release + ")"}, nil
+}
+
+func (c *CommentProvider) AddComment(ctx context.Context, req *api.AddCommentReq) (*api.CommentInfo, error) {
+	comment, err := c.store.Add(store.Comment{
+		Sku:    req.Sku,
+		Author: req.Author,
+		Rating: req.Rating,
+		Text:   req.Text,
+	})
+	if err != nil {
+		return nil, commentError(err)
+	}
+	return toAPIComment(comment), nil
+}
+
+func (c *CommentProvider) ListComments(ctx context.Context, req *api.ListCommentsReq) (*api.ListCommentsResp, error) {
+	page, err := c.store.List(req.Sku, int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, commentError(err)
+	}
+	resp := &api.ListCommentsResp{
+		Page:          int32(page.Page),
+		PageSize:      int32(page.PageSize),
+		Total:         int32(page.Total),
+		AverageRating: page.Average,
+	}
+	for _, comment := range page.Comments {
+		resp.Comments = append(resp.Comments, toAPIComment(comment))
+	}
+	return resp, nil
+}
+
+func toAPIComment(c store.Comment) *api.CommentInfo {
+	return &api.CommentInfo{
+		Id:         c.ID,
+		Sku:        c.Sku,
+		Author:     c.Author,
+		Rating:     c.Rating,
+		Text:       c.Text,
+		CreateTime: c.CreatedAt.Unix(),
+	}
+}
+
+func commentError(err error) error {
+	if errors.Is(err, store.ErrInvalidComment) || errors.Is(err, store.ErrRejected) {
+		return triple_protocol.NewError(triple_protocol.CodeInvalidArgument, err)
+	}
+	return triple_protocol.NewError(triple_protocol.CodeInternal, err)
+}
+
+// Register registers the release of the comment service on the server.
+func Register(srv *server.Server, release string) error {
+	comments, err := openStore()
+	if err != nil {
+		return err
+	}
+	return api.RegisterCommentHandler(srv, &CommentProvider{release: release, store: comments},
+		// the tag rules route the gray release by this parameter
+		server.WithParam(routing.ReleaseKey, release),
+	)
+}
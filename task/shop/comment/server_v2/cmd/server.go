@@ -18,7 +18,6 @@
 package main
 
 import (
-	"context"
 	"dubbo.apache.org/dubbo-go/v3"
 	"dubbo.apache.org/dubbo-go/v3/protocol"
 	"dubbo.apache.org/dubbo-go/v3/registry"
@@ -26,17 +25,9 @@ import (
 
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
 
-	"github.com/apache/dubbo-go-samples/task/shop/comment/api"
+	"github.com/apache/dubbo-go-samples/task/shop/comment/server_v2"
 )
 
-// CommentProvider is the provider of comment service
-type CommentProvider struct {
-}
-
-func (c *CommentProvider) GetComment(ctx context.Context, itemName *api.CommentReq) (*api.CommentResp, error) {
-	return &api.CommentResp{Msg: "Comment from v2."}, nil
-}
-
 func main() {
 	ins, err := dubbo.NewInstance(
 		dubbo.WithName("shop-comment"),
@@ -57,7 +48,7 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	if err = api.RegisterCommentHandler(srv, &CommentProvider{}); err != nil {
+	if err = server_v2.Register(srv); err != nil {
 		panic(err)
 	}
 	if err = srv.Serve(); err != nil {
@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Filter moderates the comments before they are stored. It may change a
+// comment, or reject it with an error wrapping ErrRejected.
+type Filter interface {
+	Moderate(c *Comment) error
+}
+
+// FilterFunc is a function used as a Filter.
+type FilterFunc func(c *Comment) error
+
+func (f FilterFunc) Moderate(c *Comment) error { return f(c) }
+
+// BannedWords rejects the comments with any of the words, in any case.
+func BannedWords(words ...string) Filter {
+	banned := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			banned = append(banned, w)
+		}
+	}
+	return FilterFunc(func(c *Comment) error {
+		text := strings.ToLower(c.Text)
+		for _, w := range banned {
+			if strings.Contains(text, w) {
+				return fmt.Errorf("%w: it contains %q", ErrRejected, w)
+			}
+		}
+		return nil
+	})
+}
+
+// MaxLength rejects the comments longer than n characters.
+func MaxLength(n int) Filter {
+	return FilterFunc(func(c *Comment) error {
+		if l := len([]rune(c.Text)); l > n {
+			return fmt.Errorf("%w: %d characters, at most %d", ErrRejected, l, n)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,215 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package store keeps the comments on the items of the shop. The comments are
+// kept in a file shared by the comment servers of every version, so that a
+// comment added on one version shows on the other.
+package store
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/dubbo-go-samples/task/shop/internal/filestore"
+)
+
+var (
+	ErrInvalidComment = errors.New("invalid comment")
+	ErrRejected       = errors.New("comment rejected")
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+
+	// DefaultPageSize is the page size of a list that asks for none, and
+	// MaxPageSize the largest page a list returns.
+	DefaultPageSize = 10
+	MaxPageSize     = 50
+)
+
+// Comment is a stored comment.
+type Comment struct {
+	ID        string    `json:"id"`
+	Sku       int64     `json:"sku"`
+	Author    string    `json:"author"`
+	Rating    int32     `json:"rating"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Page is a page of the comments on an item, with the rating of all of them.
+type Page struct {
+	Comments []Comment
+	Page     int // from 1
+	PageSize int
+	Total    int // comments on the item
+	Average  float64
+}
+
+// Store keeps the comments in the file at path, in memory when the path is
+// empty. The filters moderate every comment added.
+type Store struct {
+	mu      sync.Mutex
+	path    string
+	mem     []Comment
+	filters []Filter
+	now     func() time.Time
+}
+
+// Open opens the comments in the file at path, which does not have to exist
+// yet.
+func Open(path string, filters ...Filter) (*Store, error) {
+	s := &Store{path: path, filters: filters, now: time.Now}
+	if path == "" {
+		return s, nil
+	}
+	// Fail early on a file that cannot be read.
+	if _, err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Add checks the comment, runs it through the filters and stores it.
+func (s *Store) Add(c Comment) (Comment, error) {
+	c.Author = strings.TrimSpace(c.Author)
+	c.Text = strings.TrimSpace(c.Text)
+	switch {
+	case c.Sku <= 0:
+		return Comment{}, fmt.Errorf("%w: invalid sku %d", ErrInvalidComment, c.Sku)
+	case c.Author == "":
+		return Comment{}, fmt.Errorf("%w: no author", ErrInvalidComment)
+	case c.Text == "":
+		return Comment{}, fmt.Errorf("%w: no text", ErrInvalidComment)
+	case c.Rating < MinRating || c.Rating > MaxRating:
+		return Comment{}, fmt.Errorf("%w: rating %d out of %d-%d", ErrInvalidComment, c.Rating, MinRating, MaxRating)
+	}
+	for _, f := range s.filters {
+		if err := f.Moderate(&c); err != nil {
+			return Comment{}, err
+		}
+	}
+	c.ID = newID()
+	c.CreatedAt = s.now()
+	err := s.update(func(comments []Comment) ([]Comment, error) {
+		return append(comments, c), nil
+	})
+	return c, err
+}
+
+// List returns a page of the comments on the item, the latest first. Pages
+// count from 1; a page past the last one is empty.
+func (s *Store) List(sku int64, page, pageSize int) (Page, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+	s.mu.Lock()
+	comments, err := s.load()
+	s.mu.Unlock()
+	if err != nil {
+		return Page{}, err
+	}
+	p := Page{Page: page, PageSize: pageSize}
+	var on []Comment
+	sum := 0
+	for _, c := range comments {
+		if c.Sku == sku {
+			on = append(on, c)
+			sum += int(c.Rating)
+		}
+	}
+	if len(on) == 0 {
+		return p, nil
+	}
+	sort.SliceStable(on, func(i, j int) bool { return on[i].CreatedAt.After(on[j].CreatedAt) })
+	p.Total = len(on)
+	p.Average = float64(sum) / float64(len(on))
+	if from := (page - 1) * pageSize; from < len(on) {
+		to := from + pageSize
+		if to > len(on) {
+			to = len(on)
+		}
+		p.Comments = on[from:to]
+	}
+	return p, nil
+}
+
+// newID returns a new comment ID, sorting by time.
+func newID() string {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return time.Now().UTC().Format("20060102150405") + "-" + hex.EncodeToString(b)
+}
+
+// update changes the comments with fn, holding the lock of the comments file
+// so that no other server changes it meanwhile.
+func (s *Store) update(fn func(comments []Comment) ([]Comment, error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.path != "" {
+		unlock, err := filestore.Lock(s.path)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+	comments, err := s.load()
+	if err != nil {
+		return err
+	}
+	if comments, err = fn(comments); err != nil {
+		return err
+	}
+	return s.save(comments)
+}
+
+// load reads the comments. It is called with mu held.
+func (s *Store) load() ([]Comment, error) {
+	if s.path == "" {
+		return s.mem, nil
+	}
+	var comments []Comment
+	if err := filestore.ReadJSON(s.path, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// save writes the comments through a temporary file so that a reader never
+// sees half of them.
+func (s *Store) save(comments []Comment) error {
+	if s.path == "" {
+		s.mem = comments
+		return nil
+	}
+	return filestore.WriteJSON(s.path, comments)
+}
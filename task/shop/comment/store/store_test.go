@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment Comment
+		want    error
+	}{
+		{"valid", Comment{Sku: 1, Author: "alice", Rating: 5, Text: "great"}, nil},
+		{"no sku", Comment{Author: "alice", Rating: 5, Text: "great"}, ErrInvalidComment},
+		{"no author", Comment{Sku: 1, Author: " ", Rating: 5, Text: "great"}, ErrInvalidComment},
+		{"no text", Comment{Sku: 1, Author: "alice", Rating: 5}, ErrInvalidComment},
+		{"rating 0", Comment{Sku: 1, Author: "alice", Text: "great"}, ErrInvalidComment},
+		{"rating 6", Comment{Sku: 1, Author: "alice", Rating: 6, Text: "great"}, ErrInvalidComment},
+		{"banned", Comment{Sku: 1, Author: "alice", Rating: 1, Text: "what a SCAM"}, ErrRejected},
+		{"too long", Comment{Sku: 1, Author: "alice", Rating: 1, Text: strings.Repeat("a", 21)}, ErrRejected},
+	}
+	s, err := Open("", BannedWords("scam"), MaxLength(20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		c, err := s.Add(tt.comment)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+		if err == nil && (c.ID == "" || c.CreatedAt.IsZero()) {
+			t.Errorf("%s: stored as %+v", tt.name, c)
+		}
+	}
+	if p, _ := s.List(1, 1, 0); p.Total != 1 {
+		t.Errorf("%d comments stored, want 1", p.Total)
+	}
+}
+
+func TestList(t *testing.T) {
+	s, err := Open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	s.now = func() time.Time { now = now.Add(time.Second); return now }
+	for i, rating := range []int32{5, 4, 4, 2, 1} {
+		if _, err := s.Add(Comment{Sku: 1, Author: "alice", Rating: rating, Text: string(rune('a' + i))}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Add(Comment{Sku: 2, Author: "bob", Rating: 1, Text: "other"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		page, size int
+		want       string
+	}{
+		{1, 2, "ed"},
+		{2, 2, "cb"},
+		{3, 2, "a"},
+		{4, 2, ""},
+		{0, 0, "edcba"},
+	}
+	for _, tt := range tests {
+		p, err := s.List(1, tt.page, tt.size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ""
+		for _, c := range p.Comments {
+			got += c.Text
+		}
+		if got != tt.want || p.Total != 5 || p.Average != 3.2 {
+			t.Errorf("page %d of %d: got %q of %d, average %v; want %q of 5, average 3.2",
+				tt.page, tt.size, got, p.Total, p.Average, tt.want)
+		}
+	}
+	if p, _ := s.List(3, 1, 10); p.Total != 0 || p.Average != 0 || len(p.Comments) != 0 {
+		t.Errorf("no comments: got %+v", p)
+	}
+}
+
+func TestSharedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "comments.json")
+	v1, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v1.Add(Comment{Sku: 1, Author: "alice", Rating: 4, Text: "nice"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v2.Add(Comment{Sku: 1, Author: "bob", Rating: 2, Text: "meh"}); err != nil {
+		t.Fatal(err)
+	}
+	p, err := v1.List(1, 1, 0)
+	if err != nil || p.Total != 2 || p.Average != 3 {
+		t.Errorf("got %+v, %v", p, err)
+	}
+}
@@ -42,12 +42,15 @@ type Item struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sku         int64  `protobuf:"varint,1,opt,name=Sku,proto3" json:"Sku,omitempty"`
-	ItemName    string `protobuf:"bytes,2,opt,name=ItemName,proto3" json:"ItemName,omitempty"`
-	Description string `protobuf:"bytes,3,opt,name=Description,proto3" json:"Description,omitempty"`
-	Stock       int32  `protobuf:"varint,4,opt,name=Stock,proto3" json:"Stock,omitempty"`
-	Price       int64  `protobuf:"varint,5,opt,name=Price,proto3" json:"Price,omitempty"`
-	Comment     string `protobuf:"bytes,6,opt,name=Comment,proto3" json:"Comment,omitempty"`
+	Sku           int64          `protobuf:"varint,1,opt,name=Sku,proto3" json:"Sku,omitempty"`
+	ItemName      string         `protobuf:"bytes,2,opt,name=ItemName,proto3" json:"ItemName,omitempty"`
+	Description   string         `protobuf:"bytes,3,opt,name=Description,proto3" json:"Description,omitempty"`
+	Stock         int32          `protobuf:"varint,4,opt,name=Stock,proto3" json:"Stock,omitempty"`
+	Price         int64          `protobuf:"varint,5,opt,name=Price,proto3" json:"Price,omitempty"`
+	Comment       string         `protobuf:"bytes,6,opt,name=Comment,proto3" json:"Comment,omitempty"` // the latest comment
+	AverageRating float64        `protobuf:"fixed64,7,opt,name=AverageRating,proto3" json:"AverageRating,omitempty"`
+	RatingCount   int32          `protobuf:"varint,8,opt,name=RatingCount,proto3" json:"RatingCount,omitempty"`
+	Comments      []*ItemComment `protobuf:"bytes,9,rep,name=Comments,proto3" json:"Comments,omitempty"` // the latest comments
 }
 
 func (x *Item) Reset() {
@@ -124,6 +127,98 @@ func (x *Item) GetComment() string {
 	return ""
 }
 
+func (x *Item) GetAverageRating() float64 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+func (x *Item) GetRatingCount() int32 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+func (x *Item) GetComments() []*ItemComment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+type ItemComment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Author     string `protobuf:"bytes,1,opt,name=Author,proto3" json:"Author,omitempty"`
+	Rating     int32  `protobuf:"varint,2,opt,name=Rating,proto3" json:"Rating,omitempty"`
+	Text       string `protobuf:"bytes,3,opt,name=Text,proto3" json:"Text,omitempty"`
+	CreateTime int64  `protobuf:"varint,4,opt,name=CreateTime,proto3" json:"CreateTime,omitempty"` // unix seconds
+}
+
+func (x *ItemComment) Reset() {
+	*x = ItemComment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detail_api_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ItemComment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemComment) ProtoMessage() {}
+
+func (x *ItemComment) ProtoReflect() protoreflect.Message {
+	mi := &file_detail_api_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemComment.ProtoReflect.Descriptor instead.
+func (*ItemComment) Descriptor() ([]byte, []int) {
+	return file_detail_api_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ItemComment) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *ItemComment) GetRating() int32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *ItemComment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ItemComment) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
 type GetItemReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -136,7 +231,7 @@ type GetItemReq struct {
 func (x *GetItemReq) Reset() {
 	*x = GetItemReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_detail_api_proto_msgTypes[1]
+		mi := &file_detail_api_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -149,7 +244,7 @@ func (x *GetItemReq) String() string {
 func (*GetItemReq) ProtoMessage() {}
 
 func (x *GetItemReq) ProtoReflect() protoreflect.Message {
-	mi := &file_detail_api_proto_msgTypes[1]
+	mi := &file_detail_api_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -162,7 +257,7 @@ func (x *GetItemReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetItemReq.ProtoReflect.Descriptor instead.
 func (*GetItemReq) Descriptor() ([]byte, []int) {
-	return file_detail_api_proto_rawDescGZIP(), []int{1}
+	return file_detail_api_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GetItemReq) GetSku() int64 {
@@ -186,12 +281,15 @@ type DeductStockReq struct {
 
 	Sku   int64 `protobuf:"varint,1,opt,name=Sku,proto3" json:"Sku,omitempty"`
 	Count int32 `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
+	// OrderId makes the deduction of an order happen once however often it is
+	// called.
+	OrderId string `protobuf:"bytes,3,opt,name=OrderId,proto3" json:"OrderId,omitempty"`
 }
 
 func (x *DeductStockReq) Reset() {
 	*x = DeductStockReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_detail_api_proto_msgTypes[2]
+		mi := &file_detail_api_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -204,7 +302,7 @@ func (x *DeductStockReq) String() string {
 func (*DeductStockReq) ProtoMessage() {}
 
 func (x *DeductStockReq) ProtoReflect() protoreflect.Message {
-	mi := &file_detail_api_proto_msgTypes[2]
+	mi := &file_detail_api_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -217,7 +315,7 @@ func (x *DeductStockReq) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeductStockReq.ProtoReflect.Descriptor instead.
 func (*DeductStockReq) Descriptor() ([]byte, []int) {
-	return file_detail_api_proto_rawDescGZIP(), []int{2}
+	return file_detail_api_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *DeductStockReq) GetSku() int64 {
@@ -234,18 +332,26 @@ func (x *DeductStockReq) GetCount() int32 {
 	return 0
 }
 
+func (x *DeductStockReq) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
 type DeductStockResp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Success bool `protobuf:"varint,1,opt,name=Success,proto3" json:"Success,omitempty"`
+	Success bool  `protobuf:"varint,1,opt,name=Success,proto3" json:"Success,omitempty"`
+	Stock   int32 `protobuf:"varint,2,opt,name=Stock,proto3" json:"Stock,omitempty"` // left after the deduction
 }
 
 func (x *DeductStockResp) Reset() {
 	*x = DeductStockResp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_detail_api_proto_msgTypes[3]
+		mi := &file_detail_api_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -258,7 +364,7 @@ func (x *DeductStockResp) String() string {
 func (*DeductStockResp) ProtoMessage() {}
 
 func (x *DeductStockResp) ProtoReflect() protoreflect.Message {
-	mi := &file_detail_api_proto_msgTypes[3]
+	mi := &file_detail_api_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -271,7 +377,7 @@ func (x *DeductStockResp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeductStockResp.ProtoReflect.Descriptor instead.
 func (*DeductStockResp) Descriptor() ([]byte, []int) {
-	return file_detail_api_proto_rawDescGZIP(), []int{3}
+	return file_detail_api_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *DeductStockResp) GetSuccess() bool {
@@ -281,14 +387,233 @@ func (x *DeductStockResp) GetSuccess() bool {
 	return false
 }
 
+func (x *DeductStockResp) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+type RestoreStockReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sku     int64  `protobuf:"varint,1,opt,name=Sku,proto3" json:"Sku,omitempty"`
+	Count   int32  `protobuf:"varint,2,opt,name=Count,proto3" json:"Count,omitempty"`
+	OrderId string `protobuf:"bytes,3,opt,name=OrderId,proto3" json:"OrderId,omitempty"`
+}
+
+func (x *RestoreStockReq) Reset() {
+	*x = RestoreStockReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detail_api_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreStockReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreStockReq) ProtoMessage() {}
+
+func (x *RestoreStockReq) ProtoReflect() protoreflect.Message {
+	mi := &file_detail_api_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreStockReq.ProtoReflect.Descriptor instead.
+func (*RestoreStockReq) Descriptor() ([]byte, []int) {
+	return file_detail_api_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RestoreStockReq) GetSku() int64 {
+	if x != nil {
+		return x.Sku
+	}
+	return 0
+}
+
+func (x *RestoreStockReq) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RestoreStockReq) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type RestoreStockResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success bool  `protobuf:"varint,1,opt,name=Success,proto3" json:"Success,omitempty"`
+	Stock   int32 `protobuf:"varint,2,opt,name=Stock,proto3" json:"Stock,omitempty"`
+}
+
+func (x *RestoreStockResp) Reset() {
+	*x = RestoreStockResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detail_api_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreStockResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreStockResp) ProtoMessage() {}
+
+func (x *RestoreStockResp) ProtoReflect() protoreflect.Message {
+	mi := &file_detail_api_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreStockResp.ProtoReflect.Descriptor instead.
+func (*RestoreStockResp) Descriptor() ([]byte, []int) {
+	return file_detail_api_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RestoreStockResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RestoreStockResp) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+type ConfirmDeductStockReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId string `protobuf:"bytes,1,opt,name=OrderId,proto3" json:"OrderId,omitempty"`
+}
+
+func (x *ConfirmDeductStockReq) Reset() {
+	*x = ConfirmDeductStockReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detail_api_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfirmDeductStockReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmDeductStockReq) ProtoMessage() {}
+
+func (x *ConfirmDeductStockReq) ProtoReflect() protoreflect.Message {
+	mi := &file_detail_api_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmDeductStockReq.ProtoReflect.Descriptor instead.
+func (*ConfirmDeductStockReq) Descriptor() ([]byte, []int) {
+	return file_detail_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ConfirmDeductStockReq) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ConfirmDeductStockResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success bool `protobuf:"varint,1,opt,name=Success,proto3" json:"Success,omitempty"`
+}
+
+func (x *ConfirmDeductStockResp) Reset() {
+	*x = ConfirmDeductStockResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_detail_api_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfirmDeductStockResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmDeductStockResp) ProtoMessage() {}
+
+func (x *ConfirmDeductStockResp) ProtoReflect() protoreflect.Message {
+	mi := &file_detail_api_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmDeductStockResp.ProtoReflect.Descriptor instead.
+func (*ConfirmDeductStockResp) Descriptor() ([]byte, []int) {
+	return file_detail_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ConfirmDeductStockResp) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
 var File_detail_api_proto protoreflect.FileDescriptor
 
 var file_detail_api_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x12, 0x2a, 0x6f, 0x72, 0x67, 0x2e, 0x61, 0x70, 0x61, 0x63, 0x68, 0x65, 0x2e, 0x64,
 	0x75, 0x62, 0x62, 0x6f, 0x67, 0x6f, 0x2e, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2e, 0x73,
-	0x68, 0x6f, 0x70, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x2e, 0x61, 0x70, 0x69, 0x22, 0x9c,
-	0x01, 0x0a, 0x04, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x6b, 0x75, 0x18, 0x01,
+	0x68, 0x6f, 0x70, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x2e, 0x61, 0x70, 0x69, 0x22, 0xb9,
+	0x02, 0x0a, 0x04, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x6b, 0x75, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x53, 0x6b, 0x75, 0x12, 0x1a, 0x0a, 0x08, 0x49, 0x74, 0x65,
 	0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x49, 0x74, 0x65,
 	0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
@@ -297,18 +622,54 @@ var file_detail_api_proto_rawDesc = []byte{
 	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x14, 0x0a,
 	0x05, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x50, 0x72,
 	0x69, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x3a, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x24, 0x0a,
+	0x0d, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x41, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x52, 0x61, 0x74,
+	0x69, 0x6e, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x53, 0x0a, 0x08, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x6f, 0x72, 0x67, 0x2e, 0x61, 0x70,
+	0x61, 0x63, 0x68, 0x65, 0x2e, 0x64, 0x75, 0x62, 0x62, 0x6f, 0x67, 0x6f, 0x2e, 0x73, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x73, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x08, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x71, 0x0a, 0x0b, 0x49, 0x74,
+	0x65, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x41, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x41, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x65, 0x78,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x65, 0x78, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x3a, 0x0a,
 	0x0a, 0x47, 0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x53,
 	0x6b, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x53, 0x6b, 0x75, 0x12, 0x1a, 0x0a,
 	0x08, 0x55, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x55, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x38, 0x0a, 0x0e, 0x44, 0x65, 0x64,
+	0x08, 0x55, 0x73, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x52, 0x0a, 0x0e, 0x44, 0x65, 0x64,
 	0x75, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x53,
 	0x6b, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x53, 0x6b, 0x75, 0x12, 0x14, 0x0a,
 	0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x22, 0x2b, 0x0a, 0x0f, 0x44, 0x65, 0x64, 0x75, 0x63, 0x74, 0x53, 0x74, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x41, 0x0a,
+	0x0f, 0x44, 0x65, 0x64, 0x75, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x18, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x74,
+	0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x53, 0x74, 0x6f, 0x63, 0x6b,
+	0x22, 0x53, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b,
+	0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x53, 0x6b, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x03, 0x53, 0x6b, 0x75, 0x12, 0x14, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x4f, 0x72,
+	0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x42, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x53, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x22, 0x31, 0x0a, 0x15, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x72, 0x6d, 0x44, 0x65, 0x64, 0x75, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x16,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x44, 0x65, 0x64, 0x75, 0x63, 0x74, 0x53, 0x74, 0x6f,
 	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73,
 	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x32, 0x8a, 0x02, 0x0a, 0x06, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x75, 0x0a, 0x07, 0x47,
+	0x32, 0xb8, 0x04, 0x0a, 0x06, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x75, 0x0a, 0x07, 0x47,
 	0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x36, 0x2e, 0x6f, 0x72, 0x67, 0x2e, 0x61, 0x70, 0x61,
 	0x63, 0x68, 0x65, 0x2e, 0x64, 0x75, 0x62, 0x62, 0x6f, 0x67, 0x6f, 0x2e, 0x73, 0x61, 0x6d, 0x70,
 	0x6c, 0x65, 0x73, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x2e,
@@ -324,12 +685,31 @@ var file_detail_api_proto_rawDesc = []byte{
 	0x2e, 0x6f, 0x72, 0x67, 0x2e, 0x61, 0x70, 0x61, 0x63, 0x68, 0x65, 0x2e, 0x64, 0x75, 0x62, 0x62,
 	0x6f, 0x67, 0x6f, 0x2e, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2e, 0x73, 0x68, 0x6f, 0x70,
 	0x2e, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x65, 0x64, 0x75,
-	0x63, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x42, 0x3d, 0x5a,
-	0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x61, 0x63,
-	0x68, 0x65, 0x2f, 0x64, 0x75, 0x62, 0x62, 0x6f, 0x2d, 0x67, 0x6f, 0x2d, 0x73, 0x61, 0x6d, 0x70,
-	0x6c, 0x65, 0x73, 0x2f, 0x74, 0x61, 0x73, 0x6b, 0x2f, 0x73, 0x68, 0x6f, 0x70, 0x2f, 0x64, 0x65,
-	0x74, 0x61, 0x69, 0x6c, 0x2f, 0x61, 0x70, 0x69, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x63, 0x74, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x8b, 0x01,
+	0x0a, 0x0c, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x12, 0x3b,
+	0x2e, 0x6f, 0x72, 0x67, 0x2e, 0x61, 0x70, 0x61, 0x63, 0x68, 0x65, 0x2e, 0x64, 0x75, 0x62, 0x62,
+	0x6f, 0x67, 0x6f, 0x2e, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2e, 0x73, 0x68, 0x6f, 0x70,
+	0x2e, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x3c, 0x2e, 0x6f, 0x72,
+	0x67, 0x2e, 0x61, 0x70, 0x61, 0x63, 0x68, 0x65, 0x2e, 0x64, 0x75, 0x62, 0x62, 0x6f, 0x67, 0x6f,
+	0x2e, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x64, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x12, 0x9d, 0x01, 0x0a, 0x12,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x44, 0x65, 0x64, 0x75, 0x63, 0x74, 0x53, 0x74, 0x6f,
+	0x63, 0x6b, 0x12, 0x41, 0x2e, 0x6f, 0x72, 0x67, 0x2e, 0x61, 0x70, 0x61, 0x63, 0x68, 0x65, 0x2e,
+	0x64, 0x75, 0x62, 0x62, 0x6f, 0x67, 0x6f, 0x2e, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2e,
+	0x73, 0x68, 0x6f, 0x70, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x44, 0x65, 0x64, 0x75, 0x63, 0x74, 0x53, 0x74, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x42, 0x2e, 0x6f, 0x72, 0x67, 0x2e, 0x61, 0x70, 0x61, 0x63,
+	0x68, 0x65, 0x2e, 0x64, 0x75, 0x62, 0x62, 0x6f, 0x67, 0x6f, 0x2e, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x73, 0x2e, 0x73, 0x68, 0x6f, 0x70, 0x2e, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x44, 0x65, 0x64, 0x75, 0x63, 0x74,
+	0x53, 0x74, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x22, 0x00, 0x42, 0x3d, 0x5a, 0x3b, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x61, 0x63, 0x68, 0x65,
+	0x2f, 0x64, 0x75, 0x62, 0x62, 0x6f, 0x2d, 0x67, 0x6f, 0x2d, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x73, 0x2f, 0x74, 0x61, 0x73, 0x6b, 0x2f, 0x73, 0x68, 0x6f, 0x70, 0x2f, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x2f, 0x61, 0x70, 0x69, 0x3b, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -344,23 +724,33 @@ func file_detail_api_proto_rawDescGZIP() []byte {
 	return file_detail_api_proto_rawDescData
 }
 
-var file_detail_api_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_detail_api_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_detail_api_proto_goTypes = []interface{}{
-	(*Item)(nil),            // 0: org.apache.dubbogo.samples.shop.detail.api.Item
-	(*GetItemReq)(nil),      // 1: org.apache.dubbogo.samples.shop.detail.api.GetItemReq
-	(*DeductStockReq)(nil),  // 2: org.apache.dubbogo.samples.shop.detail.api.DeductStockReq
-	(*DeductStockResp)(nil), // 3: org.apache.dubbogo.samples.shop.detail.api.DeductStockResp
+	(*Item)(nil),                   // 0: org.apache.dubbogo.samples.shop.detail.api.Item
+	(*ItemComment)(nil),            // 1: org.apache.dubbogo.samples.shop.detail.api.ItemComment
+	(*GetItemReq)(nil),             // 2: org.apache.dubbogo.samples.shop.detail.api.GetItemReq
+	(*DeductStockReq)(nil),         // 3: org.apache.dubbogo.samples.shop.detail.api.DeductStockReq
+	(*DeductStockResp)(nil),        // 4: org.apache.dubbogo.samples.shop.detail.api.DeductStockResp
+	(*RestoreStockReq)(nil),        // 5: org.apache.dubbogo.samples.shop.detail.api.RestoreStockReq
+	(*RestoreStockResp)(nil),       // 6: org.apache.dubbogo.samples.shop.detail.api.RestoreStockResp
+	(*ConfirmDeductStockReq)(nil),  // 7: org.apache.dubbogo.samples.shop.detail.api.ConfirmDeductStockReq
+	(*ConfirmDeductStockResp)(nil), // 8: org.apache.dubbogo.samples.shop.detail.api.ConfirmDeductStockResp
 }
 var file_detail_api_proto_depIdxs = []int32{
-	1, // 0: org.apache.dubbogo.samples.shop.detail.api.Detail.GetItem:input_type -> org.apache.dubbogo.samples.shop.detail.api.GetItemReq
-	2, // 1: org.apache.dubbogo.samples.shop.detail.api.Detail.DeductStock:input_type -> org.apache.dubbogo.samples.shop.detail.api.DeductStockReq
-	0, // 2: org.apache.dubbogo.samples.shop.detail.api.Detail.GetItem:output_type -> org.apache.dubbogo.samples.shop.detail.api.Item
-	3, // 3: org.apache.dubbogo.samples.shop.detail.api.Detail.DeductStock:output_type -> org.apache.dubbogo.samples.shop.detail.api.DeductStockResp
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: org.apache.dubbogo.samples.shop.detail.api.Item.Comments:type_name -> org.apache.dubbogo.samples.shop.detail.api.ItemComment
+	2, // 1: org.apache.dubbogo.samples.shop.detail.api.Detail.GetItem:input_type -> org.apache.dubbogo.samples.shop.detail.api.GetItemReq
+	3, // 2: org.apache.dubbogo.samples.shop.detail.api.Detail.DeductStock:input_type -> org.apache.dubbogo.samples.shop.detail.api.DeductStockReq
+	5, // 3: org.apache.dubbogo.samples.shop.detail.api.Detail.RestoreStock:input_type -> org.apache.dubbogo.samples.shop.detail.api.RestoreStockReq
+	7, // 4: org.apache.dubbogo.samples.shop.detail.api.Detail.ConfirmDeductStock:input_type -> org.apache.dubbogo.samples.shop.detail.api.ConfirmDeductStockReq
+	0, // 5: org.apache.dubbogo.samples.shop.detail.api.Detail.GetItem:output_type -> org.apache.dubbogo.samples.shop.detail.api.Item
+	4, // 6: org.apache.dubbogo.samples.shop.detail.api.Detail.DeductStock:output_type -> org.apache.dubbogo.samples.shop.detail.api.DeductStockResp
+	6, // 7: org.apache.dubbogo.samples.shop.detail.api.Detail.RestoreStock:output_type -> org.apache.dubbogo.samples.shop.detail.api.RestoreStockResp
+	8, // 8: org.apache.dubbogo.samples.shop.detail.api.Detail.ConfirmDeductStock:output_type -> org.apache.dubbogo.samples.shop.detail.api.ConfirmDeductStockResp
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_detail_api_proto_init() }
@@ -382,7 +772,7 @@ func file_detail_api_proto_init() {
 			}
 		}
 		file_detail_api_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetItemReq); i {
+			switch v := v.(*ItemComment); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -394,7 +784,7 @@ func file_detail_api_proto_init() {
 			}
 		}
 		file_detail_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeductStockReq); i {
+			switch v := v.(*GetItemReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -406,6 +796,18 @@ func file_detail_api_proto_init() {
 			}
 		}
 		file_detail_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeductStockReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detail_api_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeductStockResp); i {
 			case 0:
 				return &v.state
@@ -417,6 +819,54 @@ func file_detail_api_proto_init() {
 				return nil
 			}
 		}
+		file_detail_api_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreStockReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detail_api_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreStockResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detail_api_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfirmDeductStockReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_detail_api_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfirmDeductStockResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -424,7 +874,7 @@ func file_detail_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_detail_api_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
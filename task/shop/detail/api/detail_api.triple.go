@@ -57,6 +57,10 @@ const (
 	DetailGetItemProcedure = "/org.apache.dubbogo.samples.shop.detail.api.Detail/GetItem"
 	// DetailDeductStockProcedure is the fully-qualified name of the Detail's DeductStock RPC.
 	DetailDeductStockProcedure = "/org.apache.dubbogo.samples.shop.detail.api.Detail/DeductStock"
+	// DetailRestoreStockProcedure is the fully-qualified name of the Detail's RestoreStock RPC.
+	DetailRestoreStockProcedure = "/org.apache.dubbogo.samples.shop.detail.api.Detail/RestoreStock"
+	// DetailConfirmDeductStockProcedure is the fully-qualified name of the Detail's ConfirmDeductStock RPC.
+	DetailConfirmDeductStockProcedure = "/org.apache.dubbogo.samples.shop.detail.api.Detail/ConfirmDeductStock"
 )
 
 var (
@@ -67,6 +71,8 @@ var (
 type Detail interface {
 	GetItem(ctx context.Context, req *GetItemReq, opts ...client.CallOption) (*Item, error)
 	DeductStock(ctx context.Context, req *DeductStockReq, opts ...client.CallOption) (*DeductStockResp, error)
+	RestoreStock(ctx context.Context, req *RestoreStockReq, opts ...client.CallOption) (*RestoreStockResp, error)
+	ConfirmDeductStock(ctx context.Context, req *ConfirmDeductStockReq, opts ...client.CallOption) (*ConfirmDeductStockResp, error)
 }
 
 // NewDetail constructs a client for the api.Detail service.
@@ -105,9 +111,25 @@ func (c *DetailImpl) DeductStock(ctx context.Context, req *DeductStockReq, opts
 	return resp, nil
 }
 
+func (c *DetailImpl) RestoreStock(ctx context.Context, req *RestoreStockReq, opts ...client.CallOption) (*RestoreStockResp, error) {
+	resp := new(RestoreStockResp)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "RestoreStock", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *DetailImpl) ConfirmDeductStock(ctx context.Context, req *ConfirmDeductStockReq, opts ...client.CallOption) (*ConfirmDeductStockResp, error) {
+	resp := new(ConfirmDeductStockResp)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "ConfirmDeductStock", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 var Detail_ClientInfo = client.ClientInfo{
 	InterfaceName: "org.apache.dubbogo.samples.shop.detail.api.Detail",
-	MethodNames:   []string{"GetItem", "DeductStock"},
+	MethodNames:   []string{"GetItem", "DeductStock", "RestoreStock", "ConfirmDeductStock"},
 	ConnectionInjectFunc: func(dubboCliRaw interface{}, conn *client.Connection) {
 		dubboCli := dubboCliRaw.(*DetailImpl)
 		dubboCli.conn = conn
@@ -118,6 +140,8 @@ var Detail_ClientInfo = client.ClientInfo{
 type DetailHandler interface {
 	GetItem(context.Context, *GetItemReq) (*Item, error)
 	DeductStock(context.Context, *DeductStockReq) (*DeductStockResp, error)
+	RestoreStock(context.Context, *RestoreStockReq) (*RestoreStockResp, error)
+	ConfirmDeductStock(context.Context, *ConfirmDeductStockReq) (*ConfirmDeductStockResp, error)
 }
 
 func RegisterDetailHandler(srv *server.Server, hdlr DetailHandler, opts ...server.ServiceOption) error {
@@ -162,5 +186,35 @@ var Detail_ServiceInfo = server.ServiceInfo{
 				return triple_protocol.NewResponse(res), nil
 			},
 		},
+		{
+			Name: "RestoreStock",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(RestoreStockReq)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*RestoreStockReq)
+				res, err := handler.(DetailHandler).RestoreStock(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "ConfirmDeductStock",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(ConfirmDeductStockReq)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*ConfirmDeductStockReq)
+				res, err := handler.(DetailHandler).ConfirmDeductStock(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
 	},
 }
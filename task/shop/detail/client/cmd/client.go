@@ -48,7 +48,7 @@ func main() {
 	svc, err := api.NewDetail(cli)
 	logger.Info("start to test dubbo")
 	req := &api.GetItemReq{
-		Sku:      11,
+		Sku:      1,
 		UserName: "test",
 	}
 	reply, err := svc.GetItem(context.Background(), req)
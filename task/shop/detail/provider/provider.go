@@ -0,0 +1,178 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider is the detail service, whichever its release.
+package provider
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"dubbo.apache.org/dubbo-go/v3/client"
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	"dubbo.apache.org/dubbo-go/v3/server"
+	"github.com/dubbogo/gost/log/logger"
+
+	commentAPI "github.com/apache/dubbo-go-samples/task/shop/comment/api"
+	"github.com/apache/dubbo-go-samples/task/shop/detail/api"
+	"github.com/apache/dubbo-go-samples/task/shop/detail/data"
+	"github.com/apache/dubbo-go-samples/task/shop/detail/store"
+	"github.com/apache/dubbo-go-samples/task/shop/routing"
+)
+
+// itemComments is how many of the latest comments an item shows.
+const itemComments = 5
+
+// DetailProvider is the provider of detail service
+type DetailProvider struct {
+	release        string
+	commentService commentAPI.Comment
+	store          *store.Store
+}
+
+// openStore opens the catalog, DETAIL_ITEMS_PATH or the built-in one, with
+// the stock in DETAIL_STOCK_PATH. Every version of the detail server uses the
+// same stock file by default, so they all show the same stock.
+func openStore() (*store.Store, error) {
+	catalog := data.Items
+	if path := os.Getenv("DETAIL_ITEMS_PATH"); path != "" {
+		var err error
+		if catalog, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	path := os.Getenv("DETAIL_STOCK_PATH")
+	if path == "" {
+		path = filepath.Join(os.TempDir(), "shop-detail-stock.json")
+	}
+	return store.Open(catalog, path)
+}
+
+func (d *DetailProvider) GetItem(ctx context.Context, req *api.GetItemReq) (*api.Item, error) {
+	item, err := d.store.Get(req.Sku)
+	if err != nil {
+		return nil, stockError(err)
+	}
+	//get comment from comment server
+	var msg string
+	comment, err := d.commentService.GetComment(ctx, &commentAPI.CommentReq{
+		ItemName: item.Name,
+		Sku:      item.Sku,
+	})
+	if err != nil {
+		logger.Infof("Detail provider get comment error: %v", err)
+	} else {
+		msg = comment.Msg
+	}
+	// the item shows without its rating when the comment server fails
+	comments, err := d.commentService.ListComments(ctx, &commentAPI.ListCommentsReq{
+		Sku:      item.Sku,
+		PageSize: itemComments,
+	})
+	if err != nil {
+		logger.Infof("Detail provider list comments error: %v", err)
+		comments = &commentAPI.ListCommentsResp{}
+	}
+	return &api.Item{
+		Sku:           item.Sku,
+		ItemName:      item.Name,
+		Description:   item.Description + " (item from detail " + d.release + ")",
+		Stock:         item.Stock,
+		Price:         item.Price,
+		Comment:       msg,
+		AverageRating: comments.AverageRating,
+		RatingCount:   comments.Total,
+		Comments:      toItemComments(comments.Comments),
+	}, nil
+}
+
+func toItemComments(comments []*commentAPI.CommentInfo) []*api.ItemComment {
+	list := make([]*api.ItemComment, 0, len(comments))
+	for _, c := range comments {
+		list = append(list, &api.ItemComment{
+			Author:     c.Author,
+			Rating:     c.Rating,
+			Text:       c.Text,
+			CreateTime: c.CreateTime,
+		})
+	}
+	return list
+}
+
+func (d *DetailProvider) DeductStock(ctx context.Context, req *api.DeductStockReq) (*api.DeductStockResp, error) {
+	left, err := d.store.Deduct(req.OrderId, req.Sku, req.Count)
+	if err != nil {
+		return nil, stockError(err)
+	}
+	return &api.DeductStockResp{Success: true, Stock: left}, nil
+}
+
+func (d *DetailProvider) RestoreStock(ctx context.Context, req *api.RestoreStockReq) (*api.RestoreStockResp, error) {
+	stock, err := d.store.Restore(req.OrderId, req.Sku, req.Count)
+	if err != nil {
+		return nil, stockError(err)
+	}
+	return &api.RestoreStockResp{Success: true, Stock: stock}, nil
+}
+
+func (d *DetailProvider) ConfirmDeductStock(ctx context.Context, req *api.ConfirmDeductStockReq) (*api.ConfirmDeductStockResp, error) {
+	if err := d.store.Confirm(req.OrderId); err != nil {
+		return nil, stockError(err)
+	}
+	return &api.ConfirmDeductStockResp{Success: true}, nil
+}
+
+// stockError tells the caller why the stock could not be read or changed.
+func stockError(err error) error {
+	switch {
+	case errors.Is(err, store.ErrUnknownSku), errors.Is(err, store.ErrNoDeduction):
+		return triple_protocol.NewError(triple_protocol.CodeNotFound, err)
+	case errors.Is(err, store.ErrInvalidCount), errors.Is(err, store.ErrNoOrder):
+		return triple_protocol.NewError(triple_protocol.CodeInvalidArgument, err)
+	case errors.Is(err, store.ErrInsufficientStock),
+		errors.Is(err, store.ErrOtherDeduction),
+		errors.Is(err, store.ErrRestored):
+		return triple_protocol.NewError(triple_protocol.CodeFailedPrecondition, err)
+	}
+	return triple_protocol.NewError(triple_protocol.CodeInternal, err)
+}
+
+// Register registers the release of the detail service on the server, calling
+// the comment service with the client.
+func Register(srv *server.Server, cli *client.Client, release string) error {
+	// the comments are optional on an item, the item does not wait long for
+	// them
+	commentService, err := commentAPI.NewComment(cli,
+		client.WithRequestTimeout(time.Second),
+		client.WithRetries(1),
+	)
+	if err != nil {
+		return err
+	}
+	stock, err := openStore()
+	if err != nil {
+		return err
+	}
+	detail := &DetailProvider{release: release, commentService: commentService, store: stock}
+	return api.RegisterDetailHandler(srv, detail,
+		// the tag rules route the gray release by this parameter
+		server.WithParam(routing.ReleaseKey, release),
+	)
+}
@@ -18,45 +18,16 @@
 package main
 
 import (
-	"context"
 	"dubbo.apache.org/dubbo-go/v3"
 	"dubbo.apache.org/dubbo-go/v3/protocol"
 	"dubbo.apache.org/dubbo-go/v3/registry"
 	"github.com/dubbogo/gost/log/logger"
 
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
-	commentAPI "github.com/apache/dubbo-go-samples/task/shop/comment/api"
 
-	"github.com/apache/dubbo-go-samples/task/shop/detail/api"
+	"github.com/apache/dubbo-go-samples/task/shop/detail/server_v1"
 )
 
-// DetailProvider is the provider of detail service
-type DetailProvider struct {
-	commentService commentAPI.Comment
-}
-
-func (d *DetailProvider) GetItem(ctx context.Context, req *api.GetItemReq) (*api.Item, error) {
-	//get comment from comment server
-	comment, err := d.commentService.GetComment(context.Background(), &commentAPI.CommentReq{
-		ItemName: "wudong",
-	})
-	if err != nil {
-		logger.Info("Detail provider get comment error: %v\n", err)
-	}
-	return &api.Item{
-		Sku:         req.Sku,
-		ItemName:    "shirt",
-		Description: "item from detail v1",
-		Stock:       100,
-		Price:       100,
-		Comment:     comment.Msg,
-	}, nil
-}
-
-func (d *DetailProvider) DeductStock(ctx context.Context, req *api.DeductStockReq) (*api.DeductStockResp, error) {
-	return &api.DeductStockResp{Success: true}, nil
-}
-
 func main() {
 	ins, err := dubbo.NewInstance(
 		dubbo.WithName("shop-detail"),
@@ -77,15 +48,11 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	commentService, err := commentAPI.NewComment(cli)
-	if err != nil {
-		panic(err)
-	}
 	srv, err := ins.NewServer()
 	if err != nil {
 		panic(err)
 	}
-	if err = api.RegisterDetailHandler(srv, &DetailProvider{commentService: commentService}); err != nil {
+	if err = server_v1.Register(srv, cli); err != nil {
 		panic(err)
 	}
 	if err = srv.Serve(); err != nil {
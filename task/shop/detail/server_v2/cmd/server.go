@@ -18,47 +18,16 @@
 package main
 
 import (
-	"context"
-	"fmt"
-
 	"dubbo.apache.org/dubbo-go/v3"
 	"dubbo.apache.org/dubbo-go/v3/protocol"
 	"dubbo.apache.org/dubbo-go/v3/registry"
 	"github.com/dubbogo/gost/log/logger"
 
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
-	commentAPI "github.com/apache/dubbo-go-samples/task/shop/comment/api"
 
-	"github.com/apache/dubbo-go-samples/task/shop/detail/api"
+	"github.com/apache/dubbo-go-samples/task/shop/detail/server_v2"
 )
 
-// DetailProvider is the provider of detail service
-type DetailProvider struct {
-	commentService commentAPI.Comment
-}
-
-func (d *DetailProvider) GetItem(ctx context.Context, req *api.GetItemReq) (*api.Item, error) {
-	//get comment from comment server
-	comment, err := d.commentService.GetComment(context.Background(), &commentAPI.CommentReq{
-		ItemName: "wudong",
-	})
-	if err != nil {
-		fmt.Printf("Detail provider get comment error: %v\n", err)
-	}
-	return &api.Item{
-		Sku:         req.Sku,
-		ItemName:    "shirt",
-		Description: "item from detail v2",
-		Stock:       100,
-		Price:       100,
-		Comment:     comment.Msg,
-	}, nil
-}
-
-func (d *DetailProvider) DeductStock(ctx context.Context, req *api.DeductStockReq) (*api.DeductStockResp, error) {
-	return &api.DeductStockResp{Success: true}, nil
-}
-
 func main() {
 	ins, err := dubbo.NewInstance(
 		dubbo.WithName("shop-detail"),
@@ -79,15 +48,11 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	commentService, err := commentAPI.NewComment(cli)
-	if err != nil {
-		panic(err)
-	}
 	srv, err := ins.NewServer()
 	if err != nil {
 		panic(err)
 	}
-	if err = api.RegisterDetailHandler(srv, &DetailProvider{commentService: commentService}); err != nil {
+	if err = server_v2.Register(srv, cli); err != nil {
 		panic(err)
 	}
 	if err = srv.Serve(); err != nil {
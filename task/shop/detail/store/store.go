@@ -0,0 +1,268 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package store keeps the catalog of the shop and the stock of its items.
+// The stock is kept in a file shared by the detail servers of every version,
+// so that a gray release shows the same stock whichever version serves.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/apache/dubbo-go-samples/task/shop/internal/filestore"
+)
+
+var (
+	ErrUnknownSku        = errors.New("unknown sku")
+	ErrInvalidCount      = errors.New("count must be positive")
+	ErrInsufficientStock = errors.New("insufficient stock")
+	ErrOtherDeduction    = errors.New("the order deducted other stock")
+	ErrRestored          = errors.New("the stock of the order was restored")
+	ErrNoDeduction       = errors.New("the order deducted no stock")
+	ErrNoOrder           = errors.New("an order ID is required")
+)
+
+const (
+	// forgetAfter is how long the deduction of an order is remembered, for
+	// repeated calls about it to be answered consistently.
+	forgetAfter = 24 * time.Hour
+)
+
+// Item is an item of the catalog with its stock.
+type Item struct {
+	Sku         int64  `json:"sku"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Price       int64  `json:"price"`
+	Stock       int32  `json:"stock"`
+}
+
+type deduction struct {
+	Sku       int64     `json:"sku"`
+	Count     int32     `json:"count"`
+	Restored  bool      `json:"restored,omitempty"`
+	Confirmed bool      `json:"confirmed,omitempty"`
+	At        time.Time `json:"at"`
+}
+
+type state struct {
+	Stock      map[int64]int32       `json:"stock"`
+	Deductions map[string]*deduction `json:"deductions"` // by order ID
+}
+
+// Store keeps the stock in the file at path, in memory when the path is empty.
+type Store struct {
+	mu      sync.Mutex
+	catalog map[int64]Item // with the initial stock
+	path    string
+	mem     *state
+	now     func() time.Time
+}
+
+// Open reads the catalog from JSON, a list of items. Items that are not in the
+// stock file yet start with their stock in the catalog.
+func Open(catalog []byte, path string) (*Store, error) {
+	var items []Item
+	if err := json.Unmarshal(catalog, &items); err != nil {
+		return nil, fmt.Errorf("parsing the catalog: %w", err)
+	}
+	s := &Store{catalog: make(map[int64]Item, len(items)), path: path, now: time.Now}
+	for _, it := range items {
+		if it.Stock < 0 {
+			return nil, fmt.Errorf("negative stock of sku %d", it.Sku)
+		}
+		s.catalog[it.Sku] = it
+	}
+	if path == "" {
+		s.mem = &state{Stock: make(map[int64]int32), Deductions: make(map[string]*deduction)}
+	}
+	// Fail early on a stock file that cannot be read.
+	if err := s.update(func(*state) error { return nil }); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Get returns the item with its current stock.
+func (s *Store) Get(sku int64) (Item, error) {
+	it, ok := s.catalog[sku]
+	if !ok {
+		return Item{}, fmt.Errorf("%w %d", ErrUnknownSku, sku)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, err := s.load()
+	if err != nil {
+		return Item{}, err
+	}
+	it.Stock = st.Stock[sku]
+	return it, nil
+}
+
+// Deduct takes count units of the item out of the stock for an order, and
+// returns the stock left. It fails when there are not enough. Deducting again
+// for the same order is a no-op, but deducting for an order restored already
+// fails with ErrRestored, so a cancel that overtook its try keeps the stock.
+func (s *Store) Deduct(orderID string, sku int64, count int32) (int32, error) {
+	if err := s.check(orderID, sku, count); err != nil {
+		return 0, err
+	}
+	var left int32
+	err := s.update(func(st *state) error {
+		if d, ok := st.Deductions[orderID]; ok {
+			if d.Sku != sku || d.Count != count {
+				return ErrOtherDeduction
+			}
+			if d.Restored {
+				return ErrRestored
+			}
+			left = st.Stock[sku]
+			return nil
+		}
+		if st.Stock[sku] < count {
+			return fmt.Errorf("%w: %d of sku %d left", ErrInsufficientStock, st.Stock[sku], sku)
+		}
+		st.Stock[sku] -= count
+		st.Deductions[orderID] = &deduction{Sku: sku, Count: count, At: s.now()}
+		left = st.Stock[sku]
+		return nil
+	})
+	return left, err
+}
+
+// Restore undoes the deduction of an order, once, putting count units of the
+// item back into the stock, and returns the stock. An order that deducted
+// nothing yet is remembered as restored, so that its deduction arriving late
+// does not take the stock.
+func (s *Store) Restore(orderID string, sku int64, count int32) (int32, error) {
+	if err := s.check(orderID, sku, count); err != nil {
+		return 0, err
+	}
+	var stock int32
+	err := s.update(func(st *state) error {
+		d, ok := st.Deductions[orderID]
+		switch {
+		case !ok:
+			st.Deductions[orderID] = &deduction{Sku: sku, Count: count, Restored: true, At: s.now()}
+			stock = st.Stock[sku]
+			return nil
+		case d.Sku != sku || d.Count != count:
+			return ErrOtherDeduction
+		case d.Restored:
+			stock = st.Stock[sku]
+			return nil
+		}
+		d.Restored = true
+		st.Stock[sku] += count
+		stock = st.Stock[sku]
+		return nil
+	})
+	return stock, err
+}
+
+// Confirm makes the deduction of an order final, the second phase of a
+// deduction run as a TCC transaction. Confirming again is a no-op.
+func (s *Store) Confirm(orderID string) error {
+	return s.update(func(st *state) error {
+		d, ok := st.Deductions[orderID]
+		switch {
+		case !ok || orderID == "":
+			return fmt.Errorf("%w: %s", ErrNoDeduction, orderID)
+		case d.Restored:
+			return ErrRestored
+		}
+		d.Confirmed = true
+		return nil
+	})
+}
+
+func (s *Store) check(orderID string, sku int64, count int32) error {
+	if orderID == "" {
+		return ErrNoOrder
+	}
+	if count <= 0 {
+		return ErrInvalidCount
+	}
+	if _, ok := s.catalog[sku]; !ok {
+		return fmt.Errorf("%w %d", ErrUnknownSku, sku)
+	}
+	return nil
+}
+
+// update changes the stock with fn, holding the lock of the stock file so
+// that no other server changes it meanwhile.
+func (s *Store) update(fn func(st *state) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.path != "" {
+		unlock, err := filestore.Lock(s.path)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+	st, err := s.load()
+	if err != nil {
+		return err
+	}
+	if err := fn(st); err != nil {
+		return err
+	}
+	return s.save(st)
+}
+
+// load reads the stock, starting the items missing from it with their stock
+// in the catalog, and forgets the old deductions. It is called with mu held.
+func (s *Store) load() (*state, error) {
+	st := s.mem
+	if s.path != "" {
+		st = &state{}
+		if err := filestore.ReadJSON(s.path, st); err != nil {
+			return nil, err
+		}
+		if st.Stock == nil {
+			st.Stock = make(map[int64]int32)
+		}
+		if st.Deductions == nil {
+			st.Deductions = make(map[string]*deduction)
+		}
+	}
+	for sku, it := range s.catalog {
+		if _, ok := st.Stock[sku]; !ok {
+			st.Stock[sku] = it.Stock
+		}
+	}
+	for id, d := range st.Deductions {
+		if s.now().Sub(d.At) > forgetAfter {
+			delete(st.Deductions, id)
+		}
+	}
+	return st, nil
+}
+
+// save writes the stock through a temporary file, so that a reader never sees
+// half of it.
+func (s *Store) save(st *state) error {
+	if s.path == "" {
+		return nil
+	}
+	return filestore.WriteJSON(s.path, st)
+}
@@ -0,0 +1,165 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+const catalog = `[
+  {"sku": 1, "name": "shirt", "price": 100, "stock": 10},
+  {"sku": 2, "name": "mug", "price": 45, "stock": 1}
+]`
+
+func TestDeduct(t *testing.T) {
+	s, err := Open([]byte(catalog), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		order string
+		sku   int64
+		count int32
+		left  int32
+		want  error
+	}{
+		{"deduct", "o1", 1, 3, 7, nil},
+		{"same order again", "o1", 1, 3, 7, nil},
+		{"same order other count", "o1", 1, 2, 0, ErrOtherDeduction},
+		{"without order", "", 1, 2, 0, ErrNoOrder},
+		{"too many", "o2", 1, 8, 0, ErrInsufficientStock},
+		{"unknown sku", "o3", 9, 1, 0, ErrUnknownSku},
+		{"no count", "o4", 1, 0, 0, ErrInvalidCount},
+		{"last one", "o5", 2, 1, 0, nil},
+	}
+	for _, tt := range tests {
+		left, err := s.Deduct(tt.order, tt.sku, tt.count)
+		if !errors.Is(err, tt.want) || left != tt.left {
+			t.Errorf("%s: got %d, %v, want %d, %v", tt.name, left, err, tt.left, tt.want)
+		}
+	}
+}
+
+func TestRestore(t *testing.T) {
+	s, err := Open([]byte(catalog), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Deduct("o1", 1, 4); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if stock, err := s.Restore("o1", 1, 4); err != nil || stock != 10 {
+			t.Errorf("restore #%d: got %d, %v", i+1, stock, err)
+		}
+	}
+	if _, err := s.Deduct("o1", 1, 4); !errors.Is(err, ErrRestored) {
+		t.Errorf("deduct after restore: got %v", err)
+	}
+
+	// The restore of an order arriving before its deduction blocks it.
+	if stock, err := s.Restore("o2", 1, 2); err != nil || stock != 10 {
+		t.Errorf("restore first: got %d, %v", stock, err)
+	}
+	if _, err := s.Deduct("o2", 1, 2); !errors.Is(err, ErrRestored) {
+		t.Errorf("deduct after early restore: got %v", err)
+	}
+
+	// Without an order the stock would be given back any number of times.
+	if _, err := s.Restore("", 1, 4); !errors.Is(err, ErrNoOrder) {
+		t.Errorf("restore without order: got %v", err)
+	}
+	if it, err := s.Get(1); err != nil || it.Stock != 10 {
+		t.Errorf("stock after restore without order = %d, %v", it.Stock, err)
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	s, err := Open([]byte(catalog), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Deduct("o1", 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := s.Confirm("o1"); err != nil {
+			t.Errorf("confirm #%d: %v", i+1, err)
+		}
+	}
+	if err := s.Confirm("o2"); !errors.Is(err, ErrNoDeduction) {
+		t.Errorf("confirm without deduction: got %v", err)
+	}
+	if _, err := s.Restore("o3", 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Confirm("o3"); !errors.Is(err, ErrRestored) {
+		t.Errorf("confirm after restore: got %v", err)
+	}
+}
+
+func TestSharedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stock.json")
+	v1, err := Open([]byte(catalog), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2, err := Open([]byte(catalog), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		s := v1
+		if i%2 == 1 {
+			s = v2
+		}
+		wg.Add(1)
+		go func(s *Store, i int) {
+			defer wg.Done()
+			_, err := s.Deduct(fmt.Sprintf("o%d", i), 1, 1)
+			errs <- err
+		}(s, i)
+	}
+	wg.Wait()
+	close(errs)
+	var ok, short int
+	for err := range errs {
+		switch {
+		case err == nil:
+			ok++
+		case errors.Is(err, ErrInsufficientStock):
+			short++
+		default:
+			t.Fatal(err)
+		}
+	}
+	if ok != 10 || short != 10 {
+		t.Errorf("%d deductions succeeded and %d failed, want 10 and 10", ok, short)
+	}
+	if it, err := v1.Get(1); err != nil || it.Stock != 0 || it.Name != "shirt" {
+		t.Errorf("Get = %+v, %v", it, err)
+	}
+}
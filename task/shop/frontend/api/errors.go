@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+// Error is a failed call of the shop to one of its services.
+type Error struct {
+	Method string // the method of the service called, such as GetItem
+	Code   triple_protocol.Code
+	Err    error
+}
+
+// NewError wraps the error of a call of the method. A call that ran out of
+// time or was cancelled has the code saying so, whatever error the client
+// gave.
+func NewError(method string, err error) *Error {
+	code := triple_protocol.CodeOf(err)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		code = triple_protocol.CodeDeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		code = triple_protocol.CodeCanceled
+	}
+	return &Error{Method: method, Code: code, Err: err}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %v", e.Method, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// HTTPStatus returns the HTTP status of a page that failed with the error.
+func (e *Error) HTTPStatus() int {
+	switch e.Code {
+	case triple_protocol.CodeInvalidArgument, triple_protocol.CodeOutOfRange:
+		return http.StatusBadRequest
+	case triple_protocol.CodeUnauthenticated:
+		return http.StatusUnauthorized
+	case triple_protocol.CodePermissionDenied:
+		return http.StatusForbidden
+	case triple_protocol.CodeNotFound:
+		return http.StatusNotFound
+	case triple_protocol.CodeAlreadyExists, triple_protocol.CodeFailedPrecondition, triple_protocol.CodeAborted:
+		return http.StatusConflict
+	case triple_protocol.CodeResourceExhausted:
+		return http.StatusTooManyRequests
+	case triple_protocol.CodeUnavailable:
+		return http.StatusServiceUnavailable
+	case triple_protocol.CodeDeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
+
+// HTTPStatus returns the HTTP status of a page that failed with the error:
+// the status of the Error it wraps, 500 for any other error.
+func HTTPStatus(err error) int {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.HTTPStatus()
+	}
+	return http.StatusInternalServerError
+}
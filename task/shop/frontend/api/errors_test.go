@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	tripleErr := func(code triple_protocol.Code) error {
+		return triple_protocol.NewError(code, errors.New("failed"))
+	}
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"bad input", NewError("SubmitOrder", tripleErr(triple_protocol.CodeInvalidArgument)), http.StatusBadRequest},
+		{"bad password", NewError("Login", tripleErr(triple_protocol.CodeUnauthenticated)), http.StatusUnauthorized},
+		{"no order", NewError("CancelOrder", tripleErr(triple_protocol.CodeNotFound)), http.StatusNotFound},
+		{"no stock", NewError("SubmitOrder", tripleErr(triple_protocol.CodeFailedPrecondition)), http.StatusConflict},
+		{"no provider", NewError("GetItem", tripleErr(triple_protocol.CodeUnavailable)), http.StatusServiceUnavailable},
+		{"timeout", NewError("GetInfo", tripleErr(triple_protocol.CodeDeadlineExceeded)), http.StatusGatewayTimeout},
+		{"context timeout", NewError("GetInfo", fmt.Errorf("call: %w", context.DeadlineExceeded)), http.StatusGatewayTimeout},
+		{"unknown", NewError("GetItem", errors.New("failed")), http.StatusInternalServerError},
+		{"wrapped", fmt.Errorf("page: %w", NewError("Login", tripleErr(triple_protocol.CodeUnauthenticated))), http.StatusUnauthorized},
+		{"not a call", errors.New("failed"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := HTTPStatus(tt.err); got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
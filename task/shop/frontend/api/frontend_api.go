@@ -18,23 +18,40 @@
 package api
 
 import (
+	"context"
+	"net/http"
+
+	commentAPI "github.com/apache/dubbo-go-samples/task/shop/comment/api"
 	detailAPI "github.com/apache/dubbo-go-samples/task/shop/detail/api"
 	orderAPI "github.com/apache/dubbo-go-samples/task/shop/order/api"
 	userAPI "github.com/apache/dubbo-go-samples/task/shop/user/api"
 )
 
+// ShopService is what the pages of the shop call. The calls end with the
+// context, the one of the page request, and fail with an *Error.
 type ShopService interface {
-	Register(username, password, realName, mail, phone string) bool
+	Register(ctx context.Context, username, password, realName, mail, phone string) error
+
+	// Login returns the user with its session token.
+	Login(ctx context.Context, username, password string) (*userAPI.User, error)
+
+	// GetUserInfo returns the user of a session token Login returned, and
+	// fails with CodeUnauthenticated for a token that is not valid.
+	GetUserInfo(ctx context.Context, token string) (*userAPI.User, error)
+
+	TimeoutLogin(ctx context.Context, username, password string) (*userAPI.User, error)
 
-	Login(username, password string) bool
+	// Tag returns the gray release tag of the requests of the user, empty
+	// for the stable release.
+	Tag(username string, header http.Header) string
 
-	GetUserInfo(username string) (*userAPI.User, error)
+	CheckItem(ctx context.Context, sku int64, username, tag string) (*detailAPI.Item, error)
 
-	TimeoutLogin(username, password string) bool
+	SubmitOrder(ctx context.Context, sku int64, count int, address, phone, receiver, username, tag string) (*orderAPI.OrderResp, error)
 
-	CheckItem(sku int64, username string) (*detailAPI.Item, error)
+	ListOrders(ctx context.Context, username string) ([]*orderAPI.OrderResp, error)
 
-	CheckItemGray(sku int64, username string) (*detailAPI.Item, error)
+	CancelOrder(ctx context.Context, orderID, username string) (*orderAPI.OrderResp, error)
 
-	SubmitOrder(sku int64, count int, address, phone, receiver string) (*orderAPI.OrderResp, error)
+	AddComment(ctx context.Context, sku int64, username string, rating int32, text string) (*commentAPI.CommentInfo, error)
 }
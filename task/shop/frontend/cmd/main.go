@@ -17,9 +17,40 @@
 
 package main
 
-import "github.com/apache/dubbo-go-samples/task/shop/frontend/pages"
+import (
+	"dubbo.apache.org/dubbo-go/v3"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+
+	_ "dubbo.apache.org/dubbo-go/v3/imports"
+
+	"github.com/apache/dubbo-go-samples/task/shop/frontend/pages"
+	"github.com/apache/dubbo-go-samples/task/shop/frontend/server_v1"
+)
 
 func main() {
-	router := pages.InitRouter()
+	// global conception
+	// configure global configurations and common modules
+	ins, err := dubbo.NewInstance(
+		dubbo.WithName("shop-frontend"),
+		dubbo.WithRegistry(
+			registry.WithZookeeper(),
+			registry.WithAddress("127.0.0.1:2181"),
+		),
+		// the routing rules of the shop are kept in the config center
+		dubbo.WithConfigCenter(
+			config_center.WithZookeeper(),
+			config_center.WithAddress("127.0.0.1:2181"),
+			config_center.WithDataID("shop-frontend"),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+	shop, err := server_v1.NewShopServiceProvider(ins)
+	if err != nil {
+		panic(err)
+	}
+	router := pages.InitRouter(shop, "../pages")
 	_ = router.Run(":8080")
 }
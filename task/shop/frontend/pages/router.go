@@ -17,19 +17,32 @@
 
 package pages
 
-import "github.com/gin-gonic/gin"
+import (
+	"path/filepath"
 
-func InitRouter() *gin.Engine {
+	"github.com/gin-gonic/gin"
+
+	"github.com/apache/dubbo-go-samples/task/shop/frontend/api"
+)
+
+// InitRouter serves the pages in the directory dir, calling the shop.
+func InitRouter(shop api.ShopService, dir string) *gin.Engine {
+	shopServer = shop
 	router := gin.Default()
 	// load the html
-	router.LoadHTMLGlob("../pages/templates/*")
+	router.LoadHTMLGlob(filepath.Join(dir, "templates", "*"))
 	// static files
-	router.Static("/static", "../pages/static")
+	router.Static("/static", filepath.Join(dir, "static"))
 	router.GET("/", Index)
 	router.GET("/login", Login)
 	router.GET("/timeoutLogin", TimeoutLogin)
-	router.GET("/grayLogin", GrayLogin)
+	// the gray rules in the config center decide who sees the gray release,
+	// the old gray login is a login like the others
+	router.GET("/grayLogin", Login)
 	router.GET("/userinfo", UserInfo)
 	router.POST("/order", CreateOrder)
+	router.GET("/orders", ListOrders)
+	router.POST("/order/cancel", CancelOrder)
+	router.POST("/comment", AddComment)
 	return router
 }
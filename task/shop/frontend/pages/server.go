@@ -18,25 +18,44 @@
 package pages
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 
 	"github.com/apache/dubbo-go-samples/task/shop/frontend/api"
-	"github.com/apache/dubbo-go-samples/task/shop/frontend/server_v1"
+	userAPI "github.com/apache/dubbo-go-samples/task/shop/user/api"
 	"github.com/gin-gonic/gin"
 )
 
+// sessionCookie keeps the session token of the user logged in.
+const sessionCookie = "shop_session"
+
 var (
 	shopServer api.ShopService
+
+	// errNoSession is the error of a page asked for without logging in.
+	errNoSession = api.NewError("GetInfo", triple_protocol.NewError(triple_protocol.CodeUnauthenticated, errors.New("no session, please log in")))
 )
 
-func init() {
-	provider, err := server_v1.NewShopServiceProvider()
-	if err != nil {
-		panic(err)
+// startSession keeps the session token of the user in a cookie the scripts
+// of the pages cannot read.
+func startSession(c *gin.Context, user *userAPI.User) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(sessionCookie, user.Token, 0, "/", "", false, true)
+}
+
+// sessionUser returns the user of the session cookie, the user service
+// verifying its token.
+func sessionUser(c *gin.Context) (*userAPI.User, error) {
+	token, err := c.Cookie(sessionCookie)
+	if err != nil || token == "" {
+		return nil, errNoSession
 	}
-	shopServer = provider
+	return shopServer.GetUserInfo(c.Request.Context(), token)
 }
 
 func Index(c *gin.Context) {
@@ -47,88 +66,165 @@ func Login(c *gin.Context) {
 	// get the query parameters
 	username := c.Query("username")
 	password := c.Query("password")
-	// login
-	if ok := shopServer.Login(username, password); !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "login failed",
+	sku, err := strconv.ParseInt(c.DefaultQuery("sku", "1"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sku"})
+		return
+	}
+	// login, the calls end with the page request
+	ctx := c.Request.Context()
+	user, err := shopServer.Login(ctx, username, password)
+	if err != nil {
+		c.JSON(api.HTTPStatus(err), gin.H{
+			"error": fmt.Sprintf("login failed error: %s", err.Error()),
 		})
 		return
 	}
-	//get item detail
-	item, err := shopServer.CheckItem(1, username)
+	startSession(c, user)
+	//get item detail, from the release the gray rules give the user
+	item, err := shopServer.CheckItem(ctx, sku, user.Username, shopServer.Tag(user.Username, c.Request.Header))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(api.HTTPStatus(err), gin.H{
 			"error": fmt.Sprintf("get item failed error: %s", err.Error()),
 		})
 		return
 	}
 	// return html
-	c.HTML(http.StatusOK, "detail.html", gin.H{"username": username, "item": item})
+	c.HTML(http.StatusOK, "detail.html", gin.H{"item": item})
 }
 
 func TimeoutLogin(c *gin.Context) {
-	defer func() {
-		if err := recover(); err != nil {
-			c.HTML(http.StatusOK, "index.html", gin.H{"result": "Failed to login, request timeout, please add timeout policy and retry!"})
-		}
-	}()
 	// get the query parameters
 	username := c.Query("username")
 	password := c.Query("password")
-	if ok := shopServer.TimeoutLogin(username, password); !ok {
-		c.HTML(http.StatusOK, "index.html", gin.H{"result": "Failed to login, request timeout, please add timeout policy and retry!"})
+	user, err := shopServer.TimeoutLogin(c.Request.Context(), username, password)
+	if err != nil {
+		result := fmt.Sprintf("Failed to login: %s", err.Error())
+		if api.HTTPStatus(err) == http.StatusGatewayTimeout {
+			result = "Failed to login, request timeout, please add timeout policy and retry!"
+		}
+		c.HTML(api.HTTPStatus(err), "index.html", gin.H{"result": result})
 		return
 	}
-	c.HTML(http.StatusOK, "detail.html", gin.H{"username": username})
+	startSession(c, user)
+	c.HTML(http.StatusOK, "detail.html", nil)
 }
 
-func GrayLogin(c *gin.Context) {
-	// get the query parameters
-	username := c.Query("username")
-	password := c.Query("password")
-	// login
-	if ok := shopServer.Login(username, password); !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "login failed",
+// UserInfo shows the user logged in.
+func UserInfo(c *gin.Context) {
+	user, err := sessionUser(c)
+	if err != nil {
+		c.JSON(api.HTTPStatus(err), gin.H{
+			"error": fmt.Sprintf("get user info failed error: %s", err.Error()),
 		})
 		return
 	}
-	//get item detail
-	item, err := shopServer.CheckItemGray(1, username)
+	c.JSON(http.StatusOK, user)
+}
+
+// CreateOrder orders for the user logged in.
+func CreateOrder(c *gin.Context) {
+	// get the form fields
+	sku, err := strconv.ParseInt(c.PostForm("sku"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("get item failed error: %s", err.Error()),
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sku"})
+		return
+	}
+	count, err := strconv.Atoi(c.DefaultPostForm("count", "1"))
+	if err != nil || count <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid count"})
+		return
+	}
+	address := strings.TrimSpace(c.PostForm("address"))
+	phone := strings.TrimSpace(c.PostForm("phone"))
+	receiver := strings.TrimSpace(c.PostForm("receiver"))
+	if address == "" || phone == "" || receiver == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "address, phone and receiver are required"})
+		return
+	}
+	user, err := sessionUser(c)
+	if err != nil {
+		c.JSON(api.HTTPStatus(err), gin.H{
+			"error": fmt.Sprintf("create order failed error: %s", err.Error()),
 		})
 		return
 	}
-	// return html
-	c.HTML(http.StatusOK, "detail.html", gin.H{"username": username, "item": item})
+	// request, from the release the gray rules give the user
+	order, err := shopServer.SubmitOrder(c.Request.Context(), sku, count, address, phone, receiver, user.Username, shopServer.Tag(user.Username, c.Request.Header))
+	if err != nil {
+		c.JSON(api.HTTPStatus(err), gin.H{
+			"error": fmt.Sprintf("create order failed error: %s", err.Error()),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, order)
 }
 
-func UserInfo(c *gin.Context) {
-	username := c.Query("username")
-	user, err := shopServer.GetUserInfo(username)
+// ListOrders lists the orders of the user logged in.
+func ListOrders(c *gin.Context) {
+	user, err := sessionUser(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("get item failed error: %s", err.Error()),
+		c.JSON(api.HTTPStatus(err), gin.H{
+			"error": fmt.Sprintf("list orders failed error: %s", err.Error()),
 		})
 		return
 	}
-	c.JSON(http.StatusOK, user)
+	orders, err := shopServer.ListOrders(c.Request.Context(), user.Username)
+	if err != nil {
+		c.JSON(api.HTTPStatus(err), gin.H{
+			"error": fmt.Sprintf("list orders failed error: %s", err.Error()),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, orders)
 }
 
-func CreateOrder(c *gin.Context) {
-	// get the query parameters
-	username := c.PostForm("username")
-	sku := c.PostForm("sku")
-	skuInt, _ := strconv.Atoi(sku)
-	// request
-	order, err := shopServer.SubmitOrder(int64(skuInt), 1, "beijing", "11111111", username)
+// CancelOrder cancels an order of the user logged in.
+func CancelOrder(c *gin.Context) {
+	user, err := sessionUser(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("get item failed error: %s", err.Error()),
+		c.JSON(api.HTTPStatus(err), gin.H{
+			"error": fmt.Sprintf("cancel order failed error: %s", err.Error()),
+		})
+		return
+	}
+	order, err := shopServer.CancelOrder(c.Request.Context(), c.PostForm("order_id"), user.Username)
+	if err != nil {
+		c.JSON(api.HTTPStatus(err), gin.H{
+			"error": fmt.Sprintf("cancel order failed error: %s", err.Error()),
 		})
 		return
 	}
 	c.JSON(http.StatusOK, order)
 }
+
+// AddComment comments as the user logged in.
+func AddComment(c *gin.Context) {
+	// get the form fields
+	sku, err := strconv.ParseInt(c.PostForm("sku"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sku"})
+		return
+	}
+	rating, err := strconv.ParseInt(c.PostForm("rating"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid rating"})
+		return
+	}
+	user, err := sessionUser(c)
+	if err != nil {
+		c.JSON(api.HTTPStatus(err), gin.H{
+			"error": fmt.Sprintf("add comment failed error: %s", err.Error()),
+		})
+		return
+	}
+	// request, the comment server checks and moderates the comment
+	comment, err := shopServer.AddComment(c.Request.Context(), sku, user.Username, int32(rating), c.PostForm("text"))
+	if err != nil {
+		c.JSON(api.HTTPStatus(err), gin.H{
+			"error": fmt.Sprintf("add comment failed error: %s", err.Error()),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, comment)
+}
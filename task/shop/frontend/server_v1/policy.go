@@ -0,0 +1,81 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server_v1
+
+import (
+	"context"
+	"time"
+
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+
+	"github.com/apache/dubbo-go-samples/task/shop/frontend/api"
+)
+
+// policy is the timeout of each try of a call and how often a failed call is
+// tried again.
+type policy struct {
+	timeout time.Duration
+	retries int
+}
+
+// policies of the methods the frontend calls. The calls that change something
+// without a key making them idempotent are never retried. TimeoutLogin has no
+// policy on purpose: the timeout task of the tutorial raises its timeout with
+// a rule in the config center.
+var policies = map[string]policy{
+	"Register":    {timeout: 3 * time.Second},
+	"Login":       {timeout: 3 * time.Second, retries: 1},
+	"GetInfo":     {timeout: 1 * time.Second, retries: 2},
+	"GetItem":     {timeout: 2 * time.Second, retries: 2},
+	"SubmitOrder": {timeout: 5 * time.Second},
+	"ListOrders":  {timeout: 2 * time.Second, retries: 2},
+	"CancelOrder": {timeout: 3 * time.Second, retries: 1},
+	"AddComment":  {timeout: 2 * time.Second},
+}
+
+// try calls the method with its policy: each try ends after the timeout, and
+// a try that timed out or found no provider is tried again, as long as ctx
+// lasts. A method without policy is tried once, with the timeout of the
+// client. The frontend tries by itself because the client of this Dubbo
+// release takes neither the timeout nor the retries of a call option; its
+// client tries once.
+func try(ctx context.Context, method string, call func(ctx context.Context) error) error {
+	p, ok := policies[method]
+	if !ok {
+		return call(ctx)
+	}
+	var err error
+	for i := 0; i <= p.retries; i++ {
+		tryCtx, cancel := context.WithTimeout(ctx, p.timeout)
+		err = call(tryCtx)
+		cancel()
+		if err == nil || ctx.Err() != nil || !retriable(method, err) {
+			return err
+		}
+	}
+	return err
+}
+
+// retriable tells whether another try may succeed where the call failed.
+func retriable(method string, err error) bool {
+	switch api.NewError(method, err).Code {
+	case triple_protocol.CodeDeadlineExceeded, triple_protocol.CodeUnavailable:
+		return true
+	}
+	return false
+}
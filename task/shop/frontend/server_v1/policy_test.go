@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server_v1
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+func TestTry(t *testing.T) {
+	policies["Fast"] = policy{timeout: 20 * time.Millisecond, retries: 2}
+	defer delete(policies, "Fast")
+
+	// hang waits out the try, as a slow provider does
+	hang := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	tests := []struct {
+		name   string
+		method string
+		fails  []error // the errors of the tries, the next try succeeds
+		tries  int
+		failed bool
+	}{
+		{"first try", "Fast", nil, 1, false},
+		{"slow try", "Fast", []error{nil}, 2, false},
+		{"no provider", "Fast", []error{triple_protocol.NewError(triple_protocol.CodeUnavailable, errors.New("down"))}, 2, false},
+		{"always slow", "Fast", []error{nil, nil, nil}, 3, true},
+		{"bad password", "Fast", []error{triple_protocol.NewError(triple_protocol.CodeUnauthenticated, errors.New("denied"))}, 1, true},
+		{"no policy", "Unknown", []error{triple_protocol.NewError(triple_protocol.CodeUnavailable, errors.New("down"))}, 1, true},
+	}
+	for _, tt := range tests {
+		tries := 0
+		err := try(context.Background(), tt.method, func(ctx context.Context) error {
+			tries++
+			if tries > len(tt.fails) {
+				return nil
+			}
+			if tt.fails[tries-1] == nil {
+				return hang(ctx)
+			}
+			return tt.fails[tries-1]
+		})
+		if tries != tt.tries || (err != nil) != tt.failed {
+			t.Errorf("%s: %d tries, err %v; want %d tries, failed %v", tt.name, tries, err, tt.tries, tt.failed)
+		}
+	}
+}
+
+func TestTryCallerGivesUp(t *testing.T) {
+	policies["Slow"] = policy{timeout: time.Second, retries: 2}
+	defer delete(policies, "Slow")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	tries := 0
+	err := try(ctx, "Slow", func(ctx context.Context) error {
+		tries++
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if tries != 1 || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("%d tries, err %v; want 1 try, deadline exceeded", tries, err)
+	}
+}
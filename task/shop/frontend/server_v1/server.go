@@ -19,62 +19,69 @@ package server_v1
 
 import (
 	"context"
-	"dubbo.apache.org/dubbo-go/v3"
-	"dubbo.apache.org/dubbo-go/v3/registry"
+	"net/http"
 
+	"dubbo.apache.org/dubbo-go/v3"
+	"dubbo.apache.org/dubbo-go/v3/client"
+	"dubbo.apache.org/dubbo-go/v3/common/config"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
+
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
 
+	commentAPI "github.com/apache/dubbo-go-samples/task/shop/comment/api"
 	detailAPI "github.com/apache/dubbo-go-samples/task/shop/detail/api"
+	"github.com/apache/dubbo-go-samples/task/shop/frontend/api"
 	orderAPI "github.com/apache/dubbo-go-samples/task/shop/order/api"
+	"github.com/apache/dubbo-go-samples/task/shop/routing"
 	userAPI "github.com/apache/dubbo-go-samples/task/shop/user/api"
 )
 
 // ShopServiceProvider provides the implementation of ShopService interface
 type ShopServiceProvider struct {
-	userService   userAPI.UserService
-	orderService  orderAPI.Order
-	detailService detailAPI.Detail
+	userService    userAPI.UserService
+	orderService   orderAPI.Order
+	detailService  detailAPI.Detail
+	commentService commentAPI.Comment
+	gray           *routing.Watcher
 }
 
-func NewShopServiceProvider() (*ShopServiceProvider, error) {
-	// global conception
-	// configure global configurations and common modules
-	ins, err := dubbo.NewInstance(
-		dubbo.WithRegistry(
-			registry.WithZookeeper(),
-			registry.WithAddress("127.0.0.1:2181"),
-		),
-	)
-	if err != nil {
-		panic(err)
-	}
-	cli, err := ins.NewClient()
+// NewShopServiceProvider calls the services of the shop with a client of the
+// instance, and tags the requests by the gray rules of the config center of
+// the process.
+func NewShopServiceProvider(ins *dubbo.Instance) (*ShopServiceProvider, error) {
+	// the calls are tried again by their policy, not by the client
+	cli, err := ins.NewClient(client.WithClientRetries(0))
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	userService, err := userAPI.NewUserService(cli)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	order, err := orderAPI.NewOrder(cli)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	detail, err := detailAPI.NewDetail(cli)
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+	comment, err := commentAPI.NewComment(cli)
+	if err != nil {
+		return nil, err
 	}
 	sp := &ShopServiceProvider{
-		userService:   userService,
-		orderService:  order,
-		detailService: detail,
+		userService:    userService,
+		orderService:   order,
+		detailService:  detail,
+		commentService: comment,
+		gray:           routing.Watch(config.GetEnvInstance().GetDynamicConfiguration()),
 	}
 	return sp, nil
 }
 
 // Register registers a user
-func (s *ShopServiceProvider) Register(username, password, realName, mail, phone string) bool {
+func (s *ShopServiceProvider) Register(ctx context.Context, username, password, realName, mail, phone string) error {
 	user := &userAPI.User{
 		Username: username,
 		Password: password,
@@ -82,71 +89,140 @@ func (s *ShopServiceProvider) Register(username, password, realName, mail, phone
 		Mail:     mail,
 		Phone:    phone,
 	}
-	if reply, err := s.userService.Register(context.Background(), user); err != nil || !reply.Success {
-		return false
-	}
-	return true
+	err := try(ctx, "Register", func(ctx context.Context) error {
+		_, err := s.userService.Register(ctx, user)
+		return err
+	})
+	return wrap("Register", err)
 }
 
-func (s *ShopServiceProvider) Login(username, password string) bool {
+func (s *ShopServiceProvider) Login(ctx context.Context, username, password string) (*userAPI.User, error) {
 	req := &userAPI.LoginReq{
 		Username: username,
 		Password: password,
 	}
-	if reply, err := s.userService.Login(context.Background(), req); err != nil || reply == nil {
-		return false
-	}
-	return true
+	var user *userAPI.User
+	err := try(ctx, "Login", func(ctx context.Context) (err error) {
+		user, err = s.userService.Login(ctx, req)
+		return err
+	})
+	return user, wrap("Login", err)
 }
 
-func (s *ShopServiceProvider) GetUserInfo(username string) (*userAPI.User, error) {
+func (s *ShopServiceProvider) GetUserInfo(ctx context.Context, token string) (*userAPI.User, error) {
 	req := &userAPI.GetInfoReq{
-		Username: username,
-	}
-	return s.userService.GetInfo(context.Background(), req)
+		Token: token,
+	}
+	var user *userAPI.User
+	err := try(ctx, "GetInfo", func(ctx context.Context) (err error) {
+		user, err = s.userService.GetInfo(ctx, req)
+		return err
+	})
+	return user, wrap("GetInfo", err)
 }
 
-func (s *ShopServiceProvider) TimeoutLogin(username, password string) bool {
+func (s *ShopServiceProvider) TimeoutLogin(ctx context.Context, username, password string) (*userAPI.User, error) {
 	req := &userAPI.LoginReq{
 		Username: username,
 		Password: password,
 	}
-	if reply, err := s.userService.TimeoutLogin(context.Background(), req); err != nil || reply == nil {
-		return false
-	}
-	return true
+	var user *userAPI.User
+	err := try(ctx, "TimeoutLogin", func(ctx context.Context) (err error) {
+		user, err = s.userService.TimeoutLogin(ctx, req)
+		return err
+	})
+	return user, wrap("TimeoutLogin", err)
 }
 
-func (s *ShopServiceProvider) CheckItem(sku int64, username string) (*detailAPI.Item, error) {
-	req := &detailAPI.GetItemReq{
-		Sku:      sku,
-		UserName: username,
-	}
-	// add tag
-	ctx := context.Background()
-	atm := map[string]string{
-		"dubbo.tag":       "gray",
-		"dubbo.force.tag": "true",
-	}
-	ctx = context.WithValue(ctx, constant.AttachmentKey, atm)
-	return s.detailService.GetItem(ctx, req)
+// Tag returns the tag of the requests of the user, as the gray rules in the
+// config center give it.
+func (s *ShopServiceProvider) Tag(username string, header http.Header) string {
+	return s.gray.Tag(username, header)
 }
 
-func (s *ShopServiceProvider) CheckItemGray(sku int64, username string) (*detailAPI.Item, error) {
+func (s *ShopServiceProvider) CheckItem(ctx context.Context, sku int64, username, tag string) (*detailAPI.Item, error) {
 	req := &detailAPI.GetItemReq{
 		Sku:      sku,
 		UserName: username,
 	}
-	return s.detailService.GetItem(context.Background(), req)
+	var item *detailAPI.Item
+	err := try(withRouting(ctx, username, tag), "GetItem", func(ctx context.Context) (err error) {
+		item, err = s.detailService.GetItem(ctx, req)
+		return err
+	})
+	return item, wrap("GetItem", err)
 }
 
-func (s *ShopServiceProvider) SubmitOrder(sku int64, count int, address, phone, receiver string) (*orderAPI.OrderResp, error) {
+func (s *ShopServiceProvider) SubmitOrder(ctx context.Context, sku int64, count int, address, phone, receiver, username, tag string) (*orderAPI.OrderResp, error) {
 	order := &orderAPI.OrderReq{
 		Sku:      sku,
 		Count:    int32(count),
 		Address:  address,
 		Phone:    phone,
 		Receiver: receiver,
+		UserName: username,
+	}
+	var resp *orderAPI.OrderResp
+	err := try(withRouting(ctx, username, tag), "SubmitOrder", func(ctx context.Context) (err error) {
+		resp, err = s.orderService.SubmitOrder(ctx, order)
+		return err
+	})
+	return resp, wrap("SubmitOrder", err)
+}
+
+func (s *ShopServiceProvider) ListOrders(ctx context.Context, username string) ([]*orderAPI.OrderResp, error) {
+	req := &orderAPI.ListOrdersReq{
+		UserName: username,
+	}
+	var reply *orderAPI.ListOrdersResp
+	err := try(ctx, "ListOrders", func(ctx context.Context) (err error) {
+		reply, err = s.orderService.ListOrders(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, wrap("ListOrders", err)
+	}
+	return reply.Orders, nil
+}
+
+func (s *ShopServiceProvider) CancelOrder(ctx context.Context, orderID, username string) (*orderAPI.OrderResp, error) {
+	req := &orderAPI.CancelOrderReq{
+		OrderId:  orderID,
+		UserName: username,
+	}
+	var resp *orderAPI.OrderResp
+	err := try(ctx, "CancelOrder", func(ctx context.Context) (err error) {
+		resp, err = s.orderService.CancelOrder(ctx, req)
+		return err
+	})
+	return resp, wrap("CancelOrder", err)
+}
+
+func (s *ShopServiceProvider) AddComment(ctx context.Context, sku int64, username string, rating int32, text string) (*commentAPI.CommentInfo, error) {
+	req := &commentAPI.AddCommentReq{
+		Sku:    sku,
+		Author: username,
+		Rating: rating,
+		Text:   text,
+	}
+	var comment *commentAPI.CommentInfo
+	err := try(ctx, "AddComment", func(ctx context.Context) (err error) {
+		comment, err = s.commentService.AddComment(ctx, req)
+		return err
+	})
+	return comment, wrap("AddComment", err)
+}
+
+// withRouting attaches the user and the tag to the calls, for the tag and
+// condition rules of the providers to route them.
+func withRouting(ctx context.Context, username, tag string) context.Context {
+	return context.WithValue(ctx, constant.AttachmentKey, routing.Attachments(username, tag))
+}
+
+// wrap turns the error of a call into an *api.Error, nil staying nil.
+func wrap(method string, err error) error {
+	if err == nil {
+		return nil
 	}
-	return s.orderService.SubmitOrder(context.Background(), order)
+	return api.NewError(method, err)
 }
@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filestore
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	n := 7
+	if err := ReadJSON(path, &n); err != nil || n != 7 {
+		t.Fatalf("missing file: %d, %v, want 7 untouched", n, err)
+	}
+	if err := WriteJSON(path, 42); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReadJSON(path, &n); err != nil || n != 42 {
+		t.Fatalf("got %d, %v, want 42", n, err)
+	}
+	if matches, _ := filepath.Glob(path + ".*"); len(matches) != 0 {
+		t.Errorf("temporary files left: %v", matches)
+	}
+}
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	// every update under the lock is kept, as if each server had the file
+	// to itself
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := Lock(path)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer unlock()
+			var n int
+			if err := ReadJSON(path, &n); err != nil {
+				t.Error(err)
+				return
+			}
+			if err := WriteJSON(path, n+1); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	var n int
+	if err := ReadJSON(path, &n); err != nil || n != 20 {
+		t.Fatalf("got %d, %v, want 20", n, err)
+	}
+
+	unlock, err := Lock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+	lockTimeout = 50 * time.Millisecond
+	defer func() { lockTimeout = 5 * time.Second }()
+	if _, err := Lock(path); err == nil {
+		t.Fatal("locked a file locked already")
+	}
+}
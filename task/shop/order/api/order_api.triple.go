@@ -55,6 +55,14 @@ const (
 const (
 	// OrderSubmitOrderProcedure is the fully-qualified name of the Order's SubmitOrder RPC.
 	OrderSubmitOrderProcedure = "/org.apache.dubbogo.samples.shop.order.api.Order/SubmitOrder"
+	// OrderGetOrderProcedure is the fully-qualified name of the Order's GetOrder RPC.
+	OrderGetOrderProcedure = "/org.apache.dubbogo.samples.shop.order.api.Order/GetOrder"
+	// OrderListOrdersProcedure is the fully-qualified name of the Order's ListOrders RPC.
+	OrderListOrdersProcedure = "/org.apache.dubbogo.samples.shop.order.api.Order/ListOrders"
+	// OrderCancelOrderProcedure is the fully-qualified name of the Order's CancelOrder RPC.
+	OrderCancelOrderProcedure = "/org.apache.dubbogo.samples.shop.order.api.Order/CancelOrder"
+	// OrderUpdateOrderStatusProcedure is the fully-qualified name of the Order's UpdateOrderStatus RPC.
+	OrderUpdateOrderStatusProcedure = "/org.apache.dubbogo.samples.shop.order.api.Order/UpdateOrderStatus"
 )
 
 var (
@@ -64,6 +72,10 @@ var (
 // Order is a client for the org.apache.dubbogo.samples.shop.order.api.Order service.
 type Order interface {
 	SubmitOrder(ctx context.Context, req *OrderReq, opts ...client.CallOption) (*OrderResp, error)
+	GetOrder(ctx context.Context, req *GetOrderReq, opts ...client.CallOption) (*OrderResp, error)
+	ListOrders(ctx context.Context, req *ListOrdersReq, opts ...client.CallOption) (*ListOrdersResp, error)
+	CancelOrder(ctx context.Context, req *CancelOrderReq, opts ...client.CallOption) (*OrderResp, error)
+	UpdateOrderStatus(ctx context.Context, req *UpdateOrderStatusReq, opts ...client.CallOption) (*OrderResp, error)
 }
 
 // NewOrder constructs a client for the api.Order service.
@@ -94,9 +106,41 @@ func (c *OrderImpl) SubmitOrder(ctx context.Context, req *OrderReq, opts ...clie
 	return resp, nil
 }
 
+func (c *OrderImpl) GetOrder(ctx context.Context, req *GetOrderReq, opts ...client.CallOption) (*OrderResp, error) {
+	resp := new(OrderResp)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "GetOrder", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *OrderImpl) ListOrders(ctx context.Context, req *ListOrdersReq, opts ...client.CallOption) (*ListOrdersResp, error) {
+	resp := new(ListOrdersResp)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "ListOrders", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *OrderImpl) CancelOrder(ctx context.Context, req *CancelOrderReq, opts ...client.CallOption) (*OrderResp, error) {
+	resp := new(OrderResp)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "CancelOrder", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *OrderImpl) UpdateOrderStatus(ctx context.Context, req *UpdateOrderStatusReq, opts ...client.CallOption) (*OrderResp, error) {
+	resp := new(OrderResp)
+	if err := c.conn.CallUnary(ctx, []interface{}{req}, resp, "UpdateOrderStatus", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 var Order_ClientInfo = client.ClientInfo{
 	InterfaceName: "org.apache.dubbogo.samples.shop.order.api.Order",
-	MethodNames:   []string{"SubmitOrder"},
+	MethodNames:   []string{"SubmitOrder", "GetOrder", "ListOrders", "CancelOrder", "UpdateOrderStatus"},
 	ConnectionInjectFunc: func(dubboCliRaw interface{}, conn *client.Connection) {
 		dubboCli := dubboCliRaw.(*OrderImpl)
 		dubboCli.conn = conn
@@ -106,6 +150,10 @@ var Order_ClientInfo = client.ClientInfo{
 // OrderHandler is an implementation of the org.apache.dubbogo.samples.shop.order.api.Order service.
 type OrderHandler interface {
 	SubmitOrder(context.Context, *OrderReq) (*OrderResp, error)
+	GetOrder(context.Context, *GetOrderReq) (*OrderResp, error)
+	ListOrders(context.Context, *ListOrdersReq) (*ListOrdersResp, error)
+	CancelOrder(context.Context, *CancelOrderReq) (*OrderResp, error)
+	UpdateOrderStatus(context.Context, *UpdateOrderStatusReq) (*OrderResp, error)
 }
 
 func RegisterOrderHandler(srv *server.Server, hdlr OrderHandler, opts ...server.ServiceOption) error {
@@ -135,5 +183,65 @@ var Order_ServiceInfo = server.ServiceInfo{
 				return triple_protocol.NewResponse(res), nil
 			},
 		},
+		{
+			Name: "GetOrder",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(GetOrderReq)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*GetOrderReq)
+				res, err := handler.(OrderHandler).GetOrder(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "ListOrders",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(ListOrdersReq)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*ListOrdersReq)
+				res, err := handler.(OrderHandler).ListOrders(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "CancelOrder",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(CancelOrderReq)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*CancelOrderReq)
+				res, err := handler.(OrderHandler).CancelOrder(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
+		{
+			Name: "UpdateOrderStatus",
+			Type: constant.CallUnary,
+			ReqInitFunc: func() interface{} {
+				return new(UpdateOrderStatusReq)
+			},
+			MethodFunc: func(ctx context.Context, args []interface{}, handler interface{}) (interface{}, error) {
+				req := args[0].(*UpdateOrderStatusReq)
+				res, err := handler.(OrderHandler).UpdateOrderStatus(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				return triple_protocol.NewResponse(res), nil
+			},
+		},
 	},
 }
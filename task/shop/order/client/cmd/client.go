@@ -49,15 +49,26 @@ func main() {
 	svc, err := api.NewOrder(cli)
 	logger.Info("start to test dubbo")
 	req := &api.OrderReq{
-		Sku:      11,
-		Count:    10,
+		Sku:      1,
+		Count:    1,
 		Address:  "beijing",
 		Phone:    "111",
 		Receiver: "test",
+		UserName: "test",
 	}
 	reply, err := svc.SubmitOrder(context.Background(), req)
 	if err != nil {
 		panic(err)
 	}
 	logger.Info(reply)
+	orders, err := svc.ListOrders(context.Background(), &api.ListOrdersReq{UserName: "test"})
+	if err != nil {
+		panic(err)
+	}
+	logger.Infof("test has %d orders", len(orders.Orders))
+	cancelled, err := svc.CancelOrder(context.Background(), &api.CancelOrderReq{OrderId: reply.OrderId, UserName: "test"})
+	if err != nil {
+		panic(err)
+	}
+	logger.Info(cancelled)
 }
@@ -0,0 +1,262 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package provider is the order service, whichever its release.
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"dubbo.apache.org/dubbo-go/v3/client"
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	"dubbo.apache.org/dubbo-go/v3/server"
+	"github.com/dubbogo/gost/log/logger"
+
+	detailAPI "github.com/apache/dubbo-go-samples/task/shop/detail/api"
+	"github.com/apache/dubbo-go-samples/task/shop/order/api"
+	"github.com/apache/dubbo-go-samples/task/shop/order/store"
+	"github.com/apache/dubbo-go-samples/task/shop/order/tcc"
+	"github.com/apache/dubbo-go-samples/task/shop/routing"
+)
+
+// OrderProvider is the provider of order service
+type OrderProvider struct {
+	release       string
+	detailService detailAPI.Detail
+	store         *store.Store
+	coordinator   tcc.Coordinator
+}
+
+// openStore opens the orders in ORDER_STORE_PATH. Every version of the order
+// server uses the same file by default, so they all see the same orders.
+func openStore() (*store.Store, error) {
+	path := os.Getenv("ORDER_STORE_PATH")
+	if path == "" {
+		path = filepath.Join(os.TempDir(), "shop-orders.json")
+	}
+	return store.Open(path)
+}
+
+func (o *OrderProvider) SubmitOrder(ctx context.Context, req *api.OrderReq) (*api.OrderResp, error) {
+	if err := requireUser(req.UserName); err != nil {
+		return nil, err
+	}
+	if req.Count <= 0 {
+		return nil, triple_protocol.NewError(triple_protocol.CodeInvalidArgument, errors.New("count must be positive"))
+	}
+	if strings.TrimSpace(req.Address) == "" || strings.TrimSpace(req.Phone) == "" || strings.TrimSpace(req.Receiver) == "" {
+		return nil, triple_protocol.NewError(triple_protocol.CodeInvalidArgument, errors.New("address, phone and receiver are required"))
+	}
+	order := store.Order{
+		ID:       store.NewID(),
+		UserName: req.UserName,
+		Sku:      req.Sku,
+		Count:    req.Count,
+		Address:  req.Address,
+		Phone:    req.Phone,
+		Receiver: req.Receiver,
+	}
+	// The stock is deducted and the order created in one global transaction:
+	// either both happen or neither does.
+	err := o.coordinator.Run(ctx, "SubmitOrder",
+		&tcc.StockBranch{Detail: o.detailService, OrderID: order.ID, Sku: order.Sku, Count: order.Count},
+		&tcc.OrderBranch{Store: o.store, Order: order},
+	)
+	switch {
+	case errors.Is(err, tcc.ErrUnfinished):
+		logger.Errorf("Order provider: order %s: %v", order.ID, err)
+	case errors.Is(err, store.ErrOrderExists):
+		return nil, triple_protocol.NewError(triple_protocol.CodeAlreadyExists, err)
+	case err != nil:
+		return nil, triple_protocol.NewError(triple_protocol.CodeOf(err), err)
+	}
+	if order, err = o.store.Get(order.ID); err != nil {
+		return nil, orderError(err)
+	}
+	return o.toAPIOrder(order), nil
+}
+
+func (o *OrderProvider) GetOrder(ctx context.Context, req *api.GetOrderReq) (*api.OrderResp, error) {
+	order, err := o.orderOf(req.OrderId, req.UserName)
+	if err != nil {
+		return nil, err
+	}
+	return o.toAPIOrder(order), nil
+}
+
+// requireUser fails when the user name is left out. Every order belongs to a
+// user: none is created that its user could not read or cancel afterwards.
+func requireUser(username string) error {
+	if username == "" {
+		return triple_protocol.NewError(triple_protocol.CodeInvalidArgument, errors.New("user name is required"))
+	}
+	return nil
+}
+
+// orderOf returns the order only to the user it belongs to. A user is
+// required, so that no caller reads or changes the orders of others by leaving
+// it out.
+func (o *OrderProvider) orderOf(orderID, username string) (store.Order, error) {
+	if err := requireUser(username); err != nil {
+		return store.Order{}, err
+	}
+	order, err := o.store.Get(orderID)
+	if err != nil {
+		return store.Order{}, orderError(err)
+	}
+	if order.UserName != username {
+		return store.Order{}, triple_protocol.NewError(triple_protocol.CodePermissionDenied,
+			fmt.Errorf("order %s is not an order of %s", order.ID, username))
+	}
+	return order, nil
+}
+
+func (o *OrderProvider) ListOrders(ctx context.Context, req *api.ListOrdersReq) (*api.ListOrdersResp, error) {
+	if err := requireUser(req.UserName); err != nil {
+		return nil, err
+	}
+	orders, err := o.store.List(req.UserName)
+	if err != nil {
+		return nil, orderError(err)
+	}
+	resp := &api.ListOrdersResp{}
+	for _, order := range orders {
+		resp.Orders = append(resp.Orders, o.toAPIOrder(order))
+	}
+	return resp, nil
+}
+
+// CancelOrder puts the stock of the order back before cancelling it, so that a
+// failure leaves the order as it was and the cancellation can be retried.
+func (o *OrderProvider) CancelOrder(ctx context.Context, req *api.CancelOrderReq) (*api.OrderResp, error) {
+	order, err := o.orderOf(req.OrderId, req.UserName)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != store.Cancelled {
+		if !store.CanMove(order.Status, store.Cancelled) {
+			return nil, orderError(store.ErrInvalidTransition)
+		}
+		if _, err := o.detailService.RestoreStock(ctx, &detailAPI.RestoreStockReq{
+			Sku:     order.Sku,
+			Count:   order.Count,
+			OrderId: order.ID,
+		}); err != nil {
+			return nil, triple_protocol.NewError(triple_protocol.CodeOf(err), err)
+		}
+	}
+	if order, err = o.store.Move(order.ID, store.Cancelled); err != nil {
+		return nil, orderError(err)
+	}
+	return o.toAPIOrder(order), nil
+}
+
+func (o *OrderProvider) UpdateOrderStatus(ctx context.Context, req *api.UpdateOrderStatusReq) (*api.OrderResp, error) {
+	if _, err := o.orderOf(req.OrderId, req.UserName); err != nil {
+		return nil, err
+	}
+	var to store.Status
+	switch req.Status {
+	case api.OrderStatus_PAID:
+		to = store.Paid
+	case api.OrderStatus_SHIPPED:
+		to = store.Shipped
+	case api.OrderStatus_CANCELLED:
+		return o.CancelOrder(ctx, &api.CancelOrderReq{OrderId: req.OrderId, UserName: req.UserName})
+	default:
+		return nil, orderError(store.ErrInvalidTransition)
+	}
+	order, err := o.store.Move(req.OrderId, to)
+	if err != nil {
+		return nil, orderError(err)
+	}
+	return o.toAPIOrder(order), nil
+}
+
+var apiStatus = map[store.Status]api.OrderStatus{
+	store.Pending:   api.OrderStatus_PENDING,
+	store.Created:   api.OrderStatus_CREATED,
+	store.Paid:      api.OrderStatus_PAID,
+	store.Shipped:   api.OrderStatus_SHIPPED,
+	store.Cancelled: api.OrderStatus_CANCELLED,
+}
+
+func (o *OrderProvider) toAPIOrder(order store.Order) *api.OrderResp {
+	return &api.OrderResp{
+		Env:        o.release,
+		Address:    order.Address,
+		Phone:      order.Phone,
+		Receiver:   order.Receiver,
+		OrderId:    order.ID,
+		Status:     apiStatus[order.Status],
+		Sku:        order.Sku,
+		Count:      order.Count,
+		UserName:   order.UserName,
+		CreateTime: order.CreatedAt.Unix(),
+	}
+}
+
+// orderError tells the caller why an order could not be read or changed.
+func orderError(err error) error {
+	switch {
+	case errors.Is(err, store.ErrOrderNotFound):
+		return triple_protocol.NewError(triple_protocol.CodeNotFound, err)
+	case errors.Is(err, store.ErrInvalidTransition):
+		return triple_protocol.NewError(triple_protocol.CodeFailedPrecondition, err)
+	}
+	return triple_protocol.NewError(triple_protocol.CodeInternal, err)
+}
+
+// Register registers the release of the order service on the server, calling
+// the detail service with the client. The orders are submitted by the TCC
+// coordinator named by ORDER_TCC_COORDINATOR, the local one by default.
+func Register(srv *server.Server, cli *client.Client, release string) error {
+	// the stock is deducted and restored within the 5 seconds the frontend
+	// gives an order. The client does not try again by itself: a deduction is
+	// only idempotent per order, the TCC coordinator decides what to do with a
+	// branch that failed.
+	detailService, err := detailAPI.NewDetail(cli,
+		client.WithRequestTimeout(2*time.Second),
+		client.WithRetries(0),
+	)
+	if err != nil {
+		return err
+	}
+	orders, err := openStore()
+	if err != nil {
+		return err
+	}
+	coordinator, err := tcc.New(os.Getenv("ORDER_TCC_COORDINATOR"))
+	if err != nil {
+		return err
+	}
+	return api.RegisterOrderHandler(srv, &OrderProvider{
+		release:       release,
+		detailService: detailService,
+		store:         orders,
+		coordinator:   coordinator,
+	},
+		// the tag rules route the gray release by this parameter
+		server.WithParam(routing.ReleaseKey, release),
+	)
+}
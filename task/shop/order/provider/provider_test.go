@@ -0,0 +1,112 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"dubbo.apache.org/dubbo-go/v3/client"
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+
+	detailAPI "github.com/apache/dubbo-go-samples/task/shop/detail/api"
+	"github.com/apache/dubbo-go-samples/task/shop/order/api"
+	"github.com/apache/dubbo-go-samples/task/shop/order/store"
+	"github.com/apache/dubbo-go-samples/task/shop/order/tcc"
+)
+
+// detail counts the stock restored, the other calls succeed.
+type detail struct {
+	restored int32
+}
+
+func (d *detail) GetItem(ctx context.Context, req *detailAPI.GetItemReq, opts ...client.CallOption) (*detailAPI.Item, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (d *detail) DeductStock(ctx context.Context, req *detailAPI.DeductStockReq, opts ...client.CallOption) (*detailAPI.DeductStockResp, error) {
+	return &detailAPI.DeductStockResp{Success: true}, nil
+}
+
+func (d *detail) RestoreStock(ctx context.Context, req *detailAPI.RestoreStockReq, opts ...client.CallOption) (*detailAPI.RestoreStockResp, error) {
+	d.restored += req.Count
+	return &detailAPI.RestoreStockResp{Success: true}, nil
+}
+
+func (d *detail) ConfirmDeductStock(ctx context.Context, req *detailAPI.ConfirmDeductStockReq, opts ...client.CallOption) (*detailAPI.ConfirmDeductStockResp, error) {
+	return &detailAPI.ConfirmDeductStockResp{Success: true}, nil
+}
+
+func TestOrderOfAnotherUser(t *testing.T) {
+	orders, err := store.Open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &detail{}
+	o := &OrderProvider{release: "v1", detailService: d, store: orders, coordinator: tcc.NewLocal()}
+	ctx := context.Background()
+	order, err := o.SubmitOrder(ctx, &api.OrderReq{Sku: 1, Count: 2, Address: "a", Phone: "p", Receiver: "r", UserName: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, user := range []string{"", "bob"} {
+		want := triple_protocol.CodePermissionDenied
+		if user == "" {
+			want = triple_protocol.CodeInvalidArgument
+		}
+		calls := map[string]func() error{
+			"GetOrder": func() error {
+				_, err := o.GetOrder(ctx, &api.GetOrderReq{OrderId: order.OrderId, UserName: user})
+				return err
+			},
+			"CancelOrder": func() error {
+				_, err := o.CancelOrder(ctx, &api.CancelOrderReq{OrderId: order.OrderId, UserName: user})
+				return err
+			},
+			"UpdateOrderStatus": func() error {
+				_, err := o.UpdateOrderStatus(ctx, &api.UpdateOrderStatusReq{OrderId: order.OrderId, Status: api.OrderStatus_CANCELLED, UserName: user})
+				return err
+			},
+		}
+		for name, call := range calls {
+			if code := triple_protocol.CodeOf(call()); code != want {
+				t.Errorf("%s by %q: code %v, want %v", name, user, code, want)
+			}
+		}
+	}
+	if d.restored != 0 {
+		t.Errorf("%d restored by other users, want none", d.restored)
+	}
+
+	if _, err := o.SubmitOrder(ctx, &api.OrderReq{Sku: 1, Count: 1, Address: "a", Phone: "p", Receiver: "r"}); triple_protocol.CodeOf(err) != triple_protocol.CodeInvalidArgument {
+		t.Errorf("SubmitOrder without a user: %v, want InvalidArgument", err)
+	}
+	if _, err := o.ListOrders(ctx, &api.ListOrdersReq{}); triple_protocol.CodeOf(err) != triple_protocol.CodeInvalidArgument {
+		t.Errorf("ListOrders without a user: %v, want InvalidArgument", err)
+	}
+
+	got, err := o.UpdateOrderStatus(ctx, &api.UpdateOrderStatusReq{OrderId: order.OrderId, Status: api.OrderStatus_CANCELLED, UserName: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != api.OrderStatus_CANCELLED || d.restored != 2 {
+		t.Errorf("cancelled by alice: status %v, %d restored, want CANCELLED and 2", got.Status, d.restored)
+	}
+}
@@ -18,7 +18,6 @@
 package main
 
 import (
-	"context"
 	"dubbo.apache.org/dubbo-go/v3"
 	"dubbo.apache.org/dubbo-go/v3/protocol"
 	"dubbo.apache.org/dubbo-go/v3/registry"
@@ -26,28 +25,9 @@ import (
 
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
 
-	detailAPI "github.com/apache/dubbo-go-samples/task/shop/detail/api"
-	"github.com/apache/dubbo-go-samples/task/shop/order/api"
+	"github.com/apache/dubbo-go-samples/task/shop/order/server_v2"
 )
 
-// OrderProvider is the provider of order service
-type OrderProvider struct {
-	detailService detailAPI.Detail
-}
-
-func (o *OrderProvider) SubmitOrder(ctx context.Context, req *api.OrderReq) (*api.OrderResp, error) {
-	o.detailService.DeductStock(context.Background(), &detailAPI.DeductStockReq{
-		Sku:   req.Sku,
-		Count: req.Count,
-	})
-	return &api.OrderResp{
-		Env:      "v2",
-		Address:  req.Address,
-		Phone:    req.Phone,
-		Receiver: req.Receiver,
-	}, nil
-}
-
 func main() {
 	ins, err := dubbo.NewInstance(
 		dubbo.WithName("shop-order"),
@@ -68,15 +48,11 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	detailService, err := detailAPI.NewDetail(cli)
-	if err != nil {
-		panic(err)
-	}
 	srv, err := ins.NewServer()
 	if err != nil {
 		panic(err)
 	}
-	if err = api.RegisterOrderHandler(srv, &OrderProvider{detailService: detailService}); err != nil {
+	if err = server_v2.Register(srv, cli); err != nil {
 		panic(err)
 	}
 	if err = srv.Serve(); err != nil {
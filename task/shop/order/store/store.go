@@ -0,0 +1,244 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package store keeps the orders of the shop. The orders are kept in a file
+// shared by the order servers of every version, so that an order submitted to
+// one version can be looked up and cancelled on the other.
+package store
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/apache/dubbo-go-samples/task/shop/internal/filestore"
+)
+
+var (
+	ErrOrderNotFound     = errors.New("order not found")
+	ErrOrderExists       = errors.New("order exists")
+	ErrInvalidTransition = errors.New("invalid order status change")
+)
+
+// Status is where an order is in its life.
+type Status string
+
+const (
+	Pending   Status = "pending" // tried by the order transaction
+	Created   Status = "created"
+	Paid      Status = "paid"
+	Shipped   Status = "shipped"
+	Cancelled Status = "cancelled"
+)
+
+// next holds the statuses an order can move to from each status. An order
+// can be cancelled until it is shipped.
+var next = map[Status][]Status{
+	Pending: {Created, Cancelled},
+	Created: {Paid, Cancelled},
+	Paid:    {Shipped, Cancelled},
+}
+
+// Order is a stored order.
+type Order struct {
+	ID        string    `json:"id"`
+	UserName  string    `json:"user_name"`
+	Sku       int64     `json:"sku"`
+	Count     int32     `json:"count"`
+	Address   string    `json:"address"`
+	Phone     string    `json:"phone"`
+	Receiver  string    `json:"receiver"`
+	Status    Status    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewID returns a new order ID: the time of the order and random bytes, so
+// that the IDs sort by time and two servers never pick the same one.
+func NewID() string {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return time.Now().UTC().Format("20060102150405") + "-" + hex.EncodeToString(b)
+}
+
+// Store keeps the orders in the file at path, in memory when the path is
+// empty.
+type Store struct {
+	mu   sync.Mutex
+	path string
+	mem  map[string]*Order
+	now  func() time.Time
+}
+
+// Open opens the orders in the file at path, which does not have to exist yet.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, now: time.Now}
+	if path == "" {
+		s.mem = make(map[string]*Order)
+		return s, nil
+	}
+	// Fail early on a file that cannot be read.
+	if _, err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Create stores a new order, created unless its status says pending.
+func (s *Store) Create(o Order) (Order, error) {
+	err := s.update(func(orders map[string]*Order) error {
+		if _, ok := orders[o.ID]; ok {
+			return fmt.Errorf("%w: %s", ErrOrderExists, o.ID)
+		}
+		if o.Status != Pending {
+			o.Status = Created
+		}
+		o.CreatedAt = s.now()
+		o.UpdatedAt = o.CreatedAt
+		orders[o.ID] = &o
+		return nil
+	})
+	return o, err
+}
+
+// Get returns the order with the ID.
+func (s *Store) Get(id string) (Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	orders, err := s.load()
+	if err != nil {
+		return Order{}, err
+	}
+	o, ok := orders[id]
+	if !ok {
+		return Order{}, fmt.Errorf("%w: %s", ErrOrderNotFound, id)
+	}
+	return *o, nil
+}
+
+// List returns the orders of the user, the latest first.
+func (s *Store) List(userName string) ([]Order, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	orders, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	var list []Order
+	for _, o := range orders {
+		if o.UserName == userName {
+			list = append(list, *o)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.After(list[j].CreatedAt)
+		}
+		return list[i].ID > list[j].ID
+	})
+	return list, nil
+}
+
+// Move changes the status of the order. Moving an order to the status it has
+// already is a no-op, so that a retried call does not fail.
+func (s *Store) Move(id string, to Status) (Order, error) {
+	var moved Order
+	err := s.update(func(orders map[string]*Order) error {
+		o, ok := orders[id]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrOrderNotFound, id)
+		}
+		if o.Status != to {
+			if !CanMove(o.Status, to) {
+				return fmt.Errorf("%w: %s order cannot be %s", ErrInvalidTransition, o.Status, to)
+			}
+			o.Status = to
+			o.UpdatedAt = s.now()
+		}
+		moved = *o
+		return nil
+	})
+	return moved, err
+}
+
+// CanMove tells whether an order can move from a status to another.
+func CanMove(from, to Status) bool {
+	for _, st := range next[from] {
+		if st == to {
+			return true
+		}
+	}
+	return false
+}
+
+// update changes the orders with fn, holding the lock of the orders file so
+// that no other server changes it meanwhile.
+func (s *Store) update(fn func(orders map[string]*Order) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.path != "" {
+		unlock, err := filestore.Lock(s.path)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+	orders, err := s.load()
+	if err != nil {
+		return err
+	}
+	if err := fn(orders); err != nil {
+		return err
+	}
+	return s.save(orders)
+}
+
+// load reads the orders. It is called with mu held.
+func (s *Store) load() (map[string]*Order, error) {
+	if s.path == "" {
+		return s.mem, nil
+	}
+	var list []*Order
+	if err := filestore.ReadJSON(s.path, &list); err != nil {
+		return nil, err
+	}
+	orders := make(map[string]*Order, len(list))
+	for _, o := range list {
+		orders[o.ID] = o
+	}
+	return orders, nil
+}
+
+// save writes the orders, sorted by ID, through a temporary file so that a
+// reader never sees half of them.
+func (s *Store) save(orders map[string]*Order) error {
+	if s.path == "" {
+		return nil
+	}
+	list := make([]*Order, 0, len(orders))
+	for _, o := range orders {
+		list = append(list, o)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return filestore.WriteJSON(s.path, list)
+}
@@ -0,0 +1,127 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMove(t *testing.T) {
+	tests := []struct {
+		name  string
+		moves []Status
+		want  error
+	}{
+		{"pay and ship", []Status{Paid, Shipped}, nil},
+		{"cancel created", []Status{Cancelled}, nil},
+		{"cancel paid", []Status{Paid, Cancelled}, nil},
+		{"cancel twice", []Status{Cancelled, Cancelled}, nil},
+		{"cancel shipped", []Status{Paid, Shipped, Cancelled}, ErrInvalidTransition},
+		{"ship unpaid", []Status{Shipped}, ErrInvalidTransition},
+		{"pay cancelled", []Status{Cancelled, Paid}, ErrInvalidTransition},
+		{"back to created", []Status{Paid, Created}, ErrInvalidTransition},
+	}
+	pending := []struct {
+		name  string
+		moves []Status
+		want  error
+	}{
+		{"confirm", []Status{Created, Paid}, nil},
+		{"cancel", []Status{Cancelled}, nil},
+		{"pay unconfirmed", []Status{Paid}, ErrInvalidTransition},
+	}
+	s, err := Open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		o, err := s.Create(Order{ID: NewID(), UserName: "alice"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, to := range tt.moves {
+			if o, err = s.Move(o.ID, to); err != nil {
+				break
+			}
+		}
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	for _, tt := range pending {
+		o, err := s.Create(Order{ID: NewID(), UserName: "alice", Status: Pending})
+		if err != nil || o.Status != Pending {
+			t.Fatalf("Create = %+v, %v", o, err)
+		}
+		for _, to := range tt.moves {
+			if o, err = s.Move(o.ID, to); err != nil {
+				break
+			}
+		}
+		if !errors.Is(err, tt.want) {
+			t.Errorf("pending, %s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if _, err := s.Move("nope", Paid); !errors.Is(err, ErrOrderNotFound) {
+		t.Errorf("unknown order: got %v", err)
+	}
+}
+
+func TestSharedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders.json")
+	v1, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v2, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	v1.now = func() time.Time { now = now.Add(time.Second); return now }
+	v2.now = v1.now
+
+	first, err := v1.Create(Order{ID: "a", UserName: "alice", Sku: 1, Count: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v2.Create(Order{ID: "b", UserName: "alice", Sku: 3, Count: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v2.Create(Order{ID: "c", UserName: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v2.Create(Order{ID: "a"}); !errors.Is(err, ErrOrderExists) {
+		t.Errorf("same ID: got %v", err)
+	}
+
+	// The order submitted to v1 is cancelled on v2.
+	if o, err := v2.Move("a", Cancelled); err != nil || o.Sku != 1 || o.Count != 2 {
+		t.Errorf("Move = %+v, %v", o, err)
+	}
+	if o, err := v1.Get("a"); err != nil || o.Status != Cancelled || !o.CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("Get = %+v, %v", o, err)
+	}
+	list, err := v1.List("alice")
+	if err != nil || len(list) != 2 || list[0].ID != "b" || list[1].ID != "a" {
+		t.Errorf("List = %+v, %v", list, err)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tcc
+
+import (
+	"context"
+	"errors"
+
+	detailAPI "github.com/apache/dubbo-go-samples/task/shop/detail/api"
+	"github.com/apache/dubbo-go-samples/task/shop/order/store"
+)
+
+// StockBranch deducts the stock of an order from the detail service. The
+// detail service keys the deduction by the order ID, so that a try repeated
+// or cancelled before it arrives does not take the stock twice.
+type StockBranch struct {
+	Detail  detailAPI.Detail
+	OrderID string
+	Sku     int64
+	Count   int32
+}
+
+func (b *StockBranch) Name() string { return "DeductStock" }
+
+func (b *StockBranch) Try(ctx context.Context) error {
+	_, err := b.Detail.DeductStock(ctx, &detailAPI.DeductStockReq{Sku: b.Sku, Count: b.Count, OrderId: b.OrderID})
+	return err
+}
+
+func (b *StockBranch) Confirm(ctx context.Context) error {
+	_, err := b.Detail.ConfirmDeductStock(ctx, &detailAPI.ConfirmDeductStockReq{OrderId: b.OrderID})
+	return err
+}
+
+func (b *StockBranch) Cancel(ctx context.Context) error {
+	_, err := b.Detail.RestoreStock(ctx, &detailAPI.RestoreStockReq{Sku: b.Sku, Count: b.Count, OrderId: b.OrderID})
+	return err
+}
+
+// OrderBranch creates an order: pending when tried, created when confirmed
+// and cancelled when cancelled.
+type OrderBranch struct {
+	Store *store.Store
+	Order store.Order
+}
+
+func (b *OrderBranch) Name() string { return "CreateOrder" }
+
+func (b *OrderBranch) Try(ctx context.Context) error {
+	o := b.Order
+	o.Status = store.Pending
+	_, err := b.Store.Create(o)
+	return err
+}
+
+func (b *OrderBranch) Confirm(ctx context.Context) error {
+	_, err := b.Store.Move(b.Order.ID, store.Created)
+	return err
+}
+
+func (b *OrderBranch) Cancel(ctx context.Context) error {
+	_, err := b.Store.Move(b.Order.ID, store.Cancelled)
+	if errors.Is(err, store.ErrOrderNotFound) {
+		return nil // never tried
+	}
+	return err
+}
@@ -0,0 +1,198 @@
+//go:build seata
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package seata runs the global transactions of the orders on a Seata server,
+// as the seata-go sample of transcation/seata-go does. Linking it in, with
+// the build tag seata, makes the coordinator "seata" known to tcc.New; its
+// client connects to the Seata server at 127.0.0.1:8091 as soon as the
+// process starts.
+package seata
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/dubbogo/gost/log/logger"
+
+	_ "github.com/seata/seata-go/pkg/imports"
+	seataTCC "github.com/seata/seata-go/pkg/rm/tcc"
+	"github.com/seata/seata-go/pkg/tm"
+
+	"github.com/apache/dubbo-go-samples/task/shop/order/tcc"
+)
+
+func init() {
+	tcc.Register("seata", func() (tcc.Coordinator, error) {
+		return New(), nil
+	})
+}
+
+// Coordinator begins the global transactions on the Seata server and
+// registers each branch with it; the server then confirms or cancels the
+// branches, calling them back in this process. This release of seata-go does
+// not hand the branches their parameters back, so they are held in memory
+// until they are finished: as with tcc.Local, a crash between the phases
+// leaves their resources reserved.
+type Coordinator struct {
+	mu       sync.Mutex
+	services map[string]*seataTCC.TCCServiceProxy // by branch name
+	branches map[string]tcc.Branch                // by XID and branch name
+}
+
+// New returns a coordinator on the Seata server.
+func New() *Coordinator {
+	return &Coordinator{
+		services: make(map[string]*seataTCC.TCCServiceProxy),
+		branches: make(map[string]tcc.Branch),
+	}
+}
+
+// Run implements tcc.Coordinator.
+func (c *Coordinator) Run(ctx context.Context, name string, branches ...tcc.Branch) error {
+	ctx, err := begin(ctx, name)
+	if err != nil {
+		return err
+	}
+	xid := tm.GetXID(ctx)
+	for _, b := range branches {
+		err := c.try(ctx, xid, b)
+		if err == nil {
+			continue
+		}
+		logger.Infof("Global transaction %s: %s failed to try, rolling back: %v", xid, b.Name(), err)
+		// the server cancels every branch registered, the failed one too
+		if err := tm.CommitOrRollback(ctx, false); err != nil {
+			logger.Errorf("Global transaction %s: failed to roll back: %v", xid, err)
+		}
+		return err
+	}
+	if err := tm.CommitOrRollback(ctx, true); err != nil {
+		return fmt.Errorf("%w: %v", tcc.ErrUnfinished, err)
+	}
+	return nil
+}
+
+// begin begins the global transaction on the server. seata-go panics when it
+// cannot.
+func begin(ctx context.Context, name string) (_ context.Context, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("begin global transaction %s: %v", name, r)
+		}
+	}()
+	return tm.Begin(ctx, name), nil
+}
+
+// try registers the branch with the server and tries it.
+func (c *Coordinator) try(ctx context.Context, xid string, b tcc.Branch) error {
+	service, err := c.service(b.Name())
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.branches[key(xid, b.Name())] = b
+	c.mu.Unlock()
+	_, err = service.Prepare(ctx)
+	return err
+}
+
+// service returns the TCC service of the branches with the name, registering
+// it with the server the first time.
+func (c *Coordinator) service(name string) (*seataTCC.TCCServiceProxy, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s, ok := c.services[name]; ok {
+		return s, nil
+	}
+	s, err := seataTCC.NewTCCServiceProxy(&action{c: c, name: name})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.RegisterResource(); err != nil {
+		return nil, err
+	}
+	c.services[name] = s
+	return s, nil
+}
+
+func (c *Coordinator) branch(xid, name string) (tcc.Branch, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, ok := c.branches[key(xid, name)]
+	return b, ok
+}
+
+func (c *Coordinator) forget(xid, name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.branches, key(xid, name))
+}
+
+func key(xid, name string) string {
+	return xid + "/" + name
+}
+
+// action is the TCC service of the branches with a name, as the Seata server
+// calls it.
+type action struct {
+	c    *Coordinator
+	name string
+}
+
+func (a *action) GetActionName() string {
+	return a.name
+}
+
+func (a *action) Prepare(ctx context.Context, _ ...interface{}) (bool, error) {
+	xid := tm.GetXID(ctx)
+	b, ok := a.c.branch(xid, a.name)
+	if !ok {
+		return false, fmt.Errorf("global transaction %s: no branch %s", xid, a.name)
+	}
+	if err := b.Try(tcc.WithXID(ctx, xid)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (a *action) Commit(ctx context.Context, actx *tm.BusinessActionContext) (bool, error) {
+	return a.finish(ctx, actx.Xid, "confirm", tcc.Branch.Confirm)
+}
+
+func (a *action) Rollback(ctx context.Context, actx *tm.BusinessActionContext) (bool, error) {
+	return a.finish(ctx, actx.Xid, "cancel", tcc.Branch.Cancel)
+}
+
+// finish runs the second phase of the branch the server asks for, which it
+// asks again until it succeeds.
+func (a *action) finish(ctx context.Context, xid, phase string, fn func(tcc.Branch, context.Context) error) (bool, error) {
+	b, ok := a.c.branch(xid, a.name)
+	if !ok {
+		// finished already, or held by a process that is gone
+		logger.Warnf("Global transaction %s: no branch %s to %s", xid, a.name, phase)
+		return true, nil
+	}
+	if err := fn(b, tcc.WithXID(ctx, xid)); err != nil {
+		logger.Errorf("Global transaction %s: failed to %s %s: %v", xid, phase, a.name, err)
+		return false, err
+	}
+	a.c.forget(xid, a.name)
+	return true, nil
+}
@@ -0,0 +1,170 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tcc runs the submission of an order as a global transaction of TCC
+// (Try-Confirm-Cancel) branches: the stock deduction and the order creation.
+package tcc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dubbogo/gost/log/logger"
+)
+
+// ErrUnfinished is returned when every branch was tried but some could not be
+// confirmed or cancelled; their resources stay reserved until then.
+var ErrUnfinished = errors.New("global transaction unfinished")
+
+// Branch is a participant of a global transaction. Try reserves its resources,
+// Confirm makes the reservation final and Cancel releases it. Confirm and
+// Cancel may be called more than once, and Cancel may be called for a branch
+// whose Try failed or did not even run.
+type Branch interface {
+	Name() string
+	Try(ctx context.Context) error
+	Confirm(ctx context.Context) error
+	Cancel(ctx context.Context) error
+}
+
+// Coordinator runs global transactions, the role of the Seata transaction
+// coordinator. The coordinator of package tcc/seata registers each branch
+// with a Seata server, which confirms or cancels them; the Local one drives
+// the branches itself.
+type Coordinator interface {
+	// Run tries the branches in order. When every try succeeds it confirms
+	// them all, otherwise it cancels the ones tried and returns the error of
+	// the failed try.
+	Run(ctx context.Context, name string, branches ...Branch) error
+}
+
+// coordinators are the coordinators New knows, by name.
+var coordinators = map[string]func() (Coordinator, error){
+	"local": func() (Coordinator, error) { return NewLocal(), nil },
+}
+
+// Register makes the coordinator returned by newCoordinator known to New by
+// name. A coordinator that is only built in on demand, as the Seata one,
+// registers itself when its package is linked in.
+func Register(name string, newCoordinator func() (Coordinator, error)) {
+	coordinators[name] = newCoordinator
+}
+
+// New returns the coordinator with the name, the Local one when the name is
+// empty.
+func New(name string) (Coordinator, error) {
+	if name == "" {
+		name = "local"
+	}
+	newCoordinator, ok := coordinators[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown TCC coordinator %q, is it built in?", name)
+	}
+	return newCoordinator()
+}
+
+type xidKey struct{}
+
+// WithXID returns ctx carrying the ID of the global transaction, for the
+// coordinators to pass it to the branches.
+func WithXID(ctx context.Context, xid string) context.Context {
+	return context.WithValue(ctx, xidKey{}, xid)
+}
+
+// XID returns the ID of the global transaction running the branch.
+func XID(ctx context.Context) string {
+	xid, _ := ctx.Value(xidKey{}).(string)
+	return xid
+}
+
+// Local is a coordinator in the process, standing in for a Seata server to
+// run the transactions without one. It does not survive a crash: a
+// transaction interrupted between its phases keeps its resources reserved.
+type Local struct {
+	// Retries is how many times a branch is confirmed or cancelled before
+	// giving up, Backoff the time between two attempts.
+	Retries int
+	Backoff time.Duration
+}
+
+// NewLocal returns a local coordinator.
+func NewLocal() *Local {
+	return &Local{Retries: 3, Backoff: 200 * time.Millisecond}
+}
+
+// Run implements Coordinator.
+func (l *Local) Run(ctx context.Context, name string, branches ...Branch) error {
+	ctx = WithXID(ctx, newXID(name))
+	for i, b := range branches {
+		if err := b.Try(ctx); err != nil {
+			logger.Infof("Global transaction %s: %s failed to try, cancelling: %v", XID(ctx), b.Name(), err)
+			// The failed branch is cancelled too, for its try may have
+			// reserved part of its resources.
+			tried := branches[:i+1]
+			for j := len(tried) - 1; j >= 0; j-- {
+				l.finish(ctx, "cancel", tried[j], tried[j].Cancel)
+			}
+			return err
+		}
+	}
+	var unfinished error
+	for _, b := range branches {
+		if err := l.finish(ctx, "confirm", b, b.Confirm); err != nil {
+			unfinished = fmt.Errorf("%w: %s not confirmed: %v", ErrUnfinished, b.Name(), err)
+		}
+	}
+	return unfinished
+}
+
+// finish runs the second phase of a branch, retrying it as a transaction
+// coordinator does. The caller giving up on the transaction does not stop
+// it: once the branches are tried, they are confirmed or cancelled all the
+// same.
+func (l *Local) finish(ctx context.Context, phase string, b Branch, fn func(context.Context) error) error {
+	ctx = detached{ctx}
+	var err error
+	for i := 0; i <= l.Retries; i++ {
+		if i > 0 {
+			time.Sleep(l.Backoff)
+		}
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+	}
+	logger.Errorf("Global transaction %s: failed to %s %s: %v", XID(ctx), phase, b.Name(), err)
+	return err
+}
+
+// detached keeps the values of a context, such as the XID and the
+// attachments of the calls, without its deadline and cancellation.
+type detached struct{ context.Context }
+
+func (detached) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detached) Done() <-chan struct{}       { return nil }
+func (detached) Err() error                  { return nil }
+
+func newXID(name string) string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return name + ":" + hex.EncodeToString(b)
+}
@@ -0,0 +1,171 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tcc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"dubbo.apache.org/dubbo-go/v3/client"
+
+	detailAPI "github.com/apache/dubbo-go-samples/task/shop/detail/api"
+	detailStore "github.com/apache/dubbo-go-samples/task/shop/detail/store"
+	"github.com/apache/dubbo-go-samples/task/shop/order/store"
+)
+
+// detail is the detail service on its store, without the network.
+type detail struct {
+	store       *detailStore.Store
+	failConfirm int
+	afterDeduct func()
+}
+
+func (d *detail) GetItem(ctx context.Context, req *detailAPI.GetItemReq, opts ...client.CallOption) (*detailAPI.Item, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (d *detail) DeductStock(ctx context.Context, req *detailAPI.DeductStockReq, opts ...client.CallOption) (*detailAPI.DeductStockResp, error) {
+	left, err := d.store.Deduct(req.OrderId, req.Sku, req.Count)
+	if d.afterDeduct != nil {
+		d.afterDeduct()
+	}
+	return &detailAPI.DeductStockResp{Success: err == nil, Stock: left}, err
+}
+
+func (d *detail) RestoreStock(ctx context.Context, req *detailAPI.RestoreStockReq, opts ...client.CallOption) (*detailAPI.RestoreStockResp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	stock, err := d.store.Restore(req.OrderId, req.Sku, req.Count)
+	return &detailAPI.RestoreStockResp{Success: err == nil, Stock: stock}, err
+}
+
+func (d *detail) ConfirmDeductStock(ctx context.Context, req *detailAPI.ConfirmDeductStockReq, opts ...client.CallOption) (*detailAPI.ConfirmDeductStockResp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if d.failConfirm > 0 {
+		d.failConfirm--
+		return nil, errors.New("unavailable")
+	}
+	return &detailAPI.ConfirmDeductStockResp{Success: true}, d.store.Confirm(req.OrderId)
+}
+
+func setup(t *testing.T) (*detail, *store.Store) {
+	stock, err := detailStore.Open([]byte(`[{"sku": 1, "name": "shirt", "stock": 3}]`), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	orders, err := store.Open("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &detail{store: stock}, orders
+}
+
+func submit(c Coordinator, d *detail, orders *store.Store, id string, count int32) error {
+	return submitContext(context.Background(), c, d, orders, id, count)
+}
+
+func submitContext(ctx context.Context, c Coordinator, d *detail, orders *store.Store, id string, count int32) error {
+	return c.Run(ctx, "SubmitOrder",
+		&StockBranch{Detail: d, OrderID: id, Sku: 1, Count: count},
+		&OrderBranch{Store: orders, Order: store.Order{ID: id, UserName: "alice", Sku: 1, Count: count}},
+	)
+}
+
+func TestCommit(t *testing.T) {
+	d, orders := setup(t)
+	c := &Local{Retries: 2}
+	d.failConfirm = 2 // confirmed on the last retry
+	if err := submit(c, d, orders, "o1", 2); err != nil {
+		t.Fatal(err)
+	}
+	if o, err := orders.Get("o1"); err != nil || o.Status != store.Created {
+		t.Errorf("order = %+v, %v", o, err)
+	}
+	if it, _ := d.store.Get(1); it.Stock != 1 {
+		t.Errorf("stock = %d, want 1", it.Stock)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	d, orders := setup(t)
+	c := &Local{}
+
+	// The stock deduction fails: no order.
+	if err := submit(c, d, orders, "o1", 4); !errors.Is(err, detailStore.ErrInsufficientStock) {
+		t.Errorf("too many: got %v", err)
+	}
+	if _, err := orders.Get("o1"); !errors.Is(err, store.ErrOrderNotFound) {
+		t.Errorf("order of failed deduction: %v", err)
+	}
+
+	// The order creation fails: the stock is restored.
+	if _, err := orders.Create(store.Order{ID: "o2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := submit(c, d, orders, "o2", 2); !errors.Is(err, store.ErrOrderExists) {
+		t.Errorf("order exists: got %v", err)
+	}
+	if it, _ := d.store.Get(1); it.Stock != 3 {
+		t.Errorf("stock = %d, want 3", it.Stock)
+	}
+}
+
+func TestUnfinished(t *testing.T) {
+	d, orders := setup(t)
+	d.failConfirm = 10
+	if err := submit(&Local{Retries: 1}, d, orders, "o1", 1); !errors.Is(err, ErrUnfinished) {
+		t.Errorf("got %v", err)
+	}
+	// The order is confirmed all the same, its stock stays deducted.
+	if o, err := orders.Get("o1"); err != nil || o.Status != store.Created {
+		t.Errorf("order = %+v, %v", o, err)
+	}
+}
+
+func TestCallerGivesUp(t *testing.T) {
+	d, orders := setup(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// The caller gives up once the stock is deducted: the order is created
+	// all the same, and confirmed.
+	d.afterDeduct = cancel
+	if err := submitContext(ctx, &Local{}, d, orders, "o1", 1); err != nil {
+		t.Fatal(err)
+	}
+	if o, err := orders.Get("o1"); err != nil || o.Status != store.Created {
+		t.Errorf("order = %+v, %v", o, err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, name := range []string{"", "local"} {
+		if c, err := New(name); err != nil {
+			t.Errorf("New(%q): %v", name, err)
+		} else if _, ok := c.(*Local); !ok {
+			t.Errorf("New(%q) = %T, want *Local", name, c)
+		}
+	}
+	// the Seata coordinator is only there in the builds tagged seata
+	if _, err := New("seata"); err == nil {
+		t.Error("New(seata) without the coordinator built in: no error")
+	}
+}
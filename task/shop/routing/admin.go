@@ -0,0 +1,215 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"github.com/dubbogo/go-zookeeper/zk"
+	"gopkg.in/yaml.v2"
+)
+
+// TagRuleKey returns the key of the tag rule of the provider application.
+func TagRuleKey(app string) string {
+	return app + constant.TagRouterRuleSuffix
+}
+
+// ConditionRuleKey returns the key of the condition rule of the service, an
+// interface name without version and group.
+func ConditionRuleKey(service string) string {
+	return service + "::" + constant.ConditionRouterRuleSuffix
+}
+
+// routerRule is a Dubbo router rule, as the tag and condition routers read it.
+type routerRule struct {
+	ConfigVersion string     `yaml:"configVersion"`
+	Scope         string     `yaml:"scope"`
+	Key           string     `yaml:"key"`
+	Force         bool       `yaml:"force"`
+	Runtime       bool       `yaml:"runtime"`
+	Enabled       bool       `yaml:"enabled"`
+	Conditions    []string   `yaml:"conditions,omitempty"`
+	Tags          []tagRoute `yaml:"tags,omitempty"`
+}
+
+type tagRoute struct {
+	Name  string       `yaml:"name"`
+	Match []paramMatch `yaml:"match"`
+}
+
+type paramMatch struct {
+	Key   string      `yaml:"key"`
+	Value stringMatch `yaml:"value"`
+}
+
+type stringMatch struct {
+	Exact string `yaml:"exact"`
+}
+
+// TagRule returns the tag rule of the provider application giving each tag
+// to the providers of a release. With force, a call with a tag that no
+// provider has fails instead of going to the providers without tag.
+func TagRule(app string, releases map[string]string, force bool) (string, error) {
+	if app == "" || len(releases) == 0 {
+		return "", fmt.Errorf("%w: a tag rule needs an application and tags", ErrInvalidRule)
+	}
+	rule := routerRule{Scope: "application", Key: app, Force: force}
+	tags := make([]string, 0, len(releases))
+	for tag := range releases {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		rule.Tags = append(rule.Tags, tagRoute{
+			Name:  tag,
+			Match: []paramMatch{{Key: ReleaseKey, Value: stringMatch{Exact: releases[tag]}}},
+		})
+	}
+	return marshalRule(rule)
+}
+
+// ConditionRule returns the condition rule of the service. Each condition is
+// in the Dubbo syntax, "when => then", for example
+// "attachments[username]=alice => shop.release=v2".
+func ConditionRule(service string, conditions []string, force bool) (string, error) {
+	if service == "" || len(conditions) == 0 {
+		return "", fmt.Errorf("%w: a condition rule needs a service and conditions", ErrInvalidRule)
+	}
+	for _, c := range conditions {
+		if !strings.Contains(c, "=>") {
+			return "", fmt.Errorf("%w: condition %q has no =>", ErrInvalidRule, c)
+		}
+	}
+	return marshalRule(routerRule{Scope: "service", Key: service, Force: force, Conditions: conditions})
+}
+
+func marshalRule(rule routerRule) (string, error) {
+	rule.ConfigVersion = "v3.0"
+	rule.Runtime = true
+	rule.Enabled = true
+	data, err := yaml.Marshal(rule)
+	return string(data), err
+}
+
+// Admin publishes the routing rules to the config center, where the shop
+// applies them without a restart.
+type Admin struct {
+	dc config_center.DynamicConfiguration
+}
+
+// NewAdmin manages the rules in the config center.
+func NewAdmin(dc config_center.DynamicConfiguration) *Admin {
+	return &Admin{dc: dc}
+}
+
+// GrayRules returns the gray rules of the frontend, none when there are no
+// rules yet. Any other failure of the config center is returned, so that the
+// rules are never replaced by a change made to none.
+func (a *Admin) GrayRules() (*GrayRules, error) {
+	value, err := a.dc.GetRule(GrayRuleKey, config_center.WithGroup(Group))
+	if isNotFound(err) || (err == nil && strings.TrimSpace(value) == "") {
+		return &GrayRules{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseGrayRules(value)
+}
+
+// isNotFound tells whether the config center has no value for the key: the
+// zookeeper and file config centers fail then, while nacos returns nothing.
+func isNotFound(err error) bool {
+	return errors.Is(err, zk.ErrNoNode) || errors.Is(err, fs.ErrNotExist)
+}
+
+// SetGrayRules replaces the gray rules of the frontend.
+func (a *Admin) SetGrayRules(rules *GrayRules) error {
+	if err := rules.Validate(); err != nil {
+		return err
+	}
+	return a.dc.PublishConfig(GrayRuleKey, Group, rules.String())
+}
+
+// SetGrayRule adds the gray rule of its tag, or replaces it.
+func (a *Admin) SetGrayRule(rule GrayRule) error {
+	rules, err := a.GrayRules()
+	if err != nil {
+		return err
+	}
+	for i, g := range rules.Rules {
+		if g.Tag == rule.Tag {
+			rules.Rules[i] = rule
+			return a.SetGrayRules(rules)
+		}
+	}
+	rules.Rules = append(rules.Rules, rule)
+	return a.SetGrayRules(rules)
+}
+
+// DeleteGrayRule removes the gray rule of the tag.
+func (a *Admin) DeleteGrayRule(tag string) error {
+	rules, err := a.GrayRules()
+	if err != nil {
+		return err
+	}
+	kept := rules.Rules[:0]
+	for _, g := range rules.Rules {
+		if g.Tag != tag {
+			kept = append(kept, g)
+		}
+	}
+	if len(kept) == 0 {
+		return a.DeleteRule(GrayRuleKey)
+	}
+	rules.Rules = kept
+	return a.SetGrayRules(rules)
+}
+
+// SetTagRule publishes the tag rule of the provider application.
+func (a *Admin) SetTagRule(app string, releases map[string]string, force bool) error {
+	rule, err := TagRule(app, releases, force)
+	if err != nil {
+		return err
+	}
+	return a.dc.PublishConfig(TagRuleKey(app), Group, rule)
+}
+
+// SetConditionRule publishes the condition rule of the service.
+func (a *Admin) SetConditionRule(service string, conditions []string, force bool) error {
+	rule, err := ConditionRule(service, conditions, force)
+	if err != nil {
+		return err
+	}
+	return a.dc.PublishConfig(ConditionRuleKey(service), Group, rule)
+}
+
+// Rule returns the rule with the key.
+func (a *Admin) Rule(key string) (string, error) {
+	return a.dc.GetRule(key, config_center.WithGroup(Group))
+}
+
+// DeleteRule removes the rule with the key.
+func (a *Admin) DeleteRule(key string) error {
+	return a.dc.RemoveConfig(key, Group)
+}
@@ -0,0 +1,221 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command cmd manages the routing rules of the shop in the config center:
+//
+//	cmd gray list
+//	cmd gray set -tag gray -users alice,bob -header X-Gray=true -percentage 10
+//	cmd gray delete -tag gray
+//	cmd tag set -app shop-detail -route gray=v2 -force
+//	cmd tag delete -app shop-detail
+//	cmd condition set -service <interface> -rule "attachments[username]=alice => shop.release=v2"
+//	cmd condition delete -service <interface>
+//	cmd show -key shop-detail.tag-router
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"dubbo.apache.org/dubbo-go/v3/config"
+
+	_ "dubbo.apache.org/dubbo-go/v3/imports"
+
+	"github.com/apache/dubbo-go-samples/task/shop/routing"
+)
+
+// list is a flag given several times or separated by commas.
+type list []string
+
+func (l *list) String() string { return strings.Join(*l, ",") }
+
+func (l *list) Set(v string) error {
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			*l = append(*l, s)
+		}
+	}
+	return nil
+}
+
+// pairs turns key=value items into a map.
+func pairs(items []string) (map[string]string, error) {
+	m := make(map[string]string, len(items))
+	for _, item := range items {
+		k, v, ok := strings.Cut(item, "=")
+		if !ok || k == "" || v == "" {
+			return nil, fmt.Errorf("%q is not key=value", item)
+		}
+		m[k] = v
+	}
+	return m, nil
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage: cmd [-zookeeper address] command [flags]
+
+commands:
+  gray list                   list the gray rules of the frontend
+  gray set -tag ...           add or replace the gray rule of a tag
+  gray delete -tag ...        remove the gray rule of a tag
+  tag set -app ... -route ... publish the tag rule of a provider application
+  tag delete -app ...         remove the tag rule of a provider application
+  condition set -service ...  publish the condition rule of a service
+  condition delete -service . remove the condition rule of a service
+  show -key ...               print a rule`)
+	os.Exit(2)
+}
+
+func main() {
+	zookeeper := flag.String("zookeeper", "127.0.0.1:2181", "address of the zookeeper config center")
+	flag.Usage = usage
+	flag.Parse()
+	args := flag.Args()
+	if len(args) == 0 {
+		usage()
+	}
+
+	dc, err := config.NewConfigCenterConfigBuilder().
+		SetProtocol("zookeeper").
+		SetAddress(*zookeeper).
+		Build().
+		GetDynamicConfiguration()
+	if err != nil {
+		fail(err)
+	}
+	admin := routing.NewAdmin(dc)
+
+	cmd, args := args[0], args[1:]
+	if cmd == "show" {
+		err = show(admin, args)
+	} else if len(args) == 0 {
+		usage()
+	} else {
+		switch cmd + " " + args[0] {
+		case "gray list":
+			err = grayList(admin)
+		case "gray set":
+			err = graySet(admin, args[1:])
+		case "gray delete":
+			err = grayDelete(admin, args[1:])
+		case "tag set":
+			err = tagSet(admin, args[1:])
+		case "tag delete":
+			err = tagDelete(admin, args[1:])
+		case "condition set":
+			err = conditionSet(admin, args[1:])
+		case "condition delete":
+			err = conditionDelete(admin, args[1:])
+		default:
+			usage()
+		}
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
+func grayList(admin *routing.Admin) error {
+	rules, err := admin.GrayRules()
+	if err != nil {
+		return err
+	}
+	fmt.Print(rules)
+	return nil
+}
+
+func graySet(admin *routing.Admin, args []string) error {
+	fs := flag.NewFlagSet("gray set", flag.ExitOnError)
+	tag := fs.String("tag", "", "tag of the matching requests")
+	var users, headers list
+	fs.Var(&users, "users", "user names, comma separated")
+	fs.Var(&headers, "header", "header the requests carry, as name=value; repeatable")
+	percentage := fs.Int("percentage", 0, "percentage of the other users, 0 to 100")
+	_ = fs.Parse(args)
+	h, err := pairs(headers)
+	if err != nil {
+		return err
+	}
+	return admin.SetGrayRule(routing.GrayRule{Tag: *tag, Users: users, Headers: h, Percentage: *percentage})
+}
+
+func grayDelete(admin *routing.Admin, args []string) error {
+	fs := flag.NewFlagSet("gray delete", flag.ExitOnError)
+	tag := fs.String("tag", "", "tag of the rule")
+	_ = fs.Parse(args)
+	return admin.DeleteGrayRule(*tag)
+}
+
+func tagSet(admin *routing.Admin, args []string) error {
+	fs := flag.NewFlagSet("tag set", flag.ExitOnError)
+	app := fs.String("app", "", "provider application, such as shop-detail")
+	var routes list
+	fs.Var(&routes, "route", "tag and the release serving it, as gray=v2; repeatable")
+	force := fs.Bool("force", false, "fail the calls with a tag no provider has")
+	_ = fs.Parse(args)
+	releases, err := pairs(routes)
+	if err != nil {
+		return err
+	}
+	return admin.SetTagRule(*app, releases, *force)
+}
+
+func tagDelete(admin *routing.Admin, args []string) error {
+	fs := flag.NewFlagSet("tag delete", flag.ExitOnError)
+	app := fs.String("app", "", "provider application")
+	_ = fs.Parse(args)
+	return admin.DeleteRule(routing.TagRuleKey(*app))
+}
+
+func conditionSet(admin *routing.Admin, args []string) error {
+	fs := flag.NewFlagSet("condition set", flag.ExitOnError)
+	service := fs.String("service", "", "service interface")
+	var rules []string
+	fs.Func("rule", `condition as "when => then"; repeatable`, func(v string) error {
+		rules = append(rules, v)
+		return nil
+	})
+	force := fs.Bool("force", false, "fail the calls no provider matches")
+	_ = fs.Parse(args)
+	return admin.SetConditionRule(*service, rules, *force)
+}
+
+func conditionDelete(admin *routing.Admin, args []string) error {
+	fs := flag.NewFlagSet("condition delete", flag.ExitOnError)
+	service := fs.String("service", "", "service interface")
+	_ = fs.Parse(args)
+	return admin.DeleteRule(routing.ConditionRuleKey(*service))
+}
+
+func show(admin *routing.Admin, args []string) error {
+	fs := flag.NewFlagSet("show", flag.ExitOnError)
+	key := fs.String("key", routing.GrayRuleKey, "key of the rule")
+	_ = fs.Parse(args)
+	rule, err := admin.Rule(*key)
+	if err != nil {
+		return err
+	}
+	fmt.Println(rule)
+	return nil
+}
@@ -0,0 +1,203 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package routing
+
+import (
+	"context"
+	"sync"
+
+	"dubbo.apache.org/dubbo-go/v3/cluster/cluster"
+	"dubbo.apache.org/dubbo-go/v3/cluster/router"
+	"dubbo.apache.org/dubbo-go/v3/common"
+	conf "dubbo.apache.org/dubbo-go/v3/common/config"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/config"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/protocol"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+	"github.com/dubbogo/gost/log/logger"
+	"gopkg.in/yaml.v2"
+
+	// the tag router of Dubbo registers first, to be replaced below
+	_ "dubbo.apache.org/dubbo-go/v3/cluster/router/tag"
+)
+
+func init() {
+	extension.SetRouterFactory(constant.TagRouterFactoryKey, newTagRouterFactory)
+	cluster.SetClusterInterceptor("shop-attachments", func() cluster.Interceptor { return attachments{} })
+}
+
+// attachments puts the attachments of the context of a call, as Attachments
+// gives them, on the call before it is routed. The client of this Dubbo
+// release leaves them out, and the routers never see the tag or the user.
+type attachments struct{}
+
+func (attachments) Invoke(ctx context.Context, next protocol.Invoker, inv protocol.Invocation) protocol.Result {
+	switch atm := ctx.Value(constant.AttachmentKey).(type) {
+	case map[string]string:
+		for k, v := range atm {
+			inv.SetAttachment(k, v)
+		}
+	case map[string]interface{}:
+		for k, v := range atm {
+			inv.SetAttachment(k, v)
+		}
+	}
+	return next.Invoke(ctx, inv)
+}
+
+type tagRouterFactory struct{}
+
+func newTagRouterFactory() router.PriorityRouterFactory {
+	return tagRouterFactory{}
+}
+
+func (tagRouterFactory) NewPriorityRouter() (router.PriorityRouter, error) {
+	return &tagRouter{rules: map[string]*config.RouterConfig{}, watched: map[string]bool{}}, nil
+}
+
+// tagRouter applies the tag rules of the config center, in place of the tag
+// router of this Dubbo release: that one reads the rule of the provider
+// application but looks it up by the application of the consumer, so it never
+// routes a call to another application by its rule. It routes as Dubbo
+// describes tag rules: a call with a tag goes to the providers of the tag,
+// or, without force, to the providers of no tag when the tag has none; a call
+// without tag goes to the providers of no tag. The tags are those of the
+// rule, matched on the parameters of the providers.
+type tagRouter struct {
+	mu      sync.RWMutex
+	rules   map[string]*config.RouterConfig // by provider application
+	watched map[string]bool
+}
+
+func (r *tagRouter) URL() *common.URL { return nil }
+
+func (r *tagRouter) Priority() int64 { return 0 }
+
+// Notify watches the tag rule of the application of the providers.
+func (r *tagRouter) Notify(invokers []protocol.Invoker) {
+	if len(invokers) == 0 {
+		return
+	}
+	app := invokers[0].GetURL().GetParam(constant.ApplicationKey, "")
+	dc := conf.GetEnvInstance().GetDynamicConfiguration()
+	if app == "" || dc == nil {
+		return
+	}
+	r.mu.Lock()
+	watched := r.watched[app]
+	r.watched[app] = true
+	r.mu.Unlock()
+	if watched {
+		return
+	}
+	l := &tagRuleListener{router: r, app: app}
+	dc.AddListener(TagRuleKey(app), l)
+	if value, err := dc.GetRule(TagRuleKey(app)); err == nil {
+		l.Process(&config_center.ConfigChangeEvent{Key: TagRuleKey(app), Value: value, ConfigType: remoting.EventTypeAdd})
+	}
+}
+
+func (r *tagRouter) Route(invokers []protocol.Invoker, url *common.URL, invocation protocol.Invocation) []protocol.Invoker {
+	if len(invokers) == 0 {
+		return invokers
+	}
+	r.mu.RLock()
+	rule := r.rules[invokers[0].GetURL().GetParam(constant.ApplicationKey, "")]
+	r.mu.RUnlock()
+	if rule == nil {
+		return invokers
+	}
+	tag := invocation.GetAttachmentWithDefaultValue(constant.Tagkey, url.GetParam(constant.Tagkey, ""))
+	if tag != "" {
+		for _, t := range rule.Tags {
+			if t.Name != tag {
+				continue
+			}
+			tagged := filter(invokers, func(u *common.URL) bool { return matchesAll(t.Match, u) })
+			if len(tagged) > 0 || (rule.Force != nil && *rule.Force) {
+				return tagged
+			}
+		}
+	}
+	return filter(invokers, func(u *common.URL) bool {
+		for _, t := range rule.Tags {
+			if matchesAll(t.Match, u) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (r *tagRouter) set(app string, rule *config.RouterConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rule == nil {
+		delete(r.rules, app)
+		return
+	}
+	r.rules[app] = rule
+}
+
+// tagRuleListener keeps the tag rule of an application up to date.
+type tagRuleListener struct {
+	router *tagRouter
+	app    string
+}
+
+// Process implements config_center.ConfigurationListener. Rules that fail to
+// parse are ignored, the previous one stays.
+func (l *tagRuleListener) Process(event *config_center.ConfigChangeEvent) {
+	value, _ := event.Value.(string)
+	if event.ConfigType == remoting.EventTypeDel || value == "" {
+		l.router.set(l.app, nil)
+		return
+	}
+	rule := &config.RouterConfig{}
+	if err := yaml.Unmarshal([]byte(value), rule); err != nil {
+		logger.Warnf("Ignoring the new tag rule of %s: %v", l.app, err)
+		return
+	}
+	if rule.Enabled != nil && !*rule.Enabled {
+		rule = nil
+	}
+	l.router.set(l.app, rule)
+}
+
+// matchesAll tells whether the provider has all the parameters; no
+// parameters match no provider.
+func matchesAll(match []*common.ParamMatch, u *common.URL) bool {
+	for _, m := range match {
+		if !m.IsMatch(u) {
+			return false
+		}
+	}
+	return len(match) > 0
+}
+
+func filter(invokers []protocol.Invoker, keep func(u *common.URL) bool) []protocol.Invoker {
+	kept := make([]protocol.Invoker, 0, len(invokers))
+	for _, ivk := range invokers {
+		if keep(ivk.GetURL()) {
+			kept = append(kept, ivk)
+		}
+	}
+	return kept
+}
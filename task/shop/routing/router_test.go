@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package routing
+
+import (
+	"context"
+	"testing"
+
+	"dubbo.apache.org/dubbo-go/v3/common"
+	conf "dubbo.apache.org/dubbo-go/v3/common/config"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+)
+
+func TestTagRouter(t *testing.T) {
+	dc := newMemoryConfig()
+	conf.GetEnvInstance().SetDynamicConfiguration(dc)
+	defer conf.GetEnvInstance().SetDynamicConfiguration(nil)
+
+	provider := func(release string) protocol.Invoker {
+		return protocol.NewBaseInvoker(common.NewURLWithOptions(
+			common.WithParamsValue(constant.ApplicationKey, "shop-detail"),
+			common.WithParamsValue(ReleaseKey, release),
+		))
+	}
+	invokers := []protocol.Invoker{provider("v1"), provider("v2"), provider("v1")}
+	consumer := common.NewURLWithOptions(common.WithParamsValue(constant.ApplicationKey, "shop-frontend"))
+	call := func(tag string) protocol.Invocation {
+		return invocation.NewRPCInvocation("GetItem", nil, map[string]interface{}{constant.Tagkey: tag})
+	}
+	releases := func(invokers []protocol.Invoker) map[string]int {
+		got := map[string]int{}
+		for _, ivk := range invokers {
+			got[ivk.GetURL().GetParam(ReleaseKey, "")]++
+		}
+		return got
+	}
+
+	r, _ := newTagRouterFactory().NewPriorityRouter()
+	r.Notify(invokers)
+	if got := releases(r.Route(invokers, consumer, call("gray"))); len(got) != 2 {
+		t.Errorf("without rule: %v", got)
+	}
+
+	admin := NewAdmin(dc)
+	tests := []struct {
+		name     string
+		releases map[string]string
+		force    bool
+		tag      string
+		want     map[string]int
+	}{
+		{"tagged", map[string]string{"gray": "v2"}, false, "gray", map[string]int{"v2": 1}},
+		{"untagged", map[string]string{"gray": "v2"}, false, "", map[string]int{"v1": 2}},
+		{"other tag", map[string]string{"gray": "v2"}, false, "canary", map[string]int{"v1": 2}},
+		{"tag without providers", map[string]string{"gray": "v3"}, false, "gray", map[string]int{"v1": 2, "v2": 1}},
+		{"forced tag without providers", map[string]string{"gray": "v3"}, true, "gray", map[string]int{}},
+	}
+	for _, tt := range tests {
+		if err := admin.SetTagRule("shop-detail", tt.releases, tt.force); err != nil {
+			t.Fatal(err)
+		}
+		got := releases(r.Route(invokers, consumer, call(tt.tag)))
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for release, n := range tt.want {
+			if got[release] != n {
+				t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+			}
+		}
+	}
+
+	if err := admin.DeleteRule(TagRuleKey("shop-detail")); err != nil {
+		t.Fatal(err)
+	}
+	if got := releases(r.Route(invokers, consumer, call("gray"))); len(got) != 2 {
+		t.Errorf("after deleting the rule: %v", got)
+	}
+}
+
+// recorder is a provider keeping the call it gets.
+type recorder struct {
+	protocol.BaseInvoker
+	got protocol.Invocation
+}
+
+func (r *recorder) Invoke(_ context.Context, inv protocol.Invocation) protocol.Result {
+	r.got = inv
+	return &protocol.RPCResult{}
+}
+
+func TestAttachments(t *testing.T) {
+	next := &recorder{}
+	ctx := context.WithValue(context.Background(), constant.AttachmentKey, Attachments("alice", "gray"))
+	attachments{}.Invoke(ctx, next, invocation.NewRPCInvocation("GetItem", nil, nil))
+	for k, want := range map[string]string{UserAttachment: "alice", constant.Tagkey: "gray"} {
+		if got := next.got.GetAttachmentWithDefaultValue(k, ""); got != want {
+			t.Errorf("attachment %s = %q, want %q", k, got, want)
+		}
+	}
+}
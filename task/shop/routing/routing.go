@@ -0,0 +1,213 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package routing manages the gray release of the shop at runtime, through
+// rules kept in the config center:
+//
+//   - the gray rules of the frontend, which tag the requests of a user by its
+//     name, a header or a percentage of the users;
+//   - the tag rules of Dubbo, which pick the instances serving a tag by the
+//     release they run;
+//   - the condition rules of Dubbo, which route the calls of a service by
+//     their method or attachments, such as the user name.
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+	"sync"
+
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+	"github.com/dubbogo/gost/log/logger"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// Group is the config center group of the rules, the one the Dubbo
+	// routers read.
+	Group = config_center.DefaultGroup
+	// GrayRuleKey is the key of the gray rules of the frontend.
+	GrayRuleKey = "shop-frontend.gray-rule"
+	// ReleaseKey is the parameter telling the release of a provider, v1 or
+	// v2, that tag rules match. Dubbo keeps its own version in "release".
+	ReleaseKey = "shop.release"
+	// UserAttachment is the attachment carrying the user name of a call, for
+	// condition rules to match as attachments[username].
+	UserAttachment = "username"
+)
+
+var ErrInvalidRule = errors.New("invalid routing rule")
+
+// GrayRule tags the requests of the users it matches: the users it names,
+// the requests with all its headers, and the given percentage of the other
+// users, always the same ones.
+type GrayRule struct {
+	Tag        string            `yaml:"tag"`
+	Users      []string          `yaml:"users,omitempty"`
+	Headers    map[string]string `yaml:"headers,omitempty"`
+	Percentage int               `yaml:"percentage,omitempty"`
+}
+
+// GrayRules are the gray rules of the frontend; the first matching one tags a
+// request.
+type GrayRules struct {
+	Rules []GrayRule `yaml:"rules"`
+}
+
+// ParseGrayRules parses and validates the gray rules in YAML.
+func ParseGrayRules(data string) (*GrayRules, error) {
+	r := &GrayRules{}
+	if err := yaml.UnmarshalStrict([]byte(data), r); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+	}
+	return r, r.Validate()
+}
+
+// Validate checks that every rule has a tag and matches something.
+func (r *GrayRules) Validate() error {
+	for i, g := range r.Rules {
+		switch {
+		case g.Tag == "":
+			return fmt.Errorf("%w: rule %d has no tag", ErrInvalidRule, i+1)
+		case g.Percentage < 0 || g.Percentage > 100:
+			return fmt.Errorf("%w: rule %d: percentage %d out of 0-100", ErrInvalidRule, i+1, g.Percentage)
+		case len(g.Users) == 0 && len(g.Headers) == 0 && g.Percentage == 0:
+			return fmt.Errorf("%w: rule %d matches nobody", ErrInvalidRule, i+1)
+		}
+	}
+	return nil
+}
+
+// String returns the rules in YAML.
+func (r *GrayRules) String() string {
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+// Tag returns the tag of the requests of the user with the header, empty when
+// no rule matches.
+func (r *GrayRules) Tag(user string, header http.Header) string {
+	if r == nil {
+		return ""
+	}
+	for _, g := range r.Rules {
+		if g.matches(user, header) {
+			return g.Tag
+		}
+	}
+	return ""
+}
+
+func (g *GrayRule) matches(user string, header http.Header) bool {
+	for _, u := range g.Users {
+		if u == user && user != "" {
+			return true
+		}
+	}
+	if len(g.Headers) > 0 {
+		all := true
+		for k, v := range g.Headers {
+			if header.Get(k) != v {
+				all = false
+				break
+			}
+		}
+		if all {
+			return true
+		}
+	}
+	// The user hashes to the same bucket in every request, so a user stays
+	// on the release it was given.
+	if g.Percentage > 0 && user != "" {
+		h := fnv.New32a()
+		h.Write([]byte(g.Tag + "/" + user))
+		return int(h.Sum32()%100) < g.Percentage
+	}
+	return false
+}
+
+// Watcher keeps the gray rules of the config center up to date.
+type Watcher struct {
+	mu    sync.RWMutex
+	rules *GrayRules
+}
+
+// Watch reads the gray rules and listens to their changes. A nil config
+// center gives no rules.
+func Watch(dc config_center.DynamicConfiguration) *Watcher {
+	w := &Watcher{}
+	if dc == nil {
+		logger.Warnf("No config center, the gray rules are off")
+		return w
+	}
+	dc.AddListener(GrayRuleKey, w, config_center.WithGroup(Group))
+	value, err := dc.GetRule(GrayRuleKey, config_center.WithGroup(Group))
+	if err != nil {
+		logger.Infof("No gray rules yet: %v", err)
+		return w
+	}
+	w.Process(&config_center.ConfigChangeEvent{Key: GrayRuleKey, Value: value, ConfigType: remoting.EventTypeAdd})
+	return w
+}
+
+// Process implements config_center.ConfigurationListener. Rules that fail to
+// parse are ignored, the previous ones stay.
+func (w *Watcher) Process(event *config_center.ConfigChangeEvent) {
+	value, _ := event.Value.(string)
+	if event.ConfigType == remoting.EventTypeDel || strings.TrimSpace(value) == "" {
+		w.set(nil)
+		logger.Infof("Gray rules removed")
+		return
+	}
+	rules, err := ParseGrayRules(value)
+	if err != nil {
+		logger.Warnf("Ignoring the new gray rules: %v", err)
+		return
+	}
+	w.set(rules)
+	logger.Infof("Gray rules updated: %d rules", len(rules.Rules))
+}
+
+func (w *Watcher) set(rules *GrayRules) {
+	w.mu.Lock()
+	w.rules = rules
+	w.mu.Unlock()
+}
+
+// Tag returns the tag of the requests of the user with the header.
+func (w *Watcher) Tag(user string, header http.Header) string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.rules.Tag(user, header)
+}
+
+// Attachments returns the attachments routing a call of the user with the tag.
+func Attachments(user, tag string) map[string]string {
+	atm := map[string]string{UserAttachment: user}
+	if tag != "" {
+		atm[constant.Tagkey] = tag
+	}
+	return atm
+}
@@ -0,0 +1,229 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/config"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+	"github.com/dubbogo/go-zookeeper/zk"
+	"gopkg.in/yaml.v2"
+)
+
+func TestGrayRulesTag(t *testing.T) {
+	rules, err := ParseGrayRules(`
+rules:
+  - tag: gray
+    users: [alice]
+    headers:
+      X-Gray: "true"
+  - tag: canary
+    percentage: 30
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gray := http.Header{"X-Gray": []string{"true"}}
+	tests := []struct {
+		name   string
+		user   string
+		header http.Header
+		want   string
+	}{
+		{"named user", "alice", nil, "gray"},
+		{"header", "", gray, "gray"},
+		{"other header", "", http.Header{"X-Gray": []string{"no"}}, ""},
+		{"anonymous", "", nil, ""},
+	}
+	for _, tt := range tests {
+		if got := rules.Tag(tt.user, tt.header); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// About 30% of the users are canary, each always the same way.
+	canary := 0
+	for i := 0; i < 1000; i++ {
+		user := fmt.Sprintf("user%d", i)
+		tag := rules.Tag(user, nil)
+		if tag != rules.Tag(user, nil) {
+			t.Fatalf("%s changes tag", user)
+		}
+		if tag == "canary" {
+			canary++
+		}
+	}
+	if canary < 250 || canary > 350 {
+		t.Errorf("%d canary users of 1000, want about 300", canary)
+	}
+}
+
+func TestParseGrayRules(t *testing.T) {
+	for name, rule := range map[string]string{
+		"no tag":       "rules: [{users: [alice]}]",
+		"no match":     "rules: [{tag: gray}]",
+		"percentage":   "rules: [{tag: gray, percentage: 101}]",
+		"unknown key":  "rules: [{tag: gray, user: alice}]",
+		"not yaml map": "[",
+	} {
+		if _, err := ParseGrayRules(rule); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("%s: got %v", name, err)
+		}
+	}
+}
+
+func TestTagRule(t *testing.T) {
+	rule, err := TagRule("shop-detail", map[string]string{"gray": "v2"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The rule reads as the tag router of Dubbo reads it.
+	var cfg config.RouterConfig
+	if err := yaml.Unmarshal([]byte(rule), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Key != "shop-detail" || !*cfg.Force || !*cfg.Enabled || len(cfg.Tags) != 1 || cfg.Tags[0].Name != "gray" {
+		t.Fatalf("rule = %s", rule)
+	}
+	v1 := common.NewURLWithOptions(common.WithParamsValue(ReleaseKey, "v1"))
+	v2 := common.NewURLWithOptions(common.WithParamsValue(ReleaseKey, "v2"))
+	if m := cfg.Tags[0].Match[0]; m.IsMatch(v1) || !m.IsMatch(v2) {
+		t.Errorf("match %+v: v1 %v, v2 %v", m, m.IsMatch(v1), m.IsMatch(v2))
+	}
+
+	if _, err := ConditionRule("Detail", []string{"release=v2"}, false); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("condition without =>: got %v", err)
+	}
+}
+
+// memoryConfig is a config center in memory, notifying its listeners.
+type memoryConfig struct {
+	config_center.DynamicConfiguration
+	values    map[string]string
+	listeners map[string]config_center.ConfigurationListener
+	down      error // returned by GetRule when set
+}
+
+func newMemoryConfig() *memoryConfig {
+	return &memoryConfig{values: map[string]string{}, listeners: map[string]config_center.ConfigurationListener{}}
+}
+
+func (m *memoryConfig) AddListener(key string, l config_center.ConfigurationListener, _ ...config_center.Option) {
+	m.listeners[key] = l
+}
+
+func (m *memoryConfig) GetRule(key string, _ ...config_center.Option) (string, error) {
+	if m.down != nil {
+		return "", m.down
+	}
+	v, ok := m.values[key]
+	if !ok {
+		return "", zk.ErrNoNode
+	}
+	return v, nil
+}
+
+func (m *memoryConfig) PublishConfig(key, group, value string) error {
+	m.values[key] = value
+	if l := m.listeners[key]; l != nil {
+		l.Process(&config_center.ConfigChangeEvent{Key: key, Value: value, ConfigType: remoting.EventTypeUpdate})
+	}
+	return nil
+}
+
+func (m *memoryConfig) RemoveConfig(key, group string) error {
+	delete(m.values, key)
+	if l := m.listeners[key]; l != nil {
+		l.Process(&config_center.ConfigChangeEvent{Key: key, ConfigType: remoting.EventTypeDel})
+	}
+	return nil
+}
+
+func TestAdminAndWatcher(t *testing.T) {
+	dc := newMemoryConfig()
+	admin := NewAdmin(dc)
+	w := Watch(dc)
+	if tag := w.Tag("alice", nil); tag != "" {
+		t.Errorf("without rules: %q", tag)
+	}
+
+	if err := admin.SetGrayRule(GrayRule{Tag: "gray", Users: []string{"alice"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := admin.SetGrayRule(GrayRule{Tag: "gray", Users: []string{"bob"}}); err != nil {
+		t.Fatal(err)
+	}
+	if a, b := w.Tag("alice", nil), w.Tag("bob", nil); a != "" || b != "gray" {
+		t.Errorf("after replacing the rule: alice %q, bob %q", a, b)
+	}
+	if err := admin.SetGrayRule(GrayRule{Tag: "gray"}); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("invalid rule: got %v", err)
+	}
+
+	// A broken rule written by hand keeps the previous rules.
+	_ = dc.PublishConfig(GrayRuleKey, Group, "rules: [{tag: ''}]")
+	if tag := w.Tag("bob", nil); tag != "gray" {
+		t.Errorf("after a broken rule: %q", tag)
+	}
+	// It is not edited either, but can be replaced as a whole.
+	if err := admin.DeleteGrayRule("gray"); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("editing a broken rule: got %v", err)
+	}
+	if err := admin.SetGrayRules(&GrayRules{Rules: []GrayRule{{Tag: "gray", Users: []string{"bob"}}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := admin.DeleteGrayRule("gray"); err != nil {
+		t.Fatal(err)
+	}
+	if tag := w.Tag("bob", nil); tag != "" {
+		t.Errorf("after deleting the rule: %q", tag)
+	}
+	if _, ok := dc.values[GrayRuleKey]; ok {
+		t.Error("the rule of no tag is kept")
+	}
+}
+
+func TestAdminConfigCenterDown(t *testing.T) {
+	dc := newMemoryConfig()
+	admin := NewAdmin(dc)
+	if err := admin.SetGrayRule(GrayRule{Tag: "gray", Users: []string{"alice"}}); err != nil {
+		t.Fatal(err)
+	}
+	kept := dc.values[GrayRuleKey]
+
+	dc.down = errors.New("connection lost")
+	if _, err := admin.GrayRules(); !errors.Is(err, dc.down) {
+		t.Errorf("GrayRules: got %v", err)
+	}
+	if err := admin.SetGrayRule(GrayRule{Tag: "canary", Users: []string{"bob"}}); !errors.Is(err, dc.down) {
+		t.Errorf("SetGrayRule: got %v", err)
+	}
+	if err := admin.DeleteGrayRule("canary"); !errors.Is(err, dc.down) {
+		t.Errorf("DeleteGrayRule: got %v", err)
+	}
+	if dc.values[GrayRuleKey] != kept {
+		t.Errorf("the rules are replaced by %q", dc.values[GrayRuleKey])
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// The sandbox runs the whole shop in one process, without ZooKeeper:
+//
+//	go run ./sandbox/cmd -run all          # run the scenarios and exit
+//	go run ./sandbox/cmd -http :8080       # serve the shop
+//
+// It runs from task/shop, where the pages of the frontend are.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/dubbogo/gost/log/logger"
+
+	"github.com/apache/dubbo-go-samples/task/shop/sandbox"
+)
+
+func main() {
+	run := flag.String("run", "", "the scenarios to run, comma separated, or all")
+	addr := flag.String("http", "", "the address to serve the shop on, after the scenarios")
+	pages := flag.String("pages", "frontend/pages", "the directory of the pages of the frontend")
+	dir := flag.String("dir", "", "the directory of the data of the services, a new temporary one by default")
+	list := flag.Bool("list", false, "list the scenarios")
+	flag.Parse()
+
+	if *list {
+		for _, s := range sandbox.Scenarios {
+			fmt.Printf("%-8s %s\n", s.Name, s.Doc)
+		}
+		return
+	}
+	scenarios, err := pick(*run)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if len(scenarios) == 0 && *addr == "" {
+		fmt.Fprintln(os.Stderr, "nothing to do: give -run or -http")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	temp := *dir == ""
+	if temp {
+		if *dir, err = os.MkdirTemp("", "shop-sandbox-"); err != nil {
+			logger.Fatal(err)
+		}
+		defer os.RemoveAll(*dir)
+	}
+	sb, err := sandbox.Start(*dir)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	handler := sb.Handler(*pages)
+
+	failed := 0
+	for _, s := range scenarios {
+		if err := s.Run(sb, handler); err != nil {
+			fmt.Printf("FAIL %s: %v\n", s.Name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("ok   %s: %s\n", s.Name, s.Doc)
+	}
+	if *addr != "" {
+		logger.Infof("Sandbox: the shop is on %s", *addr)
+		if err := http.ListenAndServe(*addr, handler); err != nil {
+			logger.Error(err)
+		}
+	}
+	if failed > 0 {
+		// os.Exit skips the deferred removal.
+		if temp {
+			os.RemoveAll(*dir)
+		}
+		os.Exit(1)
+	}
+}
+
+// pick returns the scenarios named in the list, all of them for "all".
+func pick(list string) ([]sandbox.Scenario, error) {
+	if list == "" {
+		return nil, nil
+	}
+	if list == "all" {
+		return sandbox.Scenarios, nil
+	}
+	var picked []sandbox.Scenario
+	for _, name := range strings.Split(list, ",") {
+		found := false
+		for _, s := range sandbox.Scenarios {
+			if s.Name == strings.TrimSpace(name) {
+				picked = append(picked, s)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no scenario %q, see -list", name)
+		}
+	}
+	return picked, nil
+}
@@ -0,0 +1,135 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sandbox
+
+import (
+	"fmt"
+	"sync"
+
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/parser"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+	"github.com/dubbogo/go-zookeeper/zk"
+	gxset "github.com/dubbogo/gost/container/set"
+)
+
+// ConfigCenter is a config center in memory, for the routers and the gray
+// rules of a sandbox. It has a single group: the routers read their rules
+// without one.
+type ConfigCenter struct {
+	parser parser.ConfigurationParser
+
+	mu        sync.Mutex
+	values    map[string]string
+	listeners map[string]map[config_center.ConfigurationListener]struct{}
+}
+
+// NewConfigCenter returns an empty config center.
+func NewConfigCenter() *ConfigCenter {
+	return &ConfigCenter{
+		parser:    &parser.DefaultConfigurationParser{},
+		values:    map[string]string{},
+		listeners: map[string]map[config_center.ConfigurationListener]struct{}{},
+	}
+}
+
+func (c *ConfigCenter) Parser() parser.ConfigurationParser { return c.parser }
+
+func (c *ConfigCenter) SetParser(p parser.ConfigurationParser) { c.parser = p }
+
+// AddListener adds the listener of the key. The routers add theirs each time
+// the providers change, so a listener is kept once.
+func (c *ConfigCenter) AddListener(key string, l config_center.ConfigurationListener, _ ...config_center.Option) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.listeners[key] == nil {
+		c.listeners[key] = map[config_center.ConfigurationListener]struct{}{}
+	}
+	c.listeners[key][l] = struct{}{}
+}
+
+func (c *ConfigCenter) RemoveListener(key string, l config_center.ConfigurationListener, _ ...config_center.Option) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.listeners[key], l)
+}
+
+// GetRule returns the value of the key, and the error of the ZooKeeper config
+// center when there is none.
+func (c *ConfigCenter) GetRule(key string, _ ...config_center.Option) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.values[key]
+	if !ok {
+		return "", fmt.Errorf("no config %s: %w", key, zk.ErrNoNode)
+	}
+	return v, nil
+}
+
+func (c *ConfigCenter) GetProperties(key string, opts ...config_center.Option) (string, error) {
+	return c.GetRule(key, opts...)
+}
+
+func (c *ConfigCenter) GetInternalProperty(key string, opts ...config_center.Option) (string, error) {
+	return c.GetRule(key, opts...)
+}
+
+// PublishConfig sets the value of the key and notifies its listeners.
+func (c *ConfigCenter) PublishConfig(key, _, value string) error {
+	c.mu.Lock()
+	_, ok := c.values[key]
+	c.values[key] = value
+	c.mu.Unlock()
+	event := remoting.EventTypeUpdate
+	if !ok {
+		event = remoting.EventTypeAdd
+	}
+	c.notify(&config_center.ConfigChangeEvent{Key: key, Value: value, ConfigType: event})
+	return nil
+}
+
+// RemoveConfig removes the key and notifies its listeners.
+func (c *ConfigCenter) RemoveConfig(key, _ string) error {
+	c.mu.Lock()
+	delete(c.values, key)
+	c.mu.Unlock()
+	c.notify(&config_center.ConfigChangeEvent{Key: key, ConfigType: remoting.EventTypeDel})
+	return nil
+}
+
+func (c *ConfigCenter) GetConfigKeysByGroup(string) (*gxset.HashSet, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := gxset.NewSet()
+	for k := range c.values {
+		keys.Add(k)
+	}
+	return keys, nil
+}
+
+func (c *ConfigCenter) notify(event *config_center.ConfigChangeEvent) {
+	c.mu.Lock()
+	listeners := make([]config_center.ConfigurationListener, 0, len(c.listeners[event.Key]))
+	for l := range c.listeners[event.Key] {
+		listeners = append(listeners, l)
+	}
+	c.mu.Unlock()
+	for _, l := range listeners {
+		l.Process(event)
+	}
+}
@@ -0,0 +1,233 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sandbox
+
+import (
+	"strings"
+	"sync"
+
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+// RegistryProtocol is the registry protocol of the in-memory registry.
+const RegistryProtocol = "memory"
+
+func init() {
+	extension.SetRegistry(RegistryProtocol, func(url *common.URL) (registry.Registry, error) {
+		return &memoryRegistry{url: url, table: table}, nil
+	})
+}
+
+// table holds the providers of the process, shared by every instance of the
+// in-memory registry.
+var table = &providerTable{providers: map[string][]*common.URL{}}
+
+// started is told of every server that has started. Registering its instance
+// is the last thing a server does when it starts, after it exported its
+// services and the metadata service.
+var started = make(chan struct{})
+
+type providerTable struct {
+	mu          sync.Mutex
+	providers   map[string][]*common.URL // by service key
+	subscribers []subscriber
+}
+
+type subscriber struct {
+	key      string
+	url      *common.URL
+	listener registry.NotifyListener
+}
+
+// serviceKey identifies the providers a consumer can call: the same
+// interface, group and version.
+func serviceKey(u *common.URL) string {
+	return u.Service() + ":" + u.GetParam(constant.GroupKey, "") + ":" + u.GetParam(constant.VersionKey, "")
+}
+
+func isProvider(u *common.URL) bool {
+	return u.GetParam(constant.SideKey, "") == constant.SideProvider
+}
+
+func isConsumer(u *common.URL) bool {
+	return u.GetParam(constant.SideKey, "") == constant.SideConsumer
+}
+
+func (t *providerTable) add(u *common.URL) {
+	key := serviceKey(u)
+	t.mu.Lock()
+	t.providers[key] = append(t.providers[key], u)
+	listeners := t.listeners(key)
+	t.mu.Unlock()
+	for _, l := range listeners {
+		l.Notify(&registry.ServiceEvent{Action: remoting.EventTypeAdd, Service: u})
+	}
+}
+
+func (t *providerTable) remove(u *common.URL) {
+	key := serviceKey(u)
+	t.mu.Lock()
+	kept := t.providers[key][:0]
+	var removed []*common.URL
+	for _, p := range t.providers[key] {
+		if p.Location == u.Location {
+			removed = append(removed, p)
+		} else {
+			kept = append(kept, p)
+		}
+	}
+	t.providers[key] = kept
+	listeners := t.listeners(key)
+	t.mu.Unlock()
+	for _, p := range removed {
+		for _, l := range listeners {
+			l.Notify(&registry.ServiceEvent{Action: remoting.EventTypeDel, Service: p})
+		}
+	}
+}
+
+// listeners returns the listeners of the service key. It is called with mu
+// held.
+func (t *providerTable) listeners(key string) []registry.NotifyListener {
+	var listeners []registry.NotifyListener
+	for _, s := range t.subscribers {
+		if s.key == key {
+			listeners = append(listeners, s.listener)
+		}
+	}
+	return listeners
+}
+
+// load sends the providers of the consumer URL to the listener.
+func (t *providerTable) load(u *common.URL, listener registry.NotifyListener) {
+	t.mu.Lock()
+	providers := append([]*common.URL(nil), t.providers[serviceKey(u)]...)
+	t.mu.Unlock()
+	for _, p := range providers {
+		listener.Notify(&registry.ServiceEvent{Action: remoting.EventTypeAdd, Service: p})
+	}
+}
+
+// memoryRegistry is an interface-level registry keeping the providers in the
+// process, for the services of a sandbox to find each other without a
+// registry server.
+type memoryRegistry struct {
+	url   *common.URL
+	table *providerTable
+}
+
+func (r *memoryRegistry) GetURL() *common.URL { return r.url }
+
+func (r *memoryRegistry) IsAvailable() bool { return true }
+
+func (r *memoryRegistry) Destroy() {}
+
+// Register keeps the URL of a provider; consumers register too, and are
+// ignored.
+func (r *memoryRegistry) Register(u *common.URL) error {
+	if !isProvider(u) {
+		return nil
+	}
+	// the server registers its instance only when it has exported URLs, as
+	// the application-level registries keep them
+	ms, err := extension.GetLocalMetadataService(constant.DefaultKey)
+	if err != nil {
+		return err
+	}
+	if _, err := ms.ExportURL(u); err != nil {
+		return err
+	}
+	r.table.add(asTriple(u))
+	return nil
+}
+
+// asTriple returns the URL of a provider exported under an alias of triple
+// as a triple one, the protocol its consumers call it with.
+func asTriple(u *common.URL) *common.URL {
+	if !strings.HasPrefix(u.Protocol, tripleAliasPrefix) {
+		return u
+	}
+	u = u.Clone()
+	u.Protocol = triple.TRIPLE
+	return u
+}
+
+// GetServiceDiscovery implements registry.ServiceDiscoveryHolder, for the
+// servers to tell they have started.
+func (r *memoryRegistry) GetServiceDiscovery() registry.ServiceDiscovery {
+	return instances{}
+}
+
+// instances is the application-level side of the registry. It keeps no
+// instance, the consumers look the providers up by interface.
+type instances struct {
+	registry.ServiceDiscovery
+}
+
+// Register tells the sandbox that the server of the instance has started.
+func (instances) Register(registry.ServiceInstance) error {
+	started <- struct{}{}
+	return nil
+}
+
+func (r *memoryRegistry) UnRegister(u *common.URL) error {
+	if isProvider(u) {
+		r.table.remove(u)
+	}
+	return nil
+}
+
+// Subscribe notifies the consumer of the providers of its service, those
+// there already and those to come. The subscriptions of the providers to
+// their configurators are kept nowhere: the sandbox changes no provider.
+func (r *memoryRegistry) Subscribe(u *common.URL, listener registry.NotifyListener) error {
+	if !isConsumer(u) {
+		return nil
+	}
+	key := serviceKey(u)
+	r.table.mu.Lock()
+	r.table.subscribers = append(r.table.subscribers, subscriber{key: key, url: u, listener: listener})
+	r.table.mu.Unlock()
+	r.table.load(u, listener)
+	return nil
+}
+
+func (r *memoryRegistry) UnSubscribe(u *common.URL, listener registry.NotifyListener) error {
+	r.table.mu.Lock()
+	defer r.table.mu.Unlock()
+	kept := r.table.subscribers[:0]
+	for _, s := range r.table.subscribers {
+		if s.listener != listener || s.url.Key() != u.Key() {
+			kept = append(kept, s)
+		}
+	}
+	r.table.subscribers = kept
+	return nil
+}
+
+func (r *memoryRegistry) LoadSubscribeInstances(u *common.URL, listener registry.NotifyListener) error {
+	if isConsumer(u) {
+		r.table.load(u, listener)
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sandbox runs the whole shop in one process: the user service, both
+// releases of the comment, detail and order services and the frontend. The
+// services find each other through a registry in memory, listen on free
+// ports and keep their data in a directory of their own, so that a sandbox
+// needs no ZooKeeper and several of them can run side by side.
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"dubbo.apache.org/dubbo-go/v3"
+	"dubbo.apache.org/dubbo-go/v3/client"
+	"dubbo.apache.org/dubbo-go/v3/common/config"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/protocol"
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+	"dubbo.apache.org/dubbo-go/v3/server"
+	"github.com/dubbogo/gost/log/logger"
+
+	_ "dubbo.apache.org/dubbo-go/v3/imports"
+
+	commentV1 "github.com/apache/dubbo-go-samples/task/shop/comment/server_v1"
+	commentV2 "github.com/apache/dubbo-go-samples/task/shop/comment/server_v2"
+	detailV1 "github.com/apache/dubbo-go-samples/task/shop/detail/server_v1"
+	detailV2 "github.com/apache/dubbo-go-samples/task/shop/detail/server_v2"
+	"github.com/apache/dubbo-go-samples/task/shop/frontend/api"
+	"github.com/apache/dubbo-go-samples/task/shop/frontend/pages"
+	"github.com/apache/dubbo-go-samples/task/shop/frontend/server_v1"
+	orderV1 "github.com/apache/dubbo-go-samples/task/shop/order/server_v1"
+	orderV2 "github.com/apache/dubbo-go-samples/task/shop/order/server_v2"
+	goserver "github.com/apache/dubbo-go-samples/task/shop/user/go-server"
+)
+
+// registryAddress is the address of the registry in memory, which has none;
+// a registry without address is not created.
+const registryAddress = "127.0.0.1:0"
+
+// startTimeout is how long a server has to start.
+const startTimeout = 10 * time.Second
+
+// service is a service of the shop the sandbox runs.
+type service struct {
+	app      string // the application, the tag rules are by application
+	name     string // the name in the logs, with the release
+	register func(srv *server.Server, cli *client.Client) error
+}
+
+var services = []service{
+	{"shop-user", "user",
+		func(srv *server.Server, _ *client.Client) error { return goserver.Register(srv) }},
+	{"shop-comment", "comment v1",
+		func(srv *server.Server, _ *client.Client) error { return commentV1.Register(srv) }},
+	{"shop-comment", "comment v2",
+		func(srv *server.Server, _ *client.Client) error { return commentV2.Register(srv) }},
+	{"shop-detail", "detail v1", detailV1.Register},
+	{"shop-detail", "detail v2", detailV2.Register},
+	{"shop-order", "order v1", orderV1.Register},
+	{"shop-order", "order v2", orderV2.Register},
+}
+
+// Sandbox is a shop running in the process.
+type Sandbox struct {
+	// Config is the config center of the routing rules.
+	Config *ConfigCenter
+	// Shop is the frontend calling the services.
+	Shop api.ShopService
+	// Dir is the directory of the data of the services.
+	Dir string
+	// Ports are the ports of the services, by their name.
+	Ports map[string]int
+}
+
+// Start starts the shop with its data in the directory dir. The services read
+// where their data is from the environment, which Start sets, and the
+// services of dubbo-go share the logger and the configuration of the process:
+// a process runs one sandbox, Start fails when called again.
+func Start(dir string) (*Sandbox, error) {
+	if !atomic.CompareAndSwapInt32(&running, 0, 1) {
+		return nil, errors.New("a sandbox runs in the process already")
+	}
+	for env, file := range map[string]string{
+		"USER_STORE_PATH":    "users.json",
+		"COMMENT_STORE_PATH": "comments.json",
+		"DETAIL_STOCK_PATH":  "stock.json",
+		"ORDER_STORE_PATH":   "orders.json",
+	} {
+		if err := os.Setenv(env, filepath.Join(dir, file)); err != nil {
+			return nil, err
+		}
+	}
+	// the routers and the gray rules read the config center of the process
+	cc := NewConfigCenter()
+	config.GetEnvInstance().SetDynamicConfiguration(cc)
+
+	// Every instance is built before any server starts: an instance sets the
+	// logger and the configuration of the process, which the servers use
+	// while they start.
+	sb := &Sandbox{Config: cc, Dir: dir, Ports: map[string]int{}}
+	servers := make([]*server.Server, len(services))
+	for i, s := range services {
+		port, err := freePort()
+		if err != nil {
+			return nil, err
+		}
+		if servers[i], err = build(s, tripleAlias(i), port); err != nil {
+			return nil, fmt.Errorf("build %s: %w", s.name, err)
+		}
+		sb.Ports[s.name] = port
+	}
+	ins, err := dubbo.NewInstance(dubbo.WithName("shop-frontend"), withRegistry())
+	if err != nil {
+		return nil, err
+	}
+	if sb.Shop, err = server_v1.NewShopServiceProvider(ins); err != nil {
+		return nil, err
+	}
+
+	// The servers start one after the other: starting a server writes the
+	// service map and the metadata of the process without a lock.
+	for i, s := range services {
+		if err := serve(servers[i], sb.Ports[s.name]); err != nil {
+			return nil, fmt.Errorf("start %s: %w", s.name, err)
+		}
+		logger.Infof("Sandbox: %s serves on port %d", s.name, sb.Ports[s.name])
+	}
+	return sb, nil
+}
+
+// Handler returns the pages of the shop, the templates and static files of
+// which are in the directory dir.
+func (sb *Sandbox) Handler(dir string) http.Handler {
+	return pages.InitRouter(sb.Shop, dir)
+}
+
+func withRegistry() dubbo.InstanceOption {
+	return dubbo.WithRegistry(
+		registry.WithRegistry(RegistryProtocol),
+		registry.WithAddress(registryAddress),
+		// the consumers look the providers up by interface
+		registry.WithRegisterInterface(),
+	)
+}
+
+// build creates the server of the service, which exports it with the triple
+// protocol under the name protocol on the port.
+func build(s service, protocolName string, port int) (*server.Server, error) {
+	ins, err := dubbo.NewInstance(
+		dubbo.WithName(s.app),
+		withRegistry(),
+		dubbo.WithProtocol(protocol.WithProtocol(protocolName), protocol.WithPort(port)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	cli, err := ins.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	srv, err := ins.NewServer()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.register(srv, cli); err != nil {
+		return nil, err
+	}
+	return srv, nil
+}
+
+// serve starts the server listening on the port, and returns once it has
+// started or failed to.
+func serve(srv *server.Server, port int) error {
+	failed := make(chan error, 1)
+	go func() {
+		failed <- srv.Serve()
+	}()
+	deadline := time.After(startTimeout)
+	select {
+	case <-started:
+	case err := <-failed:
+		return err
+	case <-deadline:
+		return fmt.Errorf("not started after %v", startTimeout)
+	}
+	for {
+		conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+		if err == nil {
+			return conn.Close()
+		}
+		select {
+		case <-deadline:
+			return fmt.Errorf("not listening after %v: %w", startTimeout, err)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// running tells whether a sandbox was started in the process.
+var running int32
+
+// tripleAlias returns the protocol the i-th server exports its services
+// with: triple under another name. The service map of the process refuses an
+// interface exported twice with one protocol, while the releases of a service
+// export the same interface, and every server the internal services of
+// triple. The registry hands the providers to their consumers as triple ones.
+func tripleAlias(i int) string {
+	return fmt.Sprintf("%s%d", tripleAliasPrefix, i)
+}
+
+const tripleAliasPrefix = triple.TRIPLE + "-sandbox-"
+
+func init() {
+	for i := range services {
+		extension.SetProtocol(tripleAlias(i), triple.GetProtocol)
+	}
+}
+
+// freePort returns a port nothing listens on. Another process may take it
+// before the service listens, which is unlikely enough for a sandbox.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
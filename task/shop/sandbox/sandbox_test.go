@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sandbox
+
+import (
+	"testing"
+)
+
+func TestScenarios(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts the whole shop")
+	}
+	sb, err := Start(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	pages := sb.Handler("../frontend/pages")
+	for _, s := range Scenarios {
+		s := s
+		t.Run(s.Name, func(t *testing.T) {
+			if err := s.Run(sb, pages); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dubbogo/gost/log/logger"
+
+	"github.com/apache/dubbo-go-samples/task/shop/routing"
+)
+
+// the user every user service starts with
+const (
+	demoUser     = "dubbo"
+	demoPassword = "123456"
+)
+
+// Scenario is a script run against the pages of a sandbox, checking a task of
+// the tutorial.
+type Scenario struct {
+	Name string
+	Doc  string
+	Run  func(sb *Sandbox, pages http.Handler) error
+}
+
+// Scenarios are the scenarios of the shop, in the order they run.
+var Scenarios = []Scenario{
+	{"gray", "the gray rules send alice and the X-Gray requests to detail v2, the others to v1", grayScenario},
+	{"timeout", "a login slower than its timeout fails with 504", timeoutScenario},
+	{"retry", "the user info of a slow try is served by a retry", retryScenario},
+	{"session", "the orders are listed for the user of the session cookie only", sessionScenario},
+	{"cancel", "an order is cancelled by its user only, others get 403", cancelScenario},
+}
+
+// get requests the page with the query and headers, and returns the status
+// and the body.
+func get(pages http.Handler, path string, query url.Values, header http.Header) (int, string) {
+	req := httptest.NewRequest(http.MethodGet, path+"?"+query.Encode(), nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	pages.ServeHTTP(rec, req)
+	body, _ := io.ReadAll(rec.Result().Body)
+	return rec.Code, string(body)
+}
+
+// post posts the form to the page with the headers, and returns the status and
+// the body.
+func post(pages http.Handler, path string, form url.Values, header http.Header) (int, string) {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	pages.ServeHTTP(rec, req)
+	body, _ := io.ReadAll(rec.Result().Body)
+	return rec.Code, string(body)
+}
+
+func login(pages http.Handler, username, password string, header http.Header) (int, string) {
+	return get(pages, "/login", url.Values{"username": {username}, "password": {password}}, header)
+}
+
+// session logs the user in, and returns the header sending the session cookie
+// the login set.
+func session(pages http.Handler, username, password string) (http.Header, error) {
+	req := httptest.NewRequest(http.MethodGet, "/login?"+url.Values{"username": {username}, "password": {password}}.Encode(), nil)
+	rec := httptest.NewRecorder()
+	pages.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		return nil, fmt.Errorf("login %s: status %d: %s", username, rec.Code, rec.Body.String())
+	}
+	header := http.Header{}
+	for _, c := range rec.Result().Cookies() {
+		header.Add("Cookie", c.Name+"="+c.Value)
+	}
+	return header, nil
+}
+
+func grayScenario(sb *Sandbox, pages http.Handler) error {
+	err := sb.Shop.Register(context.Background(), "alice", "alice-password", "Alice", "alice@dubbo", "22222222222")
+	if err != nil {
+		return err
+	}
+	admin := routing.NewAdmin(sb.Config)
+	if err := admin.SetTagRule("shop-detail", map[string]string{"gray": "v2"}, true); err != nil {
+		return err
+	}
+	defer admin.DeleteRule(routing.TagRuleKey("shop-detail"))
+	gray := routing.GrayRule{Tag: "gray", Users: []string{"alice"}, Headers: map[string]string{"X-Gray": "true"}}
+	if err := admin.SetGrayRule(gray); err != nil {
+		return err
+	}
+	defer admin.DeleteGrayRule("gray")
+
+	checks := []struct {
+		username, password string
+		header             http.Header
+		release            string
+	}{
+		{"alice", "alice-password", nil, "v2"},
+		{demoUser, demoPassword, http.Header{"X-Gray": {"true"}}, "v2"},
+		{demoUser, demoPassword, nil, "v1"},
+	}
+	// every call goes to the release, not to a random one
+	for i := 0; i < 5; i++ {
+		for _, c := range checks {
+			code, body := login(pages, c.username, c.password, c.header)
+			if code != http.StatusOK {
+				return fmt.Errorf("login %s: status %d: %s", c.username, code, body)
+			}
+			if want := "item from detail " + c.release; !strings.Contains(body, want) {
+				return fmt.Errorf("login %s with %v: no %q in the item", c.username, c.header, want)
+			}
+		}
+	}
+	return nil
+}
+
+func timeoutScenario(_ *Sandbox, pages http.Handler) error {
+	start := time.Now()
+	code, body := get(pages, "/timeoutLogin", url.Values{"username": {demoUser}, "password": {demoPassword}}, nil)
+	if code != http.StatusGatewayTimeout {
+		return fmt.Errorf("timeout login: status %d, want %d: %s", code, http.StatusGatewayTimeout, body)
+	}
+	if !strings.Contains(body, "request timeout") {
+		return fmt.Errorf("timeout login: the page does not tell the timeout")
+	}
+	logger.Infof("Sandbox: the timeout login failed after %v", time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// retryScenario asks for the user info more times than it takes the user
+// service to be slow once: every third call sleeps past the timeout of a try.
+func retryScenario(_ *Sandbox, pages http.Handler) error {
+	header, err := session(pages, demoUser, demoPassword)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < 6; i++ {
+		start := time.Now()
+		code, body := get(pages, "/userinfo", nil, header)
+		if code != http.StatusOK {
+			return fmt.Errorf("user info %d: status %d: %s", i+1, code, body)
+		}
+		if !strings.Contains(body, demoUser) {
+			return fmt.Errorf("user info %d: %s", i+1, body)
+		}
+		logger.Infof("Sandbox: user info %d took %v", i+1, time.Since(start).Round(time.Millisecond))
+	}
+	return nil
+}
+
+func sessionScenario(_ *Sandbox, pages http.Handler) error {
+	forged := http.Header{"Cookie": {"shop_session=" + demoUser}}
+	for _, header := range []http.Header{nil, forged} {
+		if code, body := get(pages, "/orders", nil, header); code != http.StatusUnauthorized {
+			return fmt.Errorf("orders with %v: status %d, want %d: %s", header, code, http.StatusUnauthorized, body)
+		}
+	}
+	header, err := session(pages, demoUser, demoPassword)
+	if err != nil {
+		return err
+	}
+	if code, body := get(pages, "/orders", nil, header); code != http.StatusOK {
+		return fmt.Errorf("orders of the session: status %d: %s", code, body)
+	}
+	return nil
+}
+
+// cancelScenario orders as the demo user and tries to cancel the order without
+// a session and as another user before cancelling it as the demo user.
+func cancelScenario(sb *Sandbox, pages http.Handler) error {
+	err := sb.Shop.Register(context.Background(), "bob", "bob-password", "Bob", "bob@dubbo", "33333333333")
+	if err != nil {
+		return err
+	}
+	owner, err := session(pages, demoUser, demoPassword)
+	if err != nil {
+		return err
+	}
+	other, err := session(pages, "bob", "bob-password")
+	if err != nil {
+		return err
+	}
+	code, body := post(pages, "/order", url.Values{
+		"sku": {"1"}, "count": {"1"}, "address": {"beijing"}, "phone": {"111"}, "receiver": {demoUser},
+	}, owner)
+	if code != http.StatusOK {
+		return fmt.Errorf("order: status %d: %s", code, body)
+	}
+	var order struct{ OrderId string }
+	if err := json.Unmarshal([]byte(body), &order); err != nil {
+		return fmt.Errorf("order: %v: %s", err, body)
+	}
+	cancel := url.Values{"order_id": {order.OrderId}}
+	checks := []struct {
+		who    string
+		header http.Header
+		code   int
+	}{
+		{"no session", nil, http.StatusUnauthorized},
+		{"bob", other, http.StatusForbidden},
+		{demoUser, owner, http.StatusOK},
+	}
+	for _, c := range checks {
+		if code, body := post(pages, "/order/cancel", cancel, c.header); code != c.code {
+			return fmt.Errorf("cancel as %s: status %d, want %d: %s", c.who, code, c.code, body)
+		}
+	}
+	return nil
+}
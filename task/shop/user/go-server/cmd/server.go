@@ -18,10 +18,6 @@
 package main
 
 import (
-	"context"
-	"fmt"
-	"time"
-
 	"dubbo.apache.org/dubbo-go/v3"
 	"dubbo.apache.org/dubbo-go/v3/protocol"
 	"dubbo.apache.org/dubbo-go/v3/registry"
@@ -29,7 +25,7 @@ import (
 
 	_ "dubbo.apache.org/dubbo-go/v3/imports"
 
-	"github.com/apache/dubbo-go-samples/task/shop/user/api"
+	goserver "github.com/apache/dubbo-go-samples/task/shop/user/go-server"
 )
 
 func main() {
@@ -52,59 +48,10 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	if err = api.RegisterUserServiceHandler(srv, &UserProvider{}); err != nil {
+	if err = goserver.Register(srv); err != nil {
 		panic(err)
 	}
 	if err = srv.Serve(); err != nil {
 		logger.Error(err)
 	}
 }
-
-// UserProvider is the provider of user service
-type UserProvider struct {
-	count int
-}
-
-// Register registers a user
-func (u *UserProvider) Register(ctx context.Context, req *api.User) (*api.RegisterResp, error) {
-	return &api.RegisterResp{
-		Success: true,
-	}, nil
-}
-
-// Login gets the user
-func (u *UserProvider) Login(ctx context.Context, req *api.LoginReq) (*api.User, error) {
-	return &api.User{
-		Username: req.Username,
-		Password: req.Password,
-		Phone:    "11111111111",
-		Mail:     "dubbo@dubbo",
-		RealName: "dubbo_test",
-	}, nil
-}
-
-func (u *UserProvider) TimeoutLogin(ctx context.Context, req *api.LoginReq) (*api.User, error) {
-	time.Sleep(3 * time.Second)
-	return &api.User{
-		Username: req.Username,
-		Password: req.Password,
-		Phone:    "11111111111",
-		Mail:     "dubbo@dubbo",
-		RealName: "dubbo_test",
-	}, nil
-}
-
-func (u *UserProvider) GetInfo(ctx context.Context, req *api.GetInfoReq) (*api.User, error) {
-	fmt.Println("Received getInfo request......")
-	u.count++
-	if u.count%3 == 0 {
-		time.Sleep(3 * time.Second)
-	}
-	return &api.User{
-		Username: req.Username,
-		Password: "password",
-		Phone:    "11111111111",
-		Mail:     "dubbo@dubbo",
-		RealName: "dubbo_test",
-	}, nil
-}
@@ -0,0 +1,171 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package goserver is the user service.
+package goserver
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+	"dubbo.apache.org/dubbo-go/v3/server"
+	"github.com/dubbogo/gost/log/logger"
+
+	"github.com/apache/dubbo-go-samples/task/shop/user/api"
+	"github.com/apache/dubbo-go-samples/task/shop/user/go-server/store"
+)
+
+// sessionTTL is how long a session token stays valid.
+const sessionTTL = 24 * time.Hour
+
+// Register registers the user service on the server.
+func Register(srv *server.Server) error {
+	provider, err := newUserProvider()
+	if err != nil {
+		return err
+	}
+	return api.RegisterUserServiceHandler(srv, provider)
+}
+
+// UserProvider is the provider of user service
+type UserProvider struct {
+	calls     int64 // GetInfo calls, first for the atomic access on 32-bit
+	slowEvery int64
+	users     *store.Store
+	tokens    *store.Signer
+}
+
+// newUserProvider keeps the users in the file named by USER_STORE_PATH,
+// users.json by default, and signs the session tokens with USER_TOKEN_SECRET.
+// Without a secret the tokens are signed with a random key and do not outlive
+// the process. The demo user dubbo, password 123456, is there from the start.
+// Every third GetInfo call takes 3 seconds, for the timeout and retry
+// policies to be seen at work; USER_SLOW_GETINFO=n makes it every n-th call,
+// and 0 turns it off.
+func newUserProvider() (*UserProvider, error) {
+	slowEvery := int64(3)
+	if v := os.Getenv("USER_SLOW_GETINFO"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("USER_SLOW_GETINFO: %q is not a number of calls", v)
+		}
+		slowEvery = n
+	}
+	path, ok := os.LookupEnv("USER_STORE_PATH")
+	if !ok {
+		path = "users.json"
+	}
+	users, err := store.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if users.Len() == 0 {
+		if err := users.Register("dubbo", "123456", "dubbo_test", "dubbo@dubbo", "11111111111"); err != nil {
+			return nil, err
+		}
+	}
+
+	key := []byte(os.Getenv("USER_TOKEN_SECRET"))
+	if len(key) == 0 {
+		logger.Warn("USER_TOKEN_SECRET is not set, session tokens are only valid until the server restarts")
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+	}
+	return &UserProvider{slowEvery: slowEvery, users: users, tokens: store.NewSigner(key, sessionTTL)}, nil
+}
+
+// Register registers a user
+func (u *UserProvider) Register(ctx context.Context, req *api.User) (*api.RegisterResp, error) {
+	if err := u.users.Register(req.Username, req.Password, req.RealName, req.Mail, req.Phone); err != nil {
+		return nil, userError(err)
+	}
+	logger.Infof("registered user %s", req.Username)
+	return &api.RegisterResp{
+		Success: true,
+	}, nil
+}
+
+// Login checks the password of the user, and returns the user with a session
+// token.
+func (u *UserProvider) Login(ctx context.Context, req *api.LoginReq) (*api.User, error) {
+	user, err := u.users.Authenticate(req.Username, req.Password)
+	if err != nil {
+		return nil, userError(err)
+	}
+	resp := toAPIUser(user)
+	resp.Token = u.tokens.Sign(user.Username)
+	return resp, nil
+}
+
+func (u *UserProvider) TimeoutLogin(ctx context.Context, req *api.LoginReq) (*api.User, error) {
+	time.Sleep(3 * time.Second)
+	return u.Login(ctx, req)
+}
+
+// GetInfo returns the user of the session token. A username that is not the
+// one of the token is refused.
+func (u *UserProvider) GetInfo(ctx context.Context, req *api.GetInfoReq) (*api.User, error) {
+	if u.slowEvery > 0 && atomic.AddInt64(&u.calls, 1)%u.slowEvery == 0 {
+		time.Sleep(3 * time.Second)
+	}
+	username, err := u.tokens.Verify(req.Token)
+	if err != nil {
+		return nil, userError(err)
+	}
+	if req.Username != "" && req.Username != username {
+		return nil, triple_protocol.NewError(triple_protocol.CodePermissionDenied, errors.New("the token is of another user"))
+	}
+	user, err := u.users.Get(username)
+	if err != nil {
+		return nil, userError(err)
+	}
+	return toAPIUser(user), nil
+}
+
+// toAPIUser leaves out the password hash, no password material is ever sent
+// back.
+func toAPIUser(user store.User) *api.User {
+	return &api.User{
+		Username: user.Username,
+		Phone:    user.Phone,
+		Mail:     user.Mail,
+		RealName: user.RealName,
+	}
+}
+
+func userError(err error) error {
+	switch {
+	case errors.Is(err, store.ErrInvalidUser):
+		return triple_protocol.NewError(triple_protocol.CodeInvalidArgument, err)
+	case errors.Is(err, store.ErrUserExists):
+		return triple_protocol.NewError(triple_protocol.CodeAlreadyExists, err)
+	case errors.Is(err, store.ErrUserNotFound):
+		return triple_protocol.NewError(triple_protocol.CodeNotFound, err)
+	case errors.Is(err, store.ErrBadCredentials), errors.Is(err, store.ErrInvalidToken):
+		return triple_protocol.NewError(triple_protocol.CodeUnauthenticated, err)
+	}
+	return triple_protocol.NewError(triple_protocol.CodeInternal, err)
+}
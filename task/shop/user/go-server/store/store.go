@@ -0,0 +1,152 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package store keeps the users of the shop, with their passwords hashed by
+// bcrypt, and signs their session tokens.
+package store
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/apache/dubbo-go-samples/task/shop/internal/filestore"
+)
+
+var (
+	ErrInvalidUser    = errors.New("username and password are required")
+	ErrUserExists     = errors.New("username is taken")
+	ErrUserNotFound   = errors.New("user not found")
+	ErrBadCredentials = errors.New("wrong username or password")
+)
+
+// User is a stored user. Its password is only kept as a bcrypt hash.
+type User struct {
+	Username     string `json:"username"`
+	PasswordHash string `json:"password_hash"`
+	RealName     string `json:"real_name,omitempty"`
+	Mail         string `json:"mail,omitempty"`
+	Phone        string `json:"phone,omitempty"`
+}
+
+// Store keeps the users in memory, and in a JSON file when it has a path.
+type Store struct {
+	mu    sync.RWMutex
+	path  string
+	users map[string]*User
+}
+
+// Open loads the users from the file at path, which does not have to exist
+// yet. With an empty path the users are only kept in memory.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, users: make(map[string]*User)}
+	if path == "" {
+		return s, nil
+	}
+	var users []*User
+	if err := filestore.ReadJSON(path, &users); err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		s.users[u.Username] = u
+	}
+	return s, nil
+}
+
+// Len returns the number of users.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.users)
+}
+
+// Register adds a user, unless the username is taken.
+func (s *Store) Register(username, password, realName, mail, phone string) error {
+	username = strings.TrimSpace(username)
+	if username == "" || password == "" {
+		return ErrInvalidUser
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err // the password is longer than bcrypt takes
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.users[username]; ok {
+		return ErrUserExists
+	}
+	s.users[username] = &User{
+		Username:     username,
+		PasswordHash: string(hash),
+		RealName:     realName,
+		Mail:         mail,
+		Phone:        phone,
+	}
+	if err := s.save(); err != nil {
+		delete(s.users, username)
+		return err
+	}
+	return nil
+}
+
+// dummyHash is compared against when nobody is registered with the username,
+// so that unknown usernames take as long to reject as wrong passwords.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("correct horse battery staple"), bcrypt.DefaultCost)
+
+// Authenticate returns the user with the username if the password is theirs.
+func (s *Store) Authenticate(username, password string) (User, error) {
+	s.mu.RLock()
+	u, ok := s.users[username]
+	s.mu.RUnlock()
+	if !ok {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return User{}, ErrBadCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)); err != nil {
+		return User{}, ErrBadCredentials
+	}
+	return *u, nil
+}
+
+// Get returns the user with the username.
+func (s *Store) Get(username string) (User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	u, ok := s.users[username]
+	if !ok {
+		return User{}, ErrUserNotFound
+	}
+	return *u, nil
+}
+
+// save writes all the users to the file, through a temporary file so that a
+// crash never leaves half of them. It is called with the lock held.
+func (s *Store) save() error {
+	if s.path == "" {
+		return nil
+	}
+	users := make([]*User, 0, len(s.users))
+	for _, u := range s.users {
+		users = append(users, u)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+	return filestore.WriteJSON(s.path, users)
+}
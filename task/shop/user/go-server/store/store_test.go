@@ -0,0 +1,107 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Register("alice", "secret", "Alice", "alice@example.com", "123"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		register func() error
+		want     error
+	}{
+		{"taken", func() error { return s.Register("alice", "other", "", "", "") }, ErrUserExists},
+		{"no username", func() error { return s.Register(" ", "secret", "", "", "") }, ErrInvalidUser},
+		{"no password", func() error { return s.Register("bob", "", "", "", "") }, ErrInvalidUser},
+	}
+	for _, tt := range tests {
+		if err := tt.register(); !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	if _, err := s.Authenticate("alice", "wrong"); !errors.Is(err, ErrBadCredentials) {
+		t.Errorf("wrong password: got %v", err)
+	}
+	if _, err := s.Authenticate("nobody", "secret"); !errors.Is(err, ErrBadCredentials) {
+		t.Errorf("unknown user: got %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Error("the password is stored in the clear")
+	}
+
+	// The users are back after a restart.
+	s, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := s.Authenticate("alice", "secret")
+	if err != nil || u.Mail != "alice@example.com" {
+		t.Errorf("after reopening: %+v, %v", u, err)
+	}
+}
+
+func TestSigner(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := NewSigner([]byte("key"), time.Hour)
+	s.now = func() time.Time { return now }
+
+	token := s.Sign("alice")
+	if user, err := s.Verify(token); err != nil || user != "alice" {
+		t.Errorf("Verify = %q, %v", user, err)
+	}
+
+	other := NewSigner([]byte("other key"), time.Hour)
+	other.now = s.now
+	forged := other.Sign("alice")
+	for name, tok := range map[string]string{
+		"other key": forged,
+		"garbage":   "not a token",
+		"tampered":  strings.Replace(token, token[:4], "YWRt", 1),
+	} {
+		if _, err := s.Verify(tok); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: got %v", name, err)
+		}
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, err := s.Verify(token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expired: got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidToken = errors.New("invalid or expired session token")
+
+// Signer issues session tokens: the username and the expiry, signed with
+// HMAC-SHA256.
+type Signer struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewSigner signs with key, the tokens are valid for ttl.
+func NewSigner(key []byte, ttl time.Duration) *Signer {
+	return &Signer{key: key, ttl: ttl, now: time.Now}
+}
+
+// Sign returns a session token of the user.
+func (s *Signer) Sign(username string) string {
+	payload := username + "|" + strconv.FormatInt(s.now().Add(s.ttl).Unix(), 10)
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(payload)) + "." + enc.EncodeToString(s.mac(payload))
+}
+
+// Verify returns the user of a token signed by the signer and not expired.
+func (s *Signer) Verify(token string) (string, error) {
+	enc := base64.RawURLEncoding
+	p, m, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", ErrInvalidToken
+	}
+	payload, err := enc.DecodeString(p)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	mac, err := enc.DecodeString(m)
+	if err != nil || !hmac.Equal(mac, s.mac(string(payload))) {
+		return "", ErrInvalidToken
+	}
+	i := strings.LastIndexByte(string(payload), '|')
+	if i < 0 {
+		return "", ErrInvalidToken
+	}
+	expires, err := strconv.ParseInt(string(payload[i+1:]), 10, 64)
+	if err != nil || !s.now().Before(time.Unix(expires, 0)) {
+		return "", ErrInvalidToken
+	}
+	return string(payload[:i]), nil
+}
+
+func (s *Signer) mac(payload string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}